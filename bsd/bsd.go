@@ -0,0 +1,171 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package bsd reads the type dictionaries of the OPC Binary type system
+// (.bsd files). They describe the binary encoding of structured and
+// enumerated types. The OPC UA types are defined in
+// schema/Opc.Ua.Types.bsd and servers publish the dictionaries of their
+// own types as the value of DataTypeDictionary variables.
+//
+// Specification: Part 3, Annex C
+package bsd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+)
+
+// Namespaces of the types which are referenced by the dictionaries.
+const (
+	// BinarySchemaNamespace is the namespace of the built-in types,
+	// e.g. opc:Int32.
+	BinarySchemaNamespace = "http://opcfoundation.org/BinarySchema/"
+
+	// UANamespace is the namespace of the types of the OPC UA
+	// specification, e.g. ua:LocalizedText.
+	UANamespace = "http://opcfoundation.org/UA/"
+)
+
+// TypeDictionary contains the types of a dictionary.
+type TypeDictionary struct {
+	XMLName         xml.Name      `xml:"TypeDictionary"`
+	TargetNamespace string        `xml:",attr"`
+	Imports         []*Import     `xml:"Import"`
+	Types           []*StructType `xml:"StructuredType"`
+	Enums           []*EnumType   `xml:"EnumeratedType"`
+
+	// Attrs contains the other attributes of the dictionary which
+	// declare the prefixes of the namespaces.
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+// Import is a dictionary which is imported by the dictionary.
+type Import struct {
+	Namespace string `xml:",attr"`
+	Location  string `xml:",attr"`
+}
+
+type EnumType struct {
+	Name   string       `xml:",attr"`
+	Bits   int          `xml:"LengthInBits,attr"`
+	Doc    string       `xml:"Documentation"`
+	Values []*EnumValue `xml:"EnumeratedValue"`
+}
+
+type EnumValue struct {
+	Name  string `xml:",attr"`
+	Value int    `xml:",attr"`
+}
+
+type StructType struct {
+	Name     string         `xml:",attr"`
+	BaseType string         `xml:"BaseType,attr"`
+	Doc      string         `xml:"Documentation"`
+	Fields   []*StructField `xml:"Field"`
+}
+
+// IsLengthField returns true if the field contains the length of an
+// array field.
+func (s *StructType) IsLengthField(f *StructField) bool {
+	for _, ff := range s.Fields {
+		if f.Name == ff.LengthField {
+			return true
+		}
+	}
+	return false
+}
+
+// StructField is a field of a structured type. Fields of the type
+// opc:Bit with a Length contain several bits.
+//
+// A field with a SwitchField is only encoded if the value of the switch
+// field is non-zero or, if SwitchValue is set, compares to SwitchValue
+// with the SwitchOperand which is "Equals" by default.
+type StructField struct {
+	Name          string `xml:",attr"`
+	Type          string `xml:"TypeName,attr"`
+	Length        int    `xml:",attr"`
+	LengthField   string `xml:",attr"`
+	SwitchField   string `xml:",attr"`
+	SwitchValue   int    `xml:",attr"`
+	SwitchOperand string `xml:",attr"`
+}
+
+// IsSlice returns true if the field is an array.
+func (f *StructField) IsSlice() bool {
+	return f.LengthField != ""
+}
+
+// Bits returns the number of bits of an opc:Bit field.
+func (f *StructField) Bits() int {
+	if f.Length == 0 {
+		return 1
+	}
+	return f.Length
+}
+
+// Decode reads a type dictionary.
+func Decode(r io.Reader) (*TypeDictionary, error) {
+	d := new(TypeDictionary)
+	if err := xml.NewDecoder(r).Decode(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Parse reads a type dictionary, e.g. the value of a DataTypeDictionary
+// variable.
+func Parse(b []byte) (*TypeDictionary, error) {
+	return Decode(bytes.NewReader(b))
+}
+
+// ReadFile reads the type dictionary from a file.
+func ReadFile(filename string) (*TypeDictionary, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
+
+// SplitType returns the namespace and the name of a type name like
+// "tns:Point". Type names without a declared prefix are in the target
+// namespace of the dictionary.
+func (d *TypeDictionary) SplitType(typeName string) (ns, name string) {
+	i := strings.Index(typeName, ":")
+	if i < 0 {
+		return d.TargetNamespace, typeName
+	}
+	prefix, name := typeName[:i], typeName[i+1:]
+	for _, a := range d.Attrs {
+		if a.Name.Space == "xmlns" && a.Name.Local == prefix {
+			return a.Value, name
+		}
+	}
+	return d.TargetNamespace, name
+}
+
+// Struct returns the structured type with the given name or nil.
+func (d *TypeDictionary) Struct(name string) *StructType {
+	for _, t := range d.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Enum returns the enumerated type with the given name or nil.
+func (d *TypeDictionary) Enum(name string) *EnumType {
+	for _, t := range d.Enums {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package bsd
+
+import (
+	"testing"
+
+	"github.com/pascaldekloe/goe/verify"
+)
+
+const testDictionary = `<?xml version="1.0" encoding="utf-8"?>
+<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" xmlns:ua="http://opcfoundation.org/UA/" xmlns:tns="urn:example" DefaultByteOrder="LittleEndian" TargetNamespace="urn:example">
+  <opc:Import Namespace="http://opcfoundation.org/UA/" Location="Opc.Ua.BinarySchema.bsd"/>
+  <opc:EnumeratedType Name="Mode" LengthInBits="32">
+    <opc:EnumeratedValue Name="Off" Value="0"/>
+    <opc:EnumeratedValue Name="On" Value="1"/>
+  </opc:EnumeratedType>
+  <opc:StructuredType Name="Machine" BaseType="ua:ExtensionObject">
+    <opc:Documentation>A machine.</opc:Documentation>
+    <opc:Field Name="CommentSpecified" TypeName="opc:Bit"/>
+    <opc:Field Name="Reserved1" TypeName="opc:Bit" Length="31"/>
+    <opc:Field Name="Mode" TypeName="tns:Mode"/>
+    <opc:Field Name="NoOfTags" TypeName="opc:Int32"/>
+    <opc:Field Name="Tags" TypeName="opc:String" LengthField="NoOfTags"/>
+    <opc:Field Name="Comment" TypeName="ua:LocalizedText" SwitchField="CommentSpecified"/>
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+func TestParse(t *testing.T) {
+	d, err := Parse([]byte(testDictionary))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify.Values(t, "TargetNamespace", d.TargetNamespace, "urn:example")
+	verify.Values(t, "Imports", d.Imports, []*Import{{Namespace: UANamespace, Location: "Opc.Ua.BinarySchema.bsd"}})
+	verify.Values(t, "Enums", d.Enums, []*EnumType{{
+		Name:   "Mode",
+		Bits:   32,
+		Values: []*EnumValue{{Name: "Off", Value: 0}, {Name: "On", Value: 1}},
+	}})
+
+	m := d.Struct("Machine")
+	if m == nil {
+		t.Fatal("no type Machine")
+	}
+	verify.Values(t, "BaseType", m.BaseType, "ua:ExtensionObject")
+	verify.Values(t, "Doc", m.Doc, "A machine.")
+	verify.Values(t, "Fields", m.Fields, []*StructField{
+		{Name: "CommentSpecified", Type: "opc:Bit"},
+		{Name: "Reserved1", Type: "opc:Bit", Length: 31},
+		{Name: "Mode", Type: "tns:Mode"},
+		{Name: "NoOfTags", Type: "opc:Int32"},
+		{Name: "Tags", Type: "opc:String", LengthField: "NoOfTags"},
+		{Name: "Comment", Type: "ua:LocalizedText", SwitchField: "CommentSpecified"},
+	})
+	verify.Values(t, "IsLengthField", m.IsLengthField(m.Fields[3]), true)
+	verify.Values(t, "IsSlice", m.Fields[4].IsSlice(), true)
+	verify.Values(t, "Bits", m.Fields[1].Bits(), 31)
+}
+
+func TestSplitType(t *testing.T) {
+	d, err := Parse([]byte(testDictionary))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		typeName, ns, name string
+	}{
+		{"opc:Int32", BinarySchemaNamespace, "Int32"},
+		{"ua:LocalizedText", UANamespace, "LocalizedText"},
+		{"tns:Mode", "urn:example", "Mode"},
+		{"Mode", "urn:example", "Mode"},
+	}
+	for _, tt := range tests {
+		ns, name := d.SplitType(tt.typeName)
+		verify.Values(t, tt.typeName, []string{ns, name}, []string{tt.ns, tt.name})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	d, err := ReadFile("../schema/Opc.Ua.Types.bsd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "TargetNamespace", d.TargetNamespace, UANamespace)
+	if d.Struct("ReadRequest") == nil {
+		t.Fatal("no type ReadRequest")
+	}
+	if d.Enum("NodeClass") == nil {
+		t.Fatal("no type NodeClass")
+	}
+}
@@ -93,9 +93,17 @@ type Client struct {
 	// cfg is the configuration for the client.
 	cfg *Config
 
+	// connMu guards conn and reverseConn.
+	connMu sync.Mutex
+
 	// conn is the open connection
 	conn *uacp.Conn
 
+	// reverseConn is the connection of a server which connected to the
+	// client with Reverse Connect. Dial uses it instead of connecting
+	// to the endpoint.
+	reverseConn *uacp.Conn
+
 	// sechan is the open secure channel.
 	atomicSechan atomic.Value // *uasc.SecureChannel
 	sechanErr    chan error
@@ -131,6 +139,13 @@ type Client struct {
 	// list of cached atomicNamespaces on the server
 	atomicNamespaces atomic.Value // []string
 
+	// atomicLimits contains the operation limits of the server.
+	atomicLimits atomic.Value // OperationLimits
+
+	// nodeCache caches the static attributes and the references of
+	// nodes. It is nil unless the NodeCache option is set.
+	nodeCache *nodeCache
+
 	// monitorOnce ensures only one connection monitor is running
 	monitorOnce sync.Once
 }
@@ -157,12 +172,16 @@ func NewClient(endpoint string, opts ...Option) *Client {
 		pausech:     make(chan struct{}, 2),
 		resumech:    make(chan struct{}, 2),
 	}
+	if cfg.nodeCacheTTL > 0 {
+		c.nodeCache = newNodeCache(cfg.nodeCacheTTL)
+	}
 	c.pauseSubscriptions(context.Background())
 	c.setPublishTimeout(uasc.MaxTimeout)
 	c.setState(Closed)
 	c.setSecureChannel(nil)
 	c.setSession(nil)
 	c.setNamespaces([]string{})
+	c.setOperationLimits(OperationLimits{})
 	return &c
 }
 
@@ -227,6 +246,8 @@ func (c *Client) Connect(ctx context.Context) (err error) {
 		return err
 	}
 
+	c.refreshOperationLimits(ctx)
+
 	return nil
 }
 
@@ -342,7 +363,7 @@ func (c *Client) monitor(ctx context.Context) {
 						// todo(fs): down.
 						//
 						// https://github.com/gopcua/opcua/pull/470
-						c.conn.Close()
+						c.closeConn()
 						if sc := c.SecureChannel(); sc != nil {
 							sc.Close()
 							c.setSecureChannel(nil)
@@ -397,6 +418,9 @@ func (c *Client) monitor(ctx context.Context) {
 						}
 						dlog.Printf("namespaces updated")
 
+						// the server may have been restarted with other limits
+						c.refreshOperationLimits(ctx)
+
 						action = restoreSubscriptions
 
 					case recreateSession:
@@ -427,6 +451,8 @@ func (c *Client) monitor(ctx context.Context) {
 						}
 						dlog.Printf("namespaces updated")
 
+						c.refreshOperationLimits(ctx)
+
 						action = transferSubscriptions
 
 					case transferSubscriptions:
@@ -529,21 +555,32 @@ func (c *Client) Dial(ctx context.Context) error {
 		return errors.Errorf("secure channel already connected")
 	}
 
-	var err error
-	var d = NewDialer(c.cfg)
-	c.conn, err = d.Dial(ctx, c.endpointURL)
-	if err != nil {
-		return err
+	c.connMu.Lock()
+	conn := c.reverseConn
+	c.reverseConn = nil
+	c.connMu.Unlock()
+
+	if conn == nil {
+		var d = NewDialer(c.cfg)
+		var err error
+		conn, err = d.Dial(ctx, c.endpointURL)
+		if err != nil {
+			return err
+		}
 	}
 
-	sc, err := uasc.NewSecureChannel(c.endpointURL, c.conn, c.cfg.sechan, c.sechanErr)
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+
+	sc, err := uasc.NewSecureChannel(c.endpointURL, conn, c.cfg.sechan, c.sechanErr)
 	if err != nil {
-		c.conn.Close()
+		conn.Close()
 		return err
 	}
 
 	if err := sc.Open(ctx); err != nil {
-		c.conn.Close()
+		conn.Close()
 		return err
 	}
 	c.setSecureChannel(sc)
@@ -585,11 +622,25 @@ func (c *Client) CloseWithContext(ctx context.Context) error {
 
 	// close the connection but ignore the error since there isn't
 	// anything we can do about it anyway
-	c.conn.Close()
+	c.connMu.Lock()
+	if c.reverseConn != nil {
+		c.reverseConn.Close()
+	}
+	c.connMu.Unlock()
+	c.closeConn()
 
 	return nil
 }
 
+// closeConn closes the open connection.
+func (c *Client) closeConn() {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
 // State returns the current connection state.
 func (c *Client) State() ConnState {
 	return c.atomicState.Load().(ConnState)
@@ -607,10 +658,43 @@ func (c *Client) Namespaces() []string {
 	return c.atomicNamespaces.Load().([]string)
 }
 
+// NodeIDWithNamespaceURI returns a copy of the node id which identifies
+// its namespace with the uri from the cached namespace array instead of
+// the index. The copy stays valid when the server reorders its namespaces
+// since the index is resolved again for every request.
+func (c *Client) NodeIDWithNamespaceURI(id *ua.NodeID) (*ua.NodeID, error) {
+	n := *id
+	if n.NamespaceURI() != "" {
+		return &n, nil
+	}
+	ns := c.Namespaces()
+	if int(n.Namespace()) >= len(ns) {
+		return nil, errors.Errorf("namespace %d not found in the server NamespaceArray", n.Namespace())
+	}
+	n.SetNamespaceURI(ns[n.Namespace()])
+	return &n, nil
+}
+
 func (c *Client) setNamespaces(ns []string) {
+	// the namespace indexes of the cached nodes may have changed
+	if old, ok := c.atomicNamespaces.Load().([]string); ok && !equalStrings(old, ns) {
+		c.nodeCache.invalidate()
+	}
 	c.atomicNamespaces.Store(ns)
 }
 
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Client) publishTimeout() time.Duration {
 	return c.atomicPublishTimeout.Load().(time.Duration)
 }
@@ -635,6 +719,10 @@ func (c *Client) Session() *Session {
 }
 
 func (c *Client) setSession(s *Session) {
+	// a new session may have different access rights
+	if old, ok := c.atomicSession.Load().(*Session); ok && s != nil && s != old {
+		c.nodeCache.invalidate()
+	}
 	c.atomicSession.Store(s)
 	stats.Client().Add("Session", 1)
 }
@@ -916,16 +1004,52 @@ func (c *Client) SendWithContext(ctx context.Context, req ua.Request, h func(int
 
 // sendWithTimeout sends the request via the secure channel with a custom timeout and registers a handler for
 // the response. If the client has an active session it injects the
-// authentication token.
+// authentication token. If the request has node ids with a namespace uri
+// a copy with the node ids resolved with the cached namespace array is
+// sent instead.
 func (c *Client) sendWithTimeout(ctx context.Context, req ua.Request, timeout time.Duration, h func(interface{}) error) error {
-	if c.SecureChannel() == nil {
+	sc := c.SecureChannel()
+	if sc == nil {
+		return ua.StatusBadServerNotConnected
+	}
+	r, err := ua.ResolveNamespaceURIs(req, c.Namespaces())
+	if err != nil {
+		return err
+	}
+	var authToken *ua.NodeID
+	if s := c.Session(); s != nil {
+		authToken = s.resp.AuthenticationToken
+	}
+	return sc.SendRequestWithTimeoutWithContext(ctx, r.(ua.Request), authToken, timeout, h)
+}
+
+// SendWithRelease is like SendWithContext but decodes the response
+// directly from the receive buffer of the connection. h must call release
+// once it no longer uses the response or any value of it, for example
+// byte strings, to return the buffer to the pool. Not calling release is
+// safe but the buffer is then garbage collected. This avoids copying the
+// body of responses with large byte strings or extension objects which
+// the handler only inspects.
+func (c *Client) SendWithRelease(ctx context.Context, req ua.Request, h func(v interface{}, release func()) error) error {
+	stats.Client().Add("SendWithRelease", 1)
+
+	sc := c.SecureChannel()
+	if sc == nil {
 		return ua.StatusBadServerNotConnected
 	}
+	r, err := ua.ResolveNamespaceURIs(req, c.Namespaces())
+	if err != nil {
+		stats.RecordError(err)
+		return err
+	}
 	var authToken *ua.NodeID
 	if s := c.Session(); s != nil {
 		authToken = s.resp.AuthenticationToken
 	}
-	return c.SecureChannel().SendRequestWithTimeoutWithContext(ctx, req, authToken, timeout, h)
+	err = sc.SendRequestWithRelease(ctx, r.(ua.Request), authToken, c.cfg.sechan.RequestTimeout, h)
+	stats.RecordError(err)
+
+	return err
 }
 
 // Node returns a node object which accesses its attributes
@@ -996,30 +1120,30 @@ func (c *Client) ReadWithContext(ctx context.Context, req *ua.ReadRequest) (*ua.
 	// manipulating them in-place.
 	req = cloneReadRequest(req)
 
-	var res *ua.ReadResponse
-	err := c.SendWithContext(ctx, req, func(v interface{}) error {
-		err := safeAssign(v, &res)
-
-		// If the client cannot decode an extension object then its
-		// value will be nil. However, since the EO was known to the
-		// server the StatusCode for that data value will be OK. We
-		// therefore check for extension objects with nil values and set
-		// the status code to StatusBadDataTypeIDUnknown.
-		if err == nil {
-			for _, dv := range res.Results {
-				if dv.Value == nil {
-					continue
-				}
-				val := dv.Value.Value()
-				if eo, ok := val.(*ua.ExtensionObject); ok && eo.Value == nil {
-					dv.Status = ua.StatusBadDataTypeIDUnknown
-				}
-			}
-		}
+	res, err := c.read(ctx, req)
+	if err != nil {
+		return res, err
+	}
 
-		return err
-	})
-	return res, err
+	// If the client cannot decode an extension object then its
+	// value will be nil. However, since the EO was known to the
+	// server the StatusCode for that data value will be OK. We
+	// therefore check for extension objects with nil values and set
+	// the status code to StatusBadDataTypeIDUnknown unless the data
+	// type can be loaded from the server.
+	for _, dv := range res.Results {
+		if dv.Value == nil {
+			continue
+		}
+		if c.cfg.loadDataTypes {
+			c.decodeExtensionObjects(ctx, dv.Value)
+		}
+		val := dv.Value.Value()
+		if eo, ok := val.(*ua.ExtensionObject); ok && eo.Value == nil {
+			dv.Status = ua.StatusBadDataTypeIDUnknown
+		}
+	}
+	return res, nil
 }
 
 // Write executes a synchronous write request.
@@ -1035,11 +1159,7 @@ func (c *Client) WriteWithContext(ctx context.Context, req *ua.WriteRequest) (*u
 	stats.Client().Add("Write", 1)
 	stats.Client().Add("NodesToWrite", int64(len(req.NodesToWrite)))
 
-	var res *ua.WriteResponse
-	err := c.SendWithContext(ctx, req, func(v interface{}) error {
-		return safeAssign(v, &res)
-	})
-	return res, err
+	return c.write(ctx, req)
 }
 
 func cloneBrowseRequest(req *ua.BrowseRequest) *ua.BrowseRequest {
@@ -1083,11 +1203,7 @@ func (c *Client) BrowseWithContext(ctx context.Context, req *ua.BrowseRequest) (
 	// manipulating them in-place.
 	req = cloneBrowseRequest(req)
 
-	var res *ua.BrowseResponse
-	err := c.SendWithContext(ctx, req, func(v interface{}) error {
-		return safeAssign(v, &res)
-	})
-	return res, err
+	return c.browse(ctx, req)
 }
 
 // Call executes a synchronous call request for a single method.
@@ -1118,6 +1234,16 @@ func (c *Client) CallWithContext(ctx context.Context, req *ua.CallMethodRequest)
 	return res.Results[0], nil
 }
 
+// CallMethods executes a synchronous call request for multiple methods.
+// The methods are called in batches if their number exceeds the
+// MaxNodesPerMethodCall limit of the server.
+func (c *Client) CallMethods(ctx context.Context, req *ua.CallRequest) (*ua.CallResponse, error) {
+	stats.Client().Add("CallMethods", 1)
+	stats.Client().Add("MethodsToCall", int64(len(req.MethodsToCall)))
+
+	return c.call(ctx, req)
+}
+
 // BrowseNext executes a synchronous browse request.
 //
 // Note: Starting with v0.5 this method will require a context
@@ -1137,6 +1263,22 @@ func (c *Client) BrowseNextWithContext(ctx context.Context, req *ua.BrowseNextRe
 	return res, err
 }
 
+// TranslateBrowsePaths resolves the browse paths to the node ids of all
+// their targets with a synchronous TranslateBrowsePathsToNodeIds request.
+// Targets on other servers have a RemainingPathIndex which is not
+// math.MaxUint32. The paths are translated in batches if their number
+// exceeds the MaxNodesPerTranslateBrowsePathsToNodeIds limit of the
+// server. See ua.ParseRelativePath for the text format of the relative
+// paths.
+//
+// Specification: Part 4, 5.8.4
+func (c *Client) TranslateBrowsePaths(ctx context.Context, req *ua.TranslateBrowsePathsToNodeIDsRequest) (*ua.TranslateBrowsePathsToNodeIDsResponse, error) {
+	stats.Client().Add("TranslateBrowsePaths", 1)
+	stats.Client().Add("BrowsePaths", int64(len(req.BrowsePaths)))
+
+	return c.translateBrowsePaths(ctx, req)
+}
+
 // RegisterNodes registers node ids for more efficient reads.
 //
 // Part 4, Section 5.8.5
@@ -1152,11 +1294,7 @@ func (c *Client) RegisterNodesWithContext(ctx context.Context, req *ua.RegisterN
 	stats.Client().Add("RegisterNodes", 1)
 	stats.Client().Add("NodesToRegister", int64(len(req.NodesToRegister)))
 
-	var res *ua.RegisterNodesResponse
-	err := c.SendWithContext(ctx, req, func(v interface{}) error {
-		return safeAssign(v, &res)
-	})
-	return res, err
+	return c.registerNodes(ctx, req)
 }
 
 // UnregisterNodes unregisters node ids previously registered with RegisterNodes.
@@ -1174,11 +1312,7 @@ func (c *Client) UnregisterNodesWithContext(ctx context.Context, req *ua.Unregis
 	stats.Client().Add("UnregisterNodes", 1)
 	stats.Client().Add("NodesToUnregister", int64(len(req.NodesToUnregister)))
 
-	var res *ua.UnregisterNodesResponse
-	err := c.SendWithContext(ctx, req, func(v interface{}) error {
-		return safeAssign(v, &res)
-	})
-	return res, err
+	return c.unregisterNodes(ctx, req)
 }
 
 // Note: Starting with v0.5 this method will require a context
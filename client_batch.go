@@ -0,0 +1,574 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"sync"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/stats"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// OperationLimits returns the operation limits of the server which the
+// client uses to split requests into batches. The limits are read after
+// connecting and reconnecting unless AutoBatch is disabled.
+func (c *Client) OperationLimits() OperationLimits {
+	return c.atomicLimits.Load().(OperationLimits)
+}
+
+func (c *Client) setOperationLimits(l OperationLimits) {
+	c.atomicLimits.Store(l)
+}
+
+// UpdateOperationLimits reads the operation limits of the server. Limits
+// which the server does not provide are set to zero which means that
+// there is no limit.
+//
+// Specification: Part 5, 6.3.11
+func (c *Client) UpdateOperationLimits(ctx context.Context) error {
+	stats.Client().Add("UpdateOperationLimits", 1)
+
+	var l OperationLimits
+	limits := []struct {
+		id uint32
+		v  *uint32
+	}{
+		// MaxNodesPerRead comes first since reading the other limits
+		// must not exceed it.
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRead, &l.MaxNodesPerRead},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryReadData, &l.MaxNodesPerHistoryReadData},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryReadEvents, &l.MaxNodesPerHistoryReadEvents},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerWrite, &l.MaxNodesPerWrite},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryUpdateData, &l.MaxNodesPerHistoryUpdateData},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryUpdateEvents, &l.MaxNodesPerHistoryUpdateEvents},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerMethodCall, &l.MaxNodesPerMethodCall},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerBrowse, &l.MaxNodesPerBrowse},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRegisterNodes, &l.MaxNodesPerRegisterNodes},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerTranslateBrowsePathsToNodeIDs, &l.MaxNodesPerTranslateBrowsePathsToNodeIDs},
+		{id.Server_ServerCapabilities_OperationLimits_MaxNodesPerNodeManagement, &l.MaxNodesPerNodeManagement},
+		{id.Server_ServerCapabilities_OperationLimits_MaxMonitoredItemsPerCall, &l.MaxMonitoredItemsPerCall},
+	}
+
+	read := func(lo, hi int) error {
+		req := &ua.ReadRequest{TimestampsToReturn: ua.TimestampsToReturnNeither}
+		for _, x := range limits[lo:hi] {
+			req.NodesToRead = append(req.NodesToRead, &ua.ReadValueID{
+				NodeID:      ua.NewNumericNodeID(0, x.id),
+				AttributeID: ua.AttributeIDValue,
+			})
+		}
+		res, err := c.ReadWithContext(ctx, req)
+		if err != nil {
+			return err
+		}
+		for i, dv := range res.Results {
+			if dv.Status != ua.StatusOK || dv.Value == nil {
+				continue
+			}
+			if v, ok := dv.Value.Value().(uint32); ok {
+				*limits[lo+i].v = v
+			}
+		}
+		return nil
+	}
+
+	c.setOperationLimits(OperationLimits{})
+	if err := read(0, 1); err != nil {
+		return err
+	}
+	c.setOperationLimits(OperationLimits{MaxNodesPerRead: l.MaxNodesPerRead})
+	if err := read(1, len(limits)); err != nil {
+		return err
+	}
+	c.setOperationLimits(l)
+	return nil
+}
+
+// refreshOperationLimits reads the operation limits of the server unless
+// AutoBatch is disabled. Servers which do not provide the operation
+// limits are not an error and the requests are then not split.
+func (c *Client) refreshOperationLimits(ctx context.Context) {
+	if c.cfg.noAutoBatch {
+		return
+	}
+	if err := c.UpdateOperationLimits(ctx); err != nil {
+		debug.Printf("client: cannot read operation limits: %s", err)
+	}
+}
+
+// needsBatches returns true if n operations exceed the limit and have to
+// be sent in batches.
+func (c *Client) needsBatches(n int, limit uint32) bool {
+	return !c.cfg.noAutoBatch && limit > 0 && uint64(n) > uint64(limit)
+}
+
+// sendAssign sends the request and assigns the response to res which
+// must be a pointer to a variable of the response type.
+func (c *Client) sendAssign(ctx context.Context, req ua.Request, res interface{}) error {
+	return c.SendWithContext(ctx, req, func(v interface{}) error {
+		return safeAssign(v, res)
+	})
+}
+
+// batchResponse contains the parts of the response to a batch which
+// sendBatches merges into the response to the whole request.
+type batchResponse struct {
+	header  *ua.ResponseHeader
+	results int
+	diags   []*ua.DiagnosticInfo
+}
+
+// sendBatches splits n operations into batches of at most limit
+// operations and calls send with the bounds of each batch. At most
+// batchParallelism batches are sent concurrently. send stores the
+// results of the batch in the response and returns the number of
+// results which must match the size of the batch.
+//
+// If a batch fails fail sets the results of the batch to the status
+// code of the error and the other batches are still sent. Without fail
+// sendBatches returns the first error and sends no further batches.
+//
+// sendBatches returns the response header of the first successful batch
+// and the reassembled diagnostic infos, which are nil unless a batch
+// returned diagnostic infos. It returns an error if all batches failed.
+func (c *Client) sendBatches(ctx context.Context, n int, limit uint32, send func(ctx context.Context, lo, hi int) (*batchResponse, error), fail func(lo, hi int, status ua.StatusCode)) (*ua.ResponseHeader, []*ua.DiagnosticInfo, error) {
+	m := &batchMerger{n: n, lo: -1}
+	err := runBatches(ctx, n, limit, c.cfg.batchParallelism, func(ctx context.Context, lo, hi int) error {
+		res, err := send(ctx, lo, hi)
+		if err == nil && res.results != hi-lo {
+			err = ua.StatusBadUnknownResponse
+		}
+		if err != nil {
+			if fail == nil {
+				return err
+			}
+			fail(lo, hi, batchStatus(err))
+			m.fail(err)
+			return nil
+		}
+		m.set(lo, res)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if m.lo < 0 {
+		return nil, nil, m.err
+	}
+	return m.header, m.infos, nil
+}
+
+// batchStatus returns the status code for the results of a batch which
+// failed with err.
+func batchStatus(err error) ua.StatusCode {
+	if s, ok := err.(ua.StatusCode); ok {
+		return s
+	}
+	return ua.StatusBadCommunicationError
+}
+
+// runBatches calls send for batches of at most limit operations with at
+// most parallel concurrent calls. It returns the first error and sends
+// no further batches after that.
+func runBatches(ctx context.Context, n int, limit uint32, parallel int, send func(ctx context.Context, lo, hi int) error) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		err  error
+		sem  = make(chan struct{}, parallel)
+	)
+	for lo := 0; lo < n && ctx.Err() == nil; lo += int(limit) {
+		hi := lo + int(limit)
+		if hi > n {
+			hi = n
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if e := send(ctx, lo, hi); e != nil {
+				once.Do(func() {
+					err = e
+					cancel()
+				})
+			}
+		}(lo, hi)
+	}
+	wg.Wait()
+
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// batchMerger collects the response headers and diagnostic infos of the
+// batches of a request. lo is the start of the batch of the header and
+// -1 until a batch succeeded. err is the first error of a failed batch.
+type batchMerger struct {
+	mu     sync.Mutex
+	n      int
+	lo     int
+	header *ua.ResponseHeader
+	infos  []*ua.DiagnosticInfo
+	err    error
+}
+
+func (m *batchMerger) set(lo int, res *batchResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lo < 0 || lo < m.lo {
+		m.lo, m.header = lo, res.header
+	}
+	if len(res.diags) == 0 {
+		return
+	}
+	if m.infos == nil {
+		m.infos = make([]*ua.DiagnosticInfo, m.n)
+		for i := range m.infos {
+			m.infos[i] = &ua.DiagnosticInfo{}
+		}
+	}
+	copy(m.infos[lo:lo+res.results], res.diags)
+}
+
+func (m *batchMerger) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err == nil {
+		m.err = err
+	}
+}
+
+// read sends the read request in batches of at most MaxNodesPerRead
+// nodes. The results of a failed batch have the status of the error.
+func (c *Client) read(ctx context.Context, req *ua.ReadRequest) (*ua.ReadResponse, error) {
+	n, limit := len(req.NodesToRead), c.OperationLimits().MaxNodesPerRead
+	if !c.needsBatches(n, limit) {
+		var res *ua.ReadResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.ReadResponse{Results: make([]*ua.DataValue, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.NodesToRead = req.NodesToRead[lo:hi]
+		var bres *ua.ReadResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = &ua.DataValue{EncodingMask: ua.DataValueStatusCode, Status: status}
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// write sends the write request in batches of at most MaxNodesPerWrite
+// nodes. The results of a failed batch are the status of the error.
+func (c *Client) write(ctx context.Context, req *ua.WriteRequest) (*ua.WriteResponse, error) {
+	n, limit := len(req.NodesToWrite), c.OperationLimits().MaxNodesPerWrite
+	if !c.needsBatches(n, limit) {
+		var res *ua.WriteResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.WriteResponse{Results: make([]ua.StatusCode, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.NodesToWrite = req.NodesToWrite[lo:hi]
+		var bres *ua.WriteResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = status
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// browse sends the browse request in batches of at most
+// MaxNodesPerBrowse nodes. The results of a failed batch have the status
+// of the error.
+func (c *Client) browse(ctx context.Context, req *ua.BrowseRequest) (*ua.BrowseResponse, error) {
+	n, limit := len(req.NodesToBrowse), c.OperationLimits().MaxNodesPerBrowse
+	if !c.needsBatches(n, limit) {
+		var res *ua.BrowseResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.BrowseResponse{Results: make([]*ua.BrowseResult, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.NodesToBrowse = req.NodesToBrowse[lo:hi]
+		var bres *ua.BrowseResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = &ua.BrowseResult{StatusCode: status}
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// call sends the call request in batches of at most
+// MaxNodesPerMethodCall methods. The results of a failed batch have the
+// status of the error.
+func (c *Client) call(ctx context.Context, req *ua.CallRequest) (*ua.CallResponse, error) {
+	n, limit := len(req.MethodsToCall), c.OperationLimits().MaxNodesPerMethodCall
+	if !c.needsBatches(n, limit) {
+		var res *ua.CallResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.CallResponse{Results: make([]*ua.CallMethodResult, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.MethodsToCall = req.MethodsToCall[lo:hi]
+		var bres *ua.CallResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = &ua.CallMethodResult{StatusCode: status}
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// registerNodes sends the register nodes request in batches of at most
+// MaxNodesPerRegisterNodes nodes. The registered node ids have no status
+// and therefore the first failed batch fails the whole request.
+func (c *Client) registerNodes(ctx context.Context, req *ua.RegisterNodesRequest) (*ua.RegisterNodesResponse, error) {
+	n, limit := len(req.NodesToRegister), c.OperationLimits().MaxNodesPerRegisterNodes
+	if !c.needsBatches(n, limit) {
+		var res *ua.RegisterNodesResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.RegisterNodesResponse{RegisteredNodeIDs: make([]*ua.NodeID, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.NodesToRegister = req.NodesToRegister[lo:hi]
+		var bres *ua.RegisterNodesResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.RegisteredNodeIDs[lo:hi], bres.RegisteredNodeIDs)
+		return &batchResponse{header: bres.ResponseHeader, results: len(bres.RegisteredNodeIDs)}, nil
+	}
+
+	var err error
+	res.ResponseHeader, _, err = c.sendBatches(ctx, n, limit, send, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// unregisterNodes sends the unregister nodes request in batches of at
+// most MaxNodesPerRegisterNodes nodes. The response has no results and
+// therefore the first failed batch fails the whole request.
+func (c *Client) unregisterNodes(ctx context.Context, req *ua.UnregisterNodesRequest) (*ua.UnregisterNodesResponse, error) {
+	n, limit := len(req.NodesToUnregister), c.OperationLimits().MaxNodesPerRegisterNodes
+	if !c.needsBatches(n, limit) {
+		var res *ua.UnregisterNodesResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.UnregisterNodesResponse{}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.NodesToUnregister = req.NodesToUnregister[lo:hi]
+		var bres *ua.UnregisterNodesResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		return &batchResponse{header: bres.ResponseHeader, results: hi - lo}, nil
+	}
+
+	var err error
+	res.ResponseHeader, _, err = c.sendBatches(ctx, n, limit, send, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// createMonitoredItems sends the create monitored items request in
+// batches of at most MaxMonitoredItemsPerCall items. The results of a
+// failed batch have the status of the error.
+func (c *Client) createMonitoredItems(ctx context.Context, req *ua.CreateMonitoredItemsRequest) (*ua.CreateMonitoredItemsResponse, error) {
+	n, limit := len(req.ItemsToCreate), c.OperationLimits().MaxMonitoredItemsPerCall
+	if !c.needsBatches(n, limit) {
+		var res *ua.CreateMonitoredItemsResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.CreateMonitoredItemsResponse{Results: make([]*ua.MonitoredItemCreateResult, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.ItemsToCreate = req.ItemsToCreate[lo:hi]
+		var bres *ua.CreateMonitoredItemsResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = &ua.MonitoredItemCreateResult{StatusCode: status}
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// translateBrowsePaths sends the translate browse paths request in
+// batches of at most MaxNodesPerTranslateBrowsePathsToNodeIDs paths. The
+// results of a failed batch have the status of the error.
+func (c *Client) translateBrowsePaths(ctx context.Context, req *ua.TranslateBrowsePathsToNodeIDsRequest) (*ua.TranslateBrowsePathsToNodeIDsResponse, error) {
+	n, limit := len(req.BrowsePaths), c.OperationLimits().MaxNodesPerTranslateBrowsePathsToNodeIDs
+	if !c.needsBatches(n, limit) {
+		var res *ua.TranslateBrowsePathsToNodeIDsResponse
+		err := c.sendAssign(ctx, req, &res)
+		return res, err
+	}
+
+	res := &ua.TranslateBrowsePathsToNodeIDsResponse{Results: make([]*ua.BrowsePathResult, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := *req
+		breq.BrowsePaths = req.BrowsePaths[lo:hi]
+		var bres *ua.TranslateBrowsePathsToNodeIDsResponse
+		if err := c.sendAssign(ctx, &breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = &ua.BrowsePathResult{StatusCode: status}
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// historyUpdate sends a history update request with the details in
+// batches of at most limit details. The results of a failed batch have
+// the status of the error.
+func (c *Client) historyUpdate(ctx context.Context, details []*ua.ExtensionObject, limit uint32) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryUpdateDetails", int64(len(details)))
+
+	n := len(details)
+	if !c.needsBatches(n, limit) {
+		var res *ua.HistoryUpdateResponse
+		err := c.sendAssign(ctx, &ua.HistoryUpdateRequest{HistoryUpdateDetails: details}, &res)
+		return res, err
+	}
+
+	res := &ua.HistoryUpdateResponse{Results: make([]*ua.HistoryUpdateResult, n)}
+	send := func(ctx context.Context, lo, hi int) (*batchResponse, error) {
+		breq := &ua.HistoryUpdateRequest{HistoryUpdateDetails: details[lo:hi]}
+		var bres *ua.HistoryUpdateResponse
+		if err := c.sendAssign(ctx, breq, &bres); err != nil {
+			return nil, err
+		}
+		copy(res.Results[lo:hi], bres.Results)
+		return &batchResponse{bres.ResponseHeader, len(bres.Results), bres.DiagnosticInfos}, nil
+	}
+	fail := func(lo, hi int, status ua.StatusCode) {
+		for i := lo; i < hi; i++ {
+			res.Results[i] = &ua.HistoryUpdateResult{StatusCode: status}
+		}
+	}
+
+	var err error
+	res.ResponseHeader, res.DiagnosticInfos, err = c.sendBatches(ctx, n, limit, send, fail)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
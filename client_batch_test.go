@@ -0,0 +1,370 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestClientBatches(t *testing.T) {
+	ctx := context.Background()
+	limits := OperationLimits{
+		MaxNodesPerRead:          2,
+		MaxNodesPerBrowse:        1,
+		MaxNodesPerRegisterNodes: 3,
+	}
+	srv := startTestServer(t, ServerOperationLimits(limits))
+
+	nodes := []uint32{
+		id.Server_ServerStatus_State,
+		id.Server_ServerArray,
+		id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRead,
+		id.Server_ServerCapabilities_OperationLimits_MaxNodesPerBrowse,
+		id.Server_ServerCapabilities_OperationLimits_MaxNodesPerWrite,
+	}
+	readReq := &ua.ReadRequest{}
+	browseReq := &ua.BrowseRequest{}
+	registerReq := &ua.RegisterNodesRequest{}
+	for _, n := range nodes {
+		readReq.NodesToRead = append(readReq.NodesToRead, &ua.ReadValueID{NodeID: ua.NewNumericNodeID(0, n)})
+		browseReq.NodesToBrowse = append(browseReq.NodesToBrowse, &ua.BrowseDescription{
+			NodeID:          ua.NewNumericNodeID(0, n),
+			BrowseDirection: ua.BrowseDirectionInverse,
+			IncludeSubtypes: true,
+			ResultMask:      uint32(ua.BrowseResultMaskAll),
+		})
+		registerReq.NodesToRegister = append(registerReq.NodesToRegister, ua.NewNumericNodeID(0, n))
+	}
+
+	for _, parallel := range []int{1, 3} {
+		c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false), BatchParallelism(parallel))
+		if err := c.Connect(ctx); err != nil {
+			t.Fatal(err)
+		}
+		defer c.CloseWithContext(ctx)
+
+		verify.Values(t, "limits", c.OperationLimits(), limits)
+
+		res, err := c.ReadWithContext(ctx, readReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values []interface{}
+		for _, dv := range res.Results {
+			values = append(values, dv.Value.Value())
+		}
+		verify.Values(t, "read", values, []interface{}{
+			int32(ua.ServerStateRunning),
+			[]string{"urn:gopcua:server"},
+			uint32(2),
+			uint32(1),
+			uint32(0),
+		})
+
+		bres, err := c.BrowseWithContext(ctx, browseReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "browse", len(bres.Results), len(nodes))
+		for i, r := range bres.Results {
+			if len(r.References) == 0 {
+				t.Fatalf("%d: no references", i)
+			}
+		}
+
+		rres, err := c.RegisterNodesWithContext(ctx, registerReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "register", len(rres.RegisteredNodeIDs), len(nodes))
+	}
+
+	c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false), AutoBatch(false))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	_, err := c.ReadWithContext(ctx, readReq)
+	verify.Values(t, "no batches", err, ua.StatusBadTooManyOperations)
+}
+
+// connectBatchTestClient starts a server with the operation limits and
+// the test nodes and connects a client which sends parallel batches.
+func connectBatchTestClient(t *testing.T, limits OperationLimits) (*Server, *Client, uint16) {
+	t.Helper()
+
+	srv := startTestServer(t, ServerOperationLimits(limits))
+	ns, _ := addTestNodes(t, srv)
+
+	ctx := context.Background()
+	c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false), BatchParallelism(3))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.CloseWithContext(ctx) })
+	return srv, c, ns
+}
+
+func TestClientBatchWrite(t *testing.T) {
+	ctx := context.Background()
+	_, c, ns := connectBatchTestClient(t, OperationLimits{MaxNodesPerWrite: 2})
+
+	req := &ua.WriteRequest{}
+	for i, name := range []string{"Speed", "Name", "Speed", "unknown", "Speed"} {
+		req.NodesToWrite = append(req.NodesToWrite, &ua.WriteValue{
+			NodeID:      ua.NewStringNodeID(ns, "Machine."+name),
+			AttributeID: ua.AttributeIDValue,
+			Value:       &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(float64(i))},
+		})
+	}
+	res, err := c.WriteWithContext(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "results", res.Results, []ua.StatusCode{
+		ua.StatusOK,
+		ua.StatusBadUserAccessDenied,
+		ua.StatusOK,
+		ua.StatusBadNodeIDUnknown,
+		ua.StatusOK,
+	})
+	if res.ResponseHeader == nil {
+		t.Fatal("no response header")
+	}
+}
+
+func TestClientBatchCall(t *testing.T) {
+	ctx := context.Background()
+	srv, c, ns := connectBatchTestClient(t, OperationLimits{MaxNodesPerMethodCall: 2})
+
+	machine := ua.NewStringNodeID(ns, "Machine")
+	method := ua.NewStringNodeID(ns, "Machine.Double")
+	err := srv.AddressSpace().AddNode(&MethodNode{
+		BaseNode: BaseNode{
+			NodeID:     method,
+			BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Double"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.HasComponent), false, machine),
+			},
+		},
+		Executable:     true,
+		UserExecutable: true,
+		OnCall: func(ctx context.Context, sess *ServerSession, objectID *ua.NodeID, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+			if len(args) != 1 {
+				return nil, ua.StatusBadArgumentsMissing
+			}
+			return []*ua.Variant{ua.MustVariant(2 * args[0].Value().(int32))}, ua.StatusOK
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &ua.CallRequest{}
+	for i := int32(0); i < 5; i++ {
+		req.MethodsToCall = append(req.MethodsToCall, &ua.CallMethodRequest{
+			ObjectID:       machine,
+			MethodID:       method,
+			InputArguments: []*ua.Variant{ua.MustVariant(i)},
+		})
+	}
+	req.MethodsToCall[3].InputArguments = nil
+
+	res, err := c.CallMethods(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []interface{}
+	for _, r := range res.Results {
+		if r.StatusCode != ua.StatusOK {
+			got = append(got, r.StatusCode)
+			continue
+		}
+		got = append(got, r.OutputArguments[0].Value())
+	}
+	verify.Values(t, "results", got, []interface{}{
+		int32(0),
+		int32(2),
+		int32(4),
+		ua.StatusBadArgumentsMissing,
+		int32(8),
+	})
+}
+
+func TestClientBatchMonitor(t *testing.T) {
+	_, c, ns := connectBatchTestClient(t, OperationLimits{MaxMonitoredItemsPerCall: 2})
+	sub, ch := subscribeTestClient(t, c)
+
+	var items []*ua.MonitoredItemCreateRequest
+	for i, name := range []string{"Speed", "Name", "unknown", "Speed", "Name"} {
+		items = append(items, NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, "Machine."+name), ua.AttributeIDValue, uint32(i)))
+	}
+	res, err := sub.Monitor(ua.TimestampsToReturnBoth, items...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status []ua.StatusCode
+	for _, r := range res.Results {
+		status = append(status, r.StatusCode)
+	}
+	verify.Values(t, "status", status, []ua.StatusCode{
+		ua.StatusOK,
+		ua.StatusOK,
+		ua.StatusBadNodeIDUnknown,
+		ua.StatusOK,
+		ua.StatusOK,
+	})
+
+	verify.Values(t, "initial values", nextDataChanges(t, ch, 4), map[uint32]interface{}{
+		0: 1.5,
+		1: "m1",
+		3: 1.5,
+		4: "m1",
+	})
+}
+
+func TestClientBatchFailure(t *testing.T) {
+	ctx := context.Background()
+	srv, _, ns := connectBatchTestClient(t, OperationLimits{MaxNodesPerRead: 2})
+
+	// the server fails the batches which start with Machine.Name
+	failing := ua.NewStringNodeID(ns, "Machine.Name").String()
+	srv.Handle(id.ReadRequest_Encoding_DefaultBinary, func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		if req.(*ua.ReadRequest).NodesToRead[0].NodeID.String() == failing {
+			return nil, ua.StatusBadOutOfMemory
+		}
+		return srv.handleRead(ctx, sess, req)
+	})
+
+	read := func(c *Client, names ...string) (*ua.ReadResponse, error) {
+		req := &ua.ReadRequest{}
+		for _, name := range names {
+			req.NodesToRead = append(req.NodesToRead, &ua.ReadValueID{
+				NodeID:      ua.NewStringNodeID(ns, "Machine."+name),
+				AttributeID: ua.AttributeIDValue,
+			})
+		}
+		return c.ReadWithContext(ctx, req)
+	}
+
+	for _, parallel := range []int{1, 3} {
+		c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false), BatchParallelism(parallel))
+		if err := c.Connect(ctx); err != nil {
+			t.Fatal(err)
+		}
+		defer c.CloseWithContext(ctx)
+
+		res, err := read(c, "Speed", "Speed", "Name", "Speed", "Speed")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []interface{}
+		for _, dv := range res.Results {
+			if dv.Status != ua.StatusOK {
+				got = append(got, dv.Status)
+				continue
+			}
+			got = append(got, dv.Value.Value())
+		}
+		verify.Values(t, "results", got, []interface{}{
+			1.5,
+			1.5,
+			ua.StatusBadOutOfMemory,
+			ua.StatusBadOutOfMemory,
+			1.5,
+		})
+		if res.ResponseHeader == nil {
+			t.Fatal("no response header")
+		}
+
+		// the request fails if all batches fail
+		_, err = read(c, "Name", "Speed", "Name")
+		verify.Values(t, "all failed", err, ua.StatusBadOutOfMemory)
+	}
+}
+
+func TestClientBatchDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	srv, c, ns := connectBatchTestClient(t, OperationLimits{MaxNodesPerRead: 2})
+
+	// the server returns diagnostic infos for the batches which start
+	// with Machine.Name
+	diag := ua.NewStringNodeID(ns, "Machine.Name").String()
+	srv.Handle(id.ReadRequest_Encoding_DefaultBinary, func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		resp, err := srv.handleRead(ctx, sess, req)
+		if err != nil {
+			return nil, err
+		}
+		r := req.(*ua.ReadRequest)
+		if r.NodesToRead[0].NodeID.String() == diag {
+			res := resp.(*ua.ReadResponse)
+			for _, n := range r.NodesToRead {
+				res.DiagnosticInfos = append(res.DiagnosticInfos, &ua.DiagnosticInfo{
+					EncodingMask:   ua.DiagnosticInfoAdditionalInfo,
+					AdditionalInfo: n.NodeID.StringID(),
+				})
+			}
+		}
+		return resp, nil
+	})
+
+	req := &ua.ReadRequest{}
+	for _, name := range []string{"Speed", "Speed", "Name", "Speed", "Speed"} {
+		req.NodesToRead = append(req.NodesToRead, &ua.ReadValueID{
+			NodeID:      ua.NewStringNodeID(ns, "Machine."+name),
+			AttributeID: ua.AttributeIDValue,
+		})
+	}
+	res, err := c.ReadWithContext(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "diagnostics", res.DiagnosticInfos, []*ua.DiagnosticInfo{
+		{},
+		{},
+		{EncodingMask: ua.DiagnosticInfoAdditionalInfo, AdditionalInfo: "Machine.Name"},
+		{EncodingMask: ua.DiagnosticInfoAdditionalInfo, AdditionalInfo: "Machine.Speed"},
+		{},
+	})
+
+	// no diagnostic infos without a batch which returned them
+	req.NodesToRead = req.NodesToRead[:2]
+	req.NodesToRead = append(req.NodesToRead, req.NodesToRead[0])
+	if res, err = c.ReadWithContext(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "no diagnostics", res.DiagnosticInfos, []*ua.DiagnosticInfo(nil))
+}
+
+func TestClientBatchLimitsAfterReconnect(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t, ServerOperationLimits(OperationLimits{MaxNodesPerRead: 2}))
+
+	c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), ReconnectInterval(10*time.Millisecond))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+	verify.Values(t, "limits", c.OperationLimits(), OperationLimits{MaxNodesPerRead: 2})
+
+	// restart the server with other limits on the same address
+	srv.Close()
+	srv = NewServer(srv.Endpoint(), ServerOperationLimits(OperationLimits{MaxNodesPerRead: 5}))
+	if err := srv.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for c.OperationLimits().MaxNodesPerRead != 5 {
+		if time.Now().After(deadline) {
+			t.Fatalf("got limits %+v after reconnect", c.OperationLimits())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
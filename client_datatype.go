@@ -0,0 +1,179 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// LoadDataType reads the DataTypeDefinition attribute of a data type and
+// registers the definition with the ua package. The data types of the
+// fields of a structure are loaded as well. Extension objects of the
+// data type are then decoded into *ua.DynamicStructure values unless a
+// Go type was registered with ua.RegisterExtensionObject.
+//
+// Data types without a definition are registered as simple types of
+// their supertype. Data types which are already registered and the
+// built-in data types are not loaded again.
+//
+// Specification: Part 3, 5.8.3
+func (c *Client) LoadDataType(ctx context.Context, dataTypeID *ua.NodeID) error {
+	return c.loadDataType(ctx, dataTypeID, make(map[string]bool))
+}
+
+func (c *Client) loadDataType(ctx context.Context, dataTypeID *ua.NodeID, seen map[string]bool) error {
+	if isBuiltinDataType(dataTypeID) || seen[dataTypeID.String()] || ua.LookupDataType(dataTypeID) != nil {
+		return nil
+	}
+	seen[dataTypeID.String()] = true
+
+	n := c.Node(dataTypeID)
+	dvs, err := n.AttributesWithContext(ctx, ua.AttributeIDBrowseName, ua.AttributeIDDataTypeDefinition)
+	if err != nil {
+		return err
+	}
+	if dvs[0].Status != ua.StatusOK {
+		return errors.Errorf("data type %s: %s", dataTypeID, dvs[0].Status)
+	}
+	name := dvs[0].Value.QualifiedName()
+
+	var def interface{}
+	if dvs[1].Status == ua.StatusOK {
+		if eo := dvs[1].Value.ExtensionObject(); eo != nil {
+			def = eo.Value
+		}
+	}
+
+	switch def := def.(type) {
+	case *ua.StructureDefinition:
+		if isNullNodeID(def.DefaultEncodingID) {
+			enc, err := c.defaultBinaryEncoding(ctx, dataTypeID)
+			if err != nil {
+				return err
+			}
+			def.DefaultEncodingID = enc
+		}
+		for _, f := range def.Fields {
+			if err := c.loadDataType(ctx, f.DataType, seen); err != nil {
+				return errors.Errorf("field %s of %s: %s", f.Name, name.Name, err)
+			}
+		}
+		ua.RegisterStructureDescription(&ua.StructureDescription{
+			DataTypeID:          dataTypeID,
+			Name:                name,
+			StructureDefinition: def,
+		})
+		return nil
+
+	case *ua.EnumDefinition:
+		ua.RegisterEnumDescription(&ua.EnumDescription{
+			DataTypeID:     dataTypeID,
+			Name:           name,
+			EnumDefinition: def,
+			BuiltInType:    uint8(ua.TypeIDInt32),
+		})
+		return nil
+	}
+
+	// data types without a definition are derived from a simple type
+	refs, err := n.ReferencesWithContext(ctx, id.HasSubtype, ua.BrowseDirectionInverse, ua.NodeClassDataType, false)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return errors.Errorf("data type %s has no definition and no supertype", dataTypeID)
+	}
+	base := refs[0].NodeID.NodeID
+	if isNS0(base, id.Structure) || isNS0(base, id.Union) {
+		return errors.Errorf("structure %s has no definition", dataTypeID)
+	}
+	if err := c.loadDataType(ctx, base, seen); err != nil {
+		return err
+	}
+	d := &ua.SimpleTypeDescription{
+		DataTypeID:   dataTypeID,
+		Name:         name,
+		BaseDataType: base,
+	}
+	if isBuiltinDataType(base) {
+		d.BuiltInType = uint8(base.IntID())
+	}
+	ua.RegisterSimpleTypeDescription(d)
+	return nil
+}
+
+// defaultBinaryEncoding returns the id of the "Default Binary" encoding
+// of a data type.
+func (c *Client) defaultBinaryEncoding(ctx context.Context, dataTypeID *ua.NodeID) (*ua.NodeID, error) {
+	refs, err := c.Node(dataTypeID).ReferencesWithContext(ctx, id.HasEncoding, ua.BrowseDirectionForward, ua.NodeClassObject, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range refs {
+		if r.BrowseName != nil && r.BrowseName.NamespaceIndex == 0 && r.BrowseName.Name == "Default Binary" {
+			return r.NodeID.NodeID, nil
+		}
+	}
+	return nil, errors.Errorf("data type %s has no binary encoding", dataTypeID)
+}
+
+// DecodeExtensionObject decodes the body of an extension object whose
+// type was unknown when it was received. The data type of the encoding
+// is looked up on the server and loaded with LoadDataType.
+//
+// Extension objects which are already decoded are not changed.
+func (c *Client) DecodeExtensionObject(ctx context.Context, eo *ua.ExtensionObject) error {
+	if eo == nil || eo.Value != nil || eo.EncodingMask != ua.ExtensionObjectBinary {
+		return nil
+	}
+	if err := eo.DecodeBody(); err != ua.StatusBadDataTypeIDUnknown {
+		return err
+	}
+
+	refs, err := c.Node(eo.TypeID.NodeID).ReferencesWithContext(ctx, id.HasEncoding, ua.BrowseDirectionInverse, ua.NodeClassDataType, false)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return ua.StatusBadDataTypeIDUnknown
+	}
+	if err := c.LoadDataType(ctx, refs[0].NodeID.NodeID); err != nil {
+		return err
+	}
+	return eo.DecodeBody()
+}
+
+// isBuiltinDataType returns true if the data type is one of the built-in
+// types of namespace 0.
+func isBuiltinDataType(dataTypeID *ua.NodeID) bool {
+	if dataTypeID.Namespace() != 0 {
+		return false
+	}
+	n := dataTypeID.IntID()
+	return n >= 1 && n <= 25
+}
+
+// decodeExtensionObjects decodes the extension objects of unknown types
+// in the value with DecodeExtensionObject. Errors are logged since the
+// value is returned with the status StatusBadDataTypeIDUnknown instead.
+func (c *Client) decodeExtensionObjects(ctx context.Context, v *ua.Variant) {
+	var eos []*ua.ExtensionObject
+	switch x := v.Value().(type) {
+	case *ua.ExtensionObject:
+		eos = []*ua.ExtensionObject{x}
+	case []*ua.ExtensionObject:
+		eos = x
+	}
+	for _, eo := range eos {
+		if err := c.DecodeExtensionObject(ctx, eo); err != nil {
+			debug.Printf("client: cannot decode extension object %s: %s", eo.TypeID, err)
+		}
+	}
+}
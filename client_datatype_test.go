@@ -0,0 +1,289 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+// testSensor is the binary encoding of the Sensor data type of
+// addDataTypeTestNodes. The client does not know the type.
+type testSensor struct {
+	EncodingMask uint32
+	Name         string
+	Level        float64
+	Limit        float64
+}
+
+// addDataTypeTestNodes adds a structure data type with a field of a
+// simple data type and a variable with a value of the structure. The
+// encoding is added before the data type so that it has the inverse
+// HasEncoding reference.
+func addDataTypeTestNodes(t *testing.T, srv *Server) uint16 {
+	t.Helper()
+
+	as := srv.AddressSpace()
+	ns := as.AddNamespace("urn:gopcua:datatypes", nil)
+
+	for _, n := range []ServerNode{
+		&DataTypeNode{BaseNode: BaseNode{
+			NodeID:     ua.NewNumericNodeID(ns, 100),
+			BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Percent"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.HasSubtype), false, ua.NewNumericNodeID(0, id.Double)),
+			},
+		}},
+		&ObjectNode{BaseNode: BaseNode{
+			NodeID:     ua.NewNumericNodeID(ns, 102),
+			BrowseName: &ua.QualifiedName{Name: "Default Binary"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.DataTypeEncodingType)),
+			},
+		}},
+		&DataTypeNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewNumericNodeID(ns, 101),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Sensor"},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.HasSubtype), false, ua.NewNumericNodeID(0, id.Structure)),
+					NewReference(ua.NewNumericNodeID(0, id.HasEncoding), true, ua.NewNumericNodeID(ns, 102)),
+				},
+			},
+			DataTypeDefinition: ua.NewExtensionObject(&ua.StructureDefinition{
+				DefaultEncodingID: ua.NewTwoByteNodeID(0),
+				BaseDataType:      ua.NewNumericNodeID(0, id.Structure),
+				StructureType:     ua.StructureTypeStructureWithOptionalFields,
+				Fields: []*ua.StructureField{
+					{Name: "Name", Description: &ua.LocalizedText{}, DataType: ua.NewNumericNodeID(0, id.String), ValueRank: -1},
+					{Name: "Level", Description: &ua.LocalizedText{}, DataType: ua.NewNumericNodeID(ns, 100), ValueRank: -1},
+					{Name: "Limit", Description: &ua.LocalizedText{}, DataType: ua.NewNumericNodeID(0, id.Double), ValueRank: -1, IsOptional: true},
+				},
+			}),
+		},
+		&VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewStringNodeID(ns, "Sensor1"),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Sensor1"},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.Organizes), false, ua.NewNumericNodeID(0, id.ObjectsFolder)),
+				},
+			},
+			Value: &ua.DataValue{
+				EncodingMask: ua.DataValueValue,
+				Value: ua.MustVariant(&ua.ExtensionObject{
+					EncodingMask: ua.ExtensionObjectBinary,
+					TypeID:       &ua.ExpandedNodeID{NodeID: ua.NewNumericNodeID(ns, 102)},
+					Value:        &testSensor{EncodingMask: 1, Name: "s1", Level: 42.5, Limit: 90},
+				}),
+			},
+			DataType:        ua.NewNumericNodeID(ns, 101),
+			ValueRank:       -1,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+		},
+	} {
+		if err := as.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ns
+}
+
+func TestClientLoadDataTypes(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns := addDataTypeTestNodes(t, srv)
+
+	read := func(c *Client) *ua.DataValue {
+		t.Helper()
+		res, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+			NodesToRead: []*ua.ReadValueID{{NodeID: ua.NewStringNodeID(ns, "Sensor1"), AttributeID: ua.AttributeIDValue}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0]
+	}
+
+	// the type is unknown without LoadDataTypes
+	c := connectTestClient(t, srv)
+	if got, want := read(c).Status, ua.StatusBadDataTypeIDUnknown; got != want {
+		t.Fatalf("got status %s want %s", got, want)
+	}
+
+	c = NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false), LoadDataTypes(true))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	dv := read(c)
+	if dv.Status != ua.StatusOK {
+		t.Fatalf("got status %s want %s", dv.Status, ua.StatusOK)
+	}
+	want := &ua.DynamicStructure{
+		TypeID: ua.NewNumericNodeID(ns, 101),
+		Fields: []*ua.DynamicField{
+			{Name: "Name", Value: "s1"},
+			{Name: "Level", Value: 42.5},
+			{Name: "Limit", Value: float64(90)},
+		},
+	}
+	verify.Values(t, "", dv.Value.ExtensionObject().Value, want)
+
+	d, ok := ua.LookupDataType(ua.NewNumericNodeID(ns, 100)).(*ua.SimpleTypeDescription)
+	if !ok {
+		t.Fatal("simple data type not registered")
+	}
+	if got, want := ua.TypeID(d.BuiltInType), ua.TypeIDDouble; got != want {
+		t.Fatalf("got built-in type %s want %s", got, want)
+	}
+}
+
+// testMachine is the binary encoding of the Machine type of
+// testDictionary.
+type testMachine struct {
+	EncodingMask uint32
+	Mode         int32
+	Name         string
+	Tags         []string
+	Limit        float64
+}
+
+const testDictionary = `<opc:TypeDictionary xmlns:opc="http://opcfoundation.org/BinarySchema/" xmlns:ua="http://opcfoundation.org/UA/" xmlns:tns="urn:gopcua:dictionary" DefaultByteOrder="LittleEndian" TargetNamespace="urn:gopcua:dictionary">
+  <opc:EnumeratedType Name="Mode" LengthInBits="32">
+    <opc:EnumeratedValue Name="Off" Value="0"/>
+    <opc:EnumeratedValue Name="On" Value="1"/>
+  </opc:EnumeratedType>
+  <opc:StructuredType Name="Machine" BaseType="ua:ExtensionObject">
+    <opc:Field Name="LimitSpecified" TypeName="opc:Bit"/>
+    <opc:Field Name="Reserved1" TypeName="opc:Bit" Length="31"/>
+    <opc:Field Name="Mode" TypeName="tns:Mode"/>
+    <opc:Field Name="Name" TypeName="opc:CharArray"/>
+    <opc:Field Name="NoOfTags" TypeName="opc:Int32"/>
+    <opc:Field Name="Tags" TypeName="opc:String" LengthField="NoOfTags"/>
+    <opc:Field Name="Limit" TypeName="opc:Double" SwitchField="LimitSpecified"/>
+  </opc:StructuredType>
+</opc:TypeDictionary>`
+
+// addDictionaryTestNodes adds a type dictionary of the OPC Binary type
+// system with a structure and a variable with a value of the structure.
+// The data type has no DataTypeDefinition like on servers which
+// implement a version of the specification before 1.04.
+func addDictionaryTestNodes(t *testing.T, srv *Server) uint16 {
+	t.Helper()
+
+	as := srv.AddressSpace()
+	ns := as.AddNamespace("urn:gopcua:dictionary", nil)
+
+	for _, n := range []ServerNode{
+		&ObjectNode{BaseNode: BaseNode{
+			NodeID:     ua.NewNumericNodeID(0, id.OPCBinarySchema_TypeSystem),
+			BrowseName: &ua.QualifiedName{Name: "OPC Binary"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.DataTypeSystemType)),
+			},
+		}},
+		&VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewNumericNodeID(ns, 200),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Dictionary"},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.HasComponent), false, ua.NewNumericNodeID(0, id.OPCBinarySchema_TypeSystem)),
+					NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.DataTypeDictionaryType)),
+				},
+			},
+			Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant([]byte(testDictionary))},
+			DataType:        ua.NewNumericNodeID(0, id.ByteString),
+			ValueRank:       -1,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+		},
+		&VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewNumericNodeID(ns, 201),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Machine"},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.HasComponent), false, ua.NewNumericNodeID(ns, 200)),
+					NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.DataTypeDescriptionType)),
+				},
+			},
+			Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant("Machine")},
+			DataType:        ua.NewNumericNodeID(0, id.String),
+			ValueRank:       -1,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+		},
+		&ObjectNode{BaseNode: BaseNode{
+			NodeID:     ua.NewNumericNodeID(ns, 202),
+			BrowseName: &ua.QualifiedName{Name: "Default Binary"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.DataTypeEncodingType)),
+				NewReference(ua.NewNumericNodeID(0, id.HasDescription), true, ua.NewNumericNodeID(ns, 201)),
+			},
+		}},
+		&DataTypeNode{BaseNode: BaseNode{
+			NodeID:     ua.NewNumericNodeID(ns, 203),
+			BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Machine"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.HasSubtype), false, ua.NewNumericNodeID(0, id.Structure)),
+				NewReference(ua.NewNumericNodeID(0, id.HasEncoding), true, ua.NewNumericNodeID(ns, 202)),
+			},
+		}},
+		&VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewStringNodeID(ns, "Machine1"),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Machine1"},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.Organizes), false, ua.NewNumericNodeID(0, id.ObjectsFolder)),
+				},
+			},
+			Value: &ua.DataValue{
+				EncodingMask: ua.DataValueValue,
+				Value: ua.MustVariant(&ua.ExtensionObject{
+					EncodingMask: ua.ExtensionObjectBinary,
+					TypeID:       &ua.ExpandedNodeID{NodeID: ua.NewNumericNodeID(ns, 202)},
+					Value:        &testMachine{EncodingMask: 1, Mode: 1, Name: "m1", Tags: []string{"a", "b"}, Limit: 90},
+				}),
+			},
+			DataType:        ua.NewNumericNodeID(ns, 203),
+			ValueRank:       -1,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+		},
+	} {
+		if err := as.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ns
+}
+
+func TestClientLoadDataTypeDictionaries(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns := addDictionaryTestNodes(t, srv)
+
+	c := connectTestClient(t, srv)
+	if err := c.LoadDataTypeDictionaries(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := c.Node(ua.NewStringNodeID(ns, "Machine1")).ValueWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ua.DynamicStructure{
+		TypeID: ua.NewNumericNodeID(ns, 203),
+		Fields: []*ua.DynamicField{
+			{Name: "Mode", Value: int32(1)},
+			{Name: "Name", Value: "m1"},
+			{Name: "Tags", Value: []string{"a", "b"}},
+			{Name: "Limit", Value: float64(90)},
+		},
+	}
+	verify.Values(t, "", v.ExtensionObject().Value, want)
+}
@@ -0,0 +1,362 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"strings"
+
+	"github.com/imatic-tech/opcua/bsd"
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// LoadDataTypeDictionaries reads the type dictionaries of the OPC Binary
+// type system of the server and registers their structured and enumerated
+// types with the ua package. Extension objects of these types are then
+// decoded into *ua.DynamicStructure values unless a Go type was registered
+// with ua.RegisterExtensionObject.
+//
+// Servers which implement a version of the specification before 1.04 do
+// not have the DataTypeDefinition attribute which LoadDataType uses and
+// describe their types only with the dictionaries.
+//
+// Data types which are already registered are not changed. Types which
+// cannot be described with a StructureDefinition, e.g. structures with
+// switch fields other than optional fields and unions, are skipped and
+// logged.
+//
+// Specification: Part 3, Annex C and Part 5, Annex D
+func (c *Client) LoadDataTypeDictionaries(ctx context.Context) error {
+	refs, err := c.Node(ua.NewNumericNodeID(0, id.OPCBinarySchema_TypeSystem)).ReferencesWithContext(ctx, id.HasComponent, ua.BrowseDirectionForward, ua.NodeClassVariable, true)
+	if err != nil {
+		return err
+	}
+
+	var dicts []*dictionary
+	for _, r := range refs {
+		d, err := c.readDictionary(ctx, r.NodeID.NodeID)
+		if err != nil {
+			return errors.Errorf("dictionary %s: %s", r.NodeID, err)
+		}
+		// the types of namespace 0 have Go types
+		if d.TargetNamespace == bsd.UANamespace {
+			continue
+		}
+		dicts = append(dicts, d)
+	}
+
+	// the types of a dictionary can refer to imported dictionaries
+	types := make(map[dictionaryTypeName]*dictionaryType)
+	for _, d := range dicts {
+		for _, t := range d.types {
+			types[dictionaryTypeName{d.TargetNamespace, t.name}] = t
+		}
+	}
+
+	for _, d := range dicts {
+		for _, t := range d.Enums {
+			dt := types[dictionaryTypeName{d.TargetNamespace, t.Name}]
+			if dt == nil || ua.LookupDataType(dt.dataTypeID) != nil {
+				continue
+			}
+			ua.RegisterEnumDescription(enumDescription(t, dt.dataTypeID))
+		}
+		for _, t := range d.Types {
+			dt := types[dictionaryTypeName{d.TargetNamespace, t.Name}]
+			if dt == nil || ua.LookupDataType(dt.dataTypeID) != nil {
+				continue
+			}
+			sd, err := structureDescription(d.TypeDictionary, t, dt, types)
+			if err != nil {
+				debug.Printf("client: cannot load data type %s of dictionary %s: %s", t.Name, d.TargetNamespace, err)
+				continue
+			}
+			ua.RegisterStructureDescription(sd)
+		}
+	}
+	return nil
+}
+
+// dictionary is a type dictionary of the server with the data types and
+// encodings of its types.
+type dictionary struct {
+	*bsd.TypeDictionary
+	types []*dictionaryType
+}
+
+type dictionaryTypeName struct {
+	namespace, name string
+}
+
+// dictionaryType is a type of a dictionary which has a binary encoding
+// on the server.
+type dictionaryType struct {
+	name       string
+	dataTypeID *ua.NodeID
+	encodingID *ua.NodeID
+}
+
+// readDictionary reads the value of a DataTypeDictionary variable and
+// resolves the data types and encodings of the DataTypeDescription
+// variables of the dictionary. The value of a description is the name of
+// the type in the dictionary. The encoding refers to the description with
+// a HasDescription reference and the data type refers to the encoding
+// with a HasEncoding reference.
+func (c *Client) readDictionary(ctx context.Context, dictID *ua.NodeID) (*dictionary, error) {
+	n := c.Node(dictID)
+	v, err := n.ValueWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.Value().([]byte)
+	if !ok {
+		return nil, errors.Errorf("invalid value %T", v.Value())
+	}
+	td, err := bsd.Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	d := &dictionary{TypeDictionary: td}
+	if td.TargetNamespace == bsd.UANamespace {
+		return d, nil
+	}
+
+	descs, err := n.ReferencesWithContext(ctx, id.HasComponent, ua.BrowseDirectionForward, ua.NodeClassVariable, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range descs {
+		t, err := c.readDictionaryType(ctx, r.NodeID.NodeID)
+		if err != nil {
+			return nil, errors.Errorf("description %s: %s", r.NodeID, err)
+		}
+		if t != nil {
+			d.types = append(d.types, t)
+		}
+	}
+	return d, nil
+}
+
+// readDictionaryType returns the type of a DataTypeDescription variable
+// or nil if the description has no encoding.
+func (c *Client) readDictionaryType(ctx context.Context, descID *ua.NodeID) (*dictionaryType, error) {
+	n := c.Node(descID)
+	v, err := n.ValueWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := v.Value().(string)
+	if !ok {
+		return nil, errors.Errorf("invalid value %T", v.Value())
+	}
+
+	encs, err := n.ReferencesWithContext(ctx, id.HasDescription, ua.BrowseDirectionInverse, ua.NodeClassObject, false)
+	if err != nil || len(encs) == 0 {
+		return nil, err
+	}
+	encodingID := encs[0].NodeID.NodeID
+
+	dts, err := c.Node(encodingID).ReferencesWithContext(ctx, id.HasEncoding, ua.BrowseDirectionInverse, ua.NodeClassDataType, false)
+	if err != nil || len(dts) == 0 {
+		return nil, err
+	}
+	return &dictionaryType{name: name, dataTypeID: dts[0].NodeID.NodeID, encodingID: encodingID}, nil
+}
+
+// enumDescription returns the description of an enumerated type. The
+// values are encoded as Int32 regardless of their length in bits.
+func enumDescription(t *bsd.EnumType, dataTypeID *ua.NodeID) *ua.EnumDescription {
+	def := &ua.EnumDefinition{}
+	for _, v := range t.Values {
+		def.Fields = append(def.Fields, &ua.EnumField{
+			Name:        v.Name,
+			Value:       int64(v.Value),
+			DisplayName: &ua.LocalizedText{EncodingMask: ua.LocalizedTextText, Text: v.Name},
+			Description: &ua.LocalizedText{},
+		})
+	}
+	return &ua.EnumDescription{
+		DataTypeID:     dataTypeID,
+		Name:           &ua.QualifiedName{NamespaceIndex: dataTypeID.Namespace(), Name: t.Name},
+		EnumDefinition: def,
+		BuiltInType:    uint8(ua.TypeIDInt32),
+	}
+}
+
+// structureDescription converts a structured type of a dictionary into
+// the StructureDefinition with the same binary encoding.
+//
+// The fields which contain the length of an array are omitted and the
+// array fields get a value rank of one. Leading opc:Bit fields of 32 bits
+// which switch the optional fields in order become the encoding mask of a
+// structure with optional fields. A leading UInt32 switch field which
+// selects the other fields with the values 1..n becomes a union.
+func structureDescription(d *bsd.TypeDictionary, t *bsd.StructType, dt *dictionaryType, types map[dictionaryTypeName]*dictionaryType) (*ua.StructureDescription, error) {
+	def := &ua.StructureDefinition{
+		DefaultEncodingID: dt.encodingID,
+		BaseDataType:      ua.NewNumericNodeID(0, id.Structure),
+		StructureType:     ua.StructureTypeStructure,
+	}
+
+	fields := t.Fields
+	switch {
+	case isUnion(t):
+		def.BaseDataType = ua.NewNumericNodeID(0, id.Union)
+		def.StructureType = ua.StructureTypeUnion
+		fields = fields[1:]
+
+	case len(fields) > 0 && fields[0].Type == "opc:Bit":
+		// the bits of the optional fields are numbered in order of the
+		// optional fields and padded to 32 bits
+		bits, pos := make(map[string]int), 0
+		for len(fields) > 0 && fields[0].Type == "opc:Bit" {
+			bits[fields[0].Name] = pos
+			pos += fields[0].Bits()
+			fields = fields[1:]
+		}
+		if pos != 32 {
+			return nil, errors.Errorf("%d bits of optional fields are not supported", pos)
+		}
+		n := 0
+		for _, f := range fields {
+			if f.SwitchField == "" {
+				continue
+			}
+			if b, ok := bits[f.SwitchField]; !ok || b != n {
+				return nil, errors.Errorf("optional field %s is not switched by bit %d", f.Name, n)
+			}
+			n++
+		}
+		def.StructureType = ua.StructureTypeStructureWithOptionalFields
+	}
+
+	for _, f := range fields {
+		if t.IsLengthField(f) {
+			continue
+		}
+		if f.SwitchField != "" && def.StructureType == ua.StructureTypeStructure {
+			return nil, errors.Errorf("switch field %s of field %s is not supported", f.SwitchField, f.Name)
+		}
+		if f.SwitchOperand != "" {
+			return nil, errors.Errorf("switch operand of field %s is not supported", f.Name)
+		}
+		dataType, err := dictionaryFieldType(d, f.Type, types)
+		if err != nil {
+			return nil, errors.Errorf("field %s: %s", f.Name, err)
+		}
+		sf := &ua.StructureField{
+			Name:        f.Name,
+			Description: &ua.LocalizedText{},
+			DataType:    dataType,
+			ValueRank:   -1,
+			IsOptional:  def.StructureType == ua.StructureTypeStructureWithOptionalFields && f.SwitchField != "",
+		}
+		if f.IsSlice() {
+			sf.ValueRank = 1
+			sf.ArrayDimensions = []uint32{0}
+		}
+		def.Fields = append(def.Fields, sf)
+	}
+
+	return &ua.StructureDescription{
+		DataTypeID:          dt.dataTypeID,
+		Name:                &ua.QualifiedName{NamespaceIndex: dt.dataTypeID.Namespace(), Name: t.Name},
+		StructureDefinition: def,
+	}, nil
+}
+
+// isUnion returns true if the first field of the type is a UInt32 switch
+// field which selects each of the other fields with its position.
+func isUnion(t *bsd.StructType) bool {
+	if len(t.Fields) < 2 || t.Fields[0].Type != "opc:UInt32" {
+		return false
+	}
+	for i, f := range t.Fields[1:] {
+		if f.SwitchField != t.Fields[0].Name || f.SwitchValue != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// opcTypes are the built-in types of the OPC Binary type system.
+var opcTypes = map[string]ua.TypeID{
+	"Boolean":    ua.TypeIDBoolean,
+	"SByte":      ua.TypeIDSByte,
+	"Byte":       ua.TypeIDByte,
+	"Int16":      ua.TypeIDInt16,
+	"UInt16":     ua.TypeIDUint16,
+	"Int32":      ua.TypeIDInt32,
+	"UInt32":     ua.TypeIDUint32,
+	"Int64":      ua.TypeIDInt64,
+	"UInt64":     ua.TypeIDUint64,
+	"Float":      ua.TypeIDFloat,
+	"Double":     ua.TypeIDDouble,
+	"String":     ua.TypeIDString,
+	"CharArray":  ua.TypeIDString,
+	"DateTime":   ua.TypeIDDateTime,
+	"Guid":       ua.TypeIDGUID,
+	"ByteString": ua.TypeIDByteString,
+}
+
+// uaTypes are the built-in types which are defined in the OPC UA
+// dictionary with names which are not data types of namespace 0.
+var uaTypes = map[string]ua.TypeID{
+	"ExtensionObject": ua.TypeIDExtensionObject,
+	"Variant":         ua.TypeIDVariant,
+}
+
+// uaTypeName converts the name of a type of the OPC UA dictionary into
+// the name of its constant in the id package.
+var uaTypeName = strings.NewReplacer(
+	"Guid", "GUID",
+	"Id", "ID",
+	"Json", "JSON",
+	"QualityOfService", "QoS",
+	"Uadp", "UADP",
+	"Uri", "URI",
+	"Url", "URL",
+	"Xml", "XML",
+)
+
+// dictionaryFieldType returns the data type of a field type of a
+// dictionary. Enumerated types without a data type are encoded as Int32.
+func dictionaryFieldType(d *bsd.TypeDictionary, typeName string, types map[dictionaryTypeName]*dictionaryType) (*ua.NodeID, error) {
+	ns, name := d.SplitType(typeName)
+	switch ns {
+	case bsd.BinarySchemaNamespace:
+		if t, ok := opcTypes[name]; ok {
+			return ua.NewNumericNodeID(0, uint32(t)), nil
+		}
+
+	case bsd.UANamespace:
+		if t, ok := uaTypes[name]; ok {
+			return ua.NewNumericNodeID(0, uint32(t)), nil
+		}
+		n := id.ID(strings.Replace(uaTypeName.Replace(name), "IDentity", "Identity", -1))
+		if n == 0 {
+			break
+		}
+		dataTypeID := ua.NewNumericNodeID(0, n)
+		if isBuiltinDataType(dataTypeID) || ua.LookupDataType(dataTypeID) != nil {
+			return dataTypeID, nil
+		}
+		// the structures of namespace 0 have Go types and the
+		// remaining types are enumerations
+		return ua.NewNumericNodeID(0, id.Int32), nil
+
+	default:
+		if t := types[dictionaryTypeName{ns, name}]; t != nil {
+			return t.dataTypeID, nil
+		}
+		if e := d.Enum(name); ns == d.TargetNamespace && e != nil && e.Bits == 32 {
+			return ua.NewNumericNodeID(0, id.Int32), nil
+		}
+	}
+	return nil, errors.Errorf("unknown type %s", typeName)
+}
@@ -0,0 +1,248 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/stats"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// HistoryReadProcessed reads aggregated values of the nodes, e.g. hourly
+// averages with id.AggregateFunction_Average as aggregate type and a
+// processing interval of one hour. details must contain one aggregate
+// type per node. The server capability defaults are used when the
+// aggregate configuration is nil.
+//
+// The continuation points are followed until the server has returned
+// all values. The HistoryData of each result is a *ua.HistoryData with
+// the values of all requests.
+//
+// Specification: Part 11, 6.4.4
+func (c *Client) HistoryReadProcessed(ctx context.Context, nodes []*ua.HistoryReadValueID, details *ua.ReadProcessedDetails) (*ua.HistoryReadResponse, error) {
+	stats.Client().Add("HistoryReadProcessed", 1)
+	stats.Client().Add("HistoryReadValueID", int64(len(nodes)))
+
+	if len(details.AggregateType) != len(nodes) {
+		return nil, ua.StatusBadAggregateListMismatch
+	}
+	if details.AggregateConfiguration == nil {
+		d := *details
+		d.AggregateConfiguration = &ua.AggregateConfiguration{UseServerCapabilitiesDefaults: true}
+		details = &d
+	}
+	return c.historyRead(ctx, nodes, details)
+}
+
+// HistoryReadAtTime reads the values of the nodes at the given
+// timestamps. The server interpolates values for timestamps without a
+// raw value.
+//
+// The continuation points are followed until the server has returned
+// all values. The HistoryData of each result is a *ua.HistoryData with
+// the values of all requests.
+//
+// Specification: Part 11, 6.4.5
+func (c *Client) HistoryReadAtTime(ctx context.Context, nodes []*ua.HistoryReadValueID, details *ua.ReadAtTimeDetails) (*ua.HistoryReadResponse, error) {
+	stats.Client().Add("HistoryReadAtTime", 1)
+	stats.Client().Add("HistoryReadValueID", int64(len(nodes)))
+
+	return c.historyRead(ctx, nodes, details)
+}
+
+// HistoryReadEvents reads the historical events of the nodes which match
+// the event filter of details. The event fields are returned in the order
+// of its select clauses.
+//
+// The continuation points are followed until the server has returned
+// all events. The HistoryData of each result is a *ua.HistoryEvent with
+// the events of all requests.
+//
+// Specification: Part 11, 6.4.2
+func (c *Client) HistoryReadEvents(ctx context.Context, nodes []*ua.HistoryReadValueID, details *ua.ReadEventDetails) (*ua.HistoryReadResponse, error) {
+	stats.Client().Add("HistoryReadEvents", 1)
+	stats.Client().Add("HistoryReadValueID", int64(len(nodes)))
+
+	return c.historyRead(ctx, nodes, details)
+}
+
+// historyRead reads the history of the nodes with the details until the
+// server has returned all values and merges the results. The
+// continuation points which the server still holds are released when a
+// request fails or ctx is cancelled.
+func (c *Client) historyRead(ctx context.Context, nodes []*ua.HistoryReadValueID, details interface{}) (*ua.HistoryReadResponse, error) {
+	it, err := c.NewHistoryIterator(ctx, nodes, details)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	res := &ua.HistoryReadResponse{Results: make([]*ua.HistoryReadResult, len(nodes))}
+	for it.Next() {
+		i, r := it.Index(), it.Result()
+		if res.Results[i] == nil {
+			res.Results[i] = r
+		} else {
+			res.Results[i].StatusCode = r.StatusCode
+			mergeHistoryData(res.Results[i], r.HistoryData)
+		}
+		if res.ResponseHeader == nil {
+			res.ResponseHeader = it.header
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// releaseHistoryContinuationPoints releases the continuation points of
+// the nodes on the server. It does not use the context of the history
+// read since it may have been cancelled.
+func (c *Client) releaseHistoryContinuationPoints(details *ua.ExtensionObject, nodes []*ua.HistoryReadValueID) error {
+	var release []*ua.HistoryReadValueID
+	for _, n := range nodes {
+		if len(n.ContinuationPoint) > 0 {
+			release = append(release, n)
+		}
+	}
+	if len(release) == 0 {
+		return nil
+	}
+
+	req := &ua.HistoryReadRequest{
+		HistoryReadDetails:        details,
+		TimestampsToReturn:        ua.TimestampsToReturnBoth,
+		ReleaseContinuationPoints: true,
+		NodesToRead:               release,
+	}
+	err := c.SendWithContext(context.Background(), req, func(interface{}) error {
+		return nil
+	})
+	if err != nil {
+		debug.Printf("client: cannot release history continuation points: %s", err)
+	}
+	return err
+}
+
+// mergeHistoryData appends the values or events of the history data to
+// the ones of the result.
+func mergeHistoryData(r *ua.HistoryReadResult, data *ua.ExtensionObject) {
+	if data == nil || data.Value == nil {
+		return
+	}
+	if r.HistoryData == nil || r.HistoryData.Value == nil {
+		r.HistoryData = data
+		return
+	}
+
+	switch dst := r.HistoryData.Value.(type) {
+	case *ua.HistoryData:
+		if src, ok := data.Value.(*ua.HistoryData); ok {
+			dst.DataValues = append(dst.DataValues, src.DataValues...)
+		}
+	case *ua.HistoryModifiedData:
+		if src, ok := data.Value.(*ua.HistoryModifiedData); ok {
+			dst.DataValues = append(dst.DataValues, src.DataValues...)
+			dst.ModificationInfos = append(dst.ModificationInfos, src.ModificationInfos...)
+		}
+	case *ua.HistoryEvent:
+		if src, ok := data.Value.(*ua.HistoryEvent); ok {
+			dst.Events = append(dst.Events, src.Events...)
+		}
+	}
+}
+
+// HistoryUpdateData inserts, replaces or updates the values of the nodes
+// in the history depending on PerformInsertReplace of each details. The
+// OperationResults of each result contain the status of each value.
+//
+// Specification: Part 11, 6.9.2
+func (c *Client) HistoryUpdateData(ctx context.Context, details []*ua.UpdateDataDetails) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryUpdateData", 1)
+
+	eos := make([]*ua.ExtensionObject, len(details))
+	for i, d := range details {
+		eos[i] = ua.NewExtensionObject(d)
+	}
+	return c.historyUpdate(ctx, eos, c.OperationLimits().MaxNodesPerHistoryUpdateData)
+}
+
+// HistoryUpdateStructureData inserts, replaces, updates or removes the
+// structured values, e.g. annotations, of the nodes in the history
+// depending on PerformInsertReplace of each details.
+//
+// Specification: Part 11, 6.9.3
+func (c *Client) HistoryUpdateStructureData(ctx context.Context, details []*ua.UpdateStructureDataDetails) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryUpdateStructureData", 1)
+
+	eos := make([]*ua.ExtensionObject, len(details))
+	for i, d := range details {
+		eos[i] = ua.NewExtensionObject(d)
+	}
+	return c.historyUpdate(ctx, eos, c.OperationLimits().MaxNodesPerHistoryUpdateData)
+}
+
+// HistoryUpdateEvents inserts, replaces or updates the historical events
+// of the nodes depending on PerformInsertReplace of each details. The
+// fields of the events must be in the order of the select clauses of the
+// filter.
+//
+// Specification: Part 11, 6.9.4
+func (c *Client) HistoryUpdateEvents(ctx context.Context, details []*ua.UpdateEventDetails) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryUpdateEvents", 1)
+
+	eos := make([]*ua.ExtensionObject, len(details))
+	for i, d := range details {
+		eos[i] = ua.NewExtensionObject(d)
+	}
+	return c.historyUpdate(ctx, eos, c.OperationLimits().MaxNodesPerHistoryUpdateEvents)
+}
+
+// HistoryDeleteRawModified deletes the raw or modified values of the
+// nodes between the start and end time of each details.
+//
+// Specification: Part 11, 6.9.5
+func (c *Client) HistoryDeleteRawModified(ctx context.Context, details []*ua.DeleteRawModifiedDetails) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryDeleteRawModified", 1)
+
+	eos := make([]*ua.ExtensionObject, len(details))
+	for i, d := range details {
+		eos[i] = ua.NewExtensionObject(d)
+	}
+	return c.historyUpdate(ctx, eos, c.OperationLimits().MaxNodesPerHistoryUpdateData)
+}
+
+// HistoryDeleteAtTime deletes the values of the nodes at the given
+// timestamps. The OperationResults of each result contain the status of
+// each timestamp.
+//
+// Specification: Part 11, 6.9.6
+func (c *Client) HistoryDeleteAtTime(ctx context.Context, details []*ua.DeleteAtTimeDetails) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryDeleteAtTime", 1)
+
+	eos := make([]*ua.ExtensionObject, len(details))
+	for i, d := range details {
+		eos[i] = ua.NewExtensionObject(d)
+	}
+	return c.historyUpdate(ctx, eos, c.OperationLimits().MaxNodesPerHistoryUpdateData)
+}
+
+// HistoryDeleteEvents deletes the historical events of the nodes with the
+// given event ids. The OperationResults of each result contain the status
+// of each event.
+//
+// Specification: Part 11, 6.9.7
+func (c *Client) HistoryDeleteEvents(ctx context.Context, details []*ua.DeleteEventDetails) (*ua.HistoryUpdateResponse, error) {
+	stats.Client().Add("HistoryDeleteEvents", 1)
+
+	eos := make([]*ua.ExtensionObject, len(details))
+	for i, d := range details {
+		eos[i] = ua.NewExtensionObject(d)
+	}
+	return c.historyUpdate(ctx, eos, c.OperationLimits().MaxNodesPerHistoryUpdateEvents)
+}
@@ -0,0 +1,246 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+// testHistorian serves history reads with five values or events per node
+// and returns at most two per request.
+type testHistorian struct {
+	details  []interface{}
+	released [][]byte
+
+	// failAfter fails the nth request when it is not zero.
+	failAfter int
+	requests  int
+}
+
+func (h *testHistorian) handle(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.HistoryReadRequest)
+	h.details = append(h.details, r.HistoryReadDetails.Value)
+	if r.ReleaseContinuationPoints {
+		for _, n := range r.NodesToRead {
+			h.released = append(h.released, n.ContinuationPoint)
+		}
+		return &ua.HistoryReadResponse{}, nil
+	}
+
+	h.requests++
+	if h.requests == h.failAfter {
+		return nil, ua.StatusBadInternalError
+	}
+
+	res := &ua.HistoryReadResponse{}
+	for _, n := range r.NodesToRead {
+		start := 0
+		if len(n.ContinuationPoint) > 0 {
+			start = int(n.ContinuationPoint[0])
+		}
+		end := start + 2
+		var cp []byte
+		if end < 5 {
+			cp = []byte{byte(end)}
+		} else {
+			end = 5
+		}
+
+		var data interface{}
+		switch r.HistoryReadDetails.Value.(type) {
+		case *ua.ReadEventDetails:
+			ev := &ua.HistoryEvent{}
+			for i := start; i < end; i++ {
+				ev.Events = append(ev.Events, &ua.HistoryEventFieldList{EventFields: []*ua.Variant{ua.MustVariant(int32(i))}})
+			}
+			data = ev
+		default:
+			hd := &ua.HistoryData{}
+			for i := start; i < end; i++ {
+				hd.DataValues = append(hd.DataValues, &ua.DataValue{
+					EncodingMask: ua.DataValueValue,
+					Value:        ua.MustVariant(float64(n.NodeID.IntID()*10) + float64(i)),
+				})
+			}
+			data = hd
+		}
+		res.Results = append(res.Results, &ua.HistoryReadResult{
+			StatusCode:        ua.StatusOK,
+			ContinuationPoint: cp,
+			HistoryData:       ua.NewExtensionObject(data),
+		})
+	}
+	return res, nil
+}
+
+func TestClientHistoryRead(t *testing.T) {
+	ctx := context.Background()
+	h := &testHistorian{}
+	srv := startTestServer(t)
+	srv.Handle(id.HistoryReadRequest_Encoding_DefaultBinary, h.handle)
+	c := connectTestClient(t, srv)
+
+	nodes := []*ua.HistoryReadValueID{
+		{NodeID: ua.NewNumericNodeID(1, 1), DataEncoding: &ua.QualifiedName{}},
+		{NodeID: ua.NewNumericNodeID(1, 2), DataEncoding: &ua.QualifiedName{}},
+	}
+	values := func(res *ua.HistoryReadResponse) [][]interface{} {
+		var v [][]interface{}
+		for _, r := range res.Results {
+			var x []interface{}
+			switch d := r.HistoryData.Value.(type) {
+			case *ua.HistoryData:
+				for _, dv := range d.DataValues {
+					x = append(x, dv.Value.Value())
+				}
+			case *ua.HistoryEvent:
+				for _, ev := range d.Events {
+					x = append(x, ev.EventFields[0].Value())
+				}
+			}
+			v = append(v, x)
+		}
+		return v
+	}
+
+	t.Run("processed", func(t *testing.T) {
+		*h = testHistorian{}
+		start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		res, err := c.HistoryReadProcessed(ctx, nodes, &ua.ReadProcessedDetails{
+			StartTime:          start,
+			EndTime:            start.Add(5 * time.Hour),
+			ProcessingInterval: float64(time.Hour / time.Millisecond),
+			AggregateType: []*ua.NodeID{
+				ua.NewNumericNodeID(0, id.AggregateFunction_Average),
+				ua.NewNumericNodeID(0, id.AggregateFunction_Maximum),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "values", values(res), [][]interface{}{
+			{10.0, 11.0, 12.0, 13.0, 14.0},
+			{20.0, 21.0, 22.0, 23.0, 24.0},
+		})
+		verify.Values(t, "requests", h.requests, 3)
+		verify.Values(t, "continuation points", res.Results[0].ContinuationPoint, []byte(nil))
+		d := h.details[0].(*ua.ReadProcessedDetails)
+		verify.Values(t, "aggregate configuration", d.AggregateConfiguration, &ua.AggregateConfiguration{UseServerCapabilitiesDefaults: true})
+		verify.Values(t, "nodes", nodes[0].ContinuationPoint, []byte(nil))
+	})
+
+	t.Run("aggregate list mismatch", func(t *testing.T) {
+		_, err := c.HistoryReadProcessed(ctx, nodes, &ua.ReadProcessedDetails{})
+		verify.Values(t, "", err, ua.StatusBadAggregateListMismatch)
+	})
+
+	t.Run("at time", func(t *testing.T) {
+		*h = testHistorian{}
+		res, err := c.HistoryReadAtTime(ctx, nodes[:1], &ua.ReadAtTimeDetails{
+			ReqTimes: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "values", values(res), [][]interface{}{{10.0, 11.0, 12.0, 13.0, 14.0}})
+	})
+
+	t.Run("events", func(t *testing.T) {
+		*h = testHistorian{}
+		res, err := c.HistoryReadEvents(ctx, nodes[:1], &ua.ReadEventDetails{
+			Filter: &ua.EventFilter{
+				SelectClauses: []*ua.SimpleAttributeOperand{{
+					TypeDefinitionID: ua.NewNumericNodeID(0, id.BaseEventType),
+					BrowsePath:       []*ua.QualifiedName{{Name: "Severity"}},
+					AttributeID:      ua.AttributeIDValue,
+				}},
+				WhereClause: &ua.ContentFilter{},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "events", values(res), [][]interface{}{{int32(0), int32(1), int32(2), int32(3), int32(4)}})
+	})
+
+	t.Run("release continuation points on error", func(t *testing.T) {
+		*h = testHistorian{failAfter: 2}
+		_, err := c.HistoryReadAtTime(ctx, nodes, &ua.ReadAtTimeDetails{})
+		verify.Values(t, "error", err, ua.StatusBadInternalError)
+		verify.Values(t, "released", h.released, [][]byte{{2}, {2}})
+	})
+}
+
+func TestClientHistoryUpdate(t *testing.T) {
+	ctx := context.Background()
+	var details [][]interface{}
+	handle := func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		r := req.(*ua.HistoryUpdateRequest)
+		var d []interface{}
+		res := &ua.HistoryUpdateResponse{}
+		for _, eo := range r.HistoryUpdateDetails {
+			d = append(d, eo.Value)
+			var n int
+			switch x := eo.Value.(type) {
+			case *ua.UpdateDataDetails:
+				n = len(x.UpdateValues)
+			case *ua.DeleteEventDetails:
+				n = len(x.EventIDs)
+			}
+			res.Results = append(res.Results, &ua.HistoryUpdateResult{
+				StatusCode:       ua.StatusOK,
+				OperationResults: make([]ua.StatusCode, n),
+			})
+		}
+		details = append(details, d)
+		return res, nil
+	}
+	srv := startTestServer(t, ServerOperationLimits(OperationLimits{
+		MaxNodesPerHistoryUpdateData: 2,
+	}))
+	srv.Handle(id.HistoryUpdateRequest_Encoding_DefaultBinary, handle)
+	c := connectTestClient(t, srv)
+
+	t.Run("data", func(t *testing.T) {
+		details = nil
+		ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		var upd []*ua.UpdateDataDetails
+		for i := 0; i < 3; i++ {
+			upd = append(upd, &ua.UpdateDataDetails{
+				NodeID:               ua.NewNumericNodeID(1, uint32(i)),
+				PerformInsertReplace: ua.PerformUpdateTypeInsert,
+				UpdateValues: []*ua.DataValue{{
+					EncodingMask:    ua.DataValueValue | ua.DataValueSourceTimestamp,
+					Value:           ua.MustVariant(float64(i)),
+					SourceTimestamp: ts,
+				}},
+			})
+		}
+		res, err := c.HistoryUpdateData(ctx, upd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "requests", len(details), 2)
+		verify.Values(t, "details", details[0][1], upd[1])
+		verify.Values(t, "results", len(res.Results), 3)
+		verify.Values(t, "operation results", res.Results[2].OperationResults, []ua.StatusCode{ua.StatusOK})
+	})
+
+	t.Run("delete events", func(t *testing.T) {
+		details = nil
+		del := []*ua.DeleteEventDetails{{
+			NodeID:   ua.NewNumericNodeID(1, 1),
+			EventIDs: [][]byte{{1}, {2}, {3}},
+		}}
+		res, err := c.HistoryDeleteEvents(ctx, del)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "details", details, [][]interface{}{{del[0]}})
+		verify.Values(t, "operation results", res.Results[0].OperationResults, []ua.StatusCode{ua.StatusOK, ua.StatusOK, ua.StatusOK})
+	})
+}
@@ -0,0 +1,444 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// ExportNodeSet browses the address space of the server from the start
+// nodes and returns the nodes of all namespaces except namespace 0 as a
+// node set, e.g. to write it with NodeSet.Encode. Without start nodes
+// the export starts at the root folder.
+//
+// The nodes of namespace 0 are not exported but their forward
+// hierarchical references are followed to find the nodes of the other
+// namespaces. Variables and methods of namespace 0 are not browsed. The
+// nodes which an exported node refers to with a forward reference, its
+// supertypes, the data types of variables and the data types of the
+// fields of structures are exported as well. This includes the custom
+// types of the server with their DataTypeDefinition.
+//
+// The namespace indexes of the node set are the namespace indexes of the
+// server.
+//
+// Specification: Part 6, Annex F
+func (c *Client) ExportNodeSet(ctx context.Context, start ...*ua.NodeID) (*nodeset.NodeSet, error) {
+	ns, err := c.NamespaceArrayWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(start) == 0 {
+		start = []*ua.NodeID{ua.NewNumericNodeID(0, id.RootFolder)}
+	}
+
+	e := &nodeSetExporter{
+		c:          c,
+		s:          &nodeset.NodeSet{},
+		namespaces: ns,
+		seen:       map[string]bool{},
+		exported:   map[uint16]bool{},
+	}
+	if len(ns) > 1 {
+		e.s.NamespaceURIs = ns[1:]
+	}
+	for _, n := range start {
+		e.visit(n)
+	}
+	for len(e.queue) > 0 {
+		n := e.queue[0]
+		e.queue = e.queue[1:]
+		if err := e.export(ctx, n); err != nil {
+			return nil, errors.Errorf("node %s: %s", n, err)
+		}
+	}
+
+	for i := 1; i < len(ns); i++ {
+		if e.exported[uint16(i)] {
+			e.s.Models = append(e.s.Models, &nodeset.Model{
+				ModelURI:       ns[i],
+				RequiredModels: []*nodeset.Model{{ModelURI: nodeset.UANamespace}},
+			})
+		}
+	}
+	return e.s, nil
+}
+
+// nodeSetExporter reads the nodes of the address space of a server.
+type nodeSetExporter struct {
+	c          *Client
+	s          *nodeset.NodeSet
+	namespaces []string
+	seen       map[string]bool
+	queue      []*ua.NodeID
+
+	// exported contains the namespaces with exported nodes.
+	exported map[uint16]bool
+}
+
+// visit adds the node to the queue unless it was visited before.
+func (e *nodeSetExporter) visit(n *ua.NodeID) {
+	if n == nil || e.seen[n.String()] {
+		return
+	}
+	e.seen[n.String()] = true
+	e.queue = append(e.queue, n)
+}
+
+// localNodeID returns the node id of an expanded node id which refers to
+// a node of the server or nil.
+func (e *nodeSetExporter) localNodeID(n *ua.ExpandedNodeID) *ua.NodeID {
+	if n == nil || n.NodeID == nil || n.ServerIndex != 0 {
+		return nil
+	}
+	if n.NamespaceURI == "" {
+		return n.NodeID
+	}
+	for i, uri := range e.namespaces {
+		if uri == n.NamespaceURI {
+			nodeID := *n.NodeID
+			if err := nodeID.SetNamespace(uint16(i)); err != nil {
+				return nil
+			}
+			return &nodeID
+		}
+	}
+	return nil
+}
+
+func (e *nodeSetExporter) export(ctx context.Context, nodeID *ua.NodeID) error {
+	node := e.c.Node(nodeID)
+
+	if nodeID.Namespace() == 0 {
+		refs, err := node.ReferencesWithContext(ctx, id.HierarchicalReferences, ua.BrowseDirectionForward, ua.NodeClassAll, true)
+		if err != nil {
+			return err
+		}
+		for _, r := range refs {
+			target := e.localNodeID(r.NodeID)
+			if target == nil {
+				continue
+			}
+			if target.Namespace() != 0 || (r.NodeClass != ua.NodeClassVariable && r.NodeClass != ua.NodeClassMethod) {
+				e.visit(target)
+			}
+		}
+		return nil
+	}
+
+	dv, err := node.AttributesWithContext(ctx,
+		ua.AttributeIDNodeClass,
+		ua.AttributeIDBrowseName,
+		ua.AttributeIDDisplayName,
+		ua.AttributeIDDescription,
+		ua.AttributeIDWriteMask,
+		ua.AttributeIDUserWriteMask,
+	)
+	if err != nil {
+		return err
+	}
+	if len(dv) != 6 || attributeValue(dv[0]) == nil {
+		return ua.StatusBadNodeIDUnknown
+	}
+	nodeClass := ua.NodeClass(dv[0].Value.Int())
+	browseName, ok := attributeValue(dv[1]).(*ua.QualifiedName)
+	if !ok {
+		return ua.StatusBadBrowseNameInvalid
+	}
+	n := &nodeset.Node{
+		NodeID:      nodeID.String(),
+		BrowseName:  nodeset.FormatQualifiedName(browseName),
+		DisplayName: nodeSetText(attributeValue(dv[2])),
+		Description: nodeSetText(attributeValue(dv[3])),
+	}
+	n.WriteMask, _ = attributeValue(dv[4]).(uint32)
+	n.UserWriteMask, _ = attributeValue(dv[5]).(uint32)
+
+	refs, err := node.ReferencesWithContext(ctx, id.References, ua.BrowseDirectionBoth, ua.NodeClassAll, true)
+	if err != nil {
+		return err
+	}
+	var parent string
+	for _, r := range refs {
+		target := e.localNodeID(r.NodeID)
+		if target == nil || r.ReferenceTypeID == nil {
+			continue
+		}
+		n.References = append(n.References, &nodeset.Reference{
+			ReferenceType: r.ReferenceTypeID.String(),
+			IsForward:     r.IsForward,
+			Target:        target.String(),
+		})
+		switch {
+		case target.Namespace() == 0:
+		case r.IsForward:
+			e.visit(target)
+		case isNS0(r.ReferenceTypeID, id.HasSubtype):
+			e.visit(target)
+		}
+		if !r.IsForward && parent == "" && (isNS0(r.ReferenceTypeID, id.HasComponent) || isNS0(r.ReferenceTypeID, id.HasProperty)) {
+			parent = target.String()
+		}
+	}
+
+	x, err := e.nodeClass(ctx, node, nodeClass, n, parent)
+	if err != nil {
+		return err
+	}
+	e.exported[nodeID.Namespace()] = true
+	switch x := x.(type) {
+	case *nodeset.Object:
+		e.s.Objects = append(e.s.Objects, x)
+	case *nodeset.Variable:
+		e.s.Variables = append(e.s.Variables, x)
+	case *nodeset.Method:
+		e.s.Methods = append(e.s.Methods, x)
+	case *nodeset.View:
+		e.s.Views = append(e.s.Views, x)
+	case *nodeset.ObjectType:
+		e.s.ObjectTypes = append(e.s.ObjectTypes, x)
+	case *nodeset.VariableType:
+		e.s.VariableTypes = append(e.s.VariableTypes, x)
+	case *nodeset.DataType:
+		e.s.DataTypes = append(e.s.DataTypes, x)
+	case *nodeset.ReferenceType:
+		e.s.ReferenceTypes = append(e.s.ReferenceTypes, x)
+	}
+	return nil
+}
+
+// nodeClass reads the attributes of the node class of a node.
+func (e *nodeSetExporter) nodeClass(ctx context.Context, node *Node, nodeClass ua.NodeClass, n *nodeset.Node, parent string) (interface{ Base() *nodeset.Node }, error) {
+	switch nodeClass {
+	case ua.NodeClassObject:
+		dv, err := node.AttributesWithContext(ctx, ua.AttributeIDEventNotifier)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.Object{Instance: nodeset.Instance{Node: *n, ParentNodeID: parent}}
+		x.EventNotifier, _ = attributeValue(dv[0]).(uint8)
+		return x, nil
+
+	case ua.NodeClassVariable:
+		dv, err := node.AttributesWithContext(ctx,
+			ua.AttributeIDDataType,
+			ua.AttributeIDValueRank,
+			ua.AttributeIDArrayDimensions,
+			ua.AttributeIDAccessLevel,
+			ua.AttributeIDUserAccessLevel,
+			ua.AttributeIDMinimumSamplingInterval,
+			ua.AttributeIDHistorizing,
+			ua.AttributeIDValue,
+		)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.Variable{Instance: nodeset.Instance{Node: *n, ParentNodeID: parent}}
+		x.DataType = e.dataType(attributeValue(dv[0]))
+		x.ValueRank, _ = attributeValue(dv[1]).(int32)
+		dims, _ := attributeValue(dv[2]).([]uint32)
+		x.ArrayDimensions = nodeset.FormatArrayDimensions(dims)
+		x.AccessLevel, _ = attributeValue(dv[3]).(uint8)
+		x.UserAccessLevel, _ = attributeValue(dv[4]).(uint8)
+		x.MinimumSamplingInterval, _ = attributeValue(dv[5]).(float64)
+		x.Historizing, _ = attributeValue(dv[6]).(bool)
+		x.Value = nodeSetValue(n, dv[7])
+		return x, nil
+
+	case ua.NodeClassMethod:
+		dv, err := node.AttributesWithContext(ctx, ua.AttributeIDExecutable, ua.AttributeIDUserExecutable)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.Method{Instance: nodeset.Instance{Node: *n, ParentNodeID: parent}}
+		x.Executable, _ = attributeValue(dv[0]).(bool)
+		x.UserExecutable, _ = attributeValue(dv[1]).(bool)
+		return x, nil
+
+	case ua.NodeClassView:
+		dv, err := node.AttributesWithContext(ctx, ua.AttributeIDContainsNoLoops, ua.AttributeIDEventNotifier)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.View{Instance: nodeset.Instance{Node: *n}}
+		x.ContainsNoLoops, _ = attributeValue(dv[0]).(bool)
+		x.EventNotifier, _ = attributeValue(dv[1]).(uint8)
+		return x, nil
+
+	case ua.NodeClassObjectType:
+		dv, err := node.AttributesWithContext(ctx, ua.AttributeIDIsAbstract)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.ObjectType{Node: *n}
+		x.IsAbstract, _ = attributeValue(dv[0]).(bool)
+		return x, nil
+
+	case ua.NodeClassVariableType:
+		dv, err := node.AttributesWithContext(ctx,
+			ua.AttributeIDDataType,
+			ua.AttributeIDValueRank,
+			ua.AttributeIDArrayDimensions,
+			ua.AttributeIDIsAbstract,
+			ua.AttributeIDValue,
+		)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.VariableType{Node: *n}
+		x.DataType = e.dataType(attributeValue(dv[0]))
+		x.ValueRank, _ = attributeValue(dv[1]).(int32)
+		dims, _ := attributeValue(dv[2]).([]uint32)
+		x.ArrayDimensions = nodeset.FormatArrayDimensions(dims)
+		x.IsAbstract, _ = attributeValue(dv[3]).(bool)
+		x.Value = nodeSetValue(n, dv[4])
+		return x, nil
+
+	case ua.NodeClassDataType:
+		dv, err := node.AttributesWithContext(ctx, ua.AttributeIDIsAbstract, ua.AttributeIDDataTypeDefinition)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.DataType{Node: *n}
+		x.IsAbstract, _ = attributeValue(dv[0]).(bool)
+		if def, ok := attributeValue(dv[1]).(*ua.ExtensionObject); ok {
+			if x.Definition, err = e.definition(ctx, node, n.BrowseName, def.Value); err != nil {
+				return nil, err
+			}
+		}
+		return x, nil
+
+	case ua.NodeClassReferenceType:
+		dv, err := node.AttributesWithContext(ctx, ua.AttributeIDIsAbstract, ua.AttributeIDSymmetric, ua.AttributeIDInverseName)
+		if err != nil {
+			return nil, err
+		}
+		x := &nodeset.ReferenceType{Node: *n}
+		x.IsAbstract, _ = attributeValue(dv[0]).(bool)
+		x.Symmetric, _ = attributeValue(dv[1]).(bool)
+		x.InverseName = nodeSetText(attributeValue(dv[2]))
+		return x, nil
+
+	default:
+		return nil, errors.Errorf("invalid node class %s", nodeClass)
+	}
+}
+
+// dataType returns the data type of a variable or a variable type and
+// exports it if it is not in namespace 0.
+func (e *nodeSetExporter) dataType(v interface{}) string {
+	dataType, ok := v.(*ua.NodeID)
+	if !ok {
+		return "i=24"
+	}
+	if dataType.Namespace() != 0 {
+		e.visit(dataType)
+	}
+	return dataType.String()
+}
+
+// definition returns the definition of a data type from its
+// DataTypeDefinition attribute.
+//
+// Specification: Part 3, 5.8.3
+func (e *nodeSetExporter) definition(ctx context.Context, node *Node, name string, v interface{}) (*nodeset.Definition, error) {
+	switch def := v.(type) {
+	case *ua.StructureDefinition:
+		d := &nodeset.Definition{Name: name, IsUnion: def.StructureType == ua.StructureTypeUnion}
+		for _, f := range def.Fields {
+			d.Fields = append(d.Fields, &nodeset.Field{
+				Name:            f.Name,
+				DataType:        e.dataType(f.DataType),
+				ValueRank:       f.ValueRank,
+				ArrayDimensions: nodeset.FormatArrayDimensions(f.ArrayDimensions),
+				MaxStringLength: f.MaxStringLength,
+				IsOptional:      f.IsOptional && def.StructureType == ua.StructureTypeStructureWithOptionalFields,
+				Description:     nodeSetText(f.Description),
+			})
+		}
+		return d, nil
+
+	case *ua.EnumDefinition:
+		isEnum, err := e.isEnumeration(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		d := &nodeset.Definition{Name: name, IsOptionSet: !isEnum}
+		for _, f := range def.Fields {
+			d.Fields = append(d.Fields, &nodeset.Field{
+				Name:        f.Name,
+				Value:       f.Value,
+				DisplayName: nodeSetText(f.DisplayName),
+				Description: nodeSetText(f.Description),
+			})
+		}
+		return d, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// isEnumeration returns true if the data type is a subtype of
+// Enumeration. Otherwise, an EnumDefinition describes an option set.
+func (e *nodeSetExporter) isEnumeration(ctx context.Context, node *Node) (bool, error) {
+	for depth := 0; depth < 32; depth++ {
+		if isNS0(node.ID, id.Enumeration) {
+			return true, nil
+		}
+		refs, err := node.ReferencesWithContext(ctx, id.HasSubtype, ua.BrowseDirectionInverse, ua.NodeClassDataType, false)
+		if err != nil {
+			return false, err
+		}
+		if len(refs) == 0 {
+			return false, nil
+		}
+		super := e.localNodeID(refs[0].NodeID)
+		if super == nil {
+			return false, nil
+		}
+		node = e.c.Node(super)
+	}
+	return false, nil
+}
+
+// attributeValue returns the value of an attribute or nil if the
+// attribute could not be read.
+func attributeValue(dv *ua.DataValue) interface{} {
+	if dv == nil || dv.Status != ua.StatusOK || dv.Value == nil {
+		return nil
+	}
+	return dv.Value.Value()
+}
+
+// nodeSetText returns the localized text of an attribute or nil if the
+// text is empty.
+func nodeSetText(v interface{}) []*nodeset.LocalizedText {
+	l, ok := v.(*ua.LocalizedText)
+	if !ok || l.Text == "" {
+		return nil
+	}
+	return []*nodeset.LocalizedText{{Locale: l.Locale, Text: l.Text}}
+}
+
+// nodeSetValue returns the XML encoding of the value of a variable or a
+// variable type. Values which cannot be encoded are skipped.
+func nodeSetValue(n *nodeset.Node, dv *ua.DataValue) *nodeset.Value {
+	if dv == nil || dv.Status != ua.StatusOK {
+		return nil
+	}
+	v, err := nodeset.NewValue(dv.Value)
+	if err != nil {
+		debug.Printf("nodeset: skipping value of %s: %s", n.NodeID, err)
+		return nil
+	}
+	return v
+}
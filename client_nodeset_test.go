@@ -0,0 +1,81 @@
+package opcua
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestClientExportNodeSet(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t, ServerNodeSetFile("nodeset/testdata/Test.NodeSet2.xml"))
+	c := connectTestClient(t, srv)
+
+	s, err := c.ExportNodeSet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify.Values(t, "namespaces", s.NamespaceURIs, []string{"urn:gopcua:server", "urn:gopcua:test"})
+	verify.Values(t, "models", len(s.Models), 1)
+	verify.Values(t, "model", s.Models[0].ModelURI, "urn:gopcua:test")
+
+	names := func(nodes interface{}) []string {
+		var names []string
+		switch x := nodes.(type) {
+		case []*nodeset.Object:
+			for _, n := range x {
+				names = append(names, n.BrowseName)
+			}
+		case []*nodeset.Variable:
+			for _, n := range x {
+				names = append(names, n.BrowseName)
+			}
+		case []*nodeset.DataType:
+			for _, n := range x {
+				names = append(names, n.BrowseName)
+			}
+		}
+		return names
+	}
+	verify.Values(t, "objects", names(s.Objects), []string{"2:Machine1", "Default Binary", "Default Binary"})
+	verify.Values(t, "variables", names(s.Variables), []string{"2:Speed", "2:Tags", "2:Speed"})
+	verify.Values(t, "data types", names(s.DataTypes), []string{"2:Point", "2:Machine", "2:Mode"})
+	verify.Values(t, "object types", len(s.ObjectTypes), 1)
+
+	verify.Values(t, "parent", s.Variables[0].ParentNodeID, "ns=2;i=7001")
+	verify.Values(t, "enum", s.DataTypes[2].Definition, &nodeset.Definition{
+		Name: "2:Mode",
+		Fields: []*nodeset.Field{
+			{Name: "Off", Value: 0, DisplayName: []*nodeset.LocalizedText{{Text: "Off"}}},
+			{Name: "On", Value: 1, DisplayName: []*nodeset.LocalizedText{{Text: "On"}}},
+		},
+	})
+	verify.Values(t, "optional field", s.DataTypes[1].Definition.Fields[3], &nodeset.Field{
+		Name:       "Position",
+		DataType:   "ns=2;i=3002",
+		ValueRank:  -1,
+		IsOptional: true,
+	})
+
+	// load the exported node set into another server
+	var b bytes.Buffer
+	if err := s.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	s, err = nodeset.Parse(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv2 := startTestServer(t, ServerNodeSet(s))
+	c2 := connectTestClient(t, srv2)
+	v, err := c2.Node(ua.NewNumericNodeID(2, 7002)).ValueWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "speed", v.Value(), 42.5)
+}
@@ -0,0 +1,114 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uacp"
+)
+
+// ReverseServer describes a server which is expected to connect to a
+// ReverseListener.
+type ReverseServer struct {
+	// ServerURI is the application uri of the server.
+	ServerURI string
+
+	// EndpointURL is the endpoint url of the server. An empty endpoint
+	// url accepts all endpoints of the server.
+	EndpointURL string
+}
+
+// ReverseListener accepts the connections of servers which connect to
+// the client with Reverse Connect. This allows to connect to servers
+// behind firewalls which only allow outgoing connections.
+//
+// Specification: Part 6, 7.1.3
+type ReverseListener struct {
+	l       *uacp.ReverseListener
+	servers []ReverseServer
+}
+
+// ListenReverse listens for Reverse Connect on the endpoint. The
+// endpoint must be in "opc.tcp://<addr[:port]>" format.
+//
+// Only the given servers are accepted. When no servers are given all
+// servers are accepted.
+func ListenReverse(endpoint string, servers ...ReverseServer) (*ReverseListener, error) {
+	l, err := uacp.ListenReverse(endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ReverseListener{l: l, servers: servers}, nil
+}
+
+// Accept waits for the next expected server and returns a client for the
+// endpoint the server announced. The connection of the server is used
+// when the client calls Connect or Dial. Connections of other servers are
+// rejected.
+//
+// The options configure the client like the options of NewClient. The
+// client does not reconnect automatically since the server has to
+// reconnect.
+func (l *ReverseListener) Accept(ctx context.Context, opts ...Option) (*Client, error) {
+	for {
+		conn, rhe, err := l.l.Accept(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if status := l.check(rhe); status != ua.StatusOK {
+			debug.Printf("client: rejecting reverse connect of %s at %s: %s", rhe.ServerURI, rhe.EndpointURL, status)
+			conn.SendError(status)
+			conn.Close()
+			continue
+		}
+
+		// copy the options since the caller may reuse the slice
+		copts := append(append([]Option{}, opts...), AutoReconnect(false))
+		c := NewClient(rhe.EndpointURL, copts...)
+
+		if err := conn.ReverseHandshake(rhe.EndpointURL, NewDialer(c.cfg).ClientACK); err != nil {
+			debug.Printf("client: reverse connect of %s failed: %s", rhe.ServerURI, err)
+			conn.Close()
+			continue
+		}
+
+		c.reverseConn = conn
+		return c, nil
+	}
+}
+
+// check returns StatusOK if the server of the ReverseHello message is
+// expected.
+func (l *ReverseListener) check(rhe *uacp.ReverseHello) ua.StatusCode {
+	if len(l.servers) == 0 {
+		return ua.StatusOK
+	}
+	status := ua.StatusBadServerURIInvalid
+	for _, s := range l.servers {
+		if s.ServerURI != rhe.ServerURI {
+			continue
+		}
+		if s.EndpointURL == "" || s.EndpointURL == rhe.EndpointURL {
+			return ua.StatusOK
+		}
+		status = ua.StatusBadTCPEndpointURLInvalid
+	}
+	return status
+}
+
+// Endpoint returns the endpoint url the servers connect to.
+func (l *ReverseListener) Endpoint() string {
+	return l.l.Endpoint()
+}
+
+// Close closes the listener. Clients which have been accepted are not
+// closed.
+func (l *ReverseListener) Close() error {
+	return l.l.Close()
+}
@@ -0,0 +1,78 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uacp"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestReverseConnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := startTestServer(t)
+
+	l, err := ListenReverse("opc.tcp://127.0.0.1:0", ReverseServer{ServerURI: "urn:gopcua:server"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ReverseConnect(ctx, l.Endpoint()) }()
+
+	// the options have spare capacity which Accept must not use
+	opts := make([]Option, 0, 3)
+	opts = append(opts, SecurityMode(ua.MessageSecurityModeNone), MaxMessageSize(1<<20))
+	c, err := l.Accept(ctx, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	if opts[:3][2] != nil {
+		t.Fatal("Accept modified the options")
+	}
+	verify.Values(t, "max message size", c.reverseConn.MaxMessageSize(), uint32(1<<20))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	verify.Values(t, "namespaces", c.Namespaces(), []string{"http://opcfoundation.org/UA/", "urn:gopcua:server"})
+}
+
+func TestReverseConnectRejectsUnknownServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := startTestServer(t)
+
+	tests := []struct {
+		name   string
+		server ReverseServer
+		want   ua.StatusCode
+	}{
+		{"server uri", ReverseServer{ServerURI: "urn:other"}, ua.StatusBadServerURIInvalid},
+		{"endpoint url", ReverseServer{ServerURI: "urn:gopcua:server", EndpointURL: "opc.tcp://other:4840"}, ua.StatusBadTCPEndpointURLInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := ListenReverse("opc.tcp://127.0.0.1:0", tt.server)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+
+			go l.Accept(ctx)
+
+			err = srv.ReverseConnect(ctx, l.Endpoint())
+			verify.Values(t, "", err, &uacp.Error{ErrorCode: uint32(tt.want)})
+		})
+	}
+}
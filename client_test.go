@@ -181,3 +181,142 @@ func TestCloneBrowseRequest(t *testing.T) {
 		})
 	}
 }
+
+// addTestByteString adds the variable Data with the byte string v to the
+// address space of the server.
+func addTestByteString(t testing.TB, srv *Server, v []byte) (uint16, *ua.NodeID) {
+	t.Helper()
+
+	as := srv.AddressSpace()
+	ns := as.AddNamespace("urn:gopcua:test", nil)
+	nodeID := ua.NewStringNodeID(ns, "Data")
+	err := as.AddNode(&VariableNode{
+		BaseNode:        BaseNode{NodeID: nodeID, BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Data"}},
+		Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)},
+		DataType:        ua.NewNumericNodeID(0, id.ByteString),
+		ValueRank:       -1,
+		AccessLevel:     ua.AccessLevelTypeCurrentRead,
+		UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ns, nodeID
+}
+
+func TestClientResponseBuffers(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, nodeID := addTestByteString(t, srv, []byte("first"))
+	c := connectTestClient(t, srv)
+
+	read := func() []byte {
+		t.Helper()
+		res, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+			NodesToRead: []*ua.ReadValueID{{NodeID: nodeID, AttributeID: ua.AttributeIDValue}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0].Value.Value().([]byte)
+	}
+
+	// the receive buffers are reused for the next responses which must
+	// not overwrite the byte strings of earlier responses
+	first := read()
+	setTestValue(t, srv, ns, "Data", []byte("other"))
+	for i := 0; i < 10; i++ {
+		verify.Values(t, "", read(), []byte("other"))
+	}
+	verify.Values(t, "first", first, []byte("first"))
+}
+
+func TestClientSendWithRelease(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, nodeID := addTestByteString(t, srv, []byte("first"))
+	c := connectTestClient(t, srv)
+
+	req := &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: nodeID, AttributeID: ua.AttributeIDValue, DataEncoding: &ua.QualifiedName{}}},
+	}
+	read := func() ([]byte, func()) {
+		t.Helper()
+		var (
+			v   []byte
+			rel func()
+		)
+		err := c.SendWithRelease(ctx, req, func(res interface{}, release func()) error {
+			v, rel = res.(*ua.ReadResponse).Results[0].Value.Value().([]byte), release
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v, rel
+	}
+
+	// a buffer is not reused for other responses until it is released
+	first, release := read()
+	setTestValue(t, srv, ns, "Data", []byte("other"))
+	for i := 0; i < 10; i++ {
+		v, rel := read()
+		verify.Values(t, "", v, []byte("other"))
+		rel()
+		if _, err := c.ReadWithContext(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verify.Values(t, "first", first, []byte("first"))
+
+	// releasing a buffer twice must not hand it to two responses
+	release()
+	release()
+	var got [][]byte
+	for _, w := range []string{"a", "b", "c", "d"} {
+		setTestValue(t, srv, ns, "Data", []byte(w))
+		v, rel := read()
+		defer rel()
+		got = append(got, v)
+	}
+	verify.Values(t, "held", got, [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")})
+}
+
+// benchmarkClientRead reads a byte string of 32 KiB from a local server
+// with send. Compare the allocations of SendWithContext, which copies the
+// response body, and SendWithRelease with
+//
+//	go test -run - -bench ClientSend -benchmem
+func benchmarkClientRead(b *testing.B, send func(c *Client, req *ua.ReadRequest) error) {
+	srv := startTestServer(b)
+	_, nodeID := addTestByteString(b, srv, make([]byte, 32<<10))
+	c := connectTestClient(b, srv)
+
+	req := &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: nodeID, AttributeID: ua.AttributeIDValue, DataEncoding: &ua.QualifiedName{}}},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := send(c, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClientSend(b *testing.B) {
+	benchmarkClientRead(b, func(c *Client, req *ua.ReadRequest) error {
+		return c.SendWithContext(context.Background(), req, func(v interface{}) error {
+			return nil
+		})
+	})
+}
+
+func BenchmarkClientSendWithRelease(b *testing.B) {
+	benchmarkClientRead(b, func(c *Client, req *ua.ReadRequest) error {
+		return c.SendWithRelease(context.Background(), req, func(v interface{}, release func()) error {
+			release()
+			return nil
+		})
+	})
+}
@@ -0,0 +1,284 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/stats"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// SkipReferences is returned by a WalkFunc to skip the references of the
+// visited node.
+var SkipReferences = errors.New("skip references")
+
+// defaultWalkBatchSize is the number of nodes which are browsed with one
+// request when neither the walk options nor the server limit it.
+const defaultWalkBatchSize = 1000
+
+// WalkOptions controls which references Walk follows.
+type WalkOptions struct {
+	// ReferenceTypes contains the types of the references which are
+	// followed. The default is id.HierarchicalReferences.
+	ReferenceTypes []*ua.NodeID
+
+	// ExcludeSubtypes disables following the subtypes of the
+	// reference types.
+	ExcludeSubtypes bool
+
+	// NodeClassMask limits the nodes which are visited to the given
+	// node classes. The references of the other nodes are still
+	// followed. The default is ua.NodeClassAll.
+	NodeClassMask ua.NodeClass
+
+	// MaxDepth limits the number of references between the start node
+	// and the visited nodes. Zero means no limit.
+	MaxDepth int
+
+	// MaxReferencesPerNode is the number of references which the server
+	// returns per node before it returns a continuation point. Zero
+	// lets the server decide.
+	MaxReferencesPerNode uint32
+
+	// BatchSize is the number of nodes which are browsed with one
+	// request. The default is the MaxNodesPerBrowse operation limit of
+	// the server or 1000 if the server has no limit.
+	BatchSize int
+
+	// Concurrency is the number of browse requests which are sent
+	// concurrently. The default is 1.
+	Concurrency int
+}
+
+// WalkFunc is called by Walk for every node which is reached by a
+// reference from parent. depth is 1 for the references of the start node.
+// Returning SkipReferences does not follow the references of the node
+// and any other error stops the walk.
+type WalkFunc func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error
+
+// Walk browses the address space from start in breadth-first order and
+// calls fn for every node which is reached. The nodes of each level are
+// browsed in batches and the continuation points are followed with
+// BrowseNext. Every node is visited only once even if the address space
+// contains cycles or multiple references to the same node. Nodes on
+// other servers or in namespaces which are not in Namespaces are visited
+// but their references are not followed.
+//
+// fn is called from the goroutine which called Walk.
+//
+// Specification: Part 4, 5.8.2
+func (c *Client) Walk(ctx context.Context, start *ua.NodeID, opts WalkOptions, fn WalkFunc) error {
+	stats.Client().Add("Walk", 1)
+
+	refTypes := opts.ReferenceTypes
+	if len(refTypes) == 0 {
+		refTypes = []*ua.NodeID{ua.NewNumericNodeID(0, id.HierarchicalReferences)}
+	}
+	mask := opts.NodeClassMask
+	if mask == 0 {
+		mask = ua.NodeClassAll
+	}
+	batch := opts.BatchSize
+	if batch <= 0 {
+		batch = int(c.OperationLimits().MaxNodesPerBrowse)
+	}
+	if batch <= 0 {
+		batch = defaultWalkBatchSize
+	}
+
+	ns := c.Namespaces()
+	visited := map[string]bool{start.String(): true}
+	nodes := []*ua.NodeID{start}
+	for depth := 1; len(nodes) > 0; depth++ {
+		refs := make([][]*ua.ReferenceDescription, len(nodes))
+		err := runBatches(ctx, len(nodes), uint32(batch), opts.Concurrency, func(ctx context.Context, lo, hi int) error {
+			return c.walkBatch(ctx, nodes[lo:hi], refs[lo:hi], refTypes, !opts.ExcludeSubtypes, opts.MaxReferencesPerNode)
+		})
+		if err != nil {
+			return err
+		}
+
+		var next []*ua.NodeID
+		for i, parent := range nodes {
+			for _, ref := range refs[i] {
+				if ref.NodeID == nil || ref.NodeID.NodeID == nil {
+					continue
+				}
+				local := walkNodeID(ref.NodeID, ns)
+				var key string
+				if local != nil {
+					key = local.String()
+				} else {
+					key = fmt.Sprintf("svr=%d;nsu=%s;%s", ref.NodeID.ServerIndex, ref.NodeID.NamespaceURI, ref.NodeID.NodeID)
+				}
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+
+				// the node class of nodes on other servers is unknown
+				if ref.NodeClass == ua.NodeClassUnspecified || ref.NodeClass&mask != 0 {
+					switch err := fn(parent, ref, depth); err {
+					case nil:
+					case SkipReferences:
+						continue
+					default:
+						return err
+					}
+				}
+
+				if local != nil && (opts.MaxDepth == 0 || depth < opts.MaxDepth) {
+					next = append(next, local)
+				}
+			}
+		}
+		nodes = next
+	}
+	return nil
+}
+
+// walkNodeID returns the node id of the target of a reference on this
+// server with the namespace uri resolved against the namespace array ns.
+// It returns nil if the node is on another server or the namespace uri
+// is not in ns.
+func walkNodeID(e *ua.ExpandedNodeID, ns []string) *ua.NodeID {
+	if e.ServerIndex != 0 {
+		return nil
+	}
+	if e.NamespaceURI == "" {
+		return e.NodeID
+	}
+	for i, uri := range ns {
+		if uri != e.NamespaceURI {
+			continue
+		}
+		// the node id of an expanded node id has the flags of the
+		// namespace uri in its encoding mask and cannot be sent as is.
+		n := e.NodeID
+		switch n.Type() {
+		case ua.NodeIDTypeTwoByte, ua.NodeIDTypeFourByte, ua.NodeIDTypeNumeric:
+			return ua.NewNumericNodeID(uint16(i), n.IntID())
+		case ua.NodeIDTypeString:
+			return ua.NewStringNodeID(uint16(i), n.StringID())
+		case ua.NodeIDTypeGUID:
+			return ua.NewGUIDNodeID(uint16(i), n.StringID())
+		case ua.NodeIDTypeByteString:
+			b, err := base64.StdEncoding.DecodeString(n.StringID())
+			if err != nil {
+				return nil
+			}
+			return ua.NewByteStringNodeID(uint16(i), b)
+		}
+		return nil
+	}
+	return nil
+}
+
+// walkBatch browses the references of the nodes to nodes of all node
+// classes and stores them in refs. The references of nodes which cannot
+// be browsed are skipped.
+func (c *Client) walkBatch(ctx context.Context, nodes []*ua.NodeID, refs [][]*ua.ReferenceDescription, refTypes []*ua.NodeID, subtypes bool, maxRefs uint32) error {
+	req := &ua.BrowseRequest{
+		View: &ua.ViewDescription{
+			ViewID: ua.NewTwoByteNodeID(0),
+		},
+		RequestedMaxReferencesPerNode: maxRefs,
+	}
+	for _, n := range nodes {
+		for _, rt := range refTypes {
+			req.NodesToBrowse = append(req.NodesToBrowse, &ua.BrowseDescription{
+				NodeID:          n,
+				BrowseDirection: ua.BrowseDirectionForward,
+				ReferenceTypeID: rt,
+				IncludeSubtypes: subtypes,
+				NodeClassMask:   uint32(ua.NodeClassAll),
+				ResultMask:      uint32(ua.BrowseResultMaskAll),
+			})
+		}
+	}
+
+	res, err := c.BrowseWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(res.Results) != len(req.NodesToBrowse) {
+		return ua.StatusBadUnknownResponse
+	}
+
+	// owner contains the index of the node of each pending continuation
+	// point.
+	var (
+		owner []int
+		cps   [][]byte
+	)
+	collect := func(i int, r *ua.BrowseResult) error {
+		switch r.StatusCode {
+		case ua.StatusOK:
+		case ua.StatusBadNoContinuationPoints:
+			// the references would be incomplete
+			return errors.Errorf("browse %s: %s", nodes[i], r.StatusCode)
+		default:
+			debug.Printf("client: cannot browse %s: %s", nodes[i], r.StatusCode)
+			return nil
+		}
+		refs[i] = append(refs[i], r.References...)
+		if len(r.ContinuationPoint) > 0 {
+			owner = append(owner, i)
+			cps = append(cps, r.ContinuationPoint)
+		}
+		return nil
+	}
+	for k, r := range res.Results {
+		if e := collect(k/len(refTypes), r); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	for err == nil && len(cps) > 0 {
+		nreq := &ua.BrowseNextRequest{ContinuationPoints: cps}
+		var nres *ua.BrowseNextResponse
+		nres, err = c.BrowseNextWithContext(ctx, nreq)
+		if err != nil {
+			break
+		}
+		if len(nres.Results) != len(cps) {
+			return ua.StatusBadUnknownResponse
+		}
+
+		prev := owner
+		owner, cps = nil, nil
+		for k, r := range nres.Results {
+			if e := collect(prev[k], r); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+	if err != nil {
+		c.releaseBrowseContinuationPoints(cps)
+	}
+	return err
+}
+
+// releaseBrowseContinuationPoints releases the continuation points on the
+// server. It does not use the context of the walk since it may have been
+// cancelled.
+func (c *Client) releaseBrowseContinuationPoints(cps [][]byte) {
+	if len(cps) == 0 {
+		return
+	}
+	req := &ua.BrowseNextRequest{
+		ContinuationPoints:        cps,
+		ReleaseContinuationPoints: true,
+	}
+	if _, err := c.BrowseNextWithContext(context.Background(), req); err != nil {
+		debug.Printf("client: cannot release browse continuation points: %s", err)
+	}
+}
@@ -0,0 +1,179 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestClientWalk(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	c := connectTestClient(t, srv)
+
+	// walk browses the address space one node at a time like the
+	// recursion of examples/browse.
+	walk := func(start *ua.NodeID, refType uint32, maxDepth int) map[string]int {
+		depths := map[string]int{start.String(): 0}
+		nodes := []*Node{c.Node(start)}
+		for depth := 1; len(nodes) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+			var next []*Node
+			for _, n := range nodes {
+				refs, err := n.ReferencesWithContext(ctx, refType, ua.BrowseDirectionForward, ua.NodeClassAll, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, ref := range refs {
+					key := ref.NodeID.NodeID.String()
+					if _, ok := depths[key]; ok {
+						continue
+					}
+					depths[key] = depth
+					next = append(next, c.Node(ref.NodeID.NodeID))
+				}
+			}
+			nodes = next
+		}
+		delete(depths, start.String())
+		return depths
+	}
+
+	t.Run("hierarchical", func(t *testing.T) {
+		got := map[string]int{}
+		err := c.Walk(ctx, ua.NewNumericNodeID(0, id.RootFolder), WalkOptions{
+			MaxReferencesPerNode: 3,
+			BatchSize:            4,
+			Concurrency:          2,
+		}, func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error {
+			got[ref.NodeID.String()] = depth
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "", got, walk(ua.NewNumericNodeID(0, id.RootFolder), id.HierarchicalReferences, 0))
+	})
+
+	t.Run("cycles", func(t *testing.T) {
+		// HasTypeDefinition and HasSubtype references lead back to
+		// nodes which have already been visited.
+		got := map[string]int{}
+		err := c.Walk(ctx, ua.NewNumericNodeID(0, id.Server), WalkOptions{
+			ReferenceTypes: []*ua.NodeID{ua.NewNumericNodeID(0, id.References)},
+			MaxDepth:       3,
+		}, func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error {
+			if _, ok := got[ref.NodeID.String()]; ok {
+				t.Fatalf("%s visited twice", ref.NodeID)
+			}
+			got[ref.NodeID.String()] = depth
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "", got, walk(ua.NewNumericNodeID(0, id.Server), id.References, 3))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		var got []string
+		err := c.Walk(ctx, ua.NewNumericNodeID(0, id.Server), WalkOptions{
+			ReferenceTypes: []*ua.NodeID{ua.NewNumericNodeID(0, id.HasComponent)},
+			NodeClassMask:  ua.NodeClassObject,
+		}, func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error {
+			if ref.NodeClass != ua.NodeClassObject {
+				t.Errorf("%s has node class %s", ref.NodeID, ref.NodeClass)
+			}
+			if ref.NodeID.NodeID.IntID() == id.Server_ServerCapabilities {
+				got = append(got, ref.BrowseName.Name)
+				return SkipReferences
+			}
+			if parent.IntID() == id.Server_ServerCapabilities {
+				t.Errorf("references of %s followed", parent)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "", got, []string{"ServerCapabilities"})
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		var n int
+		err := c.Walk(ctx, ua.NewNumericNodeID(0, id.RootFolder), WalkOptions{}, func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error {
+			n++
+			return ua.StatusBadRequestCancelledByClient
+		})
+		verify.Values(t, "error", err, ua.StatusBadRequestCancelledByClient)
+		verify.Values(t, "visited", n, 1)
+	})
+}
+
+func TestClientWalkNodeClassMask(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, _ := addTestNodes(t, srv)
+
+	// Line references Cell with the namespace uri instead of the index
+	cell := ua.NewStringNodeID(ns, "Cell")
+	for _, n := range []ServerNode{
+		&ObjectNode{BaseNode: BaseNode{
+			NodeID:     ua.NewStringNodeID(ns, "Line"),
+			BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Line"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.Organizes), false, ua.NewNumericNodeID(0, id.ObjectsFolder)),
+				{
+					ReferenceTypeID: ua.NewNumericNodeID(0, id.Organizes),
+					IsForward:       true,
+					TargetID:        ua.NewExpandedNodeID(ua.NewStringNodeID(0, "Cell"), "urn:gopcua:test", 0),
+				},
+			},
+		}},
+		&ObjectNode{BaseNode: BaseNode{
+			NodeID:     cell,
+			BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Cell"},
+		}},
+		&VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewStringNodeID(ns, "Cell.Temp"),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Temp"},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.HasComponent), false, cell),
+				},
+			},
+			Value:    &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(20.5)},
+			DataType: ua.NewNumericNodeID(0, id.Double),
+		},
+	} {
+		if err := srv.AddressSpace().AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := connectTestClient(t, srv)
+
+	// the variables are below objects which are not visited
+	got := map[string]int{}
+	err := c.Walk(ctx, ua.NewNumericNodeID(0, id.ObjectsFolder), WalkOptions{
+		NodeClassMask: ua.NodeClassVariable,
+	}, func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error {
+		if ref.NodeClass != ua.NodeClassVariable {
+			t.Errorf("%s has node class %s", ref.NodeID, ref.NodeClass)
+		}
+		if ref.NodeID.NodeID.Namespace() == ns {
+			got[ref.NodeID.NodeID.StringID()] = depth
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "", got, map[string]int{
+		"Machine.Speed": 2,
+		"Machine.Name":  2,
+		"Cell.Temp":     3,
+	})
+}
@@ -0,0 +1,416 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/imatic-tech/opcua/cmd/service/goname"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// Generate returns the Go source of the structures and enumerations of
+// the schema. Structures with optional fields and unions get Encode and
+// Decode methods since they cannot be encoded with reflection.
+func Generate(pkg string, h *ua.DataTypeSchemaHeader) ([]byte, error) {
+	g := &generator{
+		structs: map[string]*ua.StructureDescription{},
+		enums:   map[string]*ua.EnumDescription{},
+		simples: map[string]*ua.SimpleTypeDescription{},
+	}
+	for _, d := range h.StructureDataTypes {
+		g.structs[d.DataTypeID.String()] = d
+	}
+	for _, d := range h.EnumDataTypes {
+		g.enums[d.DataTypeID.String()] = d
+	}
+	for _, d := range h.SimpleDataTypes {
+		g.simples[d.DataTypeID.String()] = d
+	}
+
+	var enums []Type
+	for _, d := range h.EnumDataTypes {
+		enums = append(enums, g.enum(d))
+	}
+	var objs []Type
+	for _, d := range h.StructureDataTypes {
+		t, err := g.structure(d)
+		if err != nil {
+			return nil, errors.Errorf("data type %s: %s", d.DataTypeID, err)
+		}
+		objs = append(objs, t)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Name < objs[j].Name })
+
+	var b bytes.Buffer
+	err := tmplFile.Execute(&b, map[string]interface{}{
+		"Package":    pkg,
+		"Namespaces": h.Namespaces,
+		"Time":       g.time,
+		"Enums":      enums,
+		"Objects":    objs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, errors.Errorf("invalid source: %s\n%s", err, b.Bytes())
+	}
+	return src, nil
+}
+
+type generator struct {
+	structs map[string]*ua.StructureDescription
+	enums   map[string]*ua.EnumDescription
+	simples map[string]*ua.SimpleTypeDescription
+
+	// time is true if a field has the type time.Time.
+	time bool
+}
+
+type Type struct {
+	// Name is the Go name of the data type.
+	Name string
+
+	// DataTypeID is the node id of the data type.
+	DataTypeID string
+
+	// EncodingID is the Go expression of the node id of the binary
+	// encoding or empty if the structure has no encoding.
+	EncodingID string
+
+	// StructureType is the kind of structure.
+	StructureType ua.StructureType
+
+	// Fields is the list of struct fields.
+	Fields []Field
+
+	// Values is the list of enum values.
+	Values []Value
+}
+
+// IsOptional returns true if the structure has optional fields.
+func (t Type) IsOptional() bool {
+	return t.StructureType == ua.StructureTypeStructureWithOptionalFields
+}
+
+// IsUnion returns true if the structure is a union.
+func (t Type) IsUnion() bool {
+	return t.StructureType == ua.StructureTypeUnion
+}
+
+type Field struct {
+	Name string
+	Type string
+
+	// Bit is the bit of the field in the encoding mask of an optional
+	// field or -1.
+	Bit int
+
+	// Switch is the value of the switch field of a union which selects
+	// the field.
+	Switch int
+}
+
+// Elem returns the type which the pointer type of the field points to
+// or an empty string if the field is not a pointer.
+func (f Field) Elem() string {
+	if strings.HasPrefix(f.Type, "*") {
+		return f.Type[1:]
+	}
+	return ""
+}
+
+type Value struct {
+	Name  string
+	Value int64
+}
+
+func (g *generator) enum(d *ua.EnumDescription) Type {
+	t := Type{
+		Name:       identifier(d.Name.Name),
+		DataTypeID: d.DataTypeID.String(),
+	}
+	if d.EnumDefinition != nil {
+		for _, f := range d.EnumDefinition.Fields {
+			t.Values = append(t.Values, Value{Name: t.Name + identifier(f.Name), Value: f.Value})
+		}
+	}
+	return t
+}
+
+func (g *generator) structure(d *ua.StructureDescription) (Type, error) {
+	def := d.StructureDefinition
+	t := Type{
+		Name:          identifier(d.Name.Name),
+		DataTypeID:    d.DataTypeID.String(),
+		StructureType: def.StructureType,
+	}
+	if def.DefaultEncodingID != nil && !(def.DefaultEncodingID.Namespace() == 0 && def.DefaultEncodingID.IntID() == 0) {
+		t.EncodingID = nodeIDExpr(def.DefaultEncodingID)
+	}
+
+	bit := 0
+	for i, f := range def.Fields {
+		typ, err := g.goType(f.DataType, 0)
+		if err != nil {
+			return t, errors.Errorf("field %s: %s", f.Name, err)
+		}
+		switch f.ValueRank {
+		case -1:
+		case 0, 1:
+			typ = "[]" + typ
+		default:
+			return t, errors.Errorf("field %s: value rank %d not supported", f.Name, f.ValueRank)
+		}
+		gf := Field{Name: identifier(f.Name), Type: typ, Bit: -1, Switch: i + 1}
+		if f.IsOptional && t.IsOptional() {
+			gf.Bit = bit
+			bit++
+		}
+		t.Fields = append(t.Fields, gf)
+	}
+	return t, nil
+}
+
+// goType returns the Go type of a data type.
+func (g *generator) goType(dataTypeID *ua.NodeID, depth int) (string, error) {
+	if depth > 32 {
+		return "", errors.Errorf("data type %s is nested too deeply", dataTypeID)
+	}
+	key := dataTypeID.String()
+	if d := g.structs[key]; d != nil {
+		return "*" + identifier(d.Name.Name), nil
+	}
+	if d := g.enums[key]; d != nil {
+		return identifier(d.Name.Name), nil
+	}
+	if d := g.simples[key]; d != nil {
+		if d.BuiltInType != 0 {
+			return g.builtinType(ua.TypeID(d.BuiltInType))
+		}
+		return g.goType(d.BaseDataType, depth+1)
+	}
+
+	if dataTypeID.Namespace() != 0 {
+		return "", errors.Errorf("unknown data type %s", dataTypeID)
+	}
+	n := dataTypeID.IntID()
+	if n >= 1 && n <= 25 {
+		return g.builtinType(ua.TypeID(n))
+	}
+	switch d := ua.LookupDataType(dataTypeID).(type) {
+	case *ua.StructureDescription:
+		return "*ua." + goname.Format(id.Name(n)), nil
+	case *ua.SimpleTypeDescription:
+		return g.builtinType(ua.TypeID(d.BuiltInType))
+	}
+	// the remaining data types of namespace 0 are enumerations
+	return "int32", nil
+}
+
+var builtinTypes = map[ua.TypeID]string{
+	ua.TypeIDBoolean:         "bool",
+	ua.TypeIDSByte:           "int8",
+	ua.TypeIDByte:            "uint8",
+	ua.TypeIDInt16:           "int16",
+	ua.TypeIDUint16:          "uint16",
+	ua.TypeIDInt32:           "int32",
+	ua.TypeIDUint32:          "uint32",
+	ua.TypeIDInt64:           "int64",
+	ua.TypeIDUint64:          "uint64",
+	ua.TypeIDFloat:           "float32",
+	ua.TypeIDDouble:          "float64",
+	ua.TypeIDString:          "string",
+	ua.TypeIDDateTime:        "time.Time",
+	ua.TypeIDGUID:            "*ua.GUID",
+	ua.TypeIDByteString:      "[]byte",
+	ua.TypeIDXMLElement:      "ua.XMLElement",
+	ua.TypeIDNodeID:          "*ua.NodeID",
+	ua.TypeIDExpandedNodeID:  "*ua.ExpandedNodeID",
+	ua.TypeIDStatusCode:      "ua.StatusCode",
+	ua.TypeIDQualifiedName:   "*ua.QualifiedName",
+	ua.TypeIDLocalizedText:   "*ua.LocalizedText",
+	ua.TypeIDExtensionObject: "*ua.ExtensionObject",
+	ua.TypeIDDataValue:       "*ua.DataValue",
+	ua.TypeIDVariant:         "*ua.Variant",
+	ua.TypeIDDiagnosticInfo:  "*ua.DiagnosticInfo",
+}
+
+func (g *generator) builtinType(t ua.TypeID) (string, error) {
+	s, ok := builtinTypes[t]
+	if !ok {
+		return "", errors.Errorf("invalid built-in type %d", t)
+	}
+	if t == ua.TypeIDDateTime {
+		g.time = true
+	}
+	return s, nil
+}
+
+// identifier returns an exported Go identifier for the name of a data
+// type or a field. Characters which are not allowed in an identifier,
+// like the quotes in the names of some PLCs, are removed.
+func identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			b.WriteRune(r)
+		case r == '_':
+			b.WriteRune(r)
+		default:
+			upper = true
+		}
+	}
+	name := goname.Format(strings.Trim(b.String(), "_"))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "T" + name
+	}
+	return name
+}
+
+// nodeIDExpr returns the Go expression for the node id.
+func nodeIDExpr(n *ua.NodeID) string {
+	switch n.Type() {
+	case ua.NodeIDTypeTwoByte, ua.NodeIDTypeFourByte, ua.NodeIDTypeNumeric:
+		return fmt.Sprintf("ua.NewNumericNodeID(%d, %d)", n.Namespace(), n.IntID())
+	case ua.NodeIDTypeString:
+		return fmt.Sprintf("ua.NewStringNodeID(%d, %q)", n.Namespace(), n.StringID())
+	default:
+		return fmt.Sprintf("ua.MustParseNodeID(%q)", n.String())
+	}
+}
+
+var tmplFile = template.Must(template.New("").Parse(`
+// Code generated by cmd/datatypes. DO NOT EDIT!
+
+// The node ids refer to the namespaces of the server:
+//
+{{- range $i, $v := .Namespaces}}
+//	{{$i}}: {{$v}}
+{{- end}}
+
+package {{.Package}}
+
+import (
+	{{if .Time}}"time"{{end}}
+
+	"github.com/imatic-tech/opcua/ua"
+)
+
+{{range .Enums}}
+// {{.Name}} is the enumeration {{.DataTypeID}}.
+type {{.Name}} int32
+
+const (
+	{{$Name := .Name}}
+	{{range .Values}}{{.Name}} {{$Name}} = {{.Value}}
+	{{end}}
+)
+{{end}}
+
+{{range .Objects}}
+// {{.Name}} is the structure {{.DataTypeID}}.
+type {{.Name}} struct {
+	{{- if .IsOptional}}
+		EncodingMask uint32
+	{{- end}}
+	{{- if .IsUnion}}
+		SwitchField uint32
+	{{- end}}
+	{{range .Fields}}{{.Name}} {{.Type}}
+	{{end}}
+}
+{{if .IsOptional}}
+func (t *{{.Name}}) Decode(b []byte) (int, error) {
+	buf := ua.NewBuffer(b)
+	t.EncodingMask = buf.ReadUint32()
+	{{- range .Fields}}
+	{{- if ge .Bit 0}}
+	if t.EncodingMask&(1<<{{.Bit}}) != 0 {
+		{{template "read" .}}
+	}
+	{{- else}}
+	{{template "read" .}}
+	{{- end}}
+	{{- end}}
+	return buf.Pos(), buf.Error()
+}
+
+func (t *{{.Name}}) Encode() ([]byte, error) {
+	buf := ua.NewBuffer(nil)
+	buf.WriteUint32(t.EncodingMask)
+	{{- range .Fields}}
+	{{- if ge .Bit 0}}
+	if t.EncodingMask&(1<<{{.Bit}}) != 0 {
+		buf.WriteStruct(t.{{.Name}})
+	}
+	{{- else}}
+	buf.WriteStruct(t.{{.Name}})
+	{{- end}}
+	{{- end}}
+	return buf.Bytes(), buf.Error()
+}
+{{end}}
+{{- if .IsUnion}}
+func (t *{{.Name}}) Decode(b []byte) (int, error) {
+	buf := ua.NewBuffer(b)
+	t.SwitchField = buf.ReadUint32()
+	switch t.SwitchField {
+	{{- range .Fields}}
+	case {{.Switch}}:
+		{{template "read" .}}
+	{{- end}}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+func (t *{{.Name}}) Encode() ([]byte, error) {
+	buf := ua.NewBuffer(nil)
+	buf.WriteUint32(t.SwitchField)
+	switch t.SwitchField {
+	{{- range .Fields}}
+	case {{.Switch}}:
+		buf.WriteStruct(t.{{.Name}})
+	{{- end}}
+	}
+	return buf.Bytes(), buf.Error()
+}
+{{end}}
+{{end}}
+
+func init() {
+	{{- range .Objects}}
+	{{- if .EncodingID}}
+	ua.RegisterExtensionObject({{.EncodingID}}, new({{.Name}}))
+	{{- end}}
+	{{- end}}
+}
+
+{{define "read"}}
+	{{- if .Elem -}}
+		t.{{.Name}} = new({{.Elem}})
+		buf.ReadStruct(t.{{.Name}})
+	{{- else -}}
+		buf.ReadStruct(&t.{{.Name}})
+	{{- end}}
+{{- end}}
+`))
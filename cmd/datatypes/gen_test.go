@@ -0,0 +1,89 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	field := func(name string, dataType *ua.NodeID, valueRank int32, optional bool) *ua.StructureField {
+		return &ua.StructureField{Name: name, DataType: dataType, ValueRank: valueRank, IsOptional: optional}
+	}
+	structure := func(i uint32, name string, typ ua.StructureType, fields ...*ua.StructureField) *ua.StructureDescription {
+		return &ua.StructureDescription{
+			DataTypeID: ua.NewNumericNodeID(1, i),
+			Name:       &ua.QualifiedName{NamespaceIndex: 1, Name: name},
+			StructureDefinition: &ua.StructureDefinition{
+				DefaultEncodingID: ua.NewNumericNodeID(1, i+1000),
+				BaseDataType:      ua.NewNumericNodeID(0, id.Structure),
+				StructureType:     typ,
+				Fields:            fields,
+			},
+		}
+	}
+
+	h := &ua.DataTypeSchemaHeader{
+		Namespaces: []string{"http://opcfoundation.org/UA/", "urn:gopcua:test"},
+		EnumDataTypes: []*ua.EnumDescription{{
+			DataTypeID: ua.NewNumericNodeID(1, 100),
+			Name:       &ua.QualifiedName{NamespaceIndex: 1, Name: "Level"},
+			EnumDefinition: &ua.EnumDefinition{Fields: []*ua.EnumField{
+				{Name: "Low", Value: 0},
+				{Name: "High", Value: 1},
+			}},
+		}},
+		SimpleDataTypes: []*ua.SimpleTypeDescription{{
+			DataTypeID:   ua.NewNumericNodeID(1, 101),
+			Name:         &ua.QualifiedName{NamespaceIndex: 1, Name: "Percent"},
+			BaseDataType: ua.NewNumericNodeID(0, id.Double),
+			BuiltInType:  uint8(ua.TypeIDDouble),
+		}},
+		StructureDataTypes: []*ua.StructureDescription{
+			structure(102, "Sensor", ua.StructureTypeStructure,
+				field("Name", ua.NewNumericNodeID(0, id.String), -1, false),
+				field("Level", ua.NewNumericNodeID(1, 100), -1, false),
+				field("Values", ua.NewNumericNodeID(0, id.Float), 1, false),
+				field("Updated", ua.NewNumericNodeID(0, id.DateTime), -1, false),
+			),
+			structure(103, "Limits", ua.StructureTypeStructureWithOptionalFields,
+				field("Sensor", ua.NewNumericNodeID(1, 102), -1, false),
+				field("Low", ua.NewNumericNodeID(1, 101), -1, true),
+				field("High", ua.NewNumericNodeID(1, 101), -1, true),
+			),
+			structure(104, "Reading", ua.StructureTypeUnion,
+				field("Count", ua.NewNumericNodeID(0, id.Int32), -1, false),
+				field("Text", ua.NewNumericNodeID(0, id.String), -1, false),
+			),
+		},
+	}
+
+	got, err := Generate("plc", h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const golden = "testdata/types_gen.go.golden"
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("generated source differs from %s, run the test with -update and check the diff\n%s", golden, got)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Command datatypes generates Go types for the custom structures and
+// enumerations of a server. The structures are registered with
+// ua.RegisterExtensionObject so that their values are decoded into the
+// generated types.
+//
+// The data types are read from the DataTypeDefinition attribute or from
+// the type dictionaries of the server with -dictionaries. They can be
+// saved with -schema and the code can be generated from the saved schema
+// with -in without a connection to the server.
+//
+//	go run ./cmd/datatypes -endpoint opc.tcp://plc:4840 -ns 3 -pkg plc -out plc/types_gen.go
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/imatic-tech/opcua"
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+func main() {
+	var (
+		endpoint = flag.String("endpoint", "opc.tcp://localhost:4840", "OPC UA Endpoint URL")
+		policy   = flag.String("policy", "", "Security policy: None, Basic128Rsa15, Basic256, Basic256Sha256. Default: auto")
+		mode     = flag.String("mode", "", "Security mode: None, Sign, SignAndEncrypt. Default: auto")
+		certFile = flag.String("cert", "", "Path to cert.pem. Required for security mode/policy != None")
+		keyFile  = flag.String("key", "", "Path to private key.pem. Required for security mode/policy != None")
+		nsList   = flag.String("ns", "", "Comma separated list of namespace indexes or URIs. Default: all except 0")
+		dicts    = flag.Bool("dictionaries", false, "Read the data types from the type dictionaries of the server")
+		in       = flag.String("in", "", "Path to a schema saved with -schema. Generates the code without connecting to the server")
+		schema   = flag.String("schema", "", "Path to save the schema of the data types")
+		pkg      = flag.String("pkg", "main", "Go package name")
+		out      = flag.String("out", "", "Path to the output file. Default: stdout")
+	)
+	flag.BoolVar(&debug.Enable, "debug", false, "enable debug logging")
+	flag.Parse()
+	log.SetFlags(0)
+
+	var h *ua.DataTypeSchemaHeader
+	var err error
+	if *in != "" {
+		h, err = readSchema(*in)
+	} else {
+		h, err = loadSchema(*endpoint, *policy, *mode, *certFile, *keyFile, *nsList, *dicts)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *schema != "" {
+		b, err := ua.Encode(h)
+		if err != nil {
+			log.Fatalf("Failed to encode schema: %s", err)
+		}
+		if err := ioutil.WriteFile(*schema, b, 0644); err != nil {
+			log.Fatalf("Failed to write %s: %s", *schema, err)
+		}
+		log.Printf("Wrote %s", *schema)
+	}
+
+	src, err := Generate(*pkg, h)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+	log.Printf("Wrote %s", *out)
+}
+
+func readSchema(filename string) (*ua.DataTypeSchemaHeader, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	h := new(ua.DataTypeSchemaHeader)
+	if _, err := ua.Decode(b, h); err != nil {
+		return nil, errors.Errorf("invalid schema %s: %s", filename, err)
+	}
+	return h, nil
+}
+
+// loadSchema connects to the server and loads the structures and
+// enumerations of the selected namespaces and the data types they
+// depend on.
+func loadSchema(endpoint, policy, mode, certFile, keyFile, nsList string, dicts bool) (*ua.DataTypeSchemaHeader, error) {
+	ctx := context.Background()
+
+	endpoints, err := opcua.GetEndpoints(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	ep := opcua.SelectEndpoint(endpoints, policy, ua.MessageSecurityModeFromString(mode))
+	if ep == nil {
+		return nil, errors.Errorf("failed to find suitable endpoint")
+	}
+
+	opts := []opcua.Option{
+		opcua.SecurityPolicy(policy),
+		opcua.SecurityModeString(mode),
+		opcua.CertificateFile(certFile),
+		opcua.PrivateKeyFile(keyFile),
+		opcua.AuthAnonymous(),
+		opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+	}
+	c := opcua.NewClient(ep.EndpointURL, opts...)
+	if err := c.Connect(ctx); err != nil {
+		return nil, err
+	}
+	defer c.CloseWithContext(ctx)
+
+	nsa, err := c.NamespaceArrayWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectNamespaces(nsList, nsa)
+	if err != nil {
+		return nil, err
+	}
+
+	if dicts {
+		if err := c.LoadDataTypeDictionaries(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var ids []*ua.NodeID
+	for _, base := range []uint32{id.Structure, id.Enumeration} {
+		if err := subtypes(ctx, c, ua.NewNumericNodeID(0, base), selected, &ids); err != nil {
+			return nil, err
+		}
+	}
+
+	h := &ua.DataTypeSchemaHeader{Namespaces: nsa}
+	seen := map[string]bool{}
+	var add func(dataTypeID *ua.NodeID)
+	add = func(dataTypeID *ua.NodeID) {
+		if dataTypeID.Namespace() == 0 || seen[dataTypeID.String()] {
+			return
+		}
+		seen[dataTypeID.String()] = true
+		switch d := ua.LookupDataType(dataTypeID).(type) {
+		case *ua.StructureDescription:
+			h.StructureDataTypes = append(h.StructureDataTypes, d)
+			for _, f := range d.StructureDefinition.Fields {
+				add(f.DataType)
+			}
+		case *ua.EnumDescription:
+			h.EnumDataTypes = append(h.EnumDataTypes, d)
+		case *ua.SimpleTypeDescription:
+			h.SimpleDataTypes = append(h.SimpleDataTypes, d)
+			add(d.BaseDataType)
+		}
+	}
+	for _, dataTypeID := range ids {
+		if err := c.LoadDataType(ctx, dataTypeID); err != nil {
+			log.Printf("Skipping data type %s: %s", dataTypeID, err)
+			continue
+		}
+		add(dataTypeID)
+	}
+	return h, nil
+}
+
+// selectNamespaces returns the indexes of the namespaces in the comma
+// separated list of indexes or URIs. An empty list selects all namespaces
+// except namespace 0.
+func selectNamespaces(list string, nsa []string) (map[uint16]bool, error) {
+	selected := map[uint16]bool{}
+	if list == "" {
+		for i := 1; i < len(nsa); i++ {
+			selected[uint16(i)] = true
+		}
+		return selected, nil
+	}
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if n, err := strconv.ParseUint(s, 10, 16); err == nil {
+			selected[uint16(n)] = true
+			continue
+		}
+		found := false
+		for i, uri := range nsa {
+			if uri == s {
+				selected[uint16(i)] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown namespace %s", s)
+		}
+	}
+	return selected, nil
+}
+
+// subtypes appends the subtypes of the data type in the selected
+// namespaces to ids.
+func subtypes(ctx context.Context, c *opcua.Client, dataTypeID *ua.NodeID, selected map[uint16]bool, ids *[]*ua.NodeID) error {
+	refs, err := c.Node(dataTypeID).ReferencesWithContext(ctx, id.HasSubtype, ua.BrowseDirectionForward, ua.NodeClassDataType, false)
+	if err != nil {
+		return err
+	}
+	for _, r := range refs {
+		subtypeID := r.NodeID.NodeID
+		if selected[subtypeID.Namespace()] {
+			*ids = append(*ids, subtypeID)
+		}
+		if err := subtypes(ctx, c, subtypeID, selected, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
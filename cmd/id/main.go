@@ -70,7 +70,10 @@ var tmpl = template.Must(template.New("").Parse(`
 
 package id
 
-import "strconv"
+import (
+	"strconv"
+	"sync"
+)
 
 func Name(id uint32) string {
 	if s, ok := name[id]; ok {
@@ -79,6 +82,29 @@ func Name(id uint32) string {
 	return strconv.FormatUint(uint64(id), 10)
 }
 
+// ID returns the id of the node with the given name, e.g. "Range" or
+// "Range_Encoding_DefaultBinary". It returns 0 if the name is unknown.
+func ID(s string) uint32 {
+	idsOnce.Do(func() {
+		ids = make(map[string]uint32, len(name))
+		for id, s := range name {
+			ids[s] = id
+		}
+	})
+	return ids[s]
+}
+
+var (
+	idsOnce sync.Once
+	ids     map[string]uint32
+)
+
+// IsReferenceType returns true if the id is the id of a reference type
+// in namespace 0, e.g. HasComponent.
+func IsReferenceType(id uint32) bool {
+	return referenceTypes[id]
+}
+
 const (
 	{{range .}}{{index . 0}} = {{index . 1}}
 	{{end}}
@@ -89,6 +115,12 @@ var name = map[uint32]string{
 	{{index . 1}}: "{{index . 0}}",
 	{{- end}}
 }
+
+var referenceTypes = map[uint32]bool{
+	{{- range .}}{{if eq (index . 2) "ReferenceType"}}
+	{{index . 1}}: true,
+	{{- end}}{{end}}
+}
 `))
 
 func goName(s string) string {
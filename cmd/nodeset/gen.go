@@ -0,0 +1,558 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/imatic-tech/opcua/cmd/service/goname"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// generate returns the source files of the package of a namespace by
+// their path relative to the output directory.
+func (m *model) generate(uri string) (map[string][]byte, error) {
+	pkg := packageName(uri)
+	g := &generator{model: m, uri: uri, pkg: pkg, imports: map[string]bool{}}
+
+	// the names of the ids are unique within the namespace
+	ids := map[nodeKey]string{}
+	var rows []idRow
+	names := map[string]bool{}
+	for _, n := range m.order {
+		if n.key.uri != uri || n.intID == 0 {
+			continue
+		}
+		name := m.idName(n.key)
+		if names[name] {
+			name += "_" + strconv.FormatUint(uint64(n.intID), 10)
+		}
+		names[name] = true
+		ids[n.key] = name
+		rows = append(rows, idRow{name, n.intID})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	g.ids = ids
+
+	var enums, objs []Type
+	for _, n := range m.order {
+		if n.key.uri != uri || n.dataType == nil || n.dataType.Definition == nil {
+			continue
+		}
+		t, err := g.dataType(n)
+		if err != nil {
+			log.Printf("Skipping data type %s: %s", n.BrowseName, err)
+			continue
+		}
+		switch t.Kind {
+		case kindEnum, kindOptionSet:
+			enums = append(enums, t)
+		case kindStructure:
+			objs = append(objs, t)
+		}
+	}
+
+	data := map[string]interface{}{
+		"Package":    pkg,
+		"URI":        uri,
+		"Import":     m.importPath + "/" + pkg,
+		"IDs":        rows,
+		"Enums":      enums,
+		"Objects":    objs,
+		"Time":       g.time,
+		"ImportList": g.importList(),
+	}
+	// the imports which the generated code uses
+	for _, o := range objs {
+		enc := o.BinaryEncodingID + o.XMLEncodingID
+		if enc != "" {
+			data["RegisterUsesUA"] = true
+		}
+		if strings.Contains(enc, "id.") {
+			data["RegisterUsesID"] = true
+		}
+		if strings.Contains(enc, "fmt.") {
+			data["RegisterUsesFmt"] = true
+		}
+		if o.IsOptional || o.IsUnion {
+			data["UsesUA"] = true
+		}
+		for _, f := range o.Fields {
+			if strings.Contains(f.Type, "ua.") {
+				data["UsesUA"] = true
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for name, tmpl := range map[string]*template.Template{
+		pkg + "/id/id_gen.go":            tmplIDs,
+		pkg + "/enums_gen.go":            tmplEnums,
+		pkg + "/extobjs_gen.go":          tmplExtObjects,
+		pkg + "/register_extobjs_gen.go": tmplRegister,
+	} {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(b.Bytes())
+		if err != nil {
+			return nil, errors.Errorf("invalid source of %s: %s\n%s", name, err, b.Bytes())
+		}
+		files[name] = src
+	}
+	return files, nil
+}
+
+type idRow struct {
+	Name string
+	ID   uint32
+}
+
+type generator struct {
+	*model
+	uri, pkg string
+	ids      map[nodeKey]string
+	imports  map[string]bool
+
+	// time is true if a field has the type time.Time.
+	time bool
+}
+
+type Type struct {
+	// Name is the Go name of the data type.
+	Name string
+
+	// Kind is the kind of the data type.
+	Kind kind
+
+	// Type is the underlying Go type of enumerations and option sets.
+	Type string
+
+	// BrowseName is the browse name of the data type.
+	BrowseName string
+
+	// BinaryEncodingID and XMLEncodingID are the Go expressions of the
+	// node ids of the encodings with the namespace index ns or empty.
+	BinaryEncodingID string
+	XMLEncodingID    string
+
+	// IsOptional is true for structures with optional fields.
+	IsOptional bool
+
+	// IsUnion is true for unions.
+	IsUnion bool
+
+	// Fields is the list of struct fields.
+	Fields []Field
+
+	// Values is the list of enum values.
+	Values []Value
+}
+
+type Field struct {
+	Name string
+	Type string
+
+	// Bit is the bit of the field in the encoding mask of an optional
+	// field or -1.
+	Bit int
+
+	// Switch is the value of the switch field of a union which selects
+	// the field.
+	Switch int
+}
+
+// Elem returns the type which the pointer type of the field points to
+// or an empty string if the field is not a pointer.
+func (f Field) Elem() string {
+	if strings.HasPrefix(f.Type, "*") {
+		return f.Type[1:]
+	}
+	return ""
+}
+
+type Value struct {
+	Name      string
+	ShortName string
+	Value     int64
+}
+
+func (g *generator) dataType(n *node) (Type, error) {
+	k, bt, err := g.dataTypeKind(n.key)
+	if err != nil {
+		return Type{}, err
+	}
+	def := n.dataType.Definition
+	t := Type{Name: g.typeName(n), Kind: k, BrowseName: n.BrowseName}
+
+	switch {
+	case k == kindEnum:
+		t.Type = "int32"
+		for _, f := range def.Fields {
+			t.Values = append(t.Values, Value{Name: t.Name + identifier(f.Name), ShortName: f.Name, Value: f.Value})
+		}
+		return t, nil
+
+	case k == kindSimple && def.IsOptionSet:
+		t.Kind = kindOptionSet
+		if t.Type, err = g.builtinType(bt); err != nil {
+			return t, err
+		}
+		for _, f := range def.Fields {
+			t.Values = append(t.Values, Value{Name: t.Name + identifier(f.Name), ShortName: f.Name, Value: 1 << uint(f.Value)})
+		}
+		return t, nil
+
+	case k != kindStructure || def.IsOptionSet:
+		return t, errors.Errorf("definition not supported")
+	}
+
+	t.IsUnion = def.IsUnion
+	bit := 0
+	for i, f := range def.Fields {
+		fk, err := key(n.set, f.DataType)
+		if err != nil {
+			return t, err
+		}
+		typ, err := g.goType(fk)
+		if err != nil {
+			return t, errors.Errorf("field %s: %s", f.Name, err)
+		}
+		switch f.ValueRank {
+		case -1:
+		case 0, 1:
+			typ = "[]" + typ
+		default:
+			return t, errors.Errorf("field %s: value rank %d not supported", f.Name, f.ValueRank)
+		}
+		name := identifier(f.Name)
+		if f.SymbolicName != "" {
+			name = identifier(f.SymbolicName)
+		}
+		gf := Field{Name: name, Type: typ, Bit: -1, Switch: i + 1}
+		if f.IsOptional && !def.IsUnion {
+			t.IsOptional = true
+			gf.Bit = bit
+			bit++
+		}
+		t.Fields = append(t.Fields, gf)
+	}
+
+	if enc, ok := n.encodings["Default Binary"]; ok {
+		t.BinaryEncodingID = g.nodeIDExpr(enc)
+	}
+	if enc, ok := n.encodings["Default XML"]; ok {
+		t.XMLEncodingID = g.nodeIDExpr(enc)
+	}
+	return t, nil
+}
+
+// nodeIDExpr returns the Go expression of the node id of a node of the
+// namespace with the namespace index ns.
+func (g *generator) nodeIDExpr(k nodeKey) string {
+	if name, ok := g.ids[k]; ok {
+		return "ua.NewNumericNodeID(ns, id." + name + ")"
+	}
+	format := "ns=%d;" + strings.Replace(k.id, "%", "%%", -1)
+	return fmt.Sprintf("ua.MustParseNodeID(fmt.Sprintf(%s, ns))", strconv.Quote(format))
+}
+
+// goType returns the Go type of a data type.
+func (g *generator) goType(k nodeKey) (string, error) {
+	if k.uri == nodeset.UANamespace {
+		i := k.ns0()
+		switch d := ua.LookupDataType(ua.NewNumericNodeID(0, i)).(type) {
+		case *ua.StructureDescription:
+			return "*ua." + goname.Format(id.Name(i)), nil
+		case *ua.SimpleTypeDescription:
+			return g.builtinType(ua.TypeID(d.BuiltInType))
+		}
+		if i >= 1 && i <= 25 {
+			return g.builtinType(ua.TypeID(i))
+		}
+		// the remaining data types of namespace 0 are enumerations
+		return "int32", nil
+	}
+
+	n := g.nodes[k]
+	if n == nil || n.dataType == nil {
+		return "", errors.Errorf("unknown data type %s of %s", k.id, k.uri)
+	}
+	kd, bt, err := g.dataTypeKind(k)
+	if err != nil {
+		return "", err
+	}
+	def := n.dataType.Definition
+	switch {
+	case kd == kindEnum, kd == kindSimple && def != nil && def.IsOptionSet:
+		return g.qualify(k.uri) + g.typeName(n), nil
+	case kd == kindSimple:
+		return g.builtinType(bt)
+	case n.dataType.IsAbstract || def == nil:
+		return "*ua.ExtensionObject", nil
+	default:
+		return "*" + g.qualify(k.uri) + g.typeName(n), nil
+	}
+}
+
+// qualify returns the package qualifier for the types of a namespace.
+func (g *generator) qualify(uri string) string {
+	if uri == g.uri {
+		return ""
+	}
+	pkg := packageName(uri)
+	g.imports[g.importPath+"/"+pkg] = true
+	return pkg + "."
+}
+
+func (g *generator) importList() []string {
+	var l []string
+	for p := range g.imports {
+		l = append(l, p)
+	}
+	sort.Strings(l)
+	return l
+}
+
+var builtinTypes = map[ua.TypeID]string{
+	ua.TypeIDBoolean:         "bool",
+	ua.TypeIDSByte:           "int8",
+	ua.TypeIDByte:            "uint8",
+	ua.TypeIDInt16:           "int16",
+	ua.TypeIDUint16:          "uint16",
+	ua.TypeIDInt32:           "int32",
+	ua.TypeIDUint32:          "uint32",
+	ua.TypeIDInt64:           "int64",
+	ua.TypeIDUint64:          "uint64",
+	ua.TypeIDFloat:           "float32",
+	ua.TypeIDDouble:          "float64",
+	ua.TypeIDString:          "string",
+	ua.TypeIDDateTime:        "time.Time",
+	ua.TypeIDGUID:            "*ua.GUID",
+	ua.TypeIDByteString:      "[]byte",
+	ua.TypeIDXMLElement:      "ua.XMLElement",
+	ua.TypeIDNodeID:          "*ua.NodeID",
+	ua.TypeIDExpandedNodeID:  "*ua.ExpandedNodeID",
+	ua.TypeIDStatusCode:      "ua.StatusCode",
+	ua.TypeIDQualifiedName:   "*ua.QualifiedName",
+	ua.TypeIDLocalizedText:   "*ua.LocalizedText",
+	ua.TypeIDExtensionObject: "*ua.ExtensionObject",
+	ua.TypeIDDataValue:       "*ua.DataValue",
+	ua.TypeIDVariant:         "*ua.Variant",
+	ua.TypeIDDiagnosticInfo:  "*ua.DiagnosticInfo",
+}
+
+func (g *generator) builtinType(t ua.TypeID) (string, error) {
+	s, ok := builtinTypes[t]
+	if !ok {
+		return "", errors.Errorf("invalid built-in type %d", t)
+	}
+	if t == ua.TypeIDDateTime {
+		g.time = true
+	}
+	return s, nil
+}
+
+const header = `// Code generated by cmd/nodeset. DO NOT EDIT!
+`
+
+var tmplIDs = template.Must(template.New("").Parse(header + `
+// Package id contains the ids of the nodes of the namespace
+// {{.URI}}.
+package id
+
+import (
+	"strconv"
+	"sync"
+)
+
+func Name(id uint32) string {
+	if s, ok := name[id]; ok {
+		return s
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// ID returns the id of the node with the given name. It returns 0 if the
+// name is unknown.
+func ID(s string) uint32 {
+	idsOnce.Do(func() {
+		ids = make(map[string]uint32, len(name))
+		for id, s := range name {
+			ids[s] = id
+		}
+	})
+	return ids[s]
+}
+
+var (
+	idsOnce sync.Once
+	ids     map[string]uint32
+)
+
+const (
+	{{range .IDs}}{{.Name}} = {{.ID}}
+	{{end}}
+)
+
+var name = map[uint32]string{
+	{{- range .IDs}}
+	{{.ID}}: "{{.Name}}",
+	{{- end}}
+}
+`))
+
+var tmplEnums = template.Must(template.New("").Parse(header + `
+package {{.Package}}
+
+{{range .Enums}}
+// {{.Name}} is the data type {{.BrowseName}}.
+type {{.Name}} {{.Type}}
+
+func {{.Name}}FromString(s string) {{.Name}} {
+	switch s {
+		{{range .Values}}case "{{.ShortName}}": return {{.Value}}
+		{{end}}default:
+		return 0
+	}
+}
+
+const (
+	{{$Name := .Name}}
+	{{range .Values}}{{.Name}} {{$Name}} = {{.Value}}
+	{{end}}
+)
+{{end}}
+`))
+
+var tmplExtObjects = template.Must(template.New("").Parse(header + `
+package {{.Package}}
+
+import (
+	{{if .Time}}"time"{{end}}
+
+	{{if .UsesUA}}"github.com/imatic-tech/opcua/ua"{{end}}
+	{{range .ImportList}}"{{.}}"
+	{{end}}
+)
+
+{{range .Objects}}
+// {{.Name}} is the data type {{.BrowseName}}.
+type {{.Name}} struct {
+	{{- if .IsOptional}}
+		EncodingMask uint32
+	{{- end}}
+	{{- if .IsUnion}}
+		SwitchField uint32
+	{{- end}}
+	{{range .Fields}}{{.Name}} {{.Type}}
+	{{end}}
+}
+{{if .IsOptional}}
+func (t *{{.Name}}) Decode(b []byte) (int, error) {
+	buf := ua.NewBuffer(b)
+	t.EncodingMask = buf.ReadUint32()
+	{{- range .Fields}}
+	{{- if ge .Bit 0}}
+	if t.EncodingMask&(1<<{{.Bit}}) != 0 {
+		{{template "read" .}}
+	}
+	{{- else}}
+	{{template "read" .}}
+	{{- end}}
+	{{- end}}
+	return buf.Pos(), buf.Error()
+}
+
+func (t *{{.Name}}) Encode() ([]byte, error) {
+	buf := ua.NewBuffer(nil)
+	buf.WriteUint32(t.EncodingMask)
+	{{- range .Fields}}
+	{{- if ge .Bit 0}}
+	if t.EncodingMask&(1<<{{.Bit}}) != 0 {
+		buf.WriteStruct(t.{{.Name}})
+	}
+	{{- else}}
+	buf.WriteStruct(t.{{.Name}})
+	{{- end}}
+	{{- end}}
+	return buf.Bytes(), buf.Error()
+}
+{{end}}
+{{- if .IsUnion}}
+func (t *{{.Name}}) Decode(b []byte) (int, error) {
+	buf := ua.NewBuffer(b)
+	t.SwitchField = buf.ReadUint32()
+	switch t.SwitchField {
+	{{- range .Fields}}
+	case {{.Switch}}:
+		{{template "read" .}}
+	{{- end}}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+func (t *{{.Name}}) Encode() ([]byte, error) {
+	buf := ua.NewBuffer(nil)
+	buf.WriteUint32(t.SwitchField)
+	switch t.SwitchField {
+	{{- range .Fields}}
+	case {{.Switch}}:
+		buf.WriteStruct(t.{{.Name}})
+	{{- end}}
+	}
+	return buf.Bytes(), buf.Error()
+}
+{{end}}
+{{end}}
+
+{{define "read"}}
+	{{- if .Elem -}}
+		t.{{.Name}} = new({{.Elem}})
+		buf.ReadStruct(t.{{.Name}})
+	{{- else -}}
+		buf.ReadStruct(&t.{{.Name}})
+	{{- end}}
+{{- end}}
+`))
+
+var tmplRegister = template.Must(template.New("").Parse(header + `
+package {{.Package}}
+
+import (
+	{{if .RegisterUsesFmt}}"fmt"{{end}}
+
+	{{if .RegisterUsesUA}}"github.com/imatic-tech/opcua/ua"{{end}}
+	{{if .RegisterUsesID}}"{{.Import}}/id"{{end}}
+)
+
+// NamespaceURI is the URI of the namespace of the package.
+const NamespaceURI = "{{.URI}}"
+
+// RegisterExtensionObjects registers the structures of the namespace with
+// ua.RegisterExtensionObject. ns is the index of NamespaceURI in the
+// namespace array of the server, e.g. from Client.NamespaceArray.
+func RegisterExtensionObjects(ns uint16) {
+	{{- range .Objects}}
+	{{- if .BinaryEncodingID}}
+	ua.RegisterExtensionObject({{.BinaryEncodingID}}, new({{.Name}}))
+	{{- end}}
+	{{- if .XMLEncodingID}}
+	ua.RegisterExtensionObjectXML({{.XMLEncodingID}}, new({{.Name}}))
+	{{- end}}
+	{{- end}}
+}
+`))
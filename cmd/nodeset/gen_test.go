@@ -0,0 +1,67 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	s, err := nodeset.ReadFile("../../nodeset/testdata/Test.NodeSet2.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel("example.com/plant/opcua")
+	if err := m.add(s); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "models", m.models, []string{"urn:gopcua:test"})
+
+	files, err := m.generate("urn:gopcua:test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	verify.Values(t, "files", names, []string{
+		"test/enums_gen.go",
+		"test/extobjs_gen.go",
+		"test/id/id_gen.go",
+		"test/register_extobjs_gen.go",
+	})
+
+	for _, name := range names {
+		golden := filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(golden, files[name], 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(files[name], want) {
+			t.Errorf("%s differs from %s, run the test with -update and check the diff\n%s", name, golden, files[name])
+		}
+	}
+}
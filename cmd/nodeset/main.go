@@ -0,0 +1,82 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Command nodeset generates Go packages for the namespaces of NodeSet2
+// XML files, e.g. of the companion specifications. The package of a
+// namespace contains the structures and enumerations of its data types
+// and a function which registers the structures with
+// ua.RegisterExtensionObject for the namespace index of a server. The id
+// subpackage contains the numeric ids of the nodes like the id package.
+//
+// The node sets of the namespaces which a namespace depends on must be
+// passed as well and are generated into their own packages which the
+// dependent packages import.
+//
+//	go run ./cmd/nodeset -in Opc.Ua.Di.NodeSet2.xml,Opc.Ua.Machinery.NodeSet2.xml -out opcua -import example.com/plant/opcua
+//
+// generates the packages opcua/di, opcua/di/id, opcua/machinery and
+// opcua/machinery/id.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/imatic-tech/opcua/nodeset"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	in := flag.String("in", "", "Comma separated list of NodeSet2 XML files")
+	uris := flag.String("uri", "", "Comma separated list of namespace URIs to generate. Default: the models of all files")
+	out := flag.String("out", ".", "Path to the output directory")
+	importPath := flag.String("import", "", "Go import path of the output directory")
+	flag.Parse()
+
+	if *in == "" {
+		log.Fatal("-in is required")
+	}
+	if *importPath == "" {
+		log.Fatal("-import is required")
+	}
+
+	m := newModel(*importPath)
+	for _, filename := range strings.Split(*in, ",") {
+		s, err := nodeset.ReadFile(filename)
+		if err != nil {
+			log.Fatalf("Error reading %s: %v", filename, err)
+		}
+		if err := m.add(s); err != nil {
+			log.Fatalf("Error reading %s: %v", filename, err)
+		}
+	}
+
+	var gen []string
+	if *uris != "" {
+		gen = strings.Split(*uris, ",")
+	} else {
+		gen = m.models
+	}
+	for _, uri := range gen {
+		files, err := m.generate(uri)
+		if err != nil {
+			log.Fatalf("Error generating %s: %v", uri, err)
+		}
+		for name, src := range files {
+			filename := filepath.Join(*out, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+				log.Fatal(err)
+			}
+			if err := ioutil.WriteFile(filename, src, 0644); err != nil {
+				log.Fatalf("Error writing %s: %v", filename, err)
+			}
+			log.Printf("Wrote %s", filename)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/imatic-tech/opcua/cmd/service/goname"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// nodeKey identifies a node of any of the node sets by the URI of its
+// namespace and its node id without the namespace index.
+type nodeKey struct {
+	uri string
+	id  string
+}
+
+type node struct {
+	key nodeKey
+	set *nodeset.NodeSet
+	*nodeset.Node
+
+	// intID is the numeric id or 0.
+	intID uint32
+
+	// parent is the parent node of an instance.
+	parent *nodeKey
+
+	// dataType is set for data type nodes.
+	dataType *nodeset.DataType
+
+	// supertype is the supertype of a type.
+	supertype *nodeKey
+
+	// encodingOf is the data type of an encoding.
+	encodingOf *nodeKey
+
+	// encodings are the encodings of a data type by browse name.
+	encodings map[string]nodeKey
+}
+
+// model contains the nodes of all node sets.
+type model struct {
+	importPath string
+	nodes      map[nodeKey]*node
+
+	// order contains the nodes in the order of the node sets.
+	order []*node
+
+	// models contains the URIs of the models of the node sets.
+	models []string
+
+	idNames map[nodeKey]string
+}
+
+func newModel(importPath string) *model {
+	return &model{
+		importPath: importPath,
+		nodes:      map[nodeKey]*node{},
+		idNames:    map[nodeKey]string{},
+	}
+}
+
+// key returns the key of a node id or an alias of the node set.
+func key(s *nodeset.NodeSet, v string) (nodeKey, error) {
+	n, err := s.NodeID(v)
+	if err != nil {
+		return nodeKey{}, err
+	}
+	uri := s.NamespaceURI(n.Namespace())
+	if uri == "" {
+		return nodeKey{}, errors.Errorf("invalid namespace index in %s", v)
+	}
+	local := n.String()
+	if strings.HasPrefix(local, "ns=") {
+		local = local[strings.Index(local, ";")+1:]
+	}
+	return nodeKey{uri, local}, nil
+}
+
+// isNS0 returns true if the key refers to the node i=n of namespace 0.
+func (k nodeKey) isNS0(n uint32) bool {
+	return k.uri == nodeset.UANamespace && k.id == "i="+strconv.FormatUint(uint64(n), 10)
+}
+
+// ns0 returns the numeric id of a node of namespace 0 or 0.
+func (k nodeKey) ns0() uint32 {
+	if k.uri != nodeset.UANamespace || !strings.HasPrefix(k.id, "i=") {
+		return 0
+	}
+	n, _ := strconv.ParseUint(k.id[2:], 10, 32)
+	return uint32(n)
+}
+
+// add adds the nodes of a node set.
+func (m *model) add(s *nodeset.NodeSet) error {
+	for _, md := range s.Models {
+		m.models = append(m.models, md.ModelURI)
+	}
+
+	for _, n := range s.Nodes() {
+		b := n.Base()
+		k, err := key(s, b.NodeID)
+		if err != nil {
+			return err
+		}
+		nd := &node{key: k, set: s, Node: b, encodings: map[string]nodeKey{}}
+		if strings.HasPrefix(k.id, "i=") {
+			i, _ := strconv.ParseUint(k.id[2:], 10, 32)
+			nd.intID = uint32(i)
+		}
+		switch x := n.(type) {
+		case *nodeset.DataType:
+			nd.dataType = x
+		case *nodeset.Object:
+			nd.parent, err = optionalKey(s, x.ParentNodeID)
+		case *nodeset.Variable:
+			nd.parent, err = optionalKey(s, x.ParentNodeID)
+		case *nodeset.Method:
+			nd.parent, err = optionalKey(s, x.ParentNodeID)
+		case *nodeset.View:
+			nd.parent, err = optionalKey(s, x.ParentNodeID)
+		}
+		if err != nil {
+			return err
+		}
+		m.nodes[k] = nd
+		m.order = append(m.order, nd)
+	}
+
+	// resolve the supertypes and the encodings
+	for _, n := range s.Nodes() {
+		b := n.Base()
+		k, _ := key(s, b.NodeID)
+		nd := m.nodes[k]
+		for _, r := range b.References {
+			refType, err := key(s, r.ReferenceType)
+			if err != nil {
+				return err
+			}
+			target, err := key(s, r.Target)
+			if err != nil {
+				return err
+			}
+			switch {
+			case refType.isNS0(id.HasSubtype) && !r.IsForward:
+				nd.supertype = &target
+			case refType.isNS0(id.HasEncoding) && r.IsForward:
+				m.setEncoding(k, target)
+			case refType.isNS0(id.HasEncoding) && !r.IsForward:
+				m.setEncoding(target, k)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *model) setEncoding(dataType, encoding nodeKey) {
+	dt, enc := m.nodes[dataType], m.nodes[encoding]
+	if dt == nil || enc == nil {
+		return
+	}
+	enc.encodingOf = &dataType
+	dt.encodings[enc.Name()] = encoding
+}
+
+func optionalKey(s *nodeset.NodeSet, v string) (*nodeKey, error) {
+	if v == "" {
+		return nil, nil
+	}
+	k, err := key(s, v)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// idName returns the name of the id constant of a node. Instances are
+// named after their parent and encodings after their data type like in
+// NodeIds.csv, e.g. "DeviceType_DeviceClass" or
+// "Range_Encoding_DefaultBinary".
+func (m *model) idName(k nodeKey) string {
+	if s, ok := m.idNames[k]; ok {
+		return s
+	}
+	// guard against cycles of parents
+	m.idNames[k] = ""
+
+	n := m.nodes[k]
+	name := m.typeName(n)
+	switch {
+	case n.encodingOf != nil && m.nodes[*n.encodingOf] != nil:
+		name = m.idName(*n.encodingOf) + "_Encoding_" + name
+	case n.parent != nil && n.parent.uri == k.uri && m.nodes[*n.parent] != nil:
+		if p := m.idName(*n.parent); p != "" {
+			name = p + "_" + name
+		}
+	}
+	m.idNames[k] = name
+	return name
+}
+
+// kind is the kind of a data type.
+type kind int
+
+const (
+	kindSimple kind = iota
+	kindEnum
+	kindStructure
+	kindOptionSet
+)
+
+// dataTypeKind returns the kind of a data type and the built-in type of
+// simple types and option sets.
+func (m *model) dataTypeKind(k nodeKey) (kind, ua.TypeID, error) {
+	n, depth := m.nodes[k], 0
+	for ; n != nil && k.uri != nodeset.UANamespace; n = m.nodes[k] {
+		if n.supertype == nil {
+			return 0, 0, errors.Errorf("data type %s has no supertype", n.BrowseName)
+		}
+		if depth++; depth > 32 {
+			return 0, 0, errors.Errorf("data type %s is nested too deeply", n.BrowseName)
+		}
+		k = *n.supertype
+	}
+	if k.uri != nodeset.UANamespace {
+		return 0, 0, errors.Errorf("unknown data type %s of %s", k.id, k.uri)
+	}
+
+	switch i := k.ns0(); {
+	case i == id.Enumeration:
+		return kindEnum, ua.TypeIDInt32, nil
+	case i == id.Structure || i == id.Union:
+		return kindStructure, 0, nil
+	case i >= 1 && i <= 25:
+		return kindSimple, ua.TypeID(i), nil
+	default:
+		if d, ok := ua.LookupDataType(ua.NewNumericNodeID(0, i)).(*ua.SimpleTypeDescription); ok {
+			return kindSimple, ua.TypeID(d.BuiltInType), nil
+		}
+		if _, ok := ua.LookupDataType(ua.NewNumericNodeID(0, i)).(*ua.StructureDescription); ok {
+			return kindStructure, 0, nil
+		}
+		return 0, 0, errors.Errorf("unsupported supertype %s", id.Name(i))
+	}
+}
+
+// typeName returns the Go name of a node which is its symbolic name or
+// its browse name.
+func (m *model) typeName(n *node) string {
+	if n.SymbolicName != "" {
+		return identifier(n.SymbolicName)
+	}
+	return identifier(n.Name())
+}
+
+// packageName returns the name of the package of a namespace URI which
+// is the last element of its path, e.g. "di" for
+// "http://opcfoundation.org/UA/DI/".
+func packageName(uri string) string {
+	s := strings.TrimRight(uri, "/")
+	if i := strings.LastIndexAny(s, "/:"); i >= 0 {
+		s = s[i+1:]
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 || unicode.IsDigit(rune(b.String()[0])) {
+		return "ns" + b.String()
+	}
+	return b.String()
+}
+
+// identifier returns an exported Go identifier for a name. Characters
+// which are not allowed in an identifier are removed.
+func identifier(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			b.WriteRune(r)
+		case r == '_':
+			b.WriteRune(r)
+		default:
+			upper = true
+		}
+	}
+	name := goname.Format(strings.Trim(b.String(), "_"))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "T" + name
+	}
+	return name
+}
@@ -0,0 +1,156 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+// writeCodec writes the Encode and Decode methods of the extension
+// objects which replace the reflection based encoding of ua.Encode and
+// ua.Decode. The file is excluded with the reflectcodec build tag to
+// compare both in benchmarks.
+func writeCodec(objs, enums []Type) {
+	underlying := map[string]string{
+		"AttributeID": "uint32",
+		"StatusCode":  "uint32",
+	}
+	for _, e := range enums {
+		underlying[e.Name] = e.Type
+	}
+
+	var types []codecType
+	for _, o := range objs {
+		t := codecType{Name: o.Name}
+		for _, f := range o.Fields {
+			enc, dec, err := fieldCodec("v."+f.Name, "v."+f.Name, f.Type, underlying)
+			if err != nil {
+				log.Fatalf("%s.%s: %s", o.Name, f.Name, err)
+			}
+			t.Encode = append(t.Encode, enc)
+			t.Decode = append(t.Decode, dec)
+		}
+		types = append(types, t)
+	}
+
+	var b bytes.Buffer
+	if err := tmplCodec.Execute(&b, types); err != nil {
+		log.Fatal(err)
+	}
+	writeBuild(b.Bytes(), path.Join(out, "extobjs_codec_gen.go"), "!reflectcodec")
+}
+
+type codecType struct {
+	Name string
+
+	// Encode and Decode contain the statements which encode and decode
+	// the fields.
+	Encode []string
+	Decode []string
+}
+
+// readers and writers contain the names of the Buffer methods which
+// read and write the values of the built-in Go types.
+var readers = map[string]string{
+	"bool":      "Bool",
+	"uint8":     "Byte",
+	"int8":      "Int8",
+	"int16":     "Int16",
+	"uint16":    "Uint16",
+	"int32":     "Int32",
+	"uint32":    "Uint32",
+	"int64":     "Int64",
+	"uint64":    "Uint64",
+	"float32":   "Float32",
+	"float64":   "Float64",
+	"string":    "String",
+	"time.Time": "Time",
+}
+
+var writers = map[string]string{
+	"bool":      "Bool",
+	"uint8":     "Uint8",
+	"int8":      "Int8",
+	"int16":     "Int16",
+	"uint16":    "Uint16",
+	"int32":     "Int32",
+	"uint32":    "Uint32",
+	"int64":     "Int64",
+	"uint64":    "Uint64",
+	"float32":   "Float32",
+	"float64":   "Float64",
+	"string":    "String",
+	"time.Time": "Time",
+}
+
+// fieldCodec returns the statements which encode the value of enc and
+// decode the value into dec for a field with the given Go type.
+func fieldCodec(enc, dec, typ string, underlying map[string]string) (string, string, error) {
+	switch {
+	case typ == "[]byte":
+		return fmt.Sprintf("buf.WriteByteString(%s)", enc),
+			fmt.Sprintf("if n := buf.readArrayLength(); n >= 0 {\n%s = buf.ReadN(n)\n}", dec), nil
+
+	case strings.HasPrefix(typ, "[]"):
+		elemEnc, elemDec, err := fieldCodec("e", dec+"[i]", typ[2:], underlying)
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("buf.writeArrayLength(len(%[1]s), %[1]s == nil)\nfor _, e := range %[1]s {\n%[2]s\n}", enc, elemEnc),
+			fmt.Sprintf("if n := buf.readArrayLength(); n >= 0 {\n%[1]s = make(%[2]s, n)\nfor i := range %[1]s {\n%[3]s\n}\n}", dec, typ, elemDec), nil
+
+	case strings.HasPrefix(typ, "*"):
+		return fmt.Sprintf("buf.WriteStruct(%s)", enc),
+			fmt.Sprintf("%[1]s = new(%[2]s)\nbuf.ReadStruct(%[1]s)", dec, typ[1:]), nil
+
+	case readers[typ] != "":
+		return fmt.Sprintf("buf.Write%s(%s)", writers[typ], enc),
+			fmt.Sprintf("%s = buf.Read%s()", dec, readers[typ]), nil
+
+	case underlying[typ] != "":
+		u := underlying[typ]
+		return fmt.Sprintf("buf.Write%s(%s(%s))", writers[u], u, enc),
+			fmt.Sprintf("%s = %s(buf.Read%s())", dec, typ, readers[u]), nil
+
+	default:
+		return "", "", errors.Errorf("unsupported type %s", typ)
+	}
+}
+
+var tmplCodec = template.Must(template.New("").Parse(`
+{{range .}}
+// Encode encodes the structure without reflection.
+func (v *{{.Name}}) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	{{- range .Encode}}
+	{{.}}
+	{{- end}}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *{{.Name}}) Decode(b []byte) (int, error) {
+	{{- if .Decode}}
+	buf := NewBuffer(b)
+	{{- range .Decode}}
+	{{.}}
+	{{- end}}
+	return buf.Pos(), buf.Error()
+	{{- else}}
+	return 0, nil
+	{{- end}}
+}
+{{end}}
+`))
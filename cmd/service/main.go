@@ -12,6 +12,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/imatic-tech/opcua/bsd"
 	"github.com/imatic-tech/opcua/cmd/service/goname"
 	"github.com/imatic-tech/opcua/errors"
 )
@@ -26,7 +27,7 @@ func main() {
 	flag.StringVar(&pkg, "pkg", "ua", "Go package name")
 	flag.Parse()
 
-	dict, err := ReadTypes(in)
+	dict, err := bsd.ReadFile(in)
 	if err != nil {
 		log.Fatalf("Failed to read type definitions: %s", err)
 	}
@@ -35,6 +36,7 @@ func main() {
 	writeServiceRegister(ExtObjects(dict))
 	writeExtObjects(ExtObjects(dict))
 	writeRegisterExtObjects(ExtObjects(dict))
+	writeCodec(ExtObjects(dict), Enums(dict))
 }
 
 func writeEnums(enums []Type) {
@@ -73,8 +75,13 @@ func writeServiceRegister(objs []Type) {
 }
 
 func write(src []byte, filename string) {
+	writeBuild(src, filename, "")
+}
+
+// writeBuild writes a file with a build constraint.
+func writeBuild(src []byte, filename, build string) {
 	var b bytes.Buffer
-	if err := tmplHeader.Execute(&b, pkg); err != nil {
+	if err := tmplHeader.Execute(&b, map[string]string{"Pkg": pkg, "Build": build}); err != nil {
 		log.Fatalf("Failed to generate header: %s", err)
 	}
 
@@ -96,16 +103,19 @@ var tmplHeader = template.Must(template.New("").Parse(`
 // Copyright 2018-2020 opcua authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
-
+{{if .Build}}
+//go:build {{.Build}}
+// +build {{.Build}}
+{{end}}
 // Code generated by cmd/service. DO NOT EDIT!
 
-package {{.}}
+package {{.Pkg}}
 
 import "time"
 
 `))
 
-func Enums(dict *TypeDictionary) []Type {
+func Enums(dict *bsd.TypeDictionary) []Type {
 	var enums []Type
 	for _, t := range dict.Enums {
 		e := Type{
@@ -137,7 +147,7 @@ func Enums(dict *TypeDictionary) []Type {
 	return enums
 }
 
-func ExtObjects(dict *TypeDictionary) []Type {
+func ExtObjects(dict *bsd.TypeDictionary) []Type {
 	baseTypes := map[string]*Type{
 		// Extensionobject is the base class for all extension objects.
 		"ua:ExtensionObject": &Type{Name: "ExtensionObject"},
@@ -148,6 +158,11 @@ func ExtObjects(dict *TypeDictionary) []Type {
 		"tns:DataTypeDefinition": &Type{Name: "DataTypeDefinition"},
 	}
 
+	enums := map[string]bool{}
+	for _, e := range dict.Enums {
+		enums["tns:"+e.Name] = true
+	}
+
 	var objects []Type
 	for _, t := range dict.Types {
 		// check if the base type is derived from ExtensionObject
@@ -171,7 +186,7 @@ func ExtObjects(dict *TypeDictionary) []Type {
 
 			of := Field{
 				Name: goname.Format(f.Name),
-				Type: goFieldType(f),
+				Type: goFieldType(f, enums[f.Type]),
 			}
 			if of.Name == "AttributeID" {
 				of.Type = "AttributeID"
@@ -281,6 +296,7 @@ import (
 func init() {
 	{{- range $i, $v := . -}}
 		RegisterExtensionObject(NewNumericNodeID(0, id.{{$v.Name}}_Encoding_DefaultBinary), new({{$v.Name}}))
+		RegisterExtensionObjectXML(NewNumericNodeID(0, id.{{$v.Name}}_Encoding_DefaultXML), new({{$v.Name}}))
 	{{end -}}
 }
 `))
@@ -364,13 +380,13 @@ var builtins = map[string]string{
 	"opc:Guid":       "*GUID",
 }
 
-func goFieldType(f *StructField) string {
+func goFieldType(f *bsd.StructField, isEnum bool) string {
 	t, builtin := builtins[f.Type]
 	if t == "" {
 		prefix := strings.NewReplacer("ua:", "", "tns:", "")
 		t = goname.Format(prefix.Replace(f.Type))
 	}
-	if !f.IsEnum && !builtin {
+	if !isEnum && !builtin {
 		t = "*" + t
 	}
 	if f.IsSlice() {
@@ -56,6 +56,21 @@ type Config struct {
 	dialer  *uacp.Dialer
 	sechan  *uasc.Config
 	session *uasc.SessionConfig
+
+	// loadDataTypes enables loading unknown data types in Read.
+	loadDataTypes bool
+
+	// noAutoBatch disables splitting requests according to the
+	// operation limits of the server.
+	noAutoBatch bool
+
+	// batchParallelism is the maximum number of batches of a request
+	// which are sent concurrently. Zero means one.
+	batchParallelism int
+
+	// nodeCacheTTL is the time after which the cached attributes and
+	// references of nodes expire. Zero disables the cache.
+	nodeCacheTTL time.Duration
 }
 
 // NewDialer creates a uacp.Dialer from the config options
@@ -110,6 +125,48 @@ func ReconnectInterval(d time.Duration) Option {
 	}
 }
 
+// LoadDataTypes enables loading the definitions of unknown data types
+// from the server when Read returns extension objects which the client
+// cannot decode. The values are decoded into *ua.DynamicStructure values.
+// See Client.DecodeExtensionObject.
+func LoadDataTypes(b bool) Option {
+	return func(cfg *Config) {
+		cfg.loadDataTypes = b
+	}
+}
+
+// AutoBatch enables reading the operation limits of the server after
+// connecting and splitting Read, Write, Browse, CallMethods,
+// RegisterNodes, UnregisterNodes, TranslateBrowsePaths, the history
+// updates and Subscription.Monitor requests into batches which do not
+// exceed them. The results of a batch which fails have the status code
+// of the error unless all batches fail. Register and unregister requests
+// fail with the first failed batch. The default is true.
+func AutoBatch(b bool) Option {
+	return func(cfg *Config) {
+		cfg.noAutoBatch = !b
+	}
+}
+
+// BatchParallelism sets the maximum number of batches of a request which
+// are sent concurrently. The default is 1.
+func BatchParallelism(n int) Option {
+	return func(cfg *Config) {
+		cfg.batchParallelism = n
+	}
+}
+
+// NodeCache enables caching the static attributes, e.g. BrowseName,
+// DisplayName, NodeClass and DataType, and the references of nodes which
+// are read through Node for the given duration. The cache is invalidated
+// when the session is recreated or the namespace array of the server
+// changes. See Client.WatchModelChanges and Client.InvalidateNodeCache.
+func NodeCache(ttl time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.nodeCacheTTL = ttl
+	}
+}
+
 // Lifetime sets the lifetime of the secure channel in milliseconds.
 func Lifetime(d time.Duration) Option {
 	return func(cfg *Config) {
@@ -168,6 +225,15 @@ func RemoteCertificateFile(filename string) Option {
 	}
 }
 
+// CertificateValidator sets the validator for the server certificate.
+// The secure channel is not opened if the validation fails. Use a
+// uapki.Store to validate the certificate with a file based trust list.
+func CertificateValidator(v uasc.CertificateValidator) Option {
+	return func(cfg *Config) {
+		cfg.sechan.CertificateValidator = v
+	}
+}
+
 // SecurityMode sets the security mode for the secure channel.
 func SecurityMode(m ua.MessageSecurityMode) Option {
 	return func(cfg *Config) {
@@ -317,6 +383,9 @@ func SecurityFromEndpoint(ep *ua.EndpointDescription, authType ua.UserTokenType)
 		cfg.sechan.SecurityMode = ep.SecurityMode
 		cfg.sechan.RemoteCertificate = ep.ServerCertificate
 		cfg.sechan.Thumbprint = uapolicy.Thumbprint(ep.ServerCertificate)
+		if ep.Server != nil {
+			cfg.sechan.RemoteApplicationURI = ep.Server.ApplicationURI
+		}
 
 		for _, t := range ep.UserIdentityTokens {
 			if t.TokenType != authType {
@@ -520,6 +589,8 @@ func initDialer(cfg *Config) {
 		cfg.dialer.Dialer = &net.Dialer{}
 	}
 	if cfg.dialer.ClientACK == nil {
-		cfg.dialer.ClientACK = uacp.DefaultClientACK
+		// copy the defaults since the options modify the ACK
+		ack := *uacp.DefaultClientACK
+		cfg.dialer.ClientACK = &ack
 	}
 }
@@ -283,6 +283,27 @@ func TestOptions(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			name: `AutoBatch(false)`,
+			opt:  AutoBatch(false),
+			cfg: &Config{
+				noAutoBatch: true,
+			},
+		},
+		{
+			name: `BatchParallelism(4)`,
+			opt:  BatchParallelism(4),
+			cfg: &Config{
+				batchParallelism: 4,
+			},
+		},
+		{
+			name: `NodeCache(1m)`,
+			opt:  NodeCache(time.Minute),
+			cfg: &Config{
+				nodeCacheTTL: time.Minute,
+			},
+		},
 		{
 			name: `Lifetime(10ms)`,
 			opt:  Lifetime(10 * time.Millisecond),
@@ -702,9 +723,10 @@ func TestOptions(t *testing.T) {
 			opt:  MaxMessageSize(5),
 			cfg: &Config{
 				dialer: func() *uacp.Dialer {
+					ack := *uacp.DefaultClientACK
 					d := &uacp.Dialer{
 						Dialer:    &net.Dialer{},
-						ClientACK: uacp.DefaultClientACK,
+						ClientACK: &ack,
 					}
 					d.ClientACK.MaxMessageSize = 5
 					return d
@@ -716,9 +738,10 @@ func TestOptions(t *testing.T) {
 			opt:  MaxChunkCount(5),
 			cfg: &Config{
 				dialer: func() *uacp.Dialer {
+					ack := *uacp.DefaultClientACK
 					d := &uacp.Dialer{
 						Dialer:    &net.Dialer{},
-						ClientACK: uacp.DefaultClientACK,
+						ClientACK: &ack,
 					}
 					d.ClientACK.MaxChunkCount = 5
 					return d
@@ -730,9 +753,10 @@ func TestOptions(t *testing.T) {
 			opt:  ReceiveBufferSize(5),
 			cfg: &Config{
 				dialer: func() *uacp.Dialer {
+					ack := *uacp.DefaultClientACK
 					d := &uacp.Dialer{
 						Dialer:    &net.Dialer{},
-						ClientACK: uacp.DefaultClientACK,
+						ClientACK: &ack,
 					}
 					d.ClientACK.ReceiveBufSize = 5
 					return d
@@ -744,9 +768,10 @@ func TestOptions(t *testing.T) {
 			opt:  SendBufferSize(5),
 			cfg: &Config{
 				dialer: func() *uacp.Dialer {
+					ack := *uacp.DefaultClientACK
 					d := &uacp.Dialer{
 						Dialer:    &net.Dialer{},
-						ClientACK: uacp.DefaultClientACK,
+						ClientACK: &ack,
 					}
 					d.ClientACK.SendBufSize = 5
 					return d
@@ -45,122 +45,105 @@ func join(a, b string) string {
 	return a + "." + b
 }
 
-func browse(ctx context.Context, n *opcua.Node, path string, level int) ([]NodeDef, error) {
-	// fmt.Printf("node:%s path:%q level:%d\n", n, path, level)
-	if level > 10 {
-		return nil, nil
-	}
+func browse(ctx context.Context, c *opcua.Client, start *ua.NodeID) ([]NodeDef, error) {
+	paths := map[string]string{start.String(): ""}
 
-	attrs, err := n.AttributesWithContext(ctx, ua.AttributeIDNodeClass, ua.AttributeIDBrowseName, ua.AttributeIDDescription, ua.AttributeIDAccessLevel, ua.AttributeIDDataType)
+	var nodes []NodeDef
+	err := c.Walk(ctx, start, opcua.WalkOptions{
+		ReferenceTypes: []*ua.NodeID{
+			ua.NewNumericNodeID(0, id.HasComponent),
+			ua.NewNumericNodeID(0, id.Organizes),
+			ua.NewNumericNodeID(0, id.HasProperty),
+		},
+		MaxDepth:    10,
+		Concurrency: 4,
+	}, func(parent *ua.NodeID, ref *ua.ReferenceDescription, depth int) error {
+		path := join(paths[parent.String()], ref.BrowseName.Name)
+		paths[ref.NodeID.String()] = path
+		if ref.NodeClass == ua.NodeClassVariable {
+			nodes = append(nodes, NodeDef{
+				NodeID:     ref.NodeID.NodeID,
+				NodeClass:  ref.NodeClass,
+				BrowseName: ref.BrowseName.Name,
+				Path:       path,
+			})
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, errors.Errorf("walk: %s", err)
 	}
 
-	var def = NodeDef{
-		NodeID: n.ID,
-	}
-
-	switch err := attrs[0].Status; err {
-	case ua.StatusOK:
-		def.NodeClass = ua.NodeClass(attrs[0].Value.Int())
-	default:
-		return nil, err
-	}
-
-	switch err := attrs[1].Status; err {
-	case ua.StatusOK:
-		def.BrowseName = attrs[1].Value.String()
-	default:
-		return nil, err
+	attrIDs := []ua.AttributeID{ua.AttributeIDDescription, ua.AttributeIDAccessLevel, ua.AttributeIDDataType}
+	req := &ua.ReadRequest{}
+	for _, n := range nodes {
+		for _, a := range attrIDs {
+			req.NodesToRead = append(req.NodesToRead, &ua.ReadValueID{NodeID: n.NodeID, AttributeID: a})
+		}
 	}
-
-	switch err := attrs[2].Status; err {
-	case ua.StatusOK:
-		def.Description = attrs[2].Value.String()
-	case ua.StatusBadAttributeIDInvalid:
-		// ignore
-	default:
+	res, err := c.ReadWithContext(ctx, req)
+	if err != nil {
 		return nil, err
 	}
 
-	switch err := attrs[3].Status; err {
-	case ua.StatusOK:
-		def.AccessLevel = ua.AccessLevelType(attrs[3].Value.Int())
-		def.Writable = def.AccessLevel&ua.AccessLevelTypeCurrentWrite == ua.AccessLevelTypeCurrentWrite
-	case ua.StatusBadAttributeIDInvalid:
-		// ignore
-	default:
-		return nil, err
-	}
+	for i := range nodes {
+		def := &nodes[i]
+		attrs := res.Results[i*len(attrIDs):]
 
-	switch err := attrs[4].Status; err {
-	case ua.StatusOK:
-		switch v := attrs[4].Value.NodeID().IntID(); v {
-		case id.DateTime:
-			def.DataType = "time.Time"
-		case id.Boolean:
-			def.DataType = "bool"
-		case id.SByte:
-			def.DataType = "int8"
-		case id.Int16:
-			def.DataType = "int16"
-		case id.Int32:
-			def.DataType = "int32"
-		case id.Byte:
-			def.DataType = "byte"
-		case id.UInt16:
-			def.DataType = "uint16"
-		case id.UInt32:
-			def.DataType = "uint32"
-		case id.UtcTime:
-			def.DataType = "time.Time"
-		case id.String:
-			def.DataType = "string"
-		case id.Float:
-			def.DataType = "float32"
-		case id.Double:
-			def.DataType = "float64"
+		switch err := attrs[0].Status; err {
+		case ua.StatusOK:
+			def.Description = attrs[0].Value.String()
+		case ua.StatusBadAttributeIDInvalid:
+			// ignore
 		default:
-			def.DataType = attrs[4].Value.NodeID().String()
+			return nil, err
 		}
-	case ua.StatusBadAttributeIDInvalid:
-		// ignore
-	default:
-		return nil, err
-	}
-
-	def.Path = join(path, def.BrowseName)
-	// fmt.Printf("%d: def.Path:%s def.NodeClass:%s\n", level, def.Path, def.NodeClass)
-
-	var nodes []NodeDef
-	if def.NodeClass == ua.NodeClassVariable {
-		nodes = append(nodes, def)
-	}
 
-	browseChildren := func(refType uint32) error {
-		refs, err := n.ReferencedNodesWithContext(ctx, refType, ua.BrowseDirectionForward, ua.NodeClassAll, true)
-		if err != nil {
-			return errors.Errorf("References: %d: %s", refType, err)
+		switch err := attrs[1].Status; err {
+		case ua.StatusOK:
+			def.AccessLevel = ua.AccessLevelType(attrs[1].Value.Int())
+			def.Writable = def.AccessLevel&ua.AccessLevelTypeCurrentWrite == ua.AccessLevelTypeCurrentWrite
+		case ua.StatusBadAttributeIDInvalid:
+			// ignore
+		default:
+			return nil, err
 		}
-		// fmt.Printf("found %d child refs\n", len(refs))
-		for _, rn := range refs {
-			children, err := browse(ctx, rn, def.Path, level+1)
-			if err != nil {
-				return errors.Errorf("browse children: %s", err)
+
+		switch err := attrs[2].Status; err {
+		case ua.StatusOK:
+			switch v := attrs[2].Value.NodeID().IntID(); v {
+			case id.DateTime:
+				def.DataType = "time.Time"
+			case id.Boolean:
+				def.DataType = "bool"
+			case id.SByte:
+				def.DataType = "int8"
+			case id.Int16:
+				def.DataType = "int16"
+			case id.Int32:
+				def.DataType = "int32"
+			case id.Byte:
+				def.DataType = "byte"
+			case id.UInt16:
+				def.DataType = "uint16"
+			case id.UInt32:
+				def.DataType = "uint32"
+			case id.UtcTime:
+				def.DataType = "time.Time"
+			case id.String:
+				def.DataType = "string"
+			case id.Float:
+				def.DataType = "float32"
+			case id.Double:
+				def.DataType = "float64"
+			default:
+				def.DataType = attrs[2].Value.NodeID().String()
 			}
-			nodes = append(nodes, children...)
+		case ua.StatusBadAttributeIDInvalid:
+			// ignore
+		default:
+			return nil, err
 		}
-		return nil
-	}
-
-	if err := browseChildren(id.HasComponent); err != nil {
-		return nil, err
-	}
-	if err := browseChildren(id.Organizes); err != nil {
-		return nil, err
-	}
-	if err := browseChildren(id.HasProperty); err != nil {
-		return nil, err
 	}
 	return nodes, nil
 }
@@ -185,7 +168,7 @@ func main() {
 		log.Fatalf("invalid node id: %s", err)
 	}
 
-	nodeList, err := browse(ctx, c.Node(id), "", 0)
+	nodeList, err := browse(ctx, c, id)
 	if err != nil {
 		log.Fatal(err)
 	}
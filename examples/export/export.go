@@ -0,0 +1,62 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Example export writes the address space of a server as NodeSet2 XML,
+// e.g. to compare it with a previous export or to load it into a
+// simulation server with opcua.ServerNodeSetFile.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/imatic-tech/opcua"
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+func main() {
+	var (
+		endpoint = flag.String("endpoint", "opc.tcp://localhost:4840", "OPC UA Endpoint URL")
+		nodeID   = flag.String("node", "", "NodeID of the start node. Default: the root folder")
+		out      = flag.String("out", "", "Path to the NodeSet2 XML file. Default: stdout")
+	)
+	flag.BoolVar(&debug.Enable, "debug", false, "enable debug logging")
+	flag.Parse()
+	log.SetFlags(0)
+
+	ctx := context.Background()
+
+	c := opcua.NewClient(*endpoint, opcua.SecurityMode(ua.MessageSecurityModeNone))
+	if err := c.Connect(ctx); err != nil {
+		log.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	var start []*ua.NodeID
+	if *nodeID != "" {
+		id, err := ua.ParseNodeID(*nodeID)
+		if err != nil {
+			log.Fatalf("invalid node id: %v", err)
+		}
+		start = append(start, id)
+	}
+
+	s, err := c.ExportNodeSet(ctx, start...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		if err := s.Encode(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := s.WriteFile(*out); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -35,69 +35,51 @@ func main() {
 		log.Fatalf("invalid node id: %v", err)
 	}
 
-	// HistoryRead with ContinuationPoint use
-	nodesToRequest := []*ua.HistoryReadValueID{
+	nodes := []*ua.HistoryReadValueID{
 		{
 			NodeID:       id,
 			DataEncoding: &ua.QualifiedName{},
 		},
 	}
 
-	for {
-		if len(nodesToRequest) == 0 {
-			break
-		}
-
-		// For ContinuationPoint usage
-		// Get current nodes
-		nodes := nodesToRequest
-		// Reset old nodes
-		nodesToRequest = make([]*ua.HistoryReadValueID, 0)
+	// The iterator follows the continuation points of the server and
+	// releases them when it is closed early.
+	it, err := c.NewHistoryIterator(ctx, nodes, &ua.ReadRawModifiedDetails{
+		IsReadModified: false,
+		StartTime:      time.Now().UTC().AddDate(0, -1, 0),
+		EndTime:        time.Now().UTC().AddDate(0, 1, 0),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer it.Close()
 
-		data, err := c.HistoryReadRawModifiedWithContext(ctx, nodes, &ua.ReadRawModifiedDetails{
-			IsReadModified: false,
-			StartTime:      time.Now().UTC().AddDate(0, -1, 0),
-			EndTime:        time.Now().UTC().AddDate(0, 1, 0),
-		})
-		if err != nil {
-			log.Printf("HistoryReadRequest error: %s", err)
-			break
+	for it.Next() {
+		result := it.Result()
+		if result.StatusCode != ua.StatusOK {
+			log.Printf("result.StatusCode not StatusOK: %d", result.StatusCode)
+			continue
 		}
 
-		if data == nil || len(data.Results) == 0 {
-			break
+		if result.HistoryData == nil || result.HistoryData.Value == nil {
+			continue
 		}
 
-		for nodeNum, result := range data.Results {
-			if result.StatusCode != ua.StatusOK {
-				log.Printf("result.StatusCode not StatusOK: %d", result.StatusCode)
-				continue
-			}
-
-			// If this is not the end of the data - continue requesting
-			if len(result.ContinuationPoint) > 0 {
-				nodeNew := nodes[nodeNum]
-				nodeNew.ContinuationPoint = result.ContinuationPoint
-				nodesToRequest = append(nodesToRequest, nodeNew)
-			}
-
-			if result.HistoryData == nil || result.HistoryData.Value == nil {
-				continue
-			}
-
-			historyData, ok := result.HistoryData.Value.(*ua.HistoryData)
-			if !ok || historyData == nil || len(historyData.DataValues) == 0 {
-				continue
-			}
+		historyData, ok := result.HistoryData.Value.(*ua.HistoryData)
+		if !ok || historyData == nil {
+			continue
+		}
 
-			for _, value := range historyData.DataValues {
-				log.Printf(
-					"%s - %s - %v \n",
-					nodes[nodeNum].NodeID.String(),
-					value.SourceTimestamp.Format(time.RFC3339),
-					value.Value.Value(),
-				)
-			}
+		for _, value := range historyData.DataValues {
+			log.Printf(
+				"%s - %s - %v \n",
+				nodes[it.Index()].NodeID.String(),
+				value.SourceTimestamp.Format(time.RFC3339),
+				value.Value.Value(),
+			)
 		}
 	}
+	if err := it.Err(); err != nil {
+		log.Printf("HistoryReadRequest error: %s", err)
+	}
 }
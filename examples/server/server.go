@@ -8,94 +8,51 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
+	"os/signal"
 
-	"github.com/imatic-tech/opcua/uacp"
+	"github.com/imatic-tech/opcua"
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/ua"
 )
 
 func main() {
 	var (
-		endpoint = flag.String("endpoint", "opc.tcp://example.com/foo/bar", "OPC UA Endpoint URL")
+		endpoint = flag.String("endpoint", "opc.tcp://localhost:4840", "OPC UA Endpoint URL")
+		policy   = flag.String("policy", "", "Additional security policy: None, Basic128Rsa15, Basic256, Basic256Sha256")
+		mode     = flag.String("mode", "SignAndEncrypt", "Security mode of the additional security policy: Sign, SignAndEncrypt")
+		certFile = flag.String("cert", "", "Path to cert.pem. Required for security mode/policy != None")
+		keyFile  = flag.String("key", "", "Path to private key.pem. Required for security mode/policy != None")
 	)
+	flag.BoolVar(&debug.Enable, "debug", false, "enable debug logging")
 	flag.Parse()
+	log.SetFlags(0)
 
-	ctx := context.Background()
-
-	log.Printf("Listening on %s", *endpoint)
-	l, err := uacp.Listen(*endpoint, nil)
-	if err != nil {
-		log.Fatal(err)
+	opts := []opcua.ServerOption{
+		opcua.ServerCertificateFile(*certFile),
+		opcua.ServerPrivateKeyFile(*keyFile),
+		opcua.EnableSecurity("None", ua.MessageSecurityModeNone),
 	}
-	c, err := l.Accept(ctx)
-	if err != nil {
-		log.Fatal(err)
+	if *policy != "" && *policy != "None" {
+		opts = append(opts, opcua.EnableSecurity(*policy, ua.MessageSecurityModeFromString(*mode)))
 	}
-	defer c.Close()
-	log.Printf("conn %d: connection from %s", c.ID(), c.RemoteAddr())
-
-	// listener, err := uacp.Listen(*endpoint, uint32(*bufsize))
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// log.Printf("Started listening on %s.", listener.Endpoint())
-
-	// cfg := uasc.NewServerConfig(
-	// 	"http://opcfoundation.org/UA/SecurityPolicy#None",
-	// 	nil, nil, 1111, services.SecModeNone, 2222, 3600000,
-	// )
-	// for {
-	// 	func() {
-	// 		ctx := context.Background()
-	// 		ctx, cancel := context.WithCancel(ctx)
-	// 		defer cancel()
 
-	// 		conn, err := listener.Accept(ctx)
-	// 		if err != nil {
-	// 			log.Print(err)
-	// 			return
-	// 		}
-	// 		defer func() {
-	// 			conn.Close()
-	// 			log.Println("Successfully closed connection")
-	// 		}()
-	// 		log.Printf("Successfully established connection with %v", conn.RemoteAddr())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	// 		secChan, err := uasc.ListenAndAcceptSecureChannel(ctx, conn, cfg)
-	// 		if err != nil {
-	// 			log.Fatal(err)
-	// 		}
-	// 		defer func() {
-	// 			secChan.Close()
-	// 			log.Printf("Successfully closed secure channel with %v", conn.RemoteAddr())
-	// 		}()
-	// 		log.Printf("Successfully opened secure channel with %v", conn.RemoteAddr())
-
-	// 		sessCfg := uasc.NewServerSessionConfig(secChan)
-	// 		session, err := uasc.ListenAndAcceptSession(ctx, secChan, sessCfg)
-	// 		if err != nil {
-	// 			log.Fatal(err)
-	// 		}
-	// 		defer func() {
-	// 			session.Close()
-	// 			log.Printf("Successfully closed session with %v", conn.RemoteAddr())
-	// 		}()
-	// 		log.Printf("Successfully activated session with %v", conn.RemoteAddr())
+	s := opcua.NewServer(*endpoint, opts...)
+	if err := s.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+	defer s.Close()
 
-	// 		buf := make([]byte, 1024)
-	// 		for {
-	// 			n, err := session.ReadService(buf)
-	// 			if err != nil {
-	// 				log.Printf("Couldn't read UASC: %s", err)
-	// 				continue
-	// 			}
-	// 			log.Printf("Successfully received message: %x\n%s", buf[:n], utils.Wireshark(0, buf[:n]))
+	log.Printf("Listening on %s", s.Endpoint())
+	for _, ep := range s.Endpoints() {
+		log.Printf("Endpoint %s %s", ep.SecurityPolicyURI, ep.SecurityMode)
+	}
 
-	// 			srv, err := services.Decode(buf[:n])
-	// 			if err != nil {
-	// 				log.Printf("Couldn't decode received bytes as Service: %s", err)
-	// 				continue
-	// 			}
-	// 			log.Printf("Successfully decoded as Service: %v", srv)
-	// 		}
-	// 	}()
-	// }
+	sigch := make(chan os.Signal, 1)
+	signal.Notify(sigch, os.Interrupt)
+	<-sigch
+	log.Print("Shutting down")
 }
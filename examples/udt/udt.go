@@ -23,6 +23,8 @@ func main() {
 		certFile = flag.String("cert", "", "Path to cert.pem. Required for security mode/policy != None")
 		keyFile  = flag.String("key", "", "Path to private key.pem. Required for security mode/policy != None")
 		nodeID   = flag.String("node", "", "NodeID to read")
+		dynamic  = flag.Bool("dynamic", false, "Decode unknown types with the DataTypeDefinition of the server")
+		dicts    = flag.Bool("dictionaries", false, "Decode unknown types with the type dictionaries of the server")
 	)
 	flag.BoolVar(&debug.Enable, "debug", false, "enable debug logging")
 	flag.Parse()
@@ -53,6 +55,7 @@ func main() {
 		opcua.PrivateKeyFile(*keyFile),
 		opcua.AuthAnonymous(),
 		opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+		opcua.LoadDataTypes(*dynamic),
 	}
 
 	c := opcua.NewClient(ep.EndpointURL, opts...)
@@ -61,6 +64,12 @@ func main() {
 	}
 	defer c.CloseWithContext(ctx)
 
+	if *dicts {
+		if err := c.LoadDataTypeDictionaries(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	v, err := c.Node(id).ValueWithContext(ctx)
 	switch {
 	case err != nil:
@@ -76,7 +85,16 @@ func main() {
 // It must be registered with ua.RegisterExtensionObject before using it.
 //
 // Encoding and decoding is handled with reflection. Therefore, defining and
-// registering the custom type is sufficient for most cases.
+// registering the custom type is sufficient for most cases. The types and
+// their registration can be generated from the data types of the server with
+// cmd/datatypes.
+//
+// Types which are not registered can be decoded into a *ua.DynamicStructure
+// with the DataTypeDefinition of the server by enabling the
+// opcua.LoadDataTypes option, e.g. with the -dynamic flag. Servers which do
+// not provide the DataTypeDefinition attribute describe their types with type
+// dictionaries which are loaded with Client.LoadDataTypeDictionaries, e.g.
+// with the -dictionaries flag.
 //
 // If encoding and decoding requires custom logic like handling flags then you
 // can add custom Encode and Decode methods. See node_id.go for good examples.
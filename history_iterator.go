@@ -0,0 +1,234 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"sync"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/stats"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// HistoryIterator pages through the history of one or more nodes. Each
+// call to Next sends at most one history read request for the nodes
+// which still have a continuation point and returns the result of a
+// single node. Only one page per node is held in memory.
+//
+//	it, err := c.NewHistoryIterator(ctx, nodes, details)
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//	for it.Next() {
+//		r := it.Result()
+//		...
+//	}
+//	return it.Err()
+//
+// The continuation points which the server still holds are released
+// when the iterator is closed, ctx is cancelled or a request fails.
+type HistoryIterator struct {
+	c       *Client
+	ctx     context.Context
+	details *ua.ExtensionObject
+
+	// nodes contains copies of the nodes with the continuation
+	// points of the last response.
+	nodes []*ua.HistoryReadValueID
+
+	// pending contains the indexes of the nodes for the next request.
+	pending []int
+
+	// header is the response header of the last request.
+	header *ua.ResponseHeader
+
+	mu      sync.Mutex
+	index   []int
+	results []*ua.HistoryReadResult
+	pos     int
+	closed  bool
+	done    chan struct{}
+	err     error
+}
+
+// NewHistoryIterator returns an iterator over the history of the nodes
+// which reads the history with the details. details must be one of
+// *ua.ReadRawModifiedDetails, *ua.ReadProcessedDetails,
+// *ua.ReadAtTimeDetails or *ua.ReadEventDetails. The first request is
+// sent by the first call to Next.
+//
+// The iterator must be closed when the caller stops reading before Next
+// has returned false.
+//
+// Specification: Part 11, 6.4
+func (c *Client) NewHistoryIterator(ctx context.Context, nodes []*ua.HistoryReadValueID, details interface{}) (*HistoryIterator, error) {
+	switch details.(type) {
+	case *ua.ReadRawModifiedDetails, *ua.ReadProcessedDetails, *ua.ReadAtTimeDetails, *ua.ReadEventDetails:
+	default:
+		return nil, errors.Errorf("invalid history read details %T", details)
+	}
+
+	stats.Client().Add("HistoryIterator", 1)
+
+	it := &HistoryIterator{
+		c:       c,
+		ctx:     ctx,
+		details: ua.NewExtensionObject(details),
+		nodes:   make([]*ua.HistoryReadValueID, len(nodes)),
+		pending: make([]int, len(nodes)),
+		pos:     -1,
+		done:    make(chan struct{}),
+	}
+	// the nodes are copied since their continuation points are updated.
+	for i, n := range nodes {
+		nc := *n
+		it.nodes[i] = &nc
+		it.pending[i] = i
+	}
+
+	if ctx.Done() != nil {
+		go it.awaitDone()
+	}
+	return it, nil
+}
+
+// awaitDone closes the iterator when ctx is cancelled before the
+// iterator is done.
+func (it *HistoryIterator) awaitDone() {
+	select {
+	case <-it.ctx.Done():
+		it.mu.Lock()
+		it.close(it.ctx.Err())
+		it.mu.Unlock()
+	case <-it.done:
+	}
+}
+
+// Next advances the iterator to the result of the next node and sends a
+// history read request when all results of the last request have been
+// returned. It returns false when there are no more results or an error
+// occurred.
+func (it *HistoryIterator) Next() bool {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.closed {
+		return false
+	}
+
+	it.pos++
+	for it.pos >= len(it.results) {
+		if len(it.pending) == 0 {
+			it.close(nil)
+			return false
+		}
+		if err := it.read(); err != nil {
+			it.close(err)
+			return false
+		}
+	}
+	return true
+}
+
+// read sends a history read request for the pending nodes.
+func (it *HistoryIterator) read() error {
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+
+	req := &ua.HistoryReadRequest{
+		HistoryReadDetails: it.details,
+		TimestampsToReturn: ua.TimestampsToReturnBoth,
+	}
+	for _, i := range it.pending {
+		req.NodesToRead = append(req.NodesToRead, it.nodes[i])
+	}
+
+	var res *ua.HistoryReadResponse
+	err := it.c.SendWithContext(it.ctx, req, func(v interface{}) error {
+		return safeAssign(v, &res)
+	})
+	if err != nil {
+		return err
+	}
+	if len(res.Results) != len(it.pending) {
+		return ua.StatusBadUnknownResponse
+	}
+
+	var next []int
+	for k, i := range it.pending {
+		r := res.Results[k]
+		it.nodes[i].ContinuationPoint = r.ContinuationPoint
+		if len(r.ContinuationPoint) > 0 {
+			next = append(next, i)
+		}
+		r.ContinuationPoint = nil
+	}
+
+	it.index, it.results, it.pos = it.pending, res.Results, 0
+	it.pending = next
+	it.header = res.ResponseHeader
+	return nil
+}
+
+// Index returns the index of the node of the current result in the
+// nodes of NewHistoryIterator.
+func (it *HistoryIterator) Index() int {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.pos < 0 || it.pos >= len(it.index) {
+		return -1
+	}
+	return it.index[it.pos]
+}
+
+// Result returns the current result. The HistoryData contains the values
+// or events of one page and the continuation point is always nil.
+func (it *HistoryIterator) Result() *ua.HistoryReadResult {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.pos < 0 || it.pos >= len(it.results) {
+		return nil
+	}
+	return it.results[it.pos]
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *HistoryIterator) Err() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.err
+}
+
+// Close releases the continuation points which the server still holds.
+// Close can be called multiple times.
+func (it *HistoryIterator) Close() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.close(nil)
+}
+
+// close stops the iteration with the error and releases the continuation
+// points. The caller must hold it.mu.
+func (it *HistoryIterator) close(err error) error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	it.err = err
+	it.index, it.results = nil, nil
+	close(it.done)
+
+	var nodes []*ua.HistoryReadValueID
+	for _, i := range it.pending {
+		nodes = append(nodes, it.nodes[i])
+	}
+	it.pending = nil
+	return it.c.releaseHistoryContinuationPoints(it.details, nodes)
+}
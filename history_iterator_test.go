@@ -0,0 +1,97 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestHistoryIterator(t *testing.T) {
+	h := &testHistorian{}
+	srv := startTestServer(t)
+	srv.Handle(id.HistoryReadRequest_Encoding_DefaultBinary, h.handle)
+	c := connectTestClient(t, srv)
+
+	nodes := []*ua.HistoryReadValueID{
+		{NodeID: ua.NewNumericNodeID(1, 1), DataEncoding: &ua.QualifiedName{}},
+		{NodeID: ua.NewNumericNodeID(1, 2), DataEncoding: &ua.QualifiedName{}},
+	}
+	details := &ua.ReadRawModifiedDetails{
+		StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		*h = testHistorian{}
+		it, err := c.NewHistoryIterator(context.Background(), nodes, details)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer it.Close()
+		verify.Values(t, "requests before next", h.requests, 0)
+
+		var index []int
+		var values []interface{}
+		for it.Next() {
+			index = append(index, it.Index())
+			r := it.Result()
+			verify.Values(t, "continuation point", r.ContinuationPoint, []byte(nil))
+			for _, dv := range r.HistoryData.Value.(*ua.HistoryData).DataValues {
+				values = append(values, dv.Value.Value())
+			}
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "index", index, []int{0, 1, 0, 1, 0, 1})
+		verify.Values(t, "values", values, []interface{}{10.0, 11.0, 20.0, 21.0, 12.0, 13.0, 22.0, 23.0, 14.0, 24.0})
+		verify.Values(t, "requests", h.requests, 3)
+		verify.Values(t, "released", h.released, [][]byte(nil))
+	})
+
+	t.Run("close", func(t *testing.T) {
+		*h = testHistorian{}
+		it, err := c.NewHistoryIterator(context.Background(), nodes, details)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !it.Next() {
+			t.Fatal(it.Err())
+		}
+		if err := it.Close(); err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "next", it.Next(), false)
+		verify.Values(t, "released", h.released, [][]byte{{2}, {2}})
+		verify.Values(t, "close again", it.Close(), nil)
+		verify.Values(t, "released twice", len(h.released), 2)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		*h = testHistorian{}
+		ctx, cancel := context.WithCancel(context.Background())
+		it, err := c.NewHistoryIterator(ctx, nodes, details)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer it.Close()
+		if !it.Next() {
+			t.Fatal(it.Err())
+		}
+		cancel()
+		verify.Values(t, "next", it.Next() && it.Next(), false)
+		verify.Values(t, "error", it.Err(), context.Canceled)
+		verify.Values(t, "released", h.released, [][]byte{{2}, {2}})
+	})
+
+	t.Run("invalid details", func(t *testing.T) {
+		_, err := c.NewHistoryIterator(context.Background(), nodes, &ua.ReadRequest{})
+		if err == nil {
+			t.Fatal("got nil want error")
+		}
+	})
+}
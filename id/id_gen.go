@@ -6,7 +6,10 @@
 
 package id
 
-import "strconv"
+import (
+	"strconv"
+	"sync"
+)
 
 func Name(id uint32) string {
 	if s, ok := name[id]; ok {
@@ -15,6 +18,29 @@ func Name(id uint32) string {
 	return strconv.FormatUint(uint64(id), 10)
 }
 
+// ID returns the id of the node with the given name, e.g. "Range" or
+// "Range_Encoding_DefaultBinary". It returns 0 if the name is unknown.
+func ID(s string) uint32 {
+	idsOnce.Do(func() {
+		ids = make(map[string]uint32, len(name))
+		for id, s := range name {
+			ids[s] = id
+		}
+	})
+	return ids[s]
+}
+
+var (
+	idsOnce sync.Once
+	ids     map[string]uint32
+)
+
+// IsReferenceType returns true if the id is the id of a reference type
+// in namespace 0, e.g. HasComponent.
+func IsReferenceType(id uint32) bool {
+	return referenceTypes[id]
+}
+
 const (
 	Boolean                                                                                                               = 1
 	SByte                                                                                                                 = 2
@@ -21434,3 +21460,49 @@ var name = map[uint32]string{
 	21202: "PubSubConfigurationDataType_Encoding_DefaultJSON",
 	21203: "DatagramWriterGroupTransportDataType_Encoding_DefaultJSON",
 }
+
+var referenceTypes = map[uint32]bool{
+	31:    true,
+	32:    true,
+	33:    true,
+	34:    true,
+	35:    true,
+	36:    true,
+	37:    true,
+	38:    true,
+	39:    true,
+	40:    true,
+	41:    true,
+	44:    true,
+	45:    true,
+	46:    true,
+	47:    true,
+	48:    true,
+	49:    true,
+	51:    true,
+	52:    true,
+	53:    true,
+	54:    true,
+	56:    true,
+	117:   true,
+	129:   true,
+	131:   true,
+	3065:  true,
+	9004:  true,
+	9005:  true,
+	9006:  true,
+	14476: true,
+	14936: true,
+	15112: true,
+	15296: true,
+	15297: true,
+	16361: true,
+	16362: true,
+	17276: true,
+	17597: true,
+	17603: true,
+	17604: true,
+	17983: true,
+	17984: true,
+	17985: true,
+}
@@ -179,6 +179,59 @@ func (n *Node) ValueWithContext(ctx context.Context) (*ua.Variant, error) {
 	return n.AttributeWithContext(ctx, ua.AttributeIDValue)
 }
 
+// ValueRange returns the elements of the array, matrix or string value of
+// the node which are selected by the range.
+//
+// Specification: Part 4, 7.22
+func (n *Node) ValueRange(ctx context.Context, r ua.NumericRange) (*ua.Variant, error) {
+	req := &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{
+			{NodeID: n.ID, AttributeID: ua.AttributeIDValue, IndexRange: r.String()},
+		},
+	}
+	res, err := n.c.ReadWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Results) != 1 {
+		return nil, ua.StatusBadUnknownResponse
+	}
+	if res.Results[0].Status != ua.StatusOK {
+		return res.Results[0].Value, res.Results[0].Status
+	}
+	return res.Results[0].Value, nil
+}
+
+// WriteValueRange writes the elements of the array, matrix or string value
+// of the node which are selected by the range. v must have the type of the
+// value and exactly the size of the range, e.g. an array with one element
+// to write a single element.
+//
+// Specification: Part 4, 7.22
+func (n *Node) WriteValueRange(ctx context.Context, r ua.NumericRange, v *ua.Variant) error {
+	req := &ua.WriteRequest{
+		NodesToWrite: []*ua.WriteValue{
+			{
+				NodeID:      n.ID,
+				AttributeID: ua.AttributeIDValue,
+				IndexRange:  r.String(),
+				Value:       &ua.DataValue{EncodingMask: ua.DataValueValue, Value: v},
+			},
+		},
+	}
+	res, err := n.c.WriteWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(res.Results) != 1 {
+		return ua.StatusBadUnknownResponse
+	}
+	if res.Results[0] != ua.StatusOK {
+		return res.Results[0]
+	}
+	return nil
+}
+
 // Attribute returns the attribute of the node. with the given id.
 //
 // Note: Starting with v0.5 this method will require a context
@@ -189,20 +242,18 @@ func (n *Node) Attribute(attrID ua.AttributeID) (*ua.Variant, error) {
 
 // Note: Starting with v0.5 this method is superseded by the non 'WithContext' method.
 func (n *Node) AttributeWithContext(ctx context.Context, attrID ua.AttributeID) (*ua.Variant, error) {
-	rv := &ua.ReadValueID{NodeID: n.ID, AttributeID: attrID}
-	req := &ua.ReadRequest{NodesToRead: []*ua.ReadValueID{rv}}
-	res, err := n.c.ReadWithContext(ctx, req)
+	results, err := n.AttributesWithContext(ctx, attrID)
 	if err != nil {
 		return nil, err
 	}
-	if len(res.Results) == 0 {
+	if len(results) == 0 {
 		// #188: we return StatusBadUnexpectedError because it is unclear, under what
 		// circumstances the server would return no error and no results in the response
 		return nil, ua.StatusBadUnexpectedError
 	}
-	value := res.Results[0].Value
-	if res.Results[0].Status != ua.StatusOK {
-		return value, res.Results[0].Status
+	value := results[0].Value
+	if results[0].Status != ua.StatusOK {
+		return value, results[0].Status
 	}
 	return value, nil
 }
@@ -217,16 +268,40 @@ func (n *Node) Attributes(attrID ...ua.AttributeID) ([]*ua.DataValue, error) {
 
 // Note: Starting with v0.5 this method is superseded by the non 'WithContext' method.
 func (n *Node) AttributesWithContext(ctx context.Context, attrID ...ua.AttributeID) ([]*ua.DataValue, error) {
+	cache := n.c.nodeCache
+	results := make([]*ua.DataValue, len(attrID))
+
+	// miss contains the indexes of the attributes which are not cached
+	var miss []int
 	req := &ua.ReadRequest{}
-	for _, id := range attrID {
+	for i, id := range attrID {
+		if dv, ok := cache.attribute(n.ID, id); ok {
+			results[i] = dv
+			continue
+		}
+		miss = append(miss, i)
 		rv := &ua.ReadValueID{NodeID: n.ID, AttributeID: id}
 		req.NodesToRead = append(req.NodesToRead, rv)
 	}
+	if cache != nil && len(miss) == 0 {
+		return results, nil
+	}
+
 	res, err := n.c.ReadWithContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return res.Results, nil
+	if cache == nil {
+		return res.Results, nil
+	}
+	if len(res.Results) != len(miss) {
+		return nil, ua.StatusBadUnknownResponse
+	}
+	for k, i := range miss {
+		results[i] = res.Results[k]
+		cache.setAttribute(n.ID, attrID[i], res.Results[k])
+	}
+	return results, nil
 }
 
 // Children returns the child nodes which match the node class mask.
@@ -289,6 +364,11 @@ func (n *Node) ReferencesWithContext(ctx context.Context, refType uint32, dir ua
 		mask = ua.NodeClassAll
 	}
 
+	key := refCacheKey{refType: refType, dir: dir, mask: mask, includeSubtypes: includeSubtypes}
+	if refs, ok := n.c.nodeCache.references(n.ID, key); ok {
+		return refs, nil
+	}
+
 	desc := &ua.BrowseDescription{
 		NodeID:          n.ID,
 		BrowseDirection: dir,
@@ -310,7 +390,14 @@ func (n *Node) ReferencesWithContext(ctx context.Context, refType uint32, dir ua
 	if err != nil {
 		return nil, err
 	}
-	return n.browseNext(ctx, resp.Results)
+	refs, err := n.browseNext(ctx, resp.Results)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Results[0].StatusCode == ua.StatusOK {
+		n.c.nodeCache.setReferences(n.ID, key, refs)
+	}
+	return refs, nil
 }
 
 func (n *Node) browseNext(ctx context.Context, results []*ua.BrowseResult) ([]*ua.ReferenceDescription, error) {
@@ -400,3 +487,31 @@ func (n *Node) TranslateBrowsePathInNamespaceToNodeIDWithContext(ctx context.Con
 	}
 	return n.TranslateBrowsePathsToNodeIDsWithContext(ctx, names)
 }
+
+// TranslateRelativePaths resolves the relative paths in the text format,
+// e.g. "/2:Block&.Output", from this node with one request per batch of
+// paths and returns the results in the order of the paths. The results
+// contain all targets of each path. The reference types of the paths must
+// be defined in namespace 0; use Client.TranslateBrowsePaths with
+// ua.ParseRelativePathFunc otherwise.
+//
+// Specification: Part 4, A.2
+func (n *Node) TranslateRelativePaths(ctx context.Context, paths ...string) ([]*ua.BrowsePathResult, error) {
+	req := &ua.TranslateBrowsePathsToNodeIDsRequest{}
+	for _, s := range paths {
+		p, err := ua.ParseRelativePath(s)
+		if err != nil {
+			return nil, err
+		}
+		req.BrowsePaths = append(req.BrowsePaths, &ua.BrowsePath{StartingNode: n.ID, RelativePath: p})
+	}
+
+	res, err := n.c.TranslateBrowsePaths(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Results) != len(paths) {
+		return nil, ua.StatusBadUnknownResponse
+	}
+	return res.Results, nil
+}
@@ -0,0 +1,369 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// nodeCache caches the static attributes and the references of nodes
+// which are read through Node. The entries expire after ttl and are
+// invalidated when the session is recreated, the namespace array of the
+// server changes or the server reports a model change.
+//
+// The cached values are shared between the callers and must not be
+// modified.
+type nodeCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	nodes     map[string]*nodeCacheEntry
+	lastSweep time.Time
+}
+
+type nodeCacheEntry struct {
+	attrs map[ua.AttributeID]cachedValue
+	refs  map[refCacheKey]cachedRefs
+}
+
+type cachedValue struct {
+	dv      *ua.DataValue
+	expires time.Time
+}
+
+type cachedRefs struct {
+	refs    []*ua.ReferenceDescription
+	expires time.Time
+}
+
+// refCacheKey contains the parameters of Node.References.
+type refCacheKey struct {
+	refType         uint32
+	dir             ua.BrowseDirection
+	mask            ua.NodeClass
+	includeSubtypes bool
+}
+
+func newNodeCache(ttl time.Duration) *nodeCache {
+	return &nodeCache{
+		ttl:   ttl,
+		now:   time.Now,
+		nodes: make(map[string]*nodeCacheEntry),
+	}
+}
+
+// staticAttribute returns true for the attributes which usually do not
+// change while a node exists.
+func staticAttribute(attrID ua.AttributeID) bool {
+	switch attrID {
+	case ua.AttributeIDNodeID,
+		ua.AttributeIDNodeClass,
+		ua.AttributeIDBrowseName,
+		ua.AttributeIDDisplayName,
+		ua.AttributeIDDescription,
+		ua.AttributeIDIsAbstract,
+		ua.AttributeIDSymmetric,
+		ua.AttributeIDInverseName,
+		ua.AttributeIDContainsNoLoops,
+		ua.AttributeIDDataType,
+		ua.AttributeIDValueRank,
+		ua.AttributeIDArrayDimensions:
+		return true
+	default:
+		return false
+	}
+}
+
+// attribute returns the cached attribute of the node. It is safe to call
+// on a nil cache.
+func (c *nodeCache) attribute(nodeID *ua.NodeID, attrID ua.AttributeID) (*ua.DataValue, bool) {
+	if c == nil || !staticAttribute(attrID) {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.nodes[nodeID.String()]
+	if e == nil {
+		return nil, false
+	}
+	v, ok := e.attrs[attrID]
+	if !ok || c.now().After(v.expires) {
+		return nil, false
+	}
+	return v.dv, true
+}
+
+// setAttribute caches the attribute of the node if it is static and has
+// been read successfully.
+func (c *nodeCache) setAttribute(nodeID *ua.NodeID, attrID ua.AttributeID, dv *ua.DataValue) {
+	if c == nil || !staticAttribute(attrID) || dv == nil || dv.Status != ua.StatusOK {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.entry(nodeID)
+	if e.attrs == nil {
+		e.attrs = make(map[ua.AttributeID]cachedValue)
+	}
+	e.attrs[attrID] = cachedValue{dv: dv, expires: c.now().Add(c.ttl)}
+}
+
+// references returns the cached references of the node.
+func (c *nodeCache) references(nodeID *ua.NodeID, key refCacheKey) ([]*ua.ReferenceDescription, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.nodes[nodeID.String()]
+	if e == nil {
+		return nil, false
+	}
+	v, ok := e.refs[key]
+	if !ok || c.now().After(v.expires) {
+		return nil, false
+	}
+	return v.refs, true
+}
+
+// setReferences caches the references of the node.
+func (c *nodeCache) setReferences(nodeID *ua.NodeID, key refCacheKey, refs []*ua.ReferenceDescription) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.entry(nodeID)
+	if e.refs == nil {
+		e.refs = make(map[refCacheKey]cachedRefs)
+	}
+	e.refs[key] = cachedRefs{refs: refs, expires: c.now().Add(c.ttl)}
+}
+
+// entry returns the entry of the node and creates it if necessary. The
+// expired entries are removed at most once per ttl. The caller must hold
+// c.mu.
+func (c *nodeCache) entry(nodeID *ua.NodeID) *nodeCacheEntry {
+	now := c.now()
+	if now.Sub(c.lastSweep) > c.ttl {
+		c.sweep(now)
+		c.lastSweep = now
+	}
+
+	key := nodeID.String()
+	e := c.nodes[key]
+	if e == nil {
+		e = &nodeCacheEntry{}
+		c.nodes[key] = e
+	}
+	return e
+}
+
+// sweep removes the expired entries. The caller must hold c.mu.
+func (c *nodeCache) sweep(now time.Time) {
+	for key, e := range c.nodes {
+		for id, v := range e.attrs {
+			if now.After(v.expires) {
+				delete(e.attrs, id)
+			}
+		}
+		for k, v := range e.refs {
+			if now.After(v.expires) {
+				delete(e.refs, k)
+			}
+		}
+		if len(e.attrs) == 0 && len(e.refs) == 0 {
+			delete(c.nodes, key)
+		}
+	}
+}
+
+// invalidate removes the entries of the nodes or all entries if no node
+// is given.
+func (c *nodeCache) invalidate(nodeIDs ...*ua.NodeID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(nodeIDs) == 0 {
+		c.nodes = make(map[string]*nodeCacheEntry)
+		return
+	}
+	for _, n := range nodeIDs {
+		delete(c.nodes, n.String())
+	}
+}
+
+// invalidateReferences removes the cached references of all nodes.
+func (c *nodeCache) invalidateReferences() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.nodes {
+		e.refs = nil
+	}
+}
+
+// modelChanged invalidates the entries of the nodes which are affected
+// by a model change event with the fields EventType and Changes. All
+// entries are invalidated when the event does not contain the changes.
+//
+// Specification: Part 3, 9.32
+func (c *nodeCache) modelChanged(fields []*ua.Variant) {
+	if len(fields) < 2 || fields[1] == nil {
+		c.invalidate()
+		return
+	}
+	eos, ok := fields[1].Value().([]*ua.ExtensionObject)
+	if !ok || len(eos) == 0 {
+		c.invalidate()
+		return
+	}
+
+	var (
+		nodeIDs []*ua.NodeID
+		refs    bool
+	)
+	for _, eo := range eos {
+		ch, ok := eo.Value.(*ua.ModelChangeStructureDataType)
+		if !ok || ch.Affected == nil {
+			c.invalidate()
+			return
+		}
+		nodeIDs = append(nodeIDs, ch.Affected)
+
+		// the inverse references of the other nodes change as well
+		verb := ua.ModelChangeStructureVerbMask(ch.Verb)
+		if verb&(ua.ModelChangeStructureVerbMaskNodeDeleted|ua.ModelChangeStructureVerbMaskReferenceAdded|ua.ModelChangeStructureVerbMaskReferenceDeleted) != 0 {
+			refs = true
+		}
+	}
+	c.invalidate(nodeIDs...)
+	if refs {
+		c.invalidateReferences()
+	}
+}
+
+// InvalidateNodeCache removes the cached attributes and references of
+// the nodes or of all nodes if no node is given. See the NodeCache
+// option.
+func (c *Client) InvalidateNodeCache(nodeIDs ...*ua.NodeID) {
+	c.nodeCache.invalidate(nodeIDs...)
+}
+
+// WatchModelChanges subscribes to the model change events of the server
+// and invalidates the cached attributes and references of the nodes
+// which were changed. The events are published with the given interval
+// until ctx is cancelled. The node cache must be enabled with the
+// NodeCache option.
+//
+// Specification: Part 3, 9.32
+func (c *Client) WatchModelChanges(ctx context.Context, interval time.Duration) error {
+	if c.nodeCache == nil {
+		return errors.Errorf("node cache is disabled")
+	}
+
+	ch := make(chan *PublishNotificationData, 16)
+	sub, err := c.SubscribeWithContext(ctx, &SubscriptionParameters{Interval: interval}, ch)
+	if err != nil {
+		return err
+	}
+
+	filter := &ua.EventFilter{
+		SelectClauses: []*ua.SimpleAttributeOperand{
+			{
+				TypeDefinitionID: ua.NewNumericNodeID(0, id.BaseEventType),
+				BrowsePath:       []*ua.QualifiedName{{Name: "EventType"}},
+				AttributeID:      ua.AttributeIDValue,
+			},
+			{
+				TypeDefinitionID: ua.NewNumericNodeID(0, id.GeneralModelChangeEventType),
+				BrowsePath:       []*ua.QualifiedName{{Name: "Changes"}},
+				AttributeID:      ua.AttributeIDValue,
+			},
+		},
+		WhereClause: &ua.ContentFilter{
+			Elements: []*ua.ContentFilterElement{{
+				FilterOperator: ua.FilterOperatorOfType,
+				FilterOperands: []*ua.ExtensionObject{
+					ua.NewExtensionObject(&ua.LiteralOperand{
+						Value: ua.MustVariant(ua.NewNumericNodeID(0, id.BaseModelChangeEventType)),
+					}),
+				},
+			}},
+		},
+	}
+	req := &ua.MonitoredItemCreateRequest{
+		ItemToMonitor: &ua.ReadValueID{
+			NodeID:       ua.NewNumericNodeID(0, id.Server),
+			AttributeID:  ua.AttributeIDEventNotifier,
+			DataEncoding: &ua.QualifiedName{},
+		},
+		MonitoringMode: ua.MonitoringModeReporting,
+		RequestedParameters: &ua.MonitoringParameters{
+			ClientHandle:  1,
+			QueueSize:     100,
+			DiscardOldest: true,
+			Filter:        ua.NewExtensionObject(filter),
+		},
+	}
+	res, err := sub.MonitorWithContext(ctx, ua.TimestampsToReturnNeither, req)
+	if err == nil && len(res.Results) != 1 {
+		err = ua.StatusBadUnknownResponse
+	}
+	if err == nil && res.Results[0].StatusCode != ua.StatusOK {
+		err = res.Results[0].StatusCode
+	}
+	if err != nil {
+		sub.Cancel(context.Background())
+		return err
+	}
+
+	go func() {
+		defer sub.Cancel(context.Background())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-ch:
+				if msg.Error != nil {
+					debug.Printf("client: model change subscription: %s", msg.Error)
+					continue
+				}
+				evs, ok := msg.Value.(*ua.EventNotificationList)
+				if !ok {
+					continue
+				}
+				for _, ev := range evs.Events {
+					c.nodeCache.modelChanged(ev.EventFields)
+				}
+			}
+		}
+	}()
+	return nil
+}
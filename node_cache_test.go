@@ -0,0 +1,174 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestNodeCache(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+
+	var reads, browses int
+	var lastRead []ua.AttributeID
+	srv.Handle(id.ReadRequest_Encoding_DefaultBinary, func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		reads++
+		lastRead = nil
+		for _, rv := range req.(*ua.ReadRequest).NodesToRead {
+			lastRead = append(lastRead, rv.AttributeID)
+		}
+		return srv.handleRead(ctx, sess, req)
+	})
+	srv.Handle(id.BrowseRequest_Encoding_DefaultBinary, func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		browses++
+		return srv.handleBrowse(ctx, sess, req)
+	})
+
+	c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false), AutoBatch(false), NodeCache(time.Minute))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.nodeCache.now = func() time.Time { return now }
+
+	n := c.Node(ua.NewNumericNodeID(0, id.Server_ServerStatus))
+	browseName := func() {
+		t.Helper()
+		name, err := n.BrowseNameWithContext(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "browse name", name.Name, "ServerStatus")
+	}
+
+	t.Run("attributes", func(t *testing.T) {
+		reads = 0
+		browseName()
+		browseName()
+		verify.Values(t, "reads", reads, 1)
+
+		dvs, err := n.AttributesWithContext(ctx, ua.AttributeIDBrowseName, ua.AttributeIDValue, ua.AttributeIDNodeClass)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "read attributes", lastRead, []ua.AttributeID{ua.AttributeIDValue, ua.AttributeIDNodeClass})
+		verify.Values(t, "results", len(dvs), 3)
+		verify.Values(t, "cached browse name", dvs[0].Value.Value().(*ua.QualifiedName).Name, "ServerStatus")
+		verify.Values(t, "node class", dvs[2].Value.Value(), int32(ua.NodeClassVariable))
+
+		reads = 0
+		if _, err := n.ValueWithContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n.NodeClassWithContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "value is not cached", reads, 1)
+	})
+
+	t.Run("references", func(t *testing.T) {
+		browses = 0
+		for i := 0; i < 2; i++ {
+			refs, err := n.ReferencesWithContext(ctx, id.HasComponent, ua.BrowseDirectionForward, ua.NodeClassAll, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(refs) == 0 {
+				t.Fatal("no references")
+			}
+		}
+		if _, err := n.ReferencesWithContext(ctx, id.HasComponent, ua.BrowseDirectionInverse, ua.NodeClassAll, true); err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "browses", browses, 2)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		reads = 0
+		now = now.Add(2 * time.Minute)
+		browseName()
+		browseName()
+		verify.Values(t, "reads", reads, 1)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		reads = 0
+		c.InvalidateNodeCache(ua.NewNumericNodeID(0, id.Server))
+		browseName()
+		verify.Values(t, "other node", reads, 0)
+
+		c.InvalidateNodeCache(n.ID)
+		browseName()
+		verify.Values(t, "node", reads, 1)
+
+		c.InvalidateNodeCache()
+		browseName()
+		verify.Values(t, "all", reads, 2)
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		reads = 0
+		c.setNamespaces(c.Namespaces())
+		browseName()
+		verify.Values(t, "unchanged", reads, 0)
+
+		c.setNamespaces(append(c.Namespaces(), "urn:other"))
+		browseName()
+		verify.Values(t, "changed", reads, 1)
+	})
+}
+
+func TestNodeCacheModelChanged(t *testing.T) {
+	a, b := ua.NewNumericNodeID(1, 1), ua.NewNumericNodeID(1, 2)
+	key := refCacheKey{refType: id.References, mask: ua.NodeClassAll}
+	fill := func() *nodeCache {
+		c := newNodeCache(time.Minute)
+		for _, n := range []*ua.NodeID{a, b} {
+			c.setAttribute(n, ua.AttributeIDBrowseName, &ua.DataValue{Status: ua.StatusOK})
+			c.setReferences(n, key, []*ua.ReferenceDescription{})
+		}
+		return c
+	}
+	event := func(verb ua.ModelChangeStructureVerbMask) []*ua.Variant {
+		return []*ua.Variant{
+			ua.MustVariant(ua.NewNumericNodeID(0, id.GeneralModelChangeEventType)),
+			ua.MustVariant([]*ua.ExtensionObject{ua.NewExtensionObject(&ua.ModelChangeStructureDataType{
+				Affected: a,
+				Verb:     uint8(verb),
+			})}),
+		}
+	}
+	cached := func(c *nodeCache) []bool {
+		var v []bool
+		for _, n := range []*ua.NodeID{a, b} {
+			_, attr := c.attribute(n, ua.AttributeIDBrowseName)
+			_, refs := c.references(n, key)
+			v = append(v, attr, refs)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name   string
+		fields []*ua.Variant
+		want   []bool
+	}{
+		{"data type changed", event(ua.ModelChangeStructureVerbMaskDataTypeChanged), []bool{false, false, true, true}},
+		{"reference added", event(ua.ModelChangeStructureVerbMaskReferenceAdded), []bool{false, false, true, false}},
+		{"base model change", []*ua.Variant{ua.MustVariant(ua.NewNumericNodeID(0, id.BaseModelChangeEventType)), nil}, []bool{false, false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fill()
+			c.modelChanged(tt.fields)
+			verify.Values(t, "", cached(c), tt.want)
+		})
+	}
+}
@@ -0,0 +1,476 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package nodeset reads information models in the NodeSet2 XML format,
+// e.g. Opc.Ua.NodeSet2.xml or the models of the companion specifications.
+//
+// The node ids, browse names and references of the nodes refer to the
+// namespaces of the NamespaceURIs of the node set. Namespace index 0 is
+// the OPC UA namespace and index i > 0 is NamespaceURIs[i-1].
+//
+// Specification: Part 6, Annex F
+package nodeset
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// UANamespace is the URI of namespace 0.
+const UANamespace = "http://opcfoundation.org/UA/"
+
+// XMLNamespace is the XML namespace of the NodeSet2 schema.
+const XMLNamespace = "http://opcfoundation.org/UA/2011/03/UANodeSet.xsd"
+
+// NodeSet contains the nodes of an information model.
+type NodeSet struct {
+	XMLName       xml.Name `xml:"UANodeSet"`
+	NamespaceURIs []string `xml:"NamespaceUris>Uri"`
+	ServerURIs    []string `xml:"ServerUris>Uri"`
+	Models        []*Model `xml:"Models>Model"`
+	Aliases       []*Alias `xml:"Aliases>Alias"`
+
+	Objects        []*Object        `xml:"UAObject"`
+	Variables      []*Variable      `xml:"UAVariable"`
+	Methods        []*Method        `xml:"UAMethod"`
+	Views          []*View          `xml:"UAView"`
+	ObjectTypes    []*ObjectType    `xml:"UAObjectType"`
+	VariableTypes  []*VariableType  `xml:"UAVariableType"`
+	DataTypes      []*DataType      `xml:"UADataType"`
+	ReferenceTypes []*ReferenceType `xml:"UAReferenceType"`
+
+	aliases map[string]string
+}
+
+// Model describes the information model which is defined by a node set.
+type Model struct {
+	ModelURI        string   `xml:"ModelUri,attr"`
+	Version         string   `xml:",attr,omitempty"`
+	PublicationDate string   `xml:",attr,omitempty"`
+	RequiredModels  []*Model `xml:"RequiredModel"`
+}
+
+// Alias is a name for a node id, e.g. "HasComponent" for "i=47".
+type Alias struct {
+	Alias  string `xml:",attr"`
+	NodeID string `xml:",chardata"`
+}
+
+// LocalizedText is a text with an optional locale.
+type LocalizedText struct {
+	Locale string `xml:",attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
+
+// Reference is a reference from the node which contains it. The
+// reference type and the target are node ids or aliases.
+type Reference struct {
+	ReferenceType string `xml:",attr"`
+	IsForward     bool   `xml:",attr"`
+	Target        string `xml:",chardata"`
+}
+
+// UnmarshalXML decodes a reference which is a forward reference unless
+// IsForward is false.
+func (r *Reference) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type reference Reference
+	x := reference{IsForward: true}
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	x.Target = strings.TrimSpace(x.Target)
+	*r = Reference(x)
+	return nil
+}
+
+// MarshalXML encodes a reference and omits IsForward for forward
+// references.
+func (r *Reference) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "ReferenceType"}, Value: r.ReferenceType})
+	if !r.IsForward {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "IsForward"}, Value: "false"})
+	}
+	return e.EncodeElement(r.Target, start)
+}
+
+// Node contains the attributes which all node classes have.
+type Node struct {
+	NodeID        string           `xml:"NodeId,attr"`
+	BrowseName    string           `xml:",attr"`
+	SymbolicName  string           `xml:",attr,omitempty"`
+	WriteMask     uint32           `xml:",attr,omitempty"`
+	UserWriteMask uint32           `xml:",attr,omitempty"`
+	DisplayName   []*LocalizedText `xml:"DisplayName"`
+	Description   []*LocalizedText `xml:"Description"`
+	References    []*Reference     `xml:"References>Reference"`
+}
+
+// Base returns the node.
+func (n *Node) Base() *Node {
+	return n
+}
+
+// Name returns the name of the browse name without the namespace index.
+func (n *Node) Name() string {
+	return n.BrowseNameQualified().Name
+}
+
+// BrowseNameQualified returns the browse name which has the format
+// "ns:Name" or "Name" for namespace 0.
+func (n *Node) BrowseNameQualified() *ua.QualifiedName {
+	return ParseQualifiedName(n.BrowseName)
+}
+
+// Instance contains the attributes of the nodes which are instances.
+type Instance struct {
+	Node
+	ParentNodeID string `xml:"ParentNodeId,attr,omitempty"`
+}
+
+type Object struct {
+	Instance
+	EventNotifier uint8 `xml:",attr,omitempty"`
+}
+
+type Variable struct {
+	Instance
+	DataType                string  `xml:",attr"`
+	ValueRank               int32   `xml:",attr"`
+	ArrayDimensions         string  `xml:",attr,omitempty"`
+	AccessLevel             uint8   `xml:",attr"`
+	UserAccessLevel         uint8   `xml:",attr"`
+	MinimumSamplingInterval float64 `xml:",attr,omitempty"`
+	Historizing             bool    `xml:",attr,omitempty"`
+	Value                   *Value  `xml:"Value"`
+}
+
+// UnmarshalXML decodes a variable with the default values of the
+// attributes: BaseDataType, a scalar value rank and the CurrentRead
+// access level.
+func (v *Variable) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type variable Variable
+	x := variable{DataType: "i=24", ValueRank: -1, AccessLevel: 1, UserAccessLevel: 1}
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	*v = Variable(x)
+	return nil
+}
+
+type Method struct {
+	Instance
+	MethodDeclarationID string `xml:"MethodDeclarationId,attr,omitempty"`
+	Executable          bool   `xml:",attr"`
+	UserExecutable      bool   `xml:",attr"`
+}
+
+// UnmarshalXML decodes a method which is executable by default.
+func (m *Method) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type method Method
+	x := method{Executable: true, UserExecutable: true}
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	*m = Method(x)
+	return nil
+}
+
+type View struct {
+	Instance
+	ContainsNoLoops bool  `xml:",attr,omitempty"`
+	EventNotifier   uint8 `xml:",attr,omitempty"`
+}
+
+type ObjectType struct {
+	Node
+	IsAbstract bool `xml:",attr,omitempty"`
+}
+
+type VariableType struct {
+	Node
+	DataType        string `xml:",attr"`
+	ValueRank       int32  `xml:",attr"`
+	ArrayDimensions string `xml:",attr,omitempty"`
+	IsAbstract      bool   `xml:",attr,omitempty"`
+	Value           *Value `xml:"Value"`
+}
+
+// UnmarshalXML decodes a variable type with the default values of the
+// attributes: BaseDataType and a scalar value rank.
+func (v *VariableType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type variableType VariableType
+	x := variableType{DataType: "i=24", ValueRank: -1}
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	*v = VariableType(x)
+	return nil
+}
+
+type DataType struct {
+	Node
+	IsAbstract bool        `xml:",attr,omitempty"`
+	Definition *Definition `xml:"Definition"`
+}
+
+// Definition is the definition of a structure, an enumeration or an
+// option set.
+type Definition struct {
+	Name         string   `xml:",attr"`
+	SymbolicName string   `xml:",attr,omitempty"`
+	IsUnion      bool     `xml:",attr,omitempty"`
+	IsOptionSet  bool     `xml:",attr,omitempty"`
+	Fields       []*Field `xml:"Field"`
+}
+
+// Field is a field of a structure or a value of an enumeration.
+type Field struct {
+	Name            string           `xml:",attr"`
+	SymbolicName    string           `xml:",attr,omitempty"`
+	DataType        string           `xml:",attr"`
+	ValueRank       int32            `xml:",attr"`
+	ArrayDimensions string           `xml:",attr,omitempty"`
+	MaxStringLength uint32           `xml:",attr,omitempty"`
+	Value           int64            `xml:",attr"`
+	IsOptional      bool             `xml:",attr,omitempty"`
+	AllowSubTypes   bool             `xml:",attr,omitempty"`
+	DisplayName     []*LocalizedText `xml:"DisplayName"`
+	Description     []*LocalizedText `xml:"Description"`
+}
+
+// UnmarshalXML decodes a field with the default values of the
+// attributes: BaseDataType and a scalar value rank.
+func (f *Field) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type field Field
+	x := field{DataType: "i=24", ValueRank: -1}
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	*f = Field(x)
+	return nil
+}
+
+type ReferenceType struct {
+	Node
+	IsAbstract  bool             `xml:",attr,omitempty"`
+	Symmetric   bool             `xml:",attr,omitempty"`
+	InverseName []*LocalizedText `xml:"InverseName"`
+}
+
+// Value is the value of a variable or a variable type in the XML
+// encoding of a Variant. It can be decoded with ua.DecodeXML.
+type Value struct {
+	InnerXML []byte `xml:",innerxml"`
+}
+
+// Variant decodes the value.
+//
+// Specification: Part 6, 5.3.1.17
+func (v *Value) Variant() (*ua.Variant, error) {
+	var b bytes.Buffer
+	b.WriteString("<Value>")
+	b.Write(v.InnerXML)
+	b.WriteString("</Value>")
+	val := new(ua.Variant)
+	if err := ua.DecodeXML(b.Bytes(), val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// NewValue returns the XML encoding of a variant or nil if the variant is
+// empty.
+func NewValue(v *ua.Variant) (*Value, error) {
+	if v == nil || v.Type() == ua.TypeIDNull {
+		return nil, nil
+	}
+	b, err := ua.EncodeXML(v)
+	if err != nil {
+		return nil, err
+	}
+	// the value element contains the children of the variant element
+	// which need their own namespace declaration
+	b = b[bytes.IndexByte(b, '>')+1 : bytes.LastIndexByte(b, '<')]
+	i := bytes.IndexAny(b, " />")
+	if i < 0 {
+		return nil, errors.Errorf("invalid XML encoding of %v", v.Value())
+	}
+	var inner bytes.Buffer
+	inner.Write(b[:i])
+	inner.WriteString(` xmlns="` + ua.XMLNamespace + `"`)
+	inner.Write(b[i:])
+	return &Value{InnerXML: inner.Bytes()}, nil
+}
+
+// Decode reads a node set.
+func Decode(r io.Reader) (*NodeSet, error) {
+	s := new(NodeSet)
+	if err := xml.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+	s.aliases = make(map[string]string, len(s.Aliases))
+	for _, a := range s.Aliases {
+		s.aliases[a.Alias] = strings.TrimSpace(a.NodeID)
+	}
+	return s, nil
+}
+
+// Parse reads a node set.
+func Parse(b []byte) (*NodeSet, error) {
+	return Decode(bytes.NewReader(b))
+}
+
+// ReadFile reads a node set from a file.
+func ReadFile(filename string) (*NodeSet, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
+
+// Encode writes the node set as indented XML. The uax prefix is declared
+// for the values which were read with the node set.
+func (s *NodeSet) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	start := xml.StartElement{
+		Name: xml.Name{Local: "UANodeSet"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: XMLNamespace},
+			{Name: xml.Name{Local: "xmlns:uax"}, Value: ua.XMLNamespace},
+		},
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.EncodeElement(s, start); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFile writes the node set to a file.
+func (s *NodeSet) WriteFile(filename string) error {
+	var b bytes.Buffer
+	if err := s.Encode(&b); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, b.Bytes(), 0644)
+}
+
+// Nodes returns the nodes of all node classes.
+func (s *NodeSet) Nodes() []interface{ Base() *Node } {
+	var nodes []interface{ Base() *Node }
+	for _, n := range s.ReferenceTypes {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.DataTypes {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.ObjectTypes {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.VariableTypes {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.Objects {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.Variables {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.Methods {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.Views {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// NamespaceURI returns the URI of the namespace index of the node set.
+func (s *NodeSet) NamespaceURI(ns uint16) string {
+	if ns == 0 {
+		return UANamespace
+	}
+	if int(ns) > len(s.NamespaceURIs) {
+		return ""
+	}
+	return s.NamespaceURIs[ns-1]
+}
+
+// NodeID returns the node id of a node id string or an alias.
+func (s *NodeSet) NodeID(v string) (*ua.NodeID, error) {
+	v = strings.TrimSpace(v)
+	if id, ok := s.aliases[v]; ok {
+		v = id
+	}
+	return ua.ParseNodeID(v)
+}
+
+// ParseQualifiedName parses a browse name of the format "ns:Name". The
+// namespace index is 0 if there is no numeric prefix.
+func ParseQualifiedName(s string) *ua.QualifiedName {
+	if i := strings.Index(s, ":"); i > 0 {
+		if ns, err := strconv.ParseUint(s[:i], 10, 16); err == nil {
+			return &ua.QualifiedName{NamespaceIndex: uint16(ns), Name: s[i+1:]}
+		}
+	}
+	return &ua.QualifiedName{Name: s}
+}
+
+// FormatQualifiedName returns the browse name in the format "ns:Name" or
+// "Name" for namespace 0.
+func FormatQualifiedName(q *ua.QualifiedName) string {
+	if q.NamespaceIndex == 0 {
+		return q.Name
+	}
+	return strconv.Itoa(int(q.NamespaceIndex)) + ":" + q.Name
+}
+
+// ParseArrayDimensions parses a comma separated list of array
+// dimensions.
+func ParseArrayDimensions(s string) ([]uint32, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var dims []uint32
+	for _, d := range strings.Split(s, ",") {
+		n, err := strconv.ParseUint(strings.TrimSpace(d), 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("invalid array dimensions %q", s)
+		}
+		dims = append(dims, uint32(n))
+	}
+	return dims, nil
+}
+
+// FormatArrayDimensions returns the array dimensions as comma separated
+// list.
+func FormatArrayDimensions(dims []uint32) string {
+	s := make([]string, len(dims))
+	for i, d := range dims {
+		s[i] = strconv.FormatUint(uint64(d), 10)
+	}
+	return strings.Join(s, ",")
+}
+
+// Text returns the text of the first localized text or an empty string.
+func Text(l []*LocalizedText) string {
+	if len(l) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(l[0].Text)
+}
@@ -0,0 +1,144 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package nodeset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestReadFile(t *testing.T) {
+	s, err := ReadFile("testdata/Test.NodeSet2.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify.Values(t, "NamespaceURIs", s.NamespaceURIs, []string{"urn:gopcua:test"})
+	verify.Values(t, "NamespaceURI(0)", s.NamespaceURI(0), UANamespace)
+	verify.Values(t, "NamespaceURI(1)", s.NamespaceURI(1), "urn:gopcua:test")
+	verify.Values(t, "NamespaceURI(2)", s.NamespaceURI(2), "")
+	verify.Values(t, "Models", s.Models, []*Model{{
+		ModelURI:        "urn:gopcua:test",
+		Version:         "1.0.0",
+		PublicationDate: "2020-01-01T00:00:00Z",
+		RequiredModels:  []*Model{{ModelURI: UANamespace, Version: "1.04", PublicationDate: "2019-05-01T00:00:00Z"}},
+	}})
+	verify.Values(t, "Nodes", len(s.Nodes()), 12)
+
+	m := s.DataTypes[2]
+	verify.Values(t, "BrowseName", m.BrowseNameQualified(), &ua.QualifiedName{NamespaceIndex: 1, Name: "Machine"})
+	verify.Values(t, "Description", Text(m.Description), "A machine with optional fields.")
+	verify.Values(t, "References", m.References, []*Reference{{ReferenceType: "HasSubtype", IsForward: false, Target: "i=22"}})
+	verify.Values(t, "Fields[3]", m.Definition.Fields[3], &Field{Name: "Position", DataType: "ns=1;i=3002", ValueRank: -1, IsOptional: true})
+	verify.Values(t, "Fields[4]", m.Definition.Fields[4], &Field{Name: "Tags", DataType: "String", ValueRank: 1, ArrayDimensions: "0"})
+
+	v := s.Variables[0]
+	verify.Values(t, "ParentNodeID", v.ParentNodeID, "ns=1;i=1001")
+	verify.Values(t, "ValueRank", v.ValueRank, int32(-1))
+	verify.Values(t, "AccessLevel", v.AccessLevel, uint8(3))
+	verify.Values(t, "UserAccessLevel", v.UserAccessLevel, uint8(1))
+	verify.Values(t, "IsForward", v.References[0].IsForward, true)
+}
+
+func TestNodeID(t *testing.T) {
+	s, err := ReadFile("testdata/Test.NodeSet2.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in   string
+		want *ua.NodeID
+	}{
+		{"HasComponent", ua.NewTwoByteNodeID(47)},
+		{"Duration", ua.NewFourByteNodeID(0, 290)},
+		{"ns=1;i=3001", ua.NewFourByteNodeID(1, 3001)},
+		{" ns=1;s=Tags ", ua.NewStringNodeID(1, "Tags")},
+	}
+	for _, tt := range tests {
+		got, err := s.NodeID(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, tt.in, got, tt.want)
+	}
+}
+
+func TestValueVariant(t *testing.T) {
+	s, err := ReadFile("testdata/Test.NodeSet2.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := s.Variables[1].Value.Variant()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "Speed", v.Value(), 42.5)
+
+	v, err = s.Variables[2].Value.Variant()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "Tags", v.Value(), []string{"a", "b"})
+}
+
+func TestNewValue(t *testing.T) {
+	tests := []interface{}{42.5, []string{"a", "b"}, &ua.QualifiedName{NamespaceIndex: 1, Name: "x"}}
+	for _, tt := range tests {
+		v, err := NewValue(ua.MustVariant(tt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := v.Variant()
+		if err != nil {
+			t.Fatal(err)
+		}
+		verify.Values(t, "", got.Value(), tt)
+	}
+
+	v, err := NewValue(&ua.Variant{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "null", v, (*Value)(nil))
+}
+
+func TestEncode(t *testing.T) {
+	s, err := ReadFile("testdata/Test.NodeSet2.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := s.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "", got, s)
+
+	v, err := got.Variables[2].Value.Variant()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "Tags", v.Value(), []string{"a", "b"})
+}
+
+func TestParseArrayDimensions(t *testing.T) {
+	got, err := ParseArrayDimensions("2, 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "", got, []uint32{2, 3})
+
+	if _, err := ParseArrayDimensions("2,x"); err == nil {
+		t.Fatal("got nil want error")
+	}
+}
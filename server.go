@@ -4,17 +4,623 @@
 
 package opcua
 
-// Server is a high-level OPC-UA Server
+import (
+	"context"
+	"io"
+	"reflect"
+	runtimedebug "runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/stats"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uacp"
+	"github.com/imatic-tech/opcua/uasc"
+)
+
+// ServiceHandler handles a service request.
+//
+// sess is the activated session the request was sent on. It is nil for
+// services which do not require a session, like GetEndpoints. The
+// response header is set by the server. If the handler returns an error
+// the client receives a ServiceFault with the error as service result
+// if it is a ua.StatusCode and StatusBadInternalError otherwise.
+type ServiceHandler func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error)
+
+// sessionlessServices contains the services which can be called without
+// an activated session.
+var sessionlessServices = map[uint16]bool{
+	id.FindServersRequest_Encoding_DefaultBinary:          true,
+	id.FindServersOnNetworkRequest_Encoding_DefaultBinary: true,
+	id.GetEndpointsRequest_Encoding_DefaultBinary:         true,
+	id.RegisterServerRequest_Encoding_DefaultBinary:       true,
+	id.RegisterServer2Request_Encoding_DefaultBinary:      true,
+}
+
+// Server is a high-level OPC/UA server.
+//
+// It accepts connections from clients, establishes the secure channels
+// and manages the sessions. All other service requests are dispatched
+// to the registered ServiceHandlers.
 type Server struct {
-	EndpointURL string
+	// endpointURL is the endpoint URL the server listens on.
+	endpointURL string
+
+	// cfg is the configuration for the server.
+	cfg *ServerConfig
+
+	// l is the listener for new connections.
+	l *uacp.Listener
+
+	// handlers maps the type id of the service requests to their handlers.
+	handlers   map[uint16]ServiceHandler
+	handlersMu sync.RWMutex
+
+	// sessions manages the sessions of the clients.
+	sessions *sessionManager
+
+	// as is the address space of the server.
+	as *AddressSpace
+
+	// subs manages the subscriptions of the sessions.
+	subs *subscriptionManager
+
+	// startTime is the time the server was started.
+	startTime time.Time
 
-	// conn *uasc.ServerConn
+	// channelID is the id of the last secure channel.
+	channelID uint32 // atomic
+
+	// ctx is canceled when the server is stopped.
+	ctx context.Context
+
+	// cancel stops the server
+	cancel func()
+
+	// wg tracks the go routines of the server
+	wg sync.WaitGroup
+
+	closeOnce sync.Once
+
+	// err is the error of creating the server which Start returns.
+	err error
+}
+
+// NewServer creates a new Server which listens on the given endpoint.
+//
+// When no options are provided the server is created from
+// DefaultServerConfig() and accepts anonymous sessions on secure channels
+// with security policy None. Errors of loading the address space, e.g. of
+// an invalid node set, are returned by Start.
+func NewServer(endpoint string, opts ...ServerOption) *Server {
+	s := &Server{
+		endpointURL: endpoint,
+		cfg:         ApplyServerConfig(opts...),
+		handlers:    make(map[uint16]ServiceHandler),
+	}
+	s.sessions = newSessionManager(s)
+	s.subs = newSubscriptionManager(s)
+	s.sessions.onClose = append(s.sessions.onClose, s.subs.sessionClosed)
+
+	s.as = &AddressSpace{}
+	s.as.AddNamespace(namespaceZeroURI, nil)
+	s.as.AddNamespace(s.cfg.applicationURI, nil)
+	if err := s.addNamespaceZero(); err != nil {
+		s.err = err
+	}
+	if s.err == nil {
+		s.err = s.cfg.nodeSetErr
+	}
+	for _, ns := range s.cfg.nodeSets {
+		if s.err != nil {
+			break
+		}
+		if err := s.as.LoadNodeSet(ns); err != nil {
+			s.err = errors.Errorf("cannot load node set: %s", err)
+		}
+	}
+
+	s.Handle(id.GetEndpointsRequest_Encoding_DefaultBinary, s.handleGetEndpoints)
+	s.Handle(id.FindServersRequest_Encoding_DefaultBinary, s.handleFindServers)
+	s.Handle(id.ReadRequest_Encoding_DefaultBinary, s.handleRead)
+	s.Handle(id.WriteRequest_Encoding_DefaultBinary, s.handleWrite)
+	s.Handle(id.BrowseRequest_Encoding_DefaultBinary, s.handleBrowse)
+	s.Handle(id.BrowseNextRequest_Encoding_DefaultBinary, s.handleBrowseNext)
+	s.Handle(id.TranslateBrowsePathsToNodeIDsRequest_Encoding_DefaultBinary, s.handleTranslateBrowsePathsToNodeIDs)
+	s.Handle(id.RegisterNodesRequest_Encoding_DefaultBinary, s.handleRegisterNodes)
+	s.Handle(id.UnregisterNodesRequest_Encoding_DefaultBinary, s.handleUnregisterNodes)
+	s.Handle(id.CallRequest_Encoding_DefaultBinary, s.handleCall)
+	s.Handle(id.CreateSubscriptionRequest_Encoding_DefaultBinary, s.handleCreateSubscription)
+	s.Handle(id.ModifySubscriptionRequest_Encoding_DefaultBinary, s.handleModifySubscription)
+	s.Handle(id.SetPublishingModeRequest_Encoding_DefaultBinary, s.handleSetPublishingMode)
+	s.Handle(id.DeleteSubscriptionsRequest_Encoding_DefaultBinary, s.handleDeleteSubscriptions)
+	s.Handle(id.PublishRequest_Encoding_DefaultBinary, s.handlePublish)
+	s.Handle(id.RepublishRequest_Encoding_DefaultBinary, s.handleRepublish)
+	s.Handle(id.TransferSubscriptionsRequest_Encoding_DefaultBinary, s.handleTransferSubscriptions)
+	s.Handle(id.CreateMonitoredItemsRequest_Encoding_DefaultBinary, s.handleCreateMonitoredItems)
+	s.Handle(id.ModifyMonitoredItemsRequest_Encoding_DefaultBinary, s.handleModifyMonitoredItems)
+	s.Handle(id.SetMonitoringModeRequest_Encoding_DefaultBinary, s.handleSetMonitoringMode)
+	s.Handle(id.SetTriggeringRequest_Encoding_DefaultBinary, s.handleSetTriggering)
+	s.Handle(id.DeleteMonitoredItemsRequest_Encoding_DefaultBinary, s.handleDeleteMonitoredItems)
+	return s
 }
 
-func (a *Server) Open() error {
+// AddressSpace returns the address space of the server. Namespace 0
+// contains the standard nodes and namespace 1 is the local namespace of
+// the server with the application uri as namespace uri.
+func (s *Server) AddressSpace() *AddressSpace {
+	return s.as
+}
+
+// Handle registers the handler for the service request with the given
+// type id, e.g. id.ReadRequest_Encoding_DefaultBinary. It replaces an
+// already registered handler.
+//
+// The session services CreateSession, ActivateSession and CloseSession
+// are always handled by the server. The Attribute, View and Method
+// services are handled with the address space and the Subscription and
+// MonitoredItem services by the subscription engine of the server unless
+// they are replaced.
+func (s *Server) Handle(typeID uint16, h ServiceHandler) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+	if h == nil {
+		delete(s.handlers, typeID)
+		return
+	}
+	s.handlers[typeID] = h
+}
+
+func (s *Server) handler(typeID uint16) ServiceHandler {
+	s.handlersMu.RLock()
+	defer s.handlersMu.RUnlock()
+	return s.handlers[typeID]
+}
+
+// Start starts listening on the endpoint and serves the clients in the
+// background until Close is called or the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	stats.Server().Add("Start", 1)
+
+	if s.err != nil {
+		return s.err
+	}
+	if s.l != nil {
+		return errors.Errorf("server already started")
+	}
+
+	l, err := uacp.Listen(s.endpointURL, s.cfg.ack)
+	if err != nil {
+		return err
+	}
+	s.l = l
+	s.endpointURL = l.Endpoint()
+	s.startTime = time.Now()
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.ctx = ctx
+
+	debug.Printf("server: listening on %s", s.endpointURL)
+
+	s.wg.Add(2)
+	go s.acceptConns(ctx)
+	go s.sessions.monitor(ctx)
+
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
 	return nil
 }
 
-func (a *Server) Close() error {
+// Close stops the server, closes all connections and waits for all
+// requests to finish.
+func (s *Server) Close() error {
+	stats.Server().Add("Close", 1)
+
+	s.closeOnce.Do(func() {
+		if s.cancel == nil {
+			return
+		}
+		s.cancel()
+		s.wg.Wait()
+		s.sessions.closeAll()
+		s.subs.closeAll()
+	})
+	return nil
+}
+
+// Endpoint returns the endpoint URL of the server. If the server was
+// started with port 0 the URL contains the port chosen by the system.
+func (s *Server) Endpoint() string {
+	return s.endpointURL
+}
+
+// Endpoints returns the endpoint descriptions of the server.
+func (s *Server) Endpoints() []*ua.EndpointDescription {
+	var eps []*ua.EndpointDescription
+	for _, sec := range s.cfg.security {
+		ep := &ua.EndpointDescription{
+			EndpointURL:         s.endpointURL,
+			Server:              s.applicationDescription(),
+			SecurityMode:        sec.mode,
+			SecurityPolicyURI:   sec.policyURI,
+			TransportProfileURI: "http://opcfoundation.org/UA-Profile/Transport/uatcp-uasc-uabinary",
+			SecurityLevel:       securityLevel(sec.policyURI, sec.mode),
+		}
+		tokenPolicyURI := s.userTokenPolicyURI(sec.policyURI)
+		if tokenPolicyURI != "" {
+			ep.ServerCertificate = s.cfg.certificate
+		}
+		for _, t := range s.cfg.userTokens {
+			p := &ua.UserTokenPolicy{
+				PolicyID:  userTokenPolicyID(t),
+				TokenType: t,
+			}
+			if t == ua.UserTokenTypeUserName || t == ua.UserTokenTypeCertificate {
+				if tokenPolicyURI == "" {
+					continue
+				}
+				p.SecurityPolicyURI = tokenPolicyURI
+			}
+			ep.UserIdentityTokens = append(ep.UserIdentityTokens, p)
+		}
+		eps = append(eps, ep)
+	}
+	return eps
+}
+
+func (s *Server) applicationDescription() *ua.ApplicationDescription {
+	return &ua.ApplicationDescription{
+		ApplicationURI:  s.cfg.applicationURI,
+		ProductURI:      s.cfg.productURI,
+		ApplicationName: ua.NewLocalizedText(s.cfg.applicationName),
+		ApplicationType: ua.ApplicationTypeServer,
+		DiscoveryURLs:   []string{s.endpointURL},
+	}
+}
+
+// securityLevel returns the relative security level of an endpoint. A
+// higher value means more security.
+func securityLevel(policyURI string, mode ua.MessageSecurityMode) uint8 {
+	if policyURI == ua.SecurityPolicyURINone {
+		return 0
+	}
+	var level uint8
+	for i, p := range []string{
+		ua.SecurityPolicyURIBasic128Rsa15,
+		ua.SecurityPolicyURIBasic256,
+		ua.SecurityPolicyURIBasic256Sha256,
+		ua.SecurityPolicyURIAes128Sha256RsaOaep,
+		ua.SecurityPolicyURIAes256Sha256RsaPss,
+	} {
+		if p == policyURI {
+			level = uint8(i + 1)
+		}
+	}
+	if mode == ua.MessageSecurityModeSignAndEncrypt {
+		level += 10
+	}
+	return level
+}
+
+// userTokenPolicyID returns the policy id for the user token type.
+func userTokenPolicyID(t ua.UserTokenType) string {
+	switch t {
+	case ua.UserTokenTypeAnonymous:
+		return defaultAnonymousPolicyID
+	case ua.UserTokenTypeUserName:
+		return "UserName"
+	case ua.UserTokenTypeCertificate:
+		return "Certificate"
+	default:
+		return "IssuedToken"
+	}
+}
+
+// userTokenPolicyURI returns the security policy which protects the
+// passwords and certificates of user identity tokens on a secure channel
+// with the given policy. On channels without security the most secure
+// policy of the server is used. It returns an empty string if the server
+// has no certificate and the tokens cannot be protected.
+func (s *Server) userTokenPolicyURI(channelPolicyURI string) string {
+	if channelPolicyURI != ua.SecurityPolicyURINone {
+		return channelPolicyURI
+	}
+	if len(s.cfg.certificate) == 0 || s.cfg.privateKey == nil {
+		return ""
+	}
+	policyURI := ua.SecurityPolicyURIBasic256Sha256
+	for _, sec := range s.cfg.security {
+		if securityLevel(sec.policyURI, ua.MessageSecurityModeSign) > securityLevel(policyURI, ua.MessageSecurityModeSign) {
+			policyURI = sec.policyURI
+		}
+	}
+	return policyURI
+}
+
+// acceptSecurity checks that the server has an endpoint for the security
+// policy and mode the client requested for a secure channel and validates
+// the certificate of the client.
+func (s *Server) acceptSecurity(policyURI string, mode ua.MessageSecurityMode, cert []byte) error {
+	for _, sec := range s.cfg.security {
+		if sec.policyURI != policyURI || sec.mode != mode {
+			continue
+		}
+		if cert != nil && s.cfg.certValidator != nil {
+			return s.cfg.certValidator.Validate(cert, "")
+		}
+		return nil
+	}
+	for _, sec := range s.cfg.security {
+		if sec.policyURI == policyURI {
+			return ua.StatusBadSecurityModeRejected
+		}
+	}
+	return ua.StatusBadSecurityPolicyRejected
+}
+
+// ReverseConnect connects the server to a client which waits for Reverse
+// Connect at the endpoint of the client. The client uses the connection
+// like a connection to the endpoint of the server. The server must be
+// started.
+//
+// Specification: Part 6, 7.1.3
+func (s *Server) ReverseConnect(ctx context.Context, clientEndpoint string) error {
+	if s.ctx == nil {
+		return errors.Errorf("server not started")
+	}
+	if s.ctx.Err() != nil {
+		return errors.Errorf("server closed")
+	}
+	c, err := uacp.DialReverse(ctx, clientEndpoint, s.cfg.applicationURI, s.endpointURL, s.cfg.ack)
+	if err != nil {
+		return err
+	}
+
+	debug.Printf("server: conn %d: reverse connection to %s", c.ID(), c.RemoteAddr())
+	stats.Server().Add("Conn", 1)
+
+	s.wg.Add(1)
+	go s.serveConn(s.ctx, c)
 	return nil
 }
+
+// maxAcceptDelay is the maximum time acceptConns waits before it accepts
+// connections again after an error.
+const maxAcceptDelay = time.Second
+
+func (s *Server) acceptConns(ctx context.Context) {
+	defer s.wg.Done()
+
+	var delay time.Duration
+	for {
+		c, err := s.l.AcceptConn()
+		if err != nil {
+			// back off when the error persists, e.g. when the process
+			// has no file descriptors left
+			if delay == 0 {
+				delay = 5 * time.Millisecond
+			} else if delay *= 2; delay > maxAcceptDelay {
+				delay = maxAcceptDelay
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+				debug.Printf("server: accept failed: %s", err)
+				continue
+			}
+		}
+		delay = 0
+
+		debug.Printf("server: conn %d: connection from %s", c.ID(), c.RemoteAddr())
+		stats.Server().Add("Conn", 1)
+
+		s.wg.Add(1)
+		go func() {
+			// the handshake runs in the goroutine of the connection so
+			// that a client which does not send HEL does not block
+			// other connections
+			if err := s.handshake(ctx, c); err != nil {
+				debug.Printf("server: conn %d: handshake failed: %s", c.ID(), err)
+				s.wg.Done()
+				return
+			}
+			s.serveConn(ctx, c)
+		}()
+	}
+}
+
+// handshake performs the HEL/ACK handshake on an accepted connection. The
+// connection is closed when the server is closed during the handshake.
+func (s *Server) handshake(ctx context.Context, c *uacp.Conn) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+		case <-done:
+		}
+	}()
+	return s.l.Handshake(c, s.cfg.handshakeTimeout)
+}
+
+// serveConn opens a secure channel on the connection and handles the
+// requests of the client until the secure channel is closed.
+func (s *Server) serveConn(ctx context.Context, c *uacp.Conn) {
+	defer s.wg.Done()
+	defer c.Close()
+
+	cfg := &uasc.Config{
+		SecurityPolicyURI: ua.SecurityPolicyURINone,
+		SecurityMode:      ua.MessageSecurityModeNone,
+		Certificate:       s.cfg.certificate,
+		LocalKey:          s.cfg.privateKey,
+		Lifetime:          s.cfg.lifetime,
+		AcceptSecurity:    s.acceptSecurity,
+	}
+	sc, err := uasc.NewServerSecureChannel(s.endpointURL, c, cfg, atomic.AddUint32(&s.channelID, 1))
+	if err != nil {
+		debug.Printf("server: conn %d: %s", c.ID(), err)
+		return
+	}
+	defer sc.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// unblock ReadRequest when the server is closed
+	go func() {
+		<-ctx.Done()
+		c.Close()
+	}()
+
+	var reqs sync.WaitGroup
+	defer reqs.Wait()
+
+	for {
+		r, err := sc.ReadRequest()
+		if err != nil {
+			if err != io.EOF {
+				debug.Printf("server: conn %d: %s", c.ID(), err)
+				stats.RecordError(err)
+				if code, ok := err.(ua.StatusCode); ok {
+					c.SendError(code)
+				}
+			}
+			return
+		}
+
+		reqs.Add(1)
+		go func() {
+			defer reqs.Done()
+			s.handleRequest(ctx, sc, r)
+		}()
+	}
+}
+
+// handleRequest dispatches the request and sends the response to the
+// client.
+func (s *Server) handleRequest(ctx context.Context, sc *uasc.SecureChannel, r *uasc.Request) {
+	debug.Printf("server: channel %d/%d: recv %T", sc.ID(), r.ReqID, r.Value)
+
+	resp, err := s.serveRequest(ctx, sc, r.Value)
+	if err == nil && resp == nil {
+		err = ua.StatusBadInternalError
+	}
+	if err != nil {
+		debug.Printf("server: channel %d/%d: %T failed: %s", sc.ID(), r.ReqID, r.Value, err)
+		stats.RecordError(err)
+
+		status, ok := err.(ua.StatusCode)
+		if !ok {
+			status = ua.StatusBadInternalError
+		}
+		resp = &ua.ServiceFault{}
+		resp.SetHeader(responseHeader(r.Value, status))
+	} else {
+		resp.SetHeader(responseHeader(r.Value, ua.StatusOK))
+	}
+
+	if err := sc.SendResponse(r.ReqID, resp); err != nil {
+		debug.Printf("server: channel %d/%d: send %T failed: %s", sc.ID(), r.ReqID, resp, err)
+	}
+}
+
+// serveRequest calls the handler for the request. A panic in the handler
+// is returned as StatusBadInternalError so that the client gets a response.
+func (s *Server) serveRequest(ctx context.Context, sc *uasc.SecureChannel, req ua.Request) (resp ua.Response, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			debug.Printf("server: channel %d: panic in %T handler: %v\n%s", sc.ID(), req, v, runtimedebug.Stack())
+			resp, err = nil, ua.StatusBadInternalError
+		}
+	}()
+
+	typeID := ua.ServiceTypeID(req)
+	stats.Server().Add(serviceName(req), 1)
+
+	switch r := req.(type) {
+	case *ua.CreateSessionRequest:
+		return s.sessions.createSession(sc, r)
+	case *ua.ActivateSessionRequest:
+		return s.sessions.activateSession(sc, r)
+	case *ua.CloseSessionRequest:
+		return s.sessions.closeSession(sc, r)
+	}
+
+	h := s.handler(typeID)
+	if h == nil {
+		return nil, ua.StatusBadServiceUnsupported
+	}
+
+	var sess *ServerSession
+	if !sessionlessServices[typeID] {
+		if sess, err = s.sessions.activeSession(sc, req.Header().AuthenticationToken); err != nil {
+			return nil, err
+		}
+	}
+
+	return h(ctx, sess, req)
+}
+
+// serviceName returns the name of the service for the statistics.
+func serviceName(req ua.Request) string {
+	name := reflect.TypeOf(req).Elem().Name()
+	if n := len(name) - len("Request"); n > 0 && name[n:] == "Request" {
+		return name[:n]
+	}
+	return name
+}
+
+// responseHeader returns the response header for the request.
+func responseHeader(req ua.Request, status ua.StatusCode) *ua.ResponseHeader {
+	h := &ua.ResponseHeader{
+		Timestamp:          time.Now(),
+		ServiceResult:      status,
+		ServiceDiagnostics: &ua.DiagnosticInfo{},
+	}
+	if rh := req.Header(); rh != nil {
+		h.RequestHandle = rh.RequestHandle
+	}
+	return h
+}
+
+func (s *Server) handleGetEndpoints(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.GetEndpointsRequest)
+
+	var eps []*ua.EndpointDescription
+	for _, ep := range s.Endpoints() {
+		if len(r.ProfileURIs) > 0 && !stringSliceContains(ep.TransportProfileURI, r.ProfileURIs) {
+			continue
+		}
+		eps = append(eps, ep)
+	}
+	return &ua.GetEndpointsResponse{Endpoints: eps}, nil
+}
+
+func (s *Server) handleFindServers(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.FindServersRequest)
+
+	app := s.applicationDescription()
+	if len(r.ServerURIs) > 0 && !stringSliceContains(app.ApplicationURI, r.ServerURIs) {
+		return &ua.FindServersResponse{}, nil
+	}
+	return &ua.FindServersResponse{Servers: []*ua.ApplicationDescription{app}}, nil
+}
+
+func stringSliceContains(s string, a []string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
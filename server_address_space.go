@@ -0,0 +1,526 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// NodeManager manages the nodes of a namespace in the address space of a
+// server. The address space routes the service requests for a node to the
+// NodeManager of the namespace of the node id. This allows to serve one
+// namespace from memory and another one from a device.
+//
+// sess is nil when the server accesses the nodes itself.
+type NodeManager interface {
+	// Read returns the value of an attribute of a node. Errors are
+	// returned as the status code of the data value, e.g.
+	// StatusBadNodeIDUnknown or StatusBadAttributeIDInvalid.
+	Read(ctx context.Context, sess *ServerSession, rv *ua.ReadValueID) *ua.DataValue
+
+	// Write writes the value of an attribute of a node.
+	Write(ctx context.Context, sess *ServerSession, wv *ua.WriteValue) ua.StatusCode
+
+	// References returns the forward and inverse references of a node.
+	// It returns StatusBadNodeIDUnknown if the node does not exist.
+	References(ctx context.Context, sess *ServerSession, id *ua.NodeID) ([]*Reference, error)
+}
+
+// nodeAdder is implemented by node managers which can add nodes.
+type nodeAdder interface {
+	AddNode(n ServerNode) error
+}
+
+// referenceAdder is implemented by node managers which can add
+// references.
+type referenceAdder interface {
+	AddReference(source *ua.NodeID, ref *Reference) error
+}
+
+// AddressSpace is the address space of a server. It contains the
+// namespace array and the node managers of the namespaces.
+//
+// Specification: Part 3, 4
+type AddressSpace struct {
+	mu         sync.RWMutex
+	namespaces []string
+	managers   []NodeManager
+}
+
+// Namespaces returns the namespace array.
+func (as *AddressSpace) Namespaces() []string {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+	return append([]string{}, as.namespaces...)
+}
+
+// NamespaceIndex returns the index of the namespace uri.
+func (as *AddressSpace) NamespaceIndex(uri string) (uint16, bool) {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+	for i, ns := range as.namespaces {
+		if ns == uri {
+			return uint16(i), true
+		}
+	}
+	return 0, false
+}
+
+// AddNamespace adds a namespace and returns its index. If the namespace
+// already exists its node manager is replaced. If m is nil the nodes of
+// the namespace are kept in memory.
+func (as *AddressSpace) AddNamespace(uri string, m NodeManager) uint16 {
+	if m == nil {
+		m = NewMemoryNodeManager()
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	for i, ns := range as.namespaces {
+		if ns == uri {
+			as.managers[i] = m
+			return uint16(i)
+		}
+	}
+	as.namespaces = append(as.namespaces, uri)
+	as.managers = append(as.managers, m)
+	return uint16(len(as.namespaces) - 1)
+}
+
+// NodeManager returns the node manager of the namespace or nil.
+func (as *AddressSpace) NodeManager(ns uint16) NodeManager {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+	if int(ns) >= len(as.managers) {
+		return nil
+	}
+	return as.managers[ns]
+}
+
+// AddNode adds a node to the node manager of its namespace. The node
+// manager must support adding nodes, like the MemoryNodeManager.
+//
+// The inverse references of the node are added to the target nodes if
+// their node managers support adding references.
+func (as *AddressSpace) AddNode(n ServerNode) error {
+	b := n.Base()
+	if b.NodeID == nil {
+		return ua.StatusBadNodeIDInvalid
+	}
+	m, ok := as.NodeManager(b.NodeID.Namespace()).(nodeAdder)
+	if !ok {
+		return errors.Errorf("cannot add nodes to namespace %d", b.NodeID.Namespace())
+	}
+	if err := m.AddNode(n); err != nil {
+		return err
+	}
+	for _, ref := range b.References {
+		as.addInverseReference(b.NodeID, ref)
+	}
+	return nil
+}
+
+// AddReference adds a reference from the source node to the target node
+// and the inverse reference from the target node to the source node.
+func (as *AddressSpace) AddReference(source *ua.NodeID, ref *Reference) error {
+	m, ok := as.NodeManager(source.Namespace()).(referenceAdder)
+	if !ok {
+		return errors.Errorf("cannot add references to namespace %d", source.Namespace())
+	}
+	if err := m.AddReference(source, ref); err != nil {
+		return err
+	}
+	as.addInverseReference(source, ref)
+	return nil
+}
+
+func (as *AddressSpace) addInverseReference(source *ua.NodeID, ref *Reference) {
+	target := as.localNodeID(ref.TargetID)
+	if target == nil {
+		return
+	}
+	m, ok := as.NodeManager(target.Namespace()).(referenceAdder)
+	if !ok {
+		return
+	}
+	// the target node may not exist yet
+	m.AddReference(target, NewReference(ref.ReferenceTypeID, !ref.IsForward, source))
+}
+
+// localNodeID returns the node id of an expanded node id which refers to
+// a node of this server or nil.
+func (as *AddressSpace) localNodeID(e *ua.ExpandedNodeID) *ua.NodeID {
+	if e == nil || e.NodeID == nil || e.ServerIndex != 0 {
+		return nil
+	}
+	if e.NamespaceURI == "" {
+		return e.NodeID
+	}
+	ns, ok := as.NamespaceIndex(e.NamespaceURI)
+	if !ok {
+		return nil
+	}
+	n := *e.NodeID
+	if err := n.SetNamespace(ns); err != nil {
+		return nil
+	}
+	return &n
+}
+
+// Read reads the value of an attribute of a node.
+func (as *AddressSpace) Read(ctx context.Context, sess *ServerSession, rv *ua.ReadValueID) *ua.DataValue {
+	if rv.NodeID == nil {
+		return statusDataValue(ua.StatusBadNodeIDInvalid)
+	}
+	m := as.NodeManager(rv.NodeID.Namespace())
+	if m == nil {
+		return statusDataValue(ua.StatusBadNodeIDUnknown)
+	}
+	dv := m.Read(ctx, sess, rv)
+	if dv == nil {
+		return statusDataValue(ua.StatusBadInternalError)
+	}
+	return dv
+}
+
+// Write writes the value of an attribute of a node.
+func (as *AddressSpace) Write(ctx context.Context, sess *ServerSession, wv *ua.WriteValue) ua.StatusCode {
+	if wv.NodeID == nil {
+		return ua.StatusBadNodeIDInvalid
+	}
+	if wv.Value == nil {
+		return ua.StatusBadTypeMismatch
+	}
+	m := as.NodeManager(wv.NodeID.Namespace())
+	if m == nil {
+		return ua.StatusBadNodeIDUnknown
+	}
+	return m.Write(ctx, sess, wv)
+}
+
+// References returns the forward and inverse references of a node.
+func (as *AddressSpace) References(ctx context.Context, sess *ServerSession, nodeID *ua.NodeID) ([]*Reference, error) {
+	if nodeID == nil {
+		return nil, ua.StatusBadNodeIDInvalid
+	}
+	m := as.NodeManager(nodeID.Namespace())
+	if m == nil {
+		return nil, ua.StatusBadNodeIDUnknown
+	}
+	return m.References(ctx, sess, nodeID)
+}
+
+// NodeClass returns the node class of a node or ua.NodeClassUnspecified
+// if the node does not exist.
+func (as *AddressSpace) NodeClass(ctx context.Context, nodeID *ua.NodeID) ua.NodeClass {
+	dv := as.Read(ctx, nil, &ua.ReadValueID{NodeID: nodeID, AttributeID: ua.AttributeIDNodeClass})
+	if dv.Status != ua.StatusOK || dv.Value == nil {
+		return ua.NodeClassUnspecified
+	}
+	return ua.NodeClass(dv.Value.Int())
+}
+
+// IsSubtype returns true if typeID is baseID or one of its subtypes.
+func (as *AddressSpace) IsSubtype(ctx context.Context, typeID, baseID *ua.NodeID) bool {
+	seen := map[string]bool{}
+	for typeID != nil {
+		k := typeID.String()
+		if k == baseID.String() {
+			return true
+		}
+		if seen[k] {
+			return false
+		}
+		seen[k] = true
+
+		refs, err := as.References(ctx, nil, typeID)
+		if err != nil {
+			return false
+		}
+		typeID = nil
+		for _, r := range refs {
+			if !r.IsForward && isNS0(r.ReferenceTypeID, id.HasSubtype) {
+				typeID = as.localNodeID(r.TargetID)
+				break
+			}
+		}
+	}
+	return false
+}
+
+// isNS0 returns true if n is the numeric node id i in namespace 0.
+func isNS0(n *ua.NodeID, i uint32) bool {
+	if n == nil || n.Namespace() != 0 {
+		return false
+	}
+	switch n.Type() {
+	case ua.NodeIDTypeTwoByte, ua.NodeIDTypeFourByte, ua.NodeIDTypeNumeric:
+		return n.IntID() == i
+	default:
+		return false
+	}
+}
+
+// isNullNodeID returns true if n is nil or the null node id i=0.
+func isNullNodeID(n *ua.NodeID) bool {
+	return n == nil || isNS0(n, 0)
+}
+
+func statusDataValue(status ua.StatusCode) *ua.DataValue {
+	return &ua.DataValue{EncodingMask: ua.DataValueStatusCode, Status: status}
+}
+
+// MemoryNodeManager is a NodeManager which keeps the nodes in memory.
+type MemoryNodeManager struct {
+	mu    sync.RWMutex
+	nodes map[string]ServerNode
+}
+
+// NewMemoryNodeManager returns an empty MemoryNodeManager.
+func NewMemoryNodeManager() *MemoryNodeManager {
+	return &MemoryNodeManager{nodes: make(map[string]ServerNode)}
+}
+
+// AddNode adds a node. Use AddressSpace.AddNode to also add the inverse
+// references to the target nodes.
+func (m *MemoryNodeManager) AddNode(n ServerNode) error {
+	b := n.Base()
+	if b.NodeID == nil {
+		return ua.StatusBadNodeIDInvalid
+	}
+	if b.BrowseName == nil {
+		return ua.StatusBadBrowseNameInvalid
+	}
+	if b.DisplayName == nil {
+		b.DisplayName = ua.NewLocalizedText(b.BrowseName.Name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := b.NodeID.String()
+	if _, ok := m.nodes[k]; ok {
+		return ua.StatusBadNodeIDExists
+	}
+	m.nodes[k] = n
+	return nil
+}
+
+// DeleteNode removes a node. References of other nodes to the node are
+// not removed.
+func (m *MemoryNodeManager) DeleteNode(nodeID *ua.NodeID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := nodeID.String()
+	if _, ok := m.nodes[k]; !ok {
+		return ua.StatusBadNodeIDUnknown
+	}
+	delete(m.nodes, k)
+	return nil
+}
+
+// Node returns the node with the given id or nil. The node must not be
+// modified after it was added.
+func (m *MemoryNodeManager) Node(nodeID *ua.NodeID) ServerNode {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.nodes[nodeID.String()]
+}
+
+// AddReference adds a reference to the source node. Duplicate references
+// are ignored.
+func (m *MemoryNodeManager) AddReference(source *ua.NodeID, ref *Reference) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.nodes[source.String()]
+	if !ok {
+		return ua.StatusBadNodeIDUnknown
+	}
+	b := n.Base()
+	for _, r := range b.References {
+		if r.IsForward == ref.IsForward && r.ReferenceTypeID.String() == ref.ReferenceTypeID.String() && r.TargetID.NodeID.String() == ref.TargetID.NodeID.String() {
+			return nil
+		}
+	}
+	b.References = append(b.References, ref)
+	return nil
+}
+
+// SetValue sets the value of a variable. The server timestamp is set to
+// the current time. Variables with an OnWrite function are not changed.
+func (m *MemoryNodeManager) SetValue(nodeID *ua.NodeID, v *ua.DataValue) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.nodes[nodeID.String()].(*VariableNode)
+	if !ok {
+		return ua.StatusBadNodeIDUnknown
+	}
+	n.Value = stampDataValue(v, time.Now())
+	return nil
+}
+
+// Read implements NodeManager.
+//
+// The index range of a value is applied to the value which is returned
+// by OnRead.
+func (m *MemoryNodeManager) Read(ctx context.Context, sess *ServerSession, rv *ua.ReadValueID) *ua.DataValue {
+	m.mu.RLock()
+	n, ok := m.nodes[rv.NodeID.String()]
+	if !ok {
+		m.mu.RUnlock()
+		return statusDataValue(ua.StatusBadNodeIDUnknown)
+	}
+
+	v, isVariable := n.(*VariableNode)
+	if !isVariable || rv.AttributeID != ua.AttributeIDValue {
+		val, status := nodeAttribute(n, rv.AttributeID)
+		m.mu.RUnlock()
+		if status != ua.StatusOK {
+			return statusDataValue(status)
+		}
+		return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: val}
+	}
+
+	access, userAccess := v.AccessLevel, v.UserAccessLevel
+	value, onRead := v.Value, v.OnRead
+	m.mu.RUnlock()
+
+	indexRange, err := ua.ParseNumericRange(rv.IndexRange)
+	switch {
+	case access&ua.AccessLevelTypeCurrentRead == 0:
+		return statusDataValue(ua.StatusBadNotReadable)
+	case userAccess&ua.AccessLevelTypeCurrentRead == 0:
+		return statusDataValue(ua.StatusBadUserAccessDenied)
+	case err != nil:
+		return statusDataValue(ua.StatusBadIndexRangeInvalid)
+	case rv.DataEncoding != nil && rv.DataEncoding.Name != "" && rv.DataEncoding.Name != "Default Binary":
+		return statusDataValue(ua.StatusBadDataEncodingUnsupported)
+	}
+
+	if onRead != nil {
+		value = onRead(ctx, sess)
+	}
+	if value == nil {
+		return statusDataValue(ua.StatusBadWaitingForInitialData)
+	}
+	dv := *value
+	if indexRange != nil {
+		if dv.Value, err = indexRange.Slice(dv.Value); err != nil {
+			return statusDataValue(indexRangeStatus(err))
+		}
+	}
+	return &dv
+}
+
+// Write implements NodeManager.
+//
+// Values can only be written to variables which have the CurrentWrite
+// bit set in both the AccessLevel and the UserAccessLevel attribute.
+// Otherwise, the status is StatusBadUserAccessDenied.
+//
+// A value with an index range replaces the elements of the current
+// value, which is returned by OnRead if it is set, and OnWrite is called
+// with the resulting value.
+func (m *MemoryNodeManager) Write(ctx context.Context, sess *ServerSession, wv *ua.WriteValue) ua.StatusCode {
+	m.mu.Lock()
+	n, ok := m.nodes[wv.NodeID.String()]
+	if !ok {
+		m.mu.Unlock()
+		return ua.StatusBadNodeIDUnknown
+	}
+
+	v, isVariable := n.(*VariableNode)
+	if !isVariable || wv.AttributeID != ua.AttributeIDValue {
+		defer m.mu.Unlock()
+		if _, status := nodeAttribute(n, wv.AttributeID); status != ua.StatusOK {
+			return status
+		}
+		if wv.IndexRange != "" {
+			return ua.StatusBadIndexRangeInvalid
+		}
+		return setNodeAttribute(n, wv.AttributeID, wv.Value.Value)
+	}
+
+	if v.AccessLevel&v.UserAccessLevel&ua.AccessLevelTypeCurrentWrite == 0 {
+		m.mu.Unlock()
+		return ua.StatusBadUserAccessDenied
+	}
+	value := wv.Value
+	if wv.IndexRange != "" {
+		indexRange, err := ua.ParseNumericRange(wv.IndexRange)
+		if err != nil {
+			m.mu.Unlock()
+			return ua.StatusBadIndexRangeInvalid
+		}
+		current := v.Value
+		if onRead := v.OnRead; onRead != nil {
+			// OnRead is called without the lock like in Read
+			m.mu.Unlock()
+			current = onRead(ctx, sess)
+			m.mu.Lock()
+		}
+		var cur *ua.Variant
+		if current != nil {
+			cur = current.Value
+		}
+		val, err := indexRange.Replace(cur, wv.Value.Value)
+		if err != nil {
+			m.mu.Unlock()
+			return indexRangeStatus(err)
+		}
+		dv := *wv.Value
+		dv.Value = val
+		value = &dv
+	}
+	if status := checkValueType(value.Value, v.DataType, v.ValueRank); status != ua.StatusOK {
+		m.mu.Unlock()
+		return status
+	}
+
+	if onWrite := v.OnWrite; onWrite != nil {
+		m.mu.Unlock()
+		return onWrite(ctx, sess, value)
+	}
+	v.Value = stampDataValue(value, time.Now())
+	m.mu.Unlock()
+	return ua.StatusOK
+}
+
+// References implements NodeManager.
+func (m *MemoryNodeManager) References(ctx context.Context, sess *ServerSession, nodeID *ua.NodeID) ([]*Reference, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n, ok := m.nodes[nodeID.String()]
+	if !ok {
+		return nil, ua.StatusBadNodeIDUnknown
+	}
+	return append([]*Reference{}, n.Base().References...), nil
+}
+
+// indexRangeStatus returns the status code of an error which was returned
+// when an index range was applied to a value.
+func indexRangeStatus(err error) ua.StatusCode {
+	if status, ok := err.(ua.StatusCode); ok {
+		return status
+	}
+	return ua.StatusBadIndexRangeInvalid
+}
+
+// stampDataValue returns a copy of the data value with the server
+// timestamp and, if it is missing, the source timestamp set to now.
+func stampDataValue(v *ua.DataValue, now time.Time) *ua.DataValue {
+	dv := *v
+	if dv.SourceTimestamp.IsZero() {
+		dv.SourceTimestamp = now
+	}
+	dv.ServerTimestamp = now
+	dv.UpdateMask()
+	return &dv
+}
@@ -0,0 +1,82 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"time"
+
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// checkOperations returns an error if a service request contains no
+// operations or more operations than the limit.
+func checkOperations(n int, limit uint32) error {
+	if n == 0 {
+		return ua.StatusBadNothingToDo
+	}
+	if limit > 0 && uint32(n) > limit {
+		return ua.StatusBadTooManyOperations
+	}
+	return nil
+}
+
+// handleRead implements the Read service.
+//
+// Specification: Part 4, 5.10.2
+func (s *Server) handleRead(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.ReadRequest)
+	if err := checkOperations(len(r.NodesToRead), s.cfg.operationLimits.MaxNodesPerRead); err != nil {
+		return nil, err
+	}
+	if r.MaxAge < 0 {
+		return nil, ua.StatusBadMaxAgeInvalid
+	}
+	if r.TimestampsToReturn >= ua.TimestampsToReturnInvalid {
+		return nil, ua.StatusBadTimestampsToReturnInvalid
+	}
+
+	now := time.Now()
+	results := make([]*ua.DataValue, len(r.NodesToRead))
+	for i, rv := range r.NodesToRead {
+		dv := s.as.Read(ctx, sess, rv)
+		results[i] = returnTimestamps(dv, rv.AttributeID, r.TimestampsToReturn, now)
+	}
+	return &ua.ReadResponse{Results: results}, nil
+}
+
+// returnTimestamps returns a copy of the data value with the timestamps
+// the client requested. Only the Value attribute has a source timestamp.
+func returnTimestamps(v *ua.DataValue, attr ua.AttributeID, ts ua.TimestampsToReturn, now time.Time) *ua.DataValue {
+	dv := *v
+	if attr != ua.AttributeIDValue || (ts != ua.TimestampsToReturnSource && ts != ua.TimestampsToReturnBoth) {
+		dv.SourceTimestamp, dv.SourcePicoseconds = time.Time{}, 0
+	}
+	if ts == ua.TimestampsToReturnServer || ts == ua.TimestampsToReturnBoth {
+		if dv.ServerTimestamp.IsZero() {
+			dv.ServerTimestamp = now
+		}
+	} else {
+		dv.ServerTimestamp, dv.ServerPicoseconds = time.Time{}, 0
+	}
+	dv.UpdateMask()
+	return &dv
+}
+
+// handleWrite implements the Write service.
+//
+// Specification: Part 4, 5.10.4
+func (s *Server) handleWrite(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.WriteRequest)
+	if err := checkOperations(len(r.NodesToWrite), s.cfg.operationLimits.MaxNodesPerWrite); err != nil {
+		return nil, err
+	}
+
+	results := make([]ua.StatusCode, len(r.NodesToWrite))
+	for i, wv := range r.NodesToWrite {
+		results[i] = s.as.Write(ctx, sess, wv)
+	}
+	return &ua.WriteResponse{Results: results}, nil
+}
@@ -0,0 +1,330 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"log"
+	"time"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uacp"
+	"github.com/imatic-tech/opcua/uasc"
+)
+
+// serverSecurity is a combination of security policy and security mode
+// the server accepts for secure channels.
+type serverSecurity struct {
+	policyURI string
+	mode      ua.MessageSecurityMode
+}
+
+// ServerConfig contains all server config options.
+type ServerConfig struct {
+	applicationURI  string
+	productURI      string
+	applicationName string
+
+	certificate []byte
+	privateKey  *rsa.PrivateKey
+
+	security   []serverSecurity
+	userTokens []ua.UserTokenType
+
+	// certValidator validates the certificates of the clients when a
+	// secure channel is opened.
+	certValidator uasc.CertificateValidator
+
+	// userValidator validates the identity of the user when a session
+	// is activated.
+	userValidator UserValidator
+
+	ack *uacp.Acknowledge
+
+	// handshakeTimeout is the time in which a client must send the HEL
+	// message after it has connected.
+	handshakeTimeout time.Duration
+
+	maxSessions       int
+	minSessionTimeout time.Duration
+	maxSessionTimeout time.Duration
+
+	// lifetime is the maximum lifetime of a security token in milliseconds.
+	lifetime uint32
+
+	operationLimits OperationLimits
+
+	// nodeSets are loaded into the address space when the server is
+	// created.
+	nodeSets []*nodeset.NodeSet
+
+	// nodeSetErr is the error of a node set file which could not be
+	// read. Start returns it.
+	nodeSetErr error
+}
+
+// OperationLimits contains the maximum number of operations per service
+// call. Zero means that there is no limit.
+//
+// Specification: Part 5, 6.3.11
+type OperationLimits struct {
+	MaxNodesPerRead                          uint32
+	MaxNodesPerHistoryReadData               uint32
+	MaxNodesPerHistoryReadEvents             uint32
+	MaxNodesPerWrite                         uint32
+	MaxNodesPerHistoryUpdateData             uint32
+	MaxNodesPerHistoryUpdateEvents           uint32
+	MaxNodesPerMethodCall                    uint32
+	MaxNodesPerBrowse                        uint32
+	MaxNodesPerRegisterNodes                 uint32
+	MaxNodesPerTranslateBrowsePathsToNodeIDs uint32
+	MaxNodesPerNodeManagement                uint32
+	MaxMonitoredItemsPerCall                 uint32
+}
+
+// DefaultServerConfig returns the default configuration for a server.
+func DefaultServerConfig() *ServerConfig {
+	return &ServerConfig{
+		applicationURI:    "urn:gopcua:server",
+		productURI:        "urn:gopcua",
+		applicationName:   "gopcua - OPC UA implementation in Go",
+		ack:               uacp.DefaultServerACK,
+		handshakeTimeout:  10 * time.Second,
+		maxSessions:       100,
+		minSessionTimeout: 10 * time.Second,
+		maxSessionTimeout: time.Hour,
+		lifetime:          uint32(time.Hour / time.Millisecond),
+	}
+}
+
+// ApplyServerConfig applies the server options to the default server
+// configuration.
+func ApplyServerConfig(opts ...ServerOption) *ServerConfig {
+	cfg := DefaultServerConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if len(cfg.security) == 0 {
+		cfg.security = []serverSecurity{{ua.SecurityPolicyURINone, ua.MessageSecurityModeNone}}
+	}
+	if len(cfg.userTokens) == 0 {
+		cfg.userTokens = []ua.UserTokenType{ua.UserTokenTypeAnonymous}
+	}
+	return cfg
+}
+
+// UserValidator validates the user identity token of a session which is
+// activated. token is one of *ua.AnonymousIdentityToken,
+// *ua.UserNameIdentityToken with the decrypted password or
+// *ua.X509IdentityToken with a verified signature.
+//
+// If the returned error is a ua.StatusCode it is sent to the client.
+// Otherwise, the client receives StatusBadIdentityTokenRejected.
+type UserValidator func(token interface{}) error
+
+// ServerOption is an option function type to modify the server
+// configuration.
+type ServerOption func(*ServerConfig)
+
+// ServerApplicationName sets the application name of the server.
+func ServerApplicationName(s string) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.applicationName = s
+	}
+}
+
+// ServerApplicationURI sets the application uri of the server.
+func ServerApplicationURI(s string) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.applicationURI = s
+	}
+}
+
+// ServerProductURI sets the product uri of the server.
+func ServerProductURI(s string) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.productURI = s
+	}
+}
+
+// ServerCertificate sets the X509 certificate of the server. It also
+// detects and sets the application uri from the URI within the
+// certificate.
+func ServerCertificate(cert []byte) ServerOption {
+	return func(cfg *ServerConfig) {
+		setServerCertificate(cert, cfg)
+	}
+}
+
+// ServerCertificateFile sets the X509 certificate of the server from the
+// PEM or DER encoded file. It also detects and sets the application uri
+// from the URI within the certificate.
+func ServerCertificateFile(filename string) ServerOption {
+	return func(cfg *ServerConfig) {
+		if filename == "" {
+			return
+		}
+
+		cert, err := loadCertificate(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		setServerCertificate(cert, cfg)
+	}
+}
+
+func setServerCertificate(cert []byte, cfg *ServerConfig) {
+	cfg.certificate = cert
+
+	x509cert, err := x509.ParseCertificate(cert)
+	if err != nil {
+		log.Fatalf("Failed to parse certificate: %s", err)
+		return
+	}
+	if len(x509cert.URIs) == 0 {
+		return
+	}
+	if appURI := x509cert.URIs[0].String(); appURI != "" {
+		cfg.applicationURI = appURI
+	}
+}
+
+// ServerPrivateKey sets the RSA private key of the server.
+func ServerPrivateKey(key *rsa.PrivateKey) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.privateKey = key
+	}
+}
+
+// ServerPrivateKeyFile sets the RSA private key of the server from a PEM
+// or DER encoded file.
+func ServerPrivateKeyFile(filename string) ServerOption {
+	return func(cfg *ServerConfig) {
+		if filename == "" {
+			return
+		}
+		key, err := loadPrivateKey(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.privateKey = key
+	}
+}
+
+// ServerNodeSet adds the nodes of the node sets to the address space of
+// the server in the given order. Start returns an error if a node set
+// cannot be loaded. See AddressSpace.LoadNodeSet.
+func ServerNodeSet(s ...*nodeset.NodeSet) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.nodeSets = append(cfg.nodeSets, s...)
+	}
+}
+
+// ServerNodeSetFile adds the nodes of NodeSet2 XML files to the address
+// space of the server in the given order, e.g. Opc.Ua.Di.NodeSet2.xml
+// before Opc.Ua.Machinery.NodeSet2.xml. Start returns an error if a file
+// cannot be read or loaded.
+func ServerNodeSetFile(filename ...string) ServerOption {
+	return func(cfg *ServerConfig) {
+		if cfg.nodeSetErr != nil {
+			return
+		}
+		for _, f := range filename {
+			s, err := nodeset.ReadFile(f)
+			if err != nil {
+				cfg.nodeSetErr = errors.Errorf("cannot read node set %s: %s", f, err)
+				return
+			}
+			cfg.nodeSets = append(cfg.nodeSets, s)
+		}
+	}
+}
+
+// EnableSecurity adds an endpoint with the given security policy and
+// security mode. When no security is enabled the server only accepts
+// connections with security policy None.
+func EnableSecurity(policy string, mode ua.MessageSecurityMode) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.security = append(cfg.security, serverSecurity{ua.FormatSecurityPolicyURI(policy), mode})
+	}
+}
+
+// EnableAuthMode adds a user token type which the server accepts for
+// activating sessions. When no authentication mode is enabled the server
+// only accepts anonymous sessions.
+func EnableAuthMode(t ua.UserTokenType) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.userTokens = append(cfg.userTokens, t)
+	}
+}
+
+// ServerUserValidator sets the function which validates the user
+// identity when a session is activated.
+func ServerUserValidator(fn UserValidator) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.userValidator = fn
+	}
+}
+
+// ServerCertificateValidator sets the validator for the certificates
+// of the clients. Secure channels with an invalid client certificate are
+// rejected.
+func ServerCertificateValidator(v uasc.CertificateValidator) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.certValidator = v
+	}
+}
+
+// ServerACK sets the connection parameters the server acknowledges
+// during the UACP handshake.
+func ServerACK(ack *uacp.Acknowledge) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.ack = ack
+	}
+}
+
+// ServerHandshakeTimeout sets the time in which a client must send the
+// HEL message of the UACP handshake after it has connected. The default
+// is 10 seconds.
+func ServerHandshakeTimeout(d time.Duration) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.handshakeTimeout = d
+	}
+}
+
+// MaxSessions sets the maximum number of concurrent sessions.
+func MaxSessions(n int) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.maxSessions = n
+	}
+}
+
+// ServerSessionTimeout sets the limits for the session timeout a
+// client can request.
+func ServerSessionTimeout(min, max time.Duration) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.minSessionTimeout = min
+		cfg.maxSessionTimeout = max
+	}
+}
+
+// ServerLifetime sets the maximum lifetime of the security tokens for
+// the secure channels.
+func ServerLifetime(d time.Duration) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.lifetime = uint32(d / time.Millisecond)
+	}
+}
+
+// ServerOperationLimits sets the maximum number of operations per service
+// call.
+func ServerOperationLimits(l OperationLimits) ServerOption {
+	return func(cfg *ServerConfig) {
+		cfg.operationLimits = l
+	}
+}
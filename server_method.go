@@ -0,0 +1,111 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// methodCaller is implemented by node managers which can execute
+// methods.
+type methodCaller interface {
+	Call(ctx context.Context, sess *ServerSession, req *ua.CallMethodRequest) *ua.CallMethodResult
+}
+
+// Call calls a method on an object. The object must reference the method
+// with a HasComponent reference.
+//
+// Specification: Part 4, 5.11.2
+func (as *AddressSpace) Call(ctx context.Context, sess *ServerSession, req *ua.CallMethodRequest) *ua.CallMethodResult {
+	if req.ObjectID == nil || req.MethodID == nil {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadNodeIDInvalid}
+	}
+	switch as.NodeClass(ctx, req.ObjectID) {
+	case ua.NodeClassObject, ua.NodeClassObjectType:
+	case ua.NodeClassUnspecified:
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadNodeIDUnknown}
+	default:
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadNodeIDInvalid}
+	}
+	if !as.hasComponent(ctx, sess, req.ObjectID, req.MethodID) {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadMethodInvalid}
+	}
+
+	mc, ok := as.NodeManager(req.MethodID.Namespace()).(methodCaller)
+	if !ok {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadNotImplemented}
+	}
+	res := mc.Call(ctx, sess, req)
+	if res == nil {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadInternalError}
+	}
+	return res
+}
+
+// hasComponent returns true if the source node has a HasComponent
+// reference or a reference of a subtype to the target node.
+func (as *AddressSpace) hasComponent(ctx context.Context, sess *ServerSession, source, target *ua.NodeID) bool {
+	refs, err := as.References(ctx, sess, source)
+	if err != nil {
+		return false
+	}
+	hasComponent := ua.NewNumericNodeID(0, id.HasComponent)
+	for _, r := range refs {
+		if !r.IsForward || as.localNodeID(r.TargetID).String() != target.String() {
+			continue
+		}
+		if as.IsSubtype(ctx, r.ReferenceTypeID, hasComponent) {
+			return true
+		}
+	}
+	return false
+}
+
+// Call implements methodCaller.
+//
+// The method must have the Executable and the UserExecutable attribute
+// set. Otherwise, the status is StatusBadNotExecutable.
+func (m *MemoryNodeManager) Call(ctx context.Context, sess *ServerSession, req *ua.CallMethodRequest) *ua.CallMethodResult {
+	m.mu.RLock()
+	n, ok := m.nodes[req.MethodID.String()].(*MethodNode)
+	m.mu.RUnlock()
+	if !ok {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadMethodInvalid}
+	}
+	if !n.Executable || !n.UserExecutable {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadNotExecutable}
+	}
+	if n.OnCall == nil {
+		return &ua.CallMethodResult{StatusCode: ua.StatusBadNotImplemented}
+	}
+
+	out, status := n.OnCall(ctx, sess, req.ObjectID, req.InputArguments)
+	if status != ua.StatusOK {
+		return &ua.CallMethodResult{StatusCode: status}
+	}
+	if out == nil {
+		out = []*ua.Variant{}
+	}
+	return &ua.CallMethodResult{StatusCode: ua.StatusOK, OutputArguments: out}
+}
+
+// handleCall implements the Call service.
+//
+// Specification: Part 4, 5.11.2
+func (s *Server) handleCall(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.CallRequest)
+	if err := checkOperations(len(r.MethodsToCall), s.cfg.operationLimits.MaxNodesPerMethodCall); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ua.CallMethodResult, len(r.MethodsToCall))
+	for i, mr := range r.MethodsToCall {
+		results[i] = s.as.Call(ctx, sess, mr)
+	}
+	return &ua.CallResponse{Results: results}, nil
+}
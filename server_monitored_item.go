@@ -0,0 +1,585 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// Limits of the monitored items of the server.
+const (
+	minSamplingInterval = 10 * time.Millisecond
+	maxQueueSize        = 1000
+)
+
+// overflowBits are the InfoBits of the status code which signal that
+// values of a monitored item were discarded.
+//
+// Specification: Part 4, 7.34.1
+const overflowBits = 0x480
+
+// serverMonitoredItem samples an attribute of a node and queues the
+// changes for the subscription.
+type serverMonitoredItem struct {
+	id  uint32
+	sub *serverSubscription
+	rv  *ua.ReadValueID
+
+	// The fields below are guarded by the lock of the subscription.
+
+	ts               ua.TimestampsToReturn
+	mode             ua.MonitoringMode
+	clientHandle     uint32
+	samplingInterval time.Duration
+	queueSize        uint32
+	discardOldest    bool
+
+	// trigger and deadband are the parameters of the DataChangeFilter.
+	// A percent deadband is converted to an absolute deadband.
+	trigger  ua.DataChangeTrigger
+	deadband float64
+
+	// last is the last sampled value.
+	last *ua.DataValue
+
+	// queue contains the values which have not been reported.
+	queue []*ua.DataValue
+
+	// links are the ids of the items triggered by this item.
+	links map[uint32]bool
+
+	// stop ends the sampling. It is nil if the item is disabled.
+	stop func()
+}
+
+// setMode changes the monitoring mode and starts or stops the sampling.
+// The lock of the subscription must be held.
+//
+// Specification: Part 4, 5.12.1.3
+func (it *serverMonitoredItem) setMode(mode ua.MonitoringMode) {
+	it.mode = mode
+	if mode == ua.MonitoringModeDisabled {
+		it.stopSampling()
+		it.last = nil
+		it.queue = nil
+		return
+	}
+	if it.stop == nil {
+		it.startSampling()
+	}
+}
+
+// startSampling starts the sampling go routine. The lock of the
+// subscription must be held.
+func (it *serverMonitoredItem) startSampling() {
+	ctx, cancel := context.WithCancel(it.sub.ctx)
+	it.stop = cancel
+	go it.sample(ctx, it.samplingInterval)
+}
+
+// stopSampling stops the sampling go routine. The lock of the
+// subscription must be held.
+func (it *serverMonitoredItem) stopSampling() {
+	if it.stop != nil {
+		it.stop()
+		it.stop = nil
+	}
+}
+
+func (it *serverMonitoredItem) sample(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		// read without holding the lock since OnRead callbacks and
+		// custom node managers may block.
+		sess := it.sub.m.session(it.sub)
+		dv := it.sub.m.srv.as.Read(ctx, sess, it.rv)
+
+		it.sub.mu.Lock()
+		if ctx.Err() == nil {
+			it.enqueue(dv)
+		}
+		it.sub.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// enqueue adds the sampled value to the queue if it differs from the last
+// value. The lock of the subscription must be held.
+//
+// Specification: Part 4, 5.12.1.5
+func (it *serverMonitoredItem) enqueue(dv *ua.DataValue) {
+	if it.last != nil && !it.changed(it.last, dv) {
+		return
+	}
+	it.last = dv
+	v := it.notificationValue(dv)
+
+	switch {
+	case uint32(len(it.queue)) < it.queueSize:
+		it.queue = append(it.queue, v)
+	case it.queueSize == 1:
+		it.queue[0] = v
+	case it.discardOldest:
+		it.queue = append(it.queue[1:], v)
+		it.queue[0] = overflow(it.queue[0])
+	default:
+		it.queue[len(it.queue)-1] = overflow(v)
+	}
+}
+
+// notificationValue returns the value with the requested timestamps.
+func (it *serverMonitoredItem) notificationValue(dv *ua.DataValue) *ua.DataValue {
+	return returnTimestamps(dv, it.rv.AttributeID, it.ts, time.Now())
+}
+
+// overflow returns a copy of the value with the overflow bits set.
+func overflow(v *ua.DataValue) *ua.DataValue {
+	dv := *v
+	dv.Status |= overflowBits
+	dv.UpdateMask()
+	return &dv
+}
+
+// changed returns true if the new value must be reported according to
+// the DataChangeFilter of the item.
+//
+// Specification: Part 4, 7.17.2
+func (it *serverMonitoredItem) changed(old, new *ua.DataValue) bool {
+	if old.Status != new.Status {
+		return true
+	}
+	if it.trigger == ua.DataChangeTriggerStatus {
+		return false
+	}
+	if !valueEqual(old.Value, new.Value, it.deadband) {
+		return true
+	}
+	return it.trigger == ua.DataChangeTriggerStatusValueTimestamp && !old.SourceTimestamp.Equal(new.SourceTimestamp)
+}
+
+// valueEqual returns true if the values are equal. Numeric values and
+// arrays of numeric values are equal if they differ by at most deadband.
+func valueEqual(a, b *ua.Variant, deadband float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if deadband <= 0 {
+		return reflect.DeepEqual(a.Value(), b.Value())
+	}
+
+	va, vb := reflect.ValueOf(a.Value()), reflect.ValueOf(b.Value())
+	if va.Kind() != reflect.Slice {
+		fa, oka := toFloat(va)
+		fb, okb := toFloat(vb)
+		if !oka || !okb {
+			return reflect.DeepEqual(a.Value(), b.Value())
+		}
+		return math.Abs(fa-fb) <= deadband
+	}
+	if vb.Kind() != reflect.Slice || va.Len() != vb.Len() {
+		return false
+	}
+	for i := 0; i < va.Len(); i++ {
+		fa, oka := toFloat(va.Index(i))
+		fb, okb := toFloat(vb.Index(i))
+		if !oka || !okb || math.Abs(fa-fb) > deadband {
+			return false
+		}
+	}
+	return true
+}
+
+func toFloat(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// revisedSamplingInterval returns the sampling interval the server uses
+// for the requested interval in milliseconds. A negative interval selects
+// the publishing interval of the subscription.
+func revisedSamplingInterval(ms float64, publishingInterval time.Duration) time.Duration {
+	if ms < 0 || ms != ms {
+		return publishingInterval
+	}
+	d := time.Duration(ms * float64(time.Millisecond))
+	if d < minSamplingInterval {
+		return minSamplingInterval
+	}
+	if d > maxPublishingInterval {
+		return maxPublishingInterval
+	}
+	return d
+}
+
+func revisedQueueSize(n uint32) uint32 {
+	if n == 0 {
+		return 1
+	}
+	if n > maxQueueSize {
+		return maxQueueSize
+	}
+	return n
+}
+
+// dataChangeFilter validates the filter of a monitored item and returns
+// the trigger and the absolute deadband.
+//
+// Specification: Part 4, 7.17.2
+func (s *Server) dataChangeFilter(ctx context.Context, sess *ServerSession, rv *ua.ReadValueID, filter *ua.ExtensionObject) (ua.DataChangeTrigger, float64, ua.StatusCode) {
+	if filter == nil || filter.Value == nil {
+		return ua.DataChangeTriggerStatusValue, 0, ua.StatusOK
+	}
+	f, ok := filter.Value.(*ua.DataChangeFilter)
+	if !ok {
+		return 0, 0, ua.StatusBadMonitoredItemFilterUnsupported
+	}
+	if rv.AttributeID != ua.AttributeIDValue {
+		return 0, 0, ua.StatusBadFilterNotAllowed
+	}
+	if f.Trigger > ua.DataChangeTriggerStatusValueTimestamp {
+		return 0, 0, ua.StatusBadMonitoredItemFilterInvalid
+	}
+
+	switch ua.DeadbandType(f.DeadbandType) {
+	case ua.DeadbandTypeNone:
+		return f.Trigger, 0, ua.StatusOK
+	case ua.DeadbandTypeAbsolute, ua.DeadbandTypePercent:
+	default:
+		return 0, 0, ua.StatusBadDeadbandFilterInvalid
+	}
+	if f.DeadbandValue < 0 {
+		return 0, 0, ua.StatusBadDeadbandFilterInvalid
+	}
+
+	// deadbands are only defined for numeric values
+	dt := s.as.attribute(ctx, sess, rv.NodeID, ua.AttributeIDDataType)
+	if dt == nil || dt.NodeID() == nil || !s.as.IsSubtype(ctx, dt.NodeID(), ua.NewNumericNodeID(0, id.Number)) {
+		return 0, 0, ua.StatusBadFilterNotAllowed
+	}
+	if ua.DeadbandType(f.DeadbandType) == ua.DeadbandTypeAbsolute {
+		return f.Trigger, f.DeadbandValue, ua.StatusOK
+	}
+
+	if f.DeadbandValue > 100 {
+		return 0, 0, ua.StatusBadDeadbandFilterInvalid
+	}
+	r := s.euRange(ctx, sess, rv.NodeID)
+	if r == nil {
+		return 0, 0, ua.StatusBadDeadbandFilterInvalid
+	}
+	return f.Trigger, f.DeadbandValue / 100 * (r.High - r.Low), ua.StatusOK
+}
+
+// euRange returns the value of the EURange property of an analog item or
+// nil if the node has no such property.
+func (s *Server) euRange(ctx context.Context, sess *ServerSession, nodeID *ua.NodeID) *ua.Range {
+	refs, err := s.as.References(ctx, sess, nodeID)
+	if err != nil {
+		return nil
+	}
+	for _, ref := range refs {
+		if !ref.IsForward || !isNS0(ref.ReferenceTypeID, id.HasProperty) {
+			continue
+		}
+		target := s.as.localNodeID(ref.TargetID)
+		if target == nil {
+			continue
+		}
+		bn := s.as.attribute(ctx, sess, target, ua.AttributeIDBrowseName)
+		if bn == nil {
+			continue
+		}
+		if qn, ok := bn.Value().(*ua.QualifiedName); !ok || qn.NamespaceIndex != 0 || qn.Name != "EURange" {
+			continue
+		}
+		v := s.as.attribute(ctx, sess, target, ua.AttributeIDValue)
+		if v == nil {
+			return nil
+		}
+		if eo, ok := v.Value().(*ua.ExtensionObject); ok {
+			if r, ok := eo.Value.(*ua.Range); ok {
+				return r
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// monitoredItemParameters validates the filter and returns the revised
+// parameters of a monitored item.
+func (s *Server) monitoredItemParameters(ctx context.Context, sess *ServerSession, sub *serverSubscription, rv *ua.ReadValueID, p *ua.MonitoringParameters) (*serverMonitoredItem, ua.StatusCode) {
+	if p == nil {
+		p = &ua.MonitoringParameters{SamplingInterval: -1}
+	}
+	trigger, deadband, status := s.dataChangeFilter(ctx, sess, rv, p.Filter)
+	if status != ua.StatusOK {
+		return nil, status
+	}
+
+	sub.mu.Lock()
+	interval := sub.interval
+	sub.mu.Unlock()
+
+	return &serverMonitoredItem{
+		clientHandle:     p.ClientHandle,
+		samplingInterval: revisedSamplingInterval(p.SamplingInterval, interval),
+		queueSize:        revisedQueueSize(p.QueueSize),
+		discardOldest:    p.DiscardOldest,
+		trigger:          trigger,
+		deadband:         deadband,
+	}, ua.StatusOK
+}
+
+func (s *Server) handleCreateMonitoredItems(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.CreateMonitoredItemsRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOperations(len(r.ItemsToCreate), s.cfg.operationLimits.MaxMonitoredItemsPerCall); err != nil {
+		return nil, err
+	}
+	if r.TimestampsToReturn >= ua.TimestampsToReturnInvalid {
+		return nil, ua.StatusBadTimestampsToReturnInvalid
+	}
+
+	results := make([]*ua.MonitoredItemCreateResult, len(r.ItemsToCreate))
+	for i, item := range r.ItemsToCreate {
+		results[i] = s.createMonitoredItem(ctx, sess, sub, item, r.TimestampsToReturn)
+	}
+	return &ua.CreateMonitoredItemsResponse{Results: results}, nil
+}
+
+func (s *Server) createMonitoredItem(ctx context.Context, sess *ServerSession, sub *serverSubscription, item *ua.MonitoredItemCreateRequest, ts ua.TimestampsToReturn) *ua.MonitoredItemCreateResult {
+	fail := func(status ua.StatusCode) *ua.MonitoredItemCreateResult {
+		return &ua.MonitoredItemCreateResult{StatusCode: status}
+	}
+
+	rv := item.ItemToMonitor
+	if rv == nil || rv.NodeID == nil {
+		return fail(ua.StatusBadNodeIDInvalid)
+	}
+	if item.MonitoringMode > ua.MonitoringModeReporting {
+		return fail(ua.StatusBadMonitoringModeInvalid)
+	}
+	if rv.AttributeID == ua.AttributeIDEventNotifier {
+		// events are not supported by the address space
+		return fail(ua.StatusBadMonitoredItemFilterUnsupported)
+	}
+
+	// check that the node and the attribute exist
+	dv := s.as.Read(ctx, sess, &ua.ReadValueID{NodeID: rv.NodeID, AttributeID: rv.AttributeID, DataEncoding: &ua.QualifiedName{}})
+	switch dv.Status {
+	case ua.StatusBadNodeIDUnknown, ua.StatusBadNodeIDInvalid, ua.StatusBadAttributeIDInvalid:
+		return fail(dv.Status)
+	}
+
+	it, status := s.monitoredItemParameters(ctx, sess, sub, rv, item.RequestedParameters)
+	if status != ua.StatusOK {
+		return fail(status)
+	}
+	it.sub = sub
+	it.rv = rv
+	it.ts = ts
+	it.links = map[uint32]bool{}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	sub.nextItemID++
+	it.id = sub.nextItemID
+	sub.items[it.id] = it
+	it.setMode(item.MonitoringMode)
+
+	debug.Printf("server: subscription %d: created monitored item %d for %s", sub.id, it.id, rv.NodeID)
+
+	return &ua.MonitoredItemCreateResult{
+		StatusCode:              ua.StatusOK,
+		MonitoredItemID:         it.id,
+		RevisedSamplingInterval: durationToMillis(it.samplingInterval),
+		RevisedQueueSize:        it.queueSize,
+	}
+}
+
+func (s *Server) handleModifyMonitoredItems(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.ModifyMonitoredItemsRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOperations(len(r.ItemsToModify), s.cfg.operationLimits.MaxMonitoredItemsPerCall); err != nil {
+		return nil, err
+	}
+	if r.TimestampsToReturn >= ua.TimestampsToReturnInvalid {
+		return nil, ua.StatusBadTimestampsToReturnInvalid
+	}
+
+	results := make([]*ua.MonitoredItemModifyResult, len(r.ItemsToModify))
+	for i, item := range r.ItemsToModify {
+		results[i] = s.modifyMonitoredItem(ctx, sess, sub, item, r.TimestampsToReturn)
+	}
+	return &ua.ModifyMonitoredItemsResponse{Results: results}, nil
+}
+
+func (s *Server) modifyMonitoredItem(ctx context.Context, sess *ServerSession, sub *serverSubscription, item *ua.MonitoredItemModifyRequest, ts ua.TimestampsToReturn) *ua.MonitoredItemModifyResult {
+	sub.mu.Lock()
+	it := sub.items[item.MonitoredItemID]
+	sub.mu.Unlock()
+	if it == nil {
+		return &ua.MonitoredItemModifyResult{StatusCode: ua.StatusBadMonitoredItemIDInvalid}
+	}
+
+	p, status := s.monitoredItemParameters(ctx, sess, sub, it.rv, item.RequestedParameters)
+	if status != ua.StatusOK {
+		return &ua.MonitoredItemModifyResult{StatusCode: status}
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.items[it.id] != it {
+		return &ua.MonitoredItemModifyResult{StatusCode: ua.StatusBadMonitoredItemIDInvalid}
+	}
+
+	restart := it.samplingInterval != p.samplingInterval
+	it.ts = ts
+	it.clientHandle = p.clientHandle
+	it.samplingInterval = p.samplingInterval
+	it.queueSize = p.queueSize
+	it.discardOldest = p.discardOldest
+	it.trigger = p.trigger
+	it.deadband = p.deadband
+	if n := uint32(len(it.queue)); n > it.queueSize {
+		if it.discardOldest {
+			it.queue = it.queue[n-it.queueSize:]
+		} else {
+			it.queue = it.queue[:it.queueSize]
+		}
+	}
+	if restart && it.stop != nil {
+		it.stopSampling()
+		it.startSampling()
+	}
+
+	return &ua.MonitoredItemModifyResult{
+		StatusCode:              ua.StatusOK,
+		RevisedSamplingInterval: durationToMillis(it.samplingInterval),
+		RevisedQueueSize:        it.queueSize,
+	}
+}
+
+func (s *Server) handleSetMonitoringMode(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.SetMonitoringModeRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOperations(len(r.MonitoredItemIDs), s.cfg.operationLimits.MaxMonitoredItemsPerCall); err != nil {
+		return nil, err
+	}
+	if r.MonitoringMode > ua.MonitoringModeReporting {
+		return nil, ua.StatusBadMonitoringModeInvalid
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	results := make([]ua.StatusCode, len(r.MonitoredItemIDs))
+	for i, itemID := range r.MonitoredItemIDs {
+		it := sub.items[itemID]
+		if it == nil {
+			results[i] = ua.StatusBadMonitoredItemIDInvalid
+			continue
+		}
+		it.setMode(r.MonitoringMode)
+	}
+	return &ua.SetMonitoringModeResponse{Results: results}, nil
+}
+
+func (s *Server) handleSetTriggering(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.SetTriggeringRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.LinksToAdd) == 0 && len(r.LinksToRemove) == 0 {
+		return nil, ua.StatusBadNothingToDo
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	it := sub.items[r.TriggeringItemID]
+	if it == nil {
+		return nil, ua.StatusBadMonitoredItemIDInvalid
+	}
+
+	res := &ua.SetTriggeringResponse{
+		AddResults:    make([]ua.StatusCode, len(r.LinksToAdd)),
+		RemoveResults: make([]ua.StatusCode, len(r.LinksToRemove)),
+	}
+	for i, itemID := range r.LinksToRemove {
+		if !it.links[itemID] {
+			res.RemoveResults[i] = ua.StatusBadMonitoredItemIDInvalid
+			continue
+		}
+		delete(it.links, itemID)
+	}
+	for i, itemID := range r.LinksToAdd {
+		if sub.items[itemID] == nil {
+			res.AddResults[i] = ua.StatusBadMonitoredItemIDInvalid
+			continue
+		}
+		it.links[itemID] = true
+	}
+	return res, nil
+}
+
+func (s *Server) handleDeleteMonitoredItems(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.DeleteMonitoredItemsRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOperations(len(r.MonitoredItemIDs), s.cfg.operationLimits.MaxMonitoredItemsPerCall); err != nil {
+		return nil, err
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	results := make([]ua.StatusCode, len(r.MonitoredItemIDs))
+	for i, itemID := range r.MonitoredItemIDs {
+		it := sub.items[itemID]
+		if it == nil {
+			results[i] = ua.StatusBadMonitoredItemIDInvalid
+			continue
+		}
+		it.stopSampling()
+		delete(sub.items, itemID)
+		for _, other := range sub.items {
+			delete(other.links, itemID)
+		}
+	}
+	return &ua.DeleteMonitoredItemsResponse{Results: results}, nil
+}
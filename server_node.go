@@ -0,0 +1,379 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// ServerNode is a node in the address space of a server. It is one of
+// *ObjectNode, *VariableNode, *MethodNode, *ObjectTypeNode,
+// *VariableTypeNode, *ReferenceTypeNode, *DataTypeNode or *ViewNode.
+type ServerNode interface {
+	// Base returns the attributes and references which all node classes
+	// have in common.
+	Base() *BaseNode
+
+	// NodeClass returns the node class of the node.
+	NodeClass() ua.NodeClass
+}
+
+// Reference is a reference from a node to a target node.
+//
+// Specification: Part 3, 4.3.4
+type Reference struct {
+	ReferenceTypeID *ua.NodeID
+	IsForward       bool
+	TargetID        *ua.ExpandedNodeID
+}
+
+// NewReference returns a reference of the given type to the target node.
+func NewReference(refType *ua.NodeID, isForward bool, target *ua.NodeID) *Reference {
+	return &Reference{
+		ReferenceTypeID: refType,
+		IsForward:       isForward,
+		TargetID:        &ua.ExpandedNodeID{NodeID: target},
+	}
+}
+
+// BaseNode contains the attributes and references of the base node
+// class.
+//
+// Specification: Part 3, 5.2
+type BaseNode struct {
+	NodeID        *ua.NodeID
+	BrowseName    *ua.QualifiedName
+	DisplayName   *ua.LocalizedText
+	Description   *ua.LocalizedText
+	WriteMask     uint32
+	UserWriteMask uint32
+	References    []*Reference
+}
+
+// Base returns the node.
+func (n *BaseNode) Base() *BaseNode {
+	return n
+}
+
+// ObjectNode is a node of the Object node class.
+//
+// Specification: Part 3, 5.5.1
+type ObjectNode struct {
+	BaseNode
+	EventNotifier uint8
+}
+
+// NodeClass returns ua.NodeClassObject.
+func (n *ObjectNode) NodeClass() ua.NodeClass { return ua.NodeClassObject }
+
+// VariableNode is a node of the Variable node class.
+//
+// Specification: Part 3, 5.6.2
+type VariableNode struct {
+	BaseNode
+	Value                   *ua.DataValue
+	DataType                *ua.NodeID
+	ValueRank               int32
+	ArrayDimensions         []uint32
+	AccessLevel             ua.AccessLevelType
+	UserAccessLevel         ua.AccessLevelType
+	MinimumSamplingInterval float64
+	Historizing             bool
+
+	// OnRead returns the current value of the variable, e.g. from a
+	// device. If OnRead is nil the Value is returned.
+	OnRead func(ctx context.Context, sess *ServerSession) *ua.DataValue
+
+	// OnWrite writes a new value of the variable, e.g. to a device. If
+	// OnWrite is nil the value is stored in Value.
+	OnWrite func(ctx context.Context, sess *ServerSession, v *ua.DataValue) ua.StatusCode
+}
+
+// NodeClass returns ua.NodeClassVariable.
+func (n *VariableNode) NodeClass() ua.NodeClass { return ua.NodeClassVariable }
+
+// MethodNode is a node of the Method node class.
+//
+// Specification: Part 3, 5.7
+type MethodNode struct {
+	BaseNode
+	Executable     bool
+	UserExecutable bool
+
+	// OnCall is called by the Call service with the object the method
+	// is called on and the input arguments. It returns the output
+	// arguments or a bad status code, e.g. StatusBadInvalidArgument.
+	// Methods without OnCall are not implemented.
+	OnCall func(ctx context.Context, sess *ServerSession, objectID *ua.NodeID, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode)
+}
+
+// NodeClass returns ua.NodeClassMethod.
+func (n *MethodNode) NodeClass() ua.NodeClass { return ua.NodeClassMethod }
+
+// ObjectTypeNode is a node of the ObjectType node class.
+//
+// Specification: Part 3, 5.5.2
+type ObjectTypeNode struct {
+	BaseNode
+	IsAbstract bool
+}
+
+// NodeClass returns ua.NodeClassObjectType.
+func (n *ObjectTypeNode) NodeClass() ua.NodeClass { return ua.NodeClassObjectType }
+
+// VariableTypeNode is a node of the VariableType node class.
+//
+// Specification: Part 3, 5.6.5
+type VariableTypeNode struct {
+	BaseNode
+	Value           *ua.DataValue
+	DataType        *ua.NodeID
+	ValueRank       int32
+	ArrayDimensions []uint32
+	IsAbstract      bool
+}
+
+// NodeClass returns ua.NodeClassVariableType.
+func (n *VariableTypeNode) NodeClass() ua.NodeClass { return ua.NodeClassVariableType }
+
+// ReferenceTypeNode is a node of the ReferenceType node class.
+//
+// Specification: Part 3, 5.3
+type ReferenceTypeNode struct {
+	BaseNode
+	IsAbstract  bool
+	Symmetric   bool
+	InverseName *ua.LocalizedText
+}
+
+// NodeClass returns ua.NodeClassReferenceType.
+func (n *ReferenceTypeNode) NodeClass() ua.NodeClass { return ua.NodeClassReferenceType }
+
+// DataTypeNode is a node of the DataType node class.
+//
+// Specification: Part 3, 5.8.3
+type DataTypeNode struct {
+	BaseNode
+	IsAbstract         bool
+	DataTypeDefinition *ua.ExtensionObject
+}
+
+// NodeClass returns ua.NodeClassDataType.
+func (n *DataTypeNode) NodeClass() ua.NodeClass { return ua.NodeClassDataType }
+
+// ViewNode is a node of the View node class.
+//
+// Specification: Part 3, 5.4
+type ViewNode struct {
+	BaseNode
+	ContainsNoLoops bool
+	EventNotifier   uint8
+}
+
+// NodeClass returns ua.NodeClassView.
+func (n *ViewNode) NodeClass() ua.NodeClass { return ua.NodeClassView }
+
+// Bits of the WriteMask attribute.
+//
+// Specification: Part 3, 8.60
+const (
+	writeMaskDescription = 1 << 5
+	writeMaskDisplayName = 1 << 6
+)
+
+// nodeAttribute returns the value of an attribute of the node. The Value
+// attribute of variables is handled by the node manager.
+func nodeAttribute(n ServerNode, attr ua.AttributeID) (*ua.Variant, ua.StatusCode) {
+	b := n.Base()
+	switch attr {
+	case ua.AttributeIDNodeID:
+		return ua.MustVariant(b.NodeID), ua.StatusOK
+	case ua.AttributeIDNodeClass:
+		return ua.MustVariant(int32(n.NodeClass())), ua.StatusOK
+	case ua.AttributeIDBrowseName:
+		return ua.MustVariant(b.BrowseName), ua.StatusOK
+	case ua.AttributeIDDisplayName:
+		return ua.MustVariant(b.DisplayName), ua.StatusOK
+	case ua.AttributeIDDescription:
+		if b.Description == nil {
+			return ua.MustVariant(&ua.LocalizedText{}), ua.StatusOK
+		}
+		return ua.MustVariant(b.Description), ua.StatusOK
+	case ua.AttributeIDWriteMask:
+		return ua.MustVariant(b.WriteMask), ua.StatusOK
+	case ua.AttributeIDUserWriteMask:
+		return ua.MustVariant(b.UserWriteMask), ua.StatusOK
+	}
+
+	switch n := n.(type) {
+	case *ObjectNode:
+		if attr == ua.AttributeIDEventNotifier {
+			return ua.MustVariant(n.EventNotifier), ua.StatusOK
+		}
+
+	case *VariableNode:
+		switch attr {
+		case ua.AttributeIDDataType:
+			return ua.MustVariant(n.DataType), ua.StatusOK
+		case ua.AttributeIDValueRank:
+			return ua.MustVariant(n.ValueRank), ua.StatusOK
+		case ua.AttributeIDArrayDimensions:
+			return ua.MustVariant(arrayDimensions(n.ArrayDimensions)), ua.StatusOK
+		case ua.AttributeIDAccessLevel:
+			return ua.MustVariant(uint8(n.AccessLevel)), ua.StatusOK
+		case ua.AttributeIDUserAccessLevel:
+			return ua.MustVariant(uint8(n.UserAccessLevel)), ua.StatusOK
+		case ua.AttributeIDMinimumSamplingInterval:
+			return ua.MustVariant(n.MinimumSamplingInterval), ua.StatusOK
+		case ua.AttributeIDHistorizing:
+			return ua.MustVariant(n.Historizing), ua.StatusOK
+		}
+
+	case *MethodNode:
+		switch attr {
+		case ua.AttributeIDExecutable:
+			return ua.MustVariant(n.Executable), ua.StatusOK
+		case ua.AttributeIDUserExecutable:
+			return ua.MustVariant(n.UserExecutable), ua.StatusOK
+		}
+
+	case *ObjectTypeNode:
+		if attr == ua.AttributeIDIsAbstract {
+			return ua.MustVariant(n.IsAbstract), ua.StatusOK
+		}
+
+	case *VariableTypeNode:
+		switch attr {
+		case ua.AttributeIDValue:
+			if n.Value == nil || n.Value.Value == nil {
+				return nil, ua.StatusBadAttributeIDInvalid
+			}
+			return n.Value.Value, ua.StatusOK
+		case ua.AttributeIDDataType:
+			return ua.MustVariant(n.DataType), ua.StatusOK
+		case ua.AttributeIDValueRank:
+			return ua.MustVariant(n.ValueRank), ua.StatusOK
+		case ua.AttributeIDArrayDimensions:
+			return ua.MustVariant(arrayDimensions(n.ArrayDimensions)), ua.StatusOK
+		case ua.AttributeIDIsAbstract:
+			return ua.MustVariant(n.IsAbstract), ua.StatusOK
+		}
+
+	case *ReferenceTypeNode:
+		switch attr {
+		case ua.AttributeIDIsAbstract:
+			return ua.MustVariant(n.IsAbstract), ua.StatusOK
+		case ua.AttributeIDSymmetric:
+			return ua.MustVariant(n.Symmetric), ua.StatusOK
+		case ua.AttributeIDInverseName:
+			if n.InverseName == nil {
+				return ua.MustVariant(&ua.LocalizedText{}), ua.StatusOK
+			}
+			return ua.MustVariant(n.InverseName), ua.StatusOK
+		}
+
+	case *DataTypeNode:
+		switch attr {
+		case ua.AttributeIDIsAbstract:
+			return ua.MustVariant(n.IsAbstract), ua.StatusOK
+		case ua.AttributeIDDataTypeDefinition:
+			if n.DataTypeDefinition == nil {
+				return nil, ua.StatusBadAttributeIDInvalid
+			}
+			return ua.MustVariant(n.DataTypeDefinition), ua.StatusOK
+		}
+
+	case *ViewNode:
+		switch attr {
+		case ua.AttributeIDContainsNoLoops:
+			return ua.MustVariant(n.ContainsNoLoops), ua.StatusOK
+		case ua.AttributeIDEventNotifier:
+			return ua.MustVariant(n.EventNotifier), ua.StatusOK
+		}
+	}
+
+	return nil, ua.StatusBadAttributeIDInvalid
+}
+
+// arrayDimensions returns a non-nil slice since an empty array variant
+// cannot be created from a nil slice.
+func arrayDimensions(dims []uint32) []uint32 {
+	if dims == nil {
+		return []uint32{}
+	}
+	return dims
+}
+
+// setNodeAttribute writes an attribute of the node other than the Value
+// attribute of a variable.
+func setNodeAttribute(n ServerNode, attr ua.AttributeID, v *ua.Variant) ua.StatusCode {
+	b := n.Base()
+
+	var mask uint32
+	switch attr {
+	case ua.AttributeIDDisplayName:
+		mask = writeMaskDisplayName
+	case ua.AttributeIDDescription:
+		mask = writeMaskDescription
+	default:
+		return ua.StatusBadNotWritable
+	}
+	if b.WriteMask&mask == 0 {
+		return ua.StatusBadNotWritable
+	}
+	if b.UserWriteMask&mask == 0 {
+		return ua.StatusBadUserAccessDenied
+	}
+
+	if v == nil {
+		return ua.StatusBadTypeMismatch
+	}
+	lt, ok := v.Value().(*ua.LocalizedText)
+	if !ok || lt == nil {
+		return ua.StatusBadTypeMismatch
+	}
+	if attr == ua.AttributeIDDisplayName {
+		b.DisplayName = lt
+	} else {
+		b.Description = lt
+	}
+	return ua.StatusOK
+}
+
+// typeDefinition returns the target of the HasTypeDefinition reference
+// of the node or nil.
+func typeDefinition(refs []*Reference) *ua.ExpandedNodeID {
+	for _, r := range refs {
+		if r.IsForward && isNS0(r.ReferenceTypeID, id.HasTypeDefinition) {
+			return r.TargetID
+		}
+	}
+	return nil
+}
+
+// checkValueType returns StatusBadTypeMismatch if the value cannot be
+// written to a variable with the given data type and value rank. Only
+// the built-in data types are checked.
+func checkValueType(v *ua.Variant, dataType *ua.NodeID, valueRank int32) ua.StatusCode {
+	if v == nil {
+		return ua.StatusOK
+	}
+	if dataType != nil && dataType.Namespace() == 0 {
+		if t := dataType.IntID(); t >= 1 && t <= 25 && t != id.BaseDataType && ua.TypeID(t) != v.Type() {
+			return ua.StatusBadTypeMismatch
+		}
+	}
+	isArray := v.Has(ua.VariantArrayValues)
+	switch {
+	case valueRank == -1 && isArray:
+		return ua.StatusBadTypeMismatch
+	case valueRank >= 1 && !isArray:
+		return ua.StatusBadTypeMismatch
+	}
+	return ua.StatusOK
+}
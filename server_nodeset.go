@@ -0,0 +1,438 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// LoadNodeSet adds the nodes of a node set to the address space, e.g.
+// of Opc.Ua.NodeSet2.xml or of a companion specification. The node sets
+// which the node set depends on must be loaded first.
+//
+// The namespaces of the node set are added to the namespace array if
+// they do not exist and the namespace indexes of the node ids, browse
+// names, references and values are remapped to the indexes of the
+// server. Aliases are resolved.
+//
+// Nodes which already exist, like the nodes of namespace 0 which the
+// server provides itself, are not replaced but their references are
+// added.
+//
+// Specification: Part 6, Annex F
+func (as *AddressSpace) LoadNodeSet(s *nodeset.NodeSet) error {
+	l := &nodeSetLoader{as: as, s: s, ns: []uint16{0}}
+	for _, uri := range s.NamespaceURIs {
+		ns, ok := as.NamespaceIndex(uri)
+		if !ok {
+			ns = as.AddNamespace(uri, nil)
+		}
+		l.ns = append(l.ns, ns)
+	}
+	return l.load()
+}
+
+// nodeSetLoader converts the nodes of a node set into server nodes.
+type nodeSetLoader struct {
+	as *AddressSpace
+	s  *nodeset.NodeSet
+
+	// ns maps the namespace indexes of the node set to the namespace
+	// indexes of the server.
+	ns []uint16
+
+	// nodes contains the nodes of the node set by node id.
+	nodes map[string]ServerNode
+
+	// encodings contains the "Default Binary" encodings of the data
+	// types by node id.
+	encodings map[string]*ua.NodeID
+}
+
+func (l *nodeSetLoader) load() error {
+	var nodes []ServerNode
+	var dataTypes []*DataTypeNode
+	var definitions []*nodeset.Definition
+	l.nodes = map[string]ServerNode{}
+	for _, n := range l.s.Nodes() {
+		sn, err := l.node(n)
+		if err != nil {
+			return errors.Errorf("node %s: %s", n.Base().NodeID, err)
+		}
+		nodes = append(nodes, sn)
+		l.nodes[sn.Base().NodeID.String()] = sn
+		if dt, ok := n.(*nodeset.DataType); ok && dt.Definition != nil {
+			dataTypes = append(dataTypes, sn.(*DataTypeNode))
+			definitions = append(definitions, dt.Definition)
+		}
+	}
+
+	l.encodings = map[string]*ua.NodeID{}
+	for _, n := range nodes {
+		b := n.Base()
+		for _, r := range b.References {
+			if !isNS0(r.ReferenceTypeID, id.HasEncoding) {
+				continue
+			}
+			dataType, enc := b.NodeID, r.TargetID.NodeID
+			if !r.IsForward {
+				dataType, enc = enc, dataType
+			}
+			if e, ok := l.nodes[enc.String()]; ok && e.Base().BrowseName.Name == "Default Binary" {
+				l.encodings[dataType.String()] = enc
+			}
+		}
+	}
+
+	// the kind of the data type definitions depends on the supertypes
+	for i, n := range dataTypes {
+		if err := l.dataTypeDefinition(n, definitions[i]); err != nil {
+			return errors.Errorf("node %s: %s", n.NodeID, err)
+		}
+	}
+
+	var added []ServerNode
+	for _, n := range nodes {
+		b := n.Base()
+		switch err := l.as.AddNode(n); {
+		case err == ua.StatusBadNodeIDExists:
+			for _, ref := range b.References {
+				if err := l.as.AddReference(b.NodeID, ref); err != nil {
+					return errors.Errorf("node %s: %s", b.NodeID, err)
+				}
+			}
+		case err != nil:
+			return errors.Errorf("node %s: %s", b.NodeID, err)
+		default:
+			added = append(added, n)
+		}
+	}
+
+	// the inverse references to the nodes which were added later
+	for _, n := range added {
+		b := n.Base()
+		for _, ref := range b.References {
+			l.as.addInverseReference(b.NodeID, ref)
+		}
+	}
+	return nil
+}
+
+// node returns the server node of a node of the node set.
+func (l *nodeSetLoader) node(n interface{ Base() *nodeset.Node }) (ServerNode, error) {
+	base, err := l.base(n.Base())
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := n.(type) {
+	case *nodeset.Object:
+		return &ObjectNode{BaseNode: base, EventNotifier: x.EventNotifier}, nil
+
+	case *nodeset.Variable:
+		v := &VariableNode{
+			BaseNode:                base,
+			ValueRank:               x.ValueRank,
+			AccessLevel:             ua.AccessLevelType(x.AccessLevel),
+			UserAccessLevel:         ua.AccessLevelType(x.UserAccessLevel),
+			MinimumSamplingInterval: x.MinimumSamplingInterval,
+			Historizing:             x.Historizing,
+		}
+		if v.DataType, err = l.nodeID(x.DataType); err != nil {
+			return nil, err
+		}
+		if v.ArrayDimensions, err = nodeset.ParseArrayDimensions(x.ArrayDimensions); err != nil {
+			return nil, err
+		}
+		if v.Value, err = l.value(x.Value); err != nil {
+			return nil, err
+		}
+		return v, nil
+
+	case *nodeset.Method:
+		return &MethodNode{BaseNode: base, Executable: x.Executable, UserExecutable: x.UserExecutable}, nil
+
+	case *nodeset.View:
+		return &ViewNode{BaseNode: base, ContainsNoLoops: x.ContainsNoLoops, EventNotifier: x.EventNotifier}, nil
+
+	case *nodeset.ObjectType:
+		return &ObjectTypeNode{BaseNode: base, IsAbstract: x.IsAbstract}, nil
+
+	case *nodeset.VariableType:
+		v := &VariableTypeNode{BaseNode: base, ValueRank: x.ValueRank, IsAbstract: x.IsAbstract}
+		if v.DataType, err = l.nodeID(x.DataType); err != nil {
+			return nil, err
+		}
+		if v.ArrayDimensions, err = nodeset.ParseArrayDimensions(x.ArrayDimensions); err != nil {
+			return nil, err
+		}
+		if v.Value, err = l.value(x.Value); err != nil {
+			return nil, err
+		}
+		return v, nil
+
+	case *nodeset.DataType:
+		return &DataTypeNode{BaseNode: base, IsAbstract: x.IsAbstract}, nil
+
+	case *nodeset.ReferenceType:
+		r := &ReferenceTypeNode{BaseNode: base, IsAbstract: x.IsAbstract, Symmetric: x.Symmetric}
+		if len(x.InverseName) > 0 {
+			r.InverseName = localizedText(x.InverseName)
+		}
+		return r, nil
+
+	default:
+		return nil, errors.Errorf("invalid node class %T", n)
+	}
+}
+
+func (l *nodeSetLoader) base(n *nodeset.Node) (BaseNode, error) {
+	nodeID, err := l.nodeID(n.NodeID)
+	if err != nil {
+		return BaseNode{}, err
+	}
+	browseName, err := l.qualifiedName(n.BrowseNameQualified())
+	if err != nil {
+		return BaseNode{}, err
+	}
+	b := BaseNode{
+		NodeID:        nodeID,
+		BrowseName:    browseName,
+		WriteMask:     n.WriteMask,
+		UserWriteMask: n.UserWriteMask,
+	}
+	if len(n.DisplayName) > 0 {
+		b.DisplayName = localizedText(n.DisplayName)
+	}
+	if len(n.Description) > 0 {
+		b.Description = localizedText(n.Description)
+	}
+	for _, r := range n.References {
+		refType, err := l.nodeID(r.ReferenceType)
+		if err != nil {
+			return BaseNode{}, err
+		}
+		target, err := l.nodeID(r.Target)
+		if err != nil {
+			return BaseNode{}, err
+		}
+		b.References = append(b.References, NewReference(refType, r.IsForward, target))
+	}
+	return b, nil
+}
+
+// nodeID returns the node id of a node id string or an alias of the node
+// set with the namespace index of the server.
+func (l *nodeSetLoader) nodeID(v string) (*ua.NodeID, error) {
+	n, err := l.s.NodeID(v)
+	if err != nil {
+		return nil, err
+	}
+	return l.remap(n)
+}
+
+// remap returns the node id with the namespace index of the server.
+func (l *nodeSetLoader) remap(n *ua.NodeID) (*ua.NodeID, error) {
+	ns := n.Namespace()
+	if ns == 0 {
+		return n, nil
+	}
+	if int(ns) >= len(l.ns) {
+		return nil, errors.Errorf("invalid namespace index in %s", n)
+	}
+	// the namespace index may not fit into the encoding of the node id
+	s := n.String()
+	s = s[strings.Index(s, ";")+1:]
+	return ua.ParseNodeID("ns=" + strconv.Itoa(int(l.ns[ns])) + ";" + s)
+}
+
+func (l *nodeSetLoader) qualifiedName(q *ua.QualifiedName) (*ua.QualifiedName, error) {
+	if int(q.NamespaceIndex) >= len(l.ns) {
+		return nil, errors.Errorf("invalid namespace index in browse name %d:%s", q.NamespaceIndex, q.Name)
+	}
+	return &ua.QualifiedName{NamespaceIndex: l.ns[q.NamespaceIndex], Name: q.Name}, nil
+}
+
+// value decodes the value of a variable or a variable type. Node ids,
+// qualified names and the type ids of extension objects in the value are
+// remapped.
+func (l *nodeSetLoader) value(v *nodeset.Value) (*ua.DataValue, error) {
+	if v == nil {
+		return nil, nil
+	}
+	val, err := v.Variant()
+	if err != nil {
+		return nil, err
+	}
+	if err := l.remapValue(val.Value()); err != nil {
+		return nil, err
+	}
+	return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: val}, nil
+}
+
+func (l *nodeSetLoader) remapValue(v interface{}) error {
+	switch x := v.(type) {
+	case *ua.NodeID:
+		n, err := l.remap(x)
+		if err != nil {
+			return err
+		}
+		*x = *n
+	case *ua.ExpandedNodeID:
+		if x.NamespaceURI != "" || x.NodeID == nil {
+			return nil
+		}
+		n, err := l.remap(x.NodeID)
+		if err != nil {
+			return err
+		}
+		x.NodeID = n
+	case *ua.QualifiedName:
+		q, err := l.qualifiedName(x)
+		if err != nil {
+			return err
+		}
+		*x = *q
+	case *ua.ExtensionObject:
+		if x.TypeID == nil {
+			return nil
+		}
+		return l.remapValue(x.TypeID)
+	case []*ua.NodeID:
+		for _, n := range x {
+			if err := l.remapValue(n); err != nil {
+				return err
+			}
+		}
+	case []*ua.ExpandedNodeID:
+		for _, n := range x {
+			if err := l.remapValue(n); err != nil {
+				return err
+			}
+		}
+	case []*ua.QualifiedName:
+		for _, q := range x {
+			if err := l.remapValue(q); err != nil {
+				return err
+			}
+		}
+	case []*ua.ExtensionObject:
+		for _, e := range x {
+			if err := l.remapValue(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dataTypeDefinition sets the DataTypeDefinition attribute of a data type
+// from its definition in the node set. Enumerations and option sets have
+// an EnumDefinition and all other data types a StructureDefinition.
+//
+// Specification: Part 3, 5.8.3
+func (l *nodeSetLoader) dataTypeDefinition(n *DataTypeNode, def *nodeset.Definition) error {
+	if def.IsOptionSet || l.isEnumeration(n) {
+		d := &ua.EnumDefinition{}
+		for _, f := range def.Fields {
+			ef := &ua.EnumField{
+				Value:       f.Value,
+				Name:        f.Name,
+				DisplayName: ua.NewLocalizedText(f.Name),
+				Description: ua.NewLocalizedText(""),
+			}
+			if len(f.DisplayName) > 0 {
+				ef.DisplayName = localizedText(f.DisplayName)
+			}
+			if len(f.Description) > 0 {
+				ef.Description = localizedText(f.Description)
+			}
+			d.Fields = append(d.Fields, ef)
+		}
+		n.DataTypeDefinition = ua.NewExtensionObject(d)
+		return nil
+	}
+
+	d := &ua.StructureDefinition{
+		DefaultEncodingID: l.encodings[n.NodeID.String()],
+		BaseDataType:      ns0(id.Structure),
+		StructureType:     ua.StructureTypeStructure,
+	}
+	if d.DefaultEncodingID == nil {
+		d.DefaultEncodingID = ua.NewTwoByteNodeID(0)
+	}
+	for _, r := range n.References {
+		if isNS0(r.ReferenceTypeID, id.HasSubtype) && !r.IsForward {
+			d.BaseDataType = r.TargetID.NodeID
+		}
+	}
+	if def.IsUnion {
+		d.StructureType = ua.StructureTypeUnion
+	}
+	for _, f := range def.Fields {
+		dataType, err := l.nodeID(f.DataType)
+		if err != nil {
+			return err
+		}
+		dims, err := nodeset.ParseArrayDimensions(f.ArrayDimensions)
+		if err != nil {
+			return err
+		}
+		sf := &ua.StructureField{
+			Name:            f.Name,
+			Description:     ua.NewLocalizedText(""),
+			DataType:        dataType,
+			ValueRank:       f.ValueRank,
+			ArrayDimensions: dims,
+			MaxStringLength: f.MaxStringLength,
+			IsOptional:      f.IsOptional,
+		}
+		if len(f.Description) > 0 {
+			sf.Description = localizedText(f.Description)
+		}
+		if f.IsOptional && !def.IsUnion {
+			d.StructureType = ua.StructureTypeStructureWithOptionalFields
+		}
+		d.Fields = append(d.Fields, sf)
+	}
+	n.DataTypeDefinition = ua.NewExtensionObject(d)
+	return nil
+}
+
+// isEnumeration returns true if the data type is a subtype of
+// Enumeration. The supertypes are either nodes of the node set or of the
+// address space.
+func (l *nodeSetLoader) isEnumeration(n *DataTypeNode) bool {
+	for depth := 0; depth < 32; depth++ {
+		var super *ua.NodeID
+		for _, r := range n.References {
+			if isNS0(r.ReferenceTypeID, id.HasSubtype) && !r.IsForward {
+				super = r.TargetID.NodeID
+				break
+			}
+		}
+		if super == nil {
+			return false
+		}
+		dt, ok := l.nodes[super.String()].(*DataTypeNode)
+		if !ok {
+			return l.as.IsSubtype(context.Background(), super, ns0(id.Enumeration))
+		}
+		n = dt
+	}
+	return false
+}
+
+// localizedText returns the first localized text.
+func localizedText(l []*nodeset.LocalizedText) *ua.LocalizedText {
+	return ua.NewLocalizedTextWithLocale(nodeset.Text(l), l[0].Locale)
+}
@@ -0,0 +1,344 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"time"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// namespaceZeroURI is the uri of the OPC UA namespace.
+const namespaceZeroURI = "http://opcfoundation.org/UA/"
+
+// ns0 returns the numeric node id i in namespace 0.
+func ns0(i uint32) *ua.NodeID {
+	return ua.NewNumericNodeID(0, i)
+}
+
+// ns0Base returns the base attributes of a node in namespace 0.
+func ns0Base(i uint32, name string, refs ...*Reference) BaseNode {
+	return BaseNode{
+		NodeID:      ns0(i),
+		BrowseName:  &ua.QualifiedName{Name: name},
+		DisplayName: ua.NewLocalizedText(name),
+		References:  refs,
+	}
+}
+
+// forward returns a forward reference to a node in namespace 0.
+func forward(refType, target uint32) *Reference {
+	return NewReference(ns0(refType), true, ns0(target))
+}
+
+// inverse returns an inverse reference to a node in namespace 0.
+func inverse(refType, target uint32) *Reference {
+	return NewReference(ns0(refType), false, ns0(target))
+}
+
+// supertype returns the inverse HasSubtype reference to the parent type
+// or the inverse Organizes reference to the folder for the root type.
+func supertype(parent, folder uint32) *Reference {
+	if parent == 0 {
+		return inverse(id.Organizes, folder)
+	}
+	return inverse(id.HasSubtype, parent)
+}
+
+// referenceType describes a standard reference type.
+type referenceType struct {
+	id, parent  uint32
+	name, inv   string
+	isAbstract  bool
+	isSymmetric bool
+}
+
+// referenceTypes contains the standard reference types ordered such
+// that the supertype is defined before its subtypes.
+//
+// Specification: Part 3, 7 and Part 5, 11
+var referenceTypes = []referenceType{
+	{id.References, 0, "References", "", true, true},
+	{id.HierarchicalReferences, id.References, "HierarchicalReferences", "InverseHierarchicalReferences", true, false},
+	{id.NonHierarchicalReferences, id.References, "NonHierarchicalReferences", "", true, true},
+	{id.HasChild, id.HierarchicalReferences, "HasChild", "ChildOf", true, false},
+	{id.Organizes, id.HierarchicalReferences, "Organizes", "OrganizedBy", false, false},
+	{id.HasEventSource, id.HierarchicalReferences, "HasEventSource", "EventSourceOf", false, false},
+	{id.HasNotifier, id.HasEventSource, "HasNotifier", "NotifierOf", false, false},
+	{id.Aggregates, id.HasChild, "Aggregates", "AggregatedBy", true, false},
+	{id.HasSubtype, id.HasChild, "HasSubtype", "SubtypeOf", false, false},
+	{id.HasProperty, id.Aggregates, "HasProperty", "PropertyOf", false, false},
+	{id.HasComponent, id.Aggregates, "HasComponent", "ComponentOf", false, false},
+	{id.HasOrderedComponent, id.HasComponent, "HasOrderedComponent", "OrderedComponentOf", false, false},
+	{id.HasModellingRule, id.NonHierarchicalReferences, "HasModellingRule", "ModellingRuleOf", false, false},
+	{id.HasEncoding, id.NonHierarchicalReferences, "HasEncoding", "EncodingOf", false, false},
+	{id.HasDescription, id.NonHierarchicalReferences, "HasDescription", "DescriptionOf", false, false},
+	{id.HasTypeDefinition, id.NonHierarchicalReferences, "HasTypeDefinition", "TypeDefinitionOf", false, false},
+	{id.GeneratesEvent, id.NonHierarchicalReferences, "GeneratesEvent", "GeneratedBy", false, false},
+	{id.AlwaysGeneratesEvent, id.GeneratesEvent, "AlwaysGeneratesEvent", "AlwaysGeneratedBy", false, false},
+}
+
+// dataType describes a standard data type.
+type dataType struct {
+	id, parent uint32
+	name       string
+	isAbstract bool
+}
+
+// dataTypes contains the built-in data types and the data types used by
+// the nodes of the server object ordered such that the supertype is
+// defined before its subtypes.
+//
+// Specification: Part 3, 8 and Part 6, 5.1.2
+var dataTypes = []dataType{
+	{id.BaseDataType, 0, "BaseDataType", true},
+	{id.Boolean, id.BaseDataType, "Boolean", false},
+	{id.Number, id.BaseDataType, "Number", true},
+	{id.Integer, id.Number, "Integer", true},
+	{id.UInteger, id.Number, "UInteger", true},
+	{id.SByte, id.Integer, "SByte", false},
+	{id.Int16, id.Integer, "Int16", false},
+	{id.Int32, id.Integer, "Int32", false},
+	{id.Int64, id.Integer, "Int64", false},
+	{id.Byte, id.UInteger, "Byte", false},
+	{id.UInt16, id.UInteger, "UInt16", false},
+	{id.UInt32, id.UInteger, "UInt32", false},
+	{id.UInt64, id.UInteger, "UInt64", false},
+	{id.Float, id.Number, "Float", false},
+	{id.Double, id.Number, "Double", false},
+	{id.Duration, id.Double, "Duration", false},
+	{id.String, id.BaseDataType, "String", false},
+	{id.LocaleID, id.String, "LocaleId", false},
+	{id.DateTime, id.BaseDataType, "DateTime", false},
+	{id.UtcTime, id.DateTime, "UtcTime", false},
+	{id.GUID, id.BaseDataType, "Guid", false},
+	{id.ByteString, id.BaseDataType, "ByteString", false},
+	{id.XMLElement, id.BaseDataType, "XmlElement", false},
+	{id.NodeID, id.BaseDataType, "NodeId", false},
+	{id.ExpandedNodeID, id.BaseDataType, "ExpandedNodeId", false},
+	{id.StatusCode, id.BaseDataType, "StatusCode", false},
+	{id.QualifiedName, id.BaseDataType, "QualifiedName", false},
+	{id.LocalizedText, id.BaseDataType, "LocalizedText", false},
+	{id.Structure, id.BaseDataType, "Structure", true},
+	{id.DataValue, id.BaseDataType, "DataValue", false},
+	{id.DiagnosticInfo, id.BaseDataType, "DiagnosticInfo", false},
+	{id.Enumeration, id.BaseDataType, "Enumeration", true},
+	{id.ServerState, id.Enumeration, "ServerState", false},
+	{id.BuildInfo, id.Structure, "BuildInfo", false},
+	{id.ServerStatusDataType, id.Structure, "ServerStatusDataType", false},
+}
+
+// objectTypes contains the object types used by the nodes of the server
+// object ordered such that the supertype is defined before its subtypes.
+var objectTypes = []dataType{
+	{id.BaseObjectType, 0, "BaseObjectType", false},
+	{id.FolderType, id.BaseObjectType, "FolderType", false},
+	{id.ServerType, id.BaseObjectType, "ServerType", false},
+	{id.ServerCapabilitiesType, id.BaseObjectType, "ServerCapabilitiesType", false},
+	{id.OperationLimitsType, id.FolderType, "OperationLimitsType", false},
+}
+
+// variableTypes contains the variable types used by the nodes of the
+// server object ordered such that the supertype is defined before its
+// subtypes.
+var variableTypes = []dataType{
+	{id.BaseVariableType, 0, "BaseVariableType", true},
+	{id.BaseDataVariableType, id.BaseVariableType, "BaseDataVariableType", false},
+	{id.PropertyType, id.BaseVariableType, "PropertyType", false},
+	{id.ServerStatusType, id.BaseDataVariableType, "ServerStatusType", false},
+	{id.BuildInfoType, id.BaseDataVariableType, "BuildInfoType", false},
+}
+
+// addNamespaceZero adds the standard folders, types and the server object
+// to namespace 0 of the address space. It is not the complete OPC UA
+// information model but the part which clients rely on.
+func (s *Server) addNamespaceZero() error {
+	var nodes []ServerNode
+
+	folder := func(i uint32, name string, parent uint32) ServerNode {
+		n := &ObjectNode{BaseNode: ns0Base(i, name, forward(id.HasTypeDefinition, id.FolderType))}
+		if parent != 0 {
+			n.References = append(n.References, inverse(id.Organizes, parent))
+		}
+		return n
+	}
+	nodes = append(nodes,
+		folder(id.RootFolder, "Root", 0),
+		folder(id.ObjectsFolder, "Objects", id.RootFolder),
+		folder(id.TypesFolder, "Types", id.RootFolder),
+		folder(id.ViewsFolder, "Views", id.RootFolder),
+		folder(id.ObjectTypesFolder, "ObjectTypes", id.TypesFolder),
+		folder(id.VariableTypesFolder, "VariableTypes", id.TypesFolder),
+		folder(id.DataTypesFolder, "DataTypes", id.TypesFolder),
+		folder(id.ReferenceTypesFolder, "ReferenceTypes", id.TypesFolder),
+	)
+	for _, t := range referenceTypes {
+		n := &ReferenceTypeNode{
+			BaseNode:   ns0Base(t.id, t.name),
+			IsAbstract: t.isAbstract,
+			Symmetric:  t.isSymmetric,
+		}
+		if t.inv != "" {
+			n.InverseName = ua.NewLocalizedText(t.inv)
+		}
+		n.References = append(n.References, supertype(t.parent, id.ReferenceTypesFolder))
+		nodes = append(nodes, n)
+	}
+	for _, t := range dataTypes {
+		n := &DataTypeNode{BaseNode: ns0Base(t.id, t.name), IsAbstract: t.isAbstract}
+		n.References = append(n.References, supertype(t.parent, id.DataTypesFolder))
+		nodes = append(nodes, n)
+	}
+	for _, t := range objectTypes {
+		n := &ObjectTypeNode{BaseNode: ns0Base(t.id, t.name), IsAbstract: t.isAbstract}
+		n.References = append(n.References, supertype(t.parent, id.ObjectTypesFolder))
+		nodes = append(nodes, n)
+	}
+	for _, t := range variableTypes {
+		n := &VariableTypeNode{
+			BaseNode:   ns0Base(t.id, t.name),
+			DataType:   ns0(id.BaseDataType),
+			ValueRank:  -2,
+			IsAbstract: t.isAbstract,
+		}
+		n.References = append(n.References, supertype(t.parent, id.VariableTypesFolder))
+		nodes = append(nodes, n)
+	}
+
+	nodes = append(nodes, s.serverObject()...)
+
+	for _, n := range nodes {
+		if err := s.as.AddNode(n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serverObject returns the server object and its components.
+//
+// Specification: Part 5, 6.3.1
+func (s *Server) serverObject() []ServerNode {
+	property := func(i uint32, name string, parent, dataType uint32, valueRank int32, v interface{}) *VariableNode {
+		return &VariableNode{
+			BaseNode: ns0Base(i, name,
+				inverse(id.HasProperty, parent),
+				forward(id.HasTypeDefinition, id.PropertyType),
+			),
+			Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)},
+			DataType:        ns0(dataType),
+			ValueRank:       valueRank,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+		}
+	}
+	variable := func(i uint32, name string, parent, typeDef, dataType uint32, read func() interface{}) *VariableNode {
+		return &VariableNode{
+			BaseNode: ns0Base(i, name,
+				inverse(id.HasComponent, parent),
+				forward(id.HasTypeDefinition, typeDef),
+			),
+			DataType:        ns0(dataType),
+			ValueRank:       -1,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead,
+			OnRead: func(context.Context, *ServerSession) *ua.DataValue {
+				return &ua.DataValue{
+					EncodingMask:    ua.DataValueValue | ua.DataValueSourceTimestamp,
+					Value:           ua.MustVariant(read()),
+					SourceTimestamp: time.Now(),
+				}
+			},
+		}
+	}
+
+	buildInfo := func() *ua.BuildInfo {
+		return &ua.BuildInfo{
+			ProductURI:       s.cfg.productURI,
+			ManufacturerName: "gopcua",
+			ProductName:      s.cfg.applicationName,
+		}
+	}
+	limits := s.cfg.operationLimits
+	opLimit := func(i uint32, name string, v uint32) ServerNode {
+		return property(i, name, id.Server_ServerCapabilities_OperationLimits, id.UInt32, -1, v)
+	}
+
+	namespaces := property(id.Server_NamespaceArray, "NamespaceArray", id.Server, id.String, 1, []string{})
+	namespaces.OnRead = func(context.Context, *ServerSession) *ua.DataValue {
+		return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(s.as.Namespaces())}
+	}
+
+	return []ServerNode{
+		&ObjectNode{BaseNode: ns0Base(id.Server, "Server",
+			inverse(id.Organizes, id.ObjectsFolder),
+			forward(id.HasTypeDefinition, id.ServerType),
+		)},
+		property(id.Server_ServerArray, "ServerArray", id.Server, id.String, 1, []string{s.cfg.applicationURI}),
+		namespaces,
+		variable(id.Server_ServerStatus, "ServerStatus", id.Server, id.ServerStatusType, id.ServerStatusDataType, func() interface{} {
+			return ua.NewExtensionObject(&ua.ServerStatusDataType{
+				StartTime:      s.startTime,
+				CurrentTime:    time.Now(),
+				State:          ua.ServerStateRunning,
+				BuildInfo:      buildInfo(),
+				ShutdownReason: &ua.LocalizedText{},
+			})
+		}),
+		variable(id.Server_ServerStatus_StartTime, "StartTime", id.Server_ServerStatus, id.BaseDataVariableType, id.UtcTime, func() interface{} {
+			return s.startTime
+		}),
+		variable(id.Server_ServerStatus_CurrentTime, "CurrentTime", id.Server_ServerStatus, id.BaseDataVariableType, id.UtcTime, func() interface{} {
+			return time.Now()
+		}),
+		variable(id.Server_ServerStatus_State, "State", id.Server_ServerStatus, id.BaseDataVariableType, id.ServerState, func() interface{} {
+			return int32(ua.ServerStateRunning)
+		}),
+		variable(id.Server_ServerStatus_BuildInfo, "BuildInfo", id.Server_ServerStatus, id.BuildInfoType, id.BuildInfo, func() interface{} {
+			return ua.NewExtensionObject(buildInfo())
+		}),
+		variable(id.Server_ServerStatus_BuildInfo_ProductURI, "ProductUri", id.Server_ServerStatus_BuildInfo, id.BaseDataVariableType, id.String, func() interface{} {
+			return buildInfo().ProductURI
+		}),
+		variable(id.Server_ServerStatus_BuildInfo_ManufacturerName, "ManufacturerName", id.Server_ServerStatus_BuildInfo, id.BaseDataVariableType, id.String, func() interface{} {
+			return buildInfo().ManufacturerName
+		}),
+		variable(id.Server_ServerStatus_BuildInfo_ProductName, "ProductName", id.Server_ServerStatus_BuildInfo, id.BaseDataVariableType, id.String, func() interface{} {
+			return buildInfo().ProductName
+		}),
+		variable(id.Server_ServerStatus_SecondsTillShutdown, "SecondsTillShutdown", id.Server_ServerStatus, id.BaseDataVariableType, id.UInt32, func() interface{} {
+			return uint32(0)
+		}),
+		variable(id.Server_ServerStatus_ShutdownReason, "ShutdownReason", id.Server_ServerStatus, id.BaseDataVariableType, id.LocalizedText, func() interface{} {
+			return &ua.LocalizedText{}
+		}),
+		property(id.Server_ServiceLevel, "ServiceLevel", id.Server, id.Byte, -1, uint8(255)),
+
+		&ObjectNode{BaseNode: ns0Base(id.Server_ServerCapabilities, "ServerCapabilities",
+			inverse(id.HasComponent, id.Server),
+			forward(id.HasTypeDefinition, id.ServerCapabilitiesType),
+		)},
+		property(id.Server_ServerCapabilities_MaxBrowseContinuationPoints, "MaxBrowseContinuationPoints", id.Server_ServerCapabilities, id.UInt16, -1, uint16(maxBrowseContinuationPoints)),
+		&ObjectNode{BaseNode: ns0Base(id.Server_ServerCapabilities_OperationLimits, "OperationLimits",
+			inverse(id.HasComponent, id.Server_ServerCapabilities),
+			forward(id.HasTypeDefinition, id.OperationLimitsType),
+		)},
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRead, "MaxNodesPerRead", limits.MaxNodesPerRead),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryReadData, "MaxNodesPerHistoryReadData", limits.MaxNodesPerHistoryReadData),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryReadEvents, "MaxNodesPerHistoryReadEvents", limits.MaxNodesPerHistoryReadEvents),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerWrite, "MaxNodesPerWrite", limits.MaxNodesPerWrite),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryUpdateData, "MaxNodesPerHistoryUpdateData", limits.MaxNodesPerHistoryUpdateData),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerHistoryUpdateEvents, "MaxNodesPerHistoryUpdateEvents", limits.MaxNodesPerHistoryUpdateEvents),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerMethodCall, "MaxNodesPerMethodCall", limits.MaxNodesPerMethodCall),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerBrowse, "MaxNodesPerBrowse", limits.MaxNodesPerBrowse),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRegisterNodes, "MaxNodesPerRegisterNodes", limits.MaxNodesPerRegisterNodes),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerTranslateBrowsePathsToNodeIDs, "MaxNodesPerTranslateBrowsePathsToNodeIds", limits.MaxNodesPerTranslateBrowsePathsToNodeIDs),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxNodesPerNodeManagement, "MaxNodesPerNodeManagement", limits.MaxNodesPerNodeManagement),
+		opLimit(id.Server_ServerCapabilities_OperationLimits_MaxMonitoredItemsPerCall, "MaxMonitoredItemsPerCall", limits.MaxMonitoredItemsPerCall),
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/stats"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uasc"
+)
+
+// ServerSession is a session which a client created on the server as
+// described in Part 4, 5.6.
+type ServerSession struct {
+	id        *ua.NodeID
+	authToken *ua.NodeID
+	name      string
+	timeout   time.Duration
+
+	clientDescription *ua.ApplicationDescription
+	clientCertificate []byte
+
+	mu sync.Mutex
+
+	// channelID is the id of the secure channel the session is
+	// activated on.
+	channelID uint32
+
+	// channelCertificate is the client certificate of the secure
+	// channel the session was created on.
+	channelCertificate []byte
+
+	// activated is set after the first successful ActivateSession request.
+	activated bool
+
+	// nonce is the last server nonce sent to the client.
+	nonce []byte
+
+	// identity is the user identity token the session was activated with.
+	identity interface{}
+
+	// localeIDs are the locales requested by the client.
+	localeIDs []string
+
+	// lastSeen is the time of the last request on the session.
+	lastSeen time.Time
+
+	// continuationPoints contains the remaining references of Browse
+	// operations by continuation point.
+	continuationPoints map[string]*browseContinuation
+
+	// deleteSubscriptions is set when the client closes the session and
+	// requests to delete its subscriptions.
+	deleteSubscriptions bool
+
+	// ctx is cancelled when the session is closed.
+	ctx    context.Context
+	cancel func()
+}
+
+// ID returns the session id.
+func (s *ServerSession) ID() *ua.NodeID {
+	return s.id
+}
+
+// Name returns the session name the client requested.
+func (s *ServerSession) Name() string {
+	return s.name
+}
+
+// ClientDescription returns the description of the client application.
+func (s *ServerSession) ClientDescription() *ua.ApplicationDescription {
+	return s.clientDescription
+}
+
+// UserIdentity returns the user identity token the session was
+// activated with.
+func (s *ServerSession) UserIdentity() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.identity
+}
+
+// LocaleIDs returns the locales the client requested.
+func (s *ServerSession) LocaleIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.localeIDs
+}
+
+// Context returns a context which is cancelled when the session is
+// closed or expires.
+func (s *ServerSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ServerSession) touch() {
+	s.mu.Lock()
+	s.lastSeen = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *ServerSession) expired(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Sub(s.lastSeen) > s.timeout
+}
+
+// sessionManager creates, activates and closes the sessions of a server.
+type sessionManager struct {
+	srv *Server
+
+	mu sync.Mutex
+
+	// sessions maps the authentication tokens to the sessions.
+	sessions map[string]*ServerSession
+
+	// nextID is the numeric identifier of the next session.
+	nextID uint32
+
+	// onClose is called for every session after it was removed.
+	onClose []func(*ServerSession)
+}
+
+func newSessionManager(srv *Server) *sessionManager {
+	return &sessionManager{
+		srv:      srv,
+		sessions: make(map[string]*ServerSession),
+	}
+}
+
+// monitor closes the sessions which have expired.
+func (m *sessionManager) monitor(ctx context.Context) {
+	defer m.srv.wg.Done()
+
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			m.mu.Lock()
+			var expired []*ServerSession
+			for k, s := range m.sessions {
+				if s.expired(now) {
+					delete(m.sessions, k)
+					expired = append(expired, s)
+				}
+			}
+			m.mu.Unlock()
+
+			for _, s := range expired {
+				debug.Printf("server: session %s expired", s.id)
+				stats.Server().Add("SessionExpired", 1)
+				m.close(s)
+			}
+		}
+	}
+}
+
+// closeAll closes all sessions.
+func (m *sessionManager) closeAll() {
+	m.mu.Lock()
+	all := m.sessions
+	m.sessions = make(map[string]*ServerSession)
+	m.mu.Unlock()
+
+	for _, s := range all {
+		m.close(s)
+	}
+}
+
+func (m *sessionManager) close(s *ServerSession) {
+	s.cancel()
+	for _, f := range m.onClose {
+		f(s)
+	}
+}
+
+func (m *sessionManager) lookup(authToken *ua.NodeID) *ServerSession {
+	if authToken == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sessions[authToken.String()]
+}
+
+// activeSession returns the session for the authentication token. The
+// session must be activated on the secure channel.
+func (m *sessionManager) activeSession(sc *uasc.SecureChannel, authToken *ua.NodeID) (*ServerSession, error) {
+	s := m.lookup(authToken)
+	if s == nil {
+		return nil, ua.StatusBadSessionIDInvalid
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.activated || s.channelID != sc.ID() {
+		return nil, ua.StatusBadSessionNotActivated
+	}
+	s.lastSeen = time.Now()
+	return s, nil
+}
+
+func (m *sessionManager) createSession(sc *uasc.SecureChannel, req *ua.CreateSessionRequest) (ua.Response, error) {
+	secure := sc.SecurityPolicyURI() != ua.SecurityPolicyURINone
+	if secure && len(req.ClientNonce) < 32 {
+		return nil, ua.StatusBadNonceInvalid
+	}
+
+	timeout := time.Duration(req.RequestedSessionTimeout) * time.Millisecond
+	if timeout < m.srv.cfg.minSessionTimeout {
+		timeout = m.srv.cfg.minSessionTimeout
+	}
+	if timeout > m.srv.cfg.maxSessionTimeout {
+		timeout = m.srv.cfg.maxSessionTimeout
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sig := &ua.SignatureData{}
+	if secure {
+		b, alg, err := sc.NewSessionSignature(req.ClientCertificate, req.ClientNonce)
+		if err != nil {
+			return nil, ua.StatusBadCertificateInvalid
+		}
+		sig.Signature, sig.Algorithm = b, alg
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.sessions) >= m.srv.cfg.maxSessions {
+		return nil, ua.StatusBadTooManySessions
+	}
+
+	m.nextID++
+	s := &ServerSession{
+		id:                 ua.NewNumericNodeID(1, m.nextID),
+		authToken:          ua.NewByteStringNodeID(0, token),
+		name:               req.SessionName,
+		timeout:            timeout,
+		clientDescription:  req.ClientDescription,
+		clientCertificate:  req.ClientCertificate,
+		channelID:          sc.ID(),
+		channelCertificate: sc.RemoteCertificate(),
+		nonce:              nonce,
+		lastSeen:           time.Now(),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	m.sessions[s.authToken.String()] = s
+
+	debug.Printf("server: created session %s %q", s.id, s.name)
+
+	// the client needs the certificate to protect its user identity
+	// token even if the channel has no security
+	var cert []byte
+	if m.srv.userTokenPolicyURI(sc.SecurityPolicyURI()) != "" {
+		cert = m.srv.cfg.certificate
+	}
+
+	return &ua.CreateSessionResponse{
+		SessionID:             s.id,
+		AuthenticationToken:   s.authToken,
+		RevisedSessionTimeout: float64(timeout / time.Millisecond),
+		ServerNonce:           nonce,
+		ServerCertificate:     cert,
+		ServerEndpoints:       m.srv.Endpoints(),
+		ServerSignature:       sig,
+	}, nil
+}
+
+func (m *sessionManager) activateSession(sc *uasc.SecureChannel, req *ua.ActivateSessionRequest) (ua.Response, error) {
+	s := m.lookup(req.RequestHeader.AuthenticationToken)
+	if s == nil {
+		return nil, ua.StatusBadSessionIDInvalid
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The first activation must happen on the channel which created the
+	// session. Afterwards the session can only be moved to a channel of
+	// the same client and with the same user. See Part 4, 5.6.3.
+	moved := s.channelID != sc.ID()
+	if moved && (!s.activated || !bytes.Equal(s.channelCertificate, sc.RemoteCertificate())) {
+		return nil, ua.StatusBadSecureChannelIDInvalid
+	}
+
+	if sc.SecurityPolicyURI() != ua.SecurityPolicyURINone {
+		if req.ClientSignature == nil {
+			return nil, ua.StatusBadApplicationSignatureInvalid
+		}
+		if err := sc.VerifySessionSignature(s.clientCertificate, s.nonce, req.ClientSignature.Signature); err != nil {
+			return nil, ua.StatusBadApplicationSignatureInvalid
+		}
+	}
+
+	identity, err := m.userIdentity(sc, s, req)
+	if err != nil {
+		return nil, err
+	}
+	if moved && !sameUserIdentity(s.identity, identity) {
+		return nil, ua.StatusBadIdentityChangeNotSupported
+	}
+
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	s.activated = true
+	s.channelID = sc.ID()
+	s.identity = identity
+	s.nonce = nonce
+	s.lastSeen = time.Now()
+	if len(req.LocaleIDs) > 0 {
+		s.localeIDs = req.LocaleIDs
+	}
+
+	debug.Printf("server: activated session %s on channel %d", s.id, s.channelID)
+
+	return &ua.ActivateSessionResponse{ServerNonce: nonce}, nil
+}
+
+// userIdentity checks the user identity token of the ActivateSession
+// request. The session lock must be held.
+func (m *sessionManager) userIdentity(sc *uasc.SecureChannel, s *ServerSession, req *ua.ActivateSessionRequest) (interface{}, error) {
+	var tok interface{} = &ua.AnonymousIdentityToken{PolicyID: defaultAnonymousPolicyID}
+	if req.UserIdentityToken != nil && req.UserIdentityToken.Value != nil {
+		tok = req.UserIdentityToken.Value
+	}
+
+	var (
+		policyID  string
+		tokenType ua.UserTokenType
+	)
+
+	// passwords and user certificates are never accepted without
+	// protection, not even on channels without security.
+	tokenPolicyURI := m.srv.userTokenPolicyURI(sc.SecurityPolicyURI())
+
+	switch t := tok.(type) {
+	case *ua.AnonymousIdentityToken:
+		policyID, tokenType = t.PolicyID, ua.UserTokenTypeAnonymous
+
+	case *ua.UserNameIdentityToken:
+		policyID, tokenType = t.PolicyID, ua.UserTokenTypeUserName
+		if tokenPolicyURI == "" || t.EncryptionAlgorithm == "" {
+			return nil, ua.StatusBadIdentityTokenInvalid
+		}
+		pass, err := sc.DecryptUserPassword(tokenPolicyURI, t.Password, s.nonce)
+		if err != nil {
+			return nil, ua.StatusBadIdentityTokenInvalid
+		}
+		t.Password = []byte(pass)
+
+	case *ua.X509IdentityToken:
+		policyID, tokenType = t.PolicyID, ua.UserTokenTypeCertificate
+		if tokenPolicyURI == "" {
+			return nil, ua.StatusBadIdentityTokenInvalid
+		}
+		var sig []byte
+		if req.UserTokenSignature != nil {
+			sig = req.UserTokenSignature.Signature
+		}
+		if err := sc.VerifyUserTokenSignature(tokenPolicyURI, t.CertificateData, s.nonce, sig); err != nil {
+			return nil, ua.StatusBadUserSignatureInvalid
+		}
+
+	default:
+		return nil, ua.StatusBadIdentityTokenInvalid
+	}
+
+	accepted := false
+	for _, t := range m.srv.cfg.userTokens {
+		if t == tokenType && (policyID == "" || policyID == userTokenPolicyID(t)) {
+			accepted = true
+		}
+	}
+	if !accepted {
+		return nil, ua.StatusBadIdentityTokenInvalid
+	}
+
+	if m.srv.cfg.userValidator != nil {
+		if err := m.srv.cfg.userValidator(tok); err != nil {
+			if code, ok := err.(ua.StatusCode); ok {
+				return nil, code
+			}
+			return nil, ua.StatusBadIdentityTokenRejected
+		}
+	}
+
+	return tok, nil
+}
+
+// sameUserIdentity returns true if both user identity tokens identify the
+// same user.
+func sameUserIdentity(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *ua.AnonymousIdentityToken:
+		_, ok := b.(*ua.AnonymousIdentityToken)
+		return ok
+	case *ua.UserNameIdentityToken:
+		y, ok := b.(*ua.UserNameIdentityToken)
+		return ok && x.UserName == y.UserName && bytes.Equal(x.Password, y.Password)
+	case *ua.X509IdentityToken:
+		y, ok := b.(*ua.X509IdentityToken)
+		return ok && bytes.Equal(x.CertificateData, y.CertificateData)
+	case *ua.IssuedIdentityToken:
+		y, ok := b.(*ua.IssuedIdentityToken)
+		return ok && bytes.Equal(x.TokenData, y.TokenData)
+	default:
+		return false
+	}
+}
+
+func (m *sessionManager) closeSession(sc *uasc.SecureChannel, req *ua.CloseSessionRequest) (ua.Response, error) {
+	s, err := m.activeSession(sc, req.RequestHeader.AuthenticationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	delete(m.sessions, s.authToken.String())
+	m.mu.Unlock()
+
+	debug.Printf("server: closed session %s", s.id)
+	s.deleteSubscriptions = req.DeleteSubscriptions
+	m.close(s)
+
+	return &ua.CloseSessionResponse{}, nil
+}
@@ -0,0 +1,767 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// Limits of the subscriptions of the server.
+const (
+	minPublishingInterval      = 10 * time.Millisecond
+	maxPublishingInterval      = time.Hour
+	defaultMaxKeepAliveCount   = 10
+	maxPublishRequests         = 100
+	maxRetransmissionQueueSize = 100
+)
+
+// publishRequest is a Publish request which waits for a notification
+// message.
+type publishRequest struct {
+	// results are the results of the acknowledgements of the request.
+	results []ua.StatusCode
+
+	ch chan publishResult
+}
+
+type publishResult struct {
+	res *ua.PublishResponse
+	err error
+}
+
+func newPublishRequest(results []ua.StatusCode) *publishRequest {
+	return &publishRequest{results: results, ch: make(chan publishResult, 1)}
+}
+
+// respond completes the request. It must be called only once.
+func (r *publishRequest) respond(res *ua.PublishResponse, err error) {
+	if res != nil {
+		res.Results = r.results
+	}
+	r.ch <- publishResult{res, err}
+}
+
+// subscriptionManager implements the Subscription and MonitoredItem
+// services of the server.
+//
+// Specification: Part 4, 5.12 and 5.13
+type subscriptionManager struct {
+	srv *Server
+
+	// mu guards the fields below and the session of all subscriptions.
+	// It may be acquired while holding the lock of a subscription but
+	// not the other way round.
+	mu sync.Mutex
+
+	// nextID is the id of the last subscription.
+	nextID uint32
+
+	subs map[uint32]*serverSubscription
+
+	// queues contains the pending Publish requests per session.
+	queues map[*ServerSession][]*publishRequest
+
+	// statusChanges contains the StatusChangeNotification messages per
+	// session which are sent with the next Publish request.
+	statusChanges map[*ServerSession][]*ua.PublishResponse
+}
+
+func newSubscriptionManager(srv *Server) *subscriptionManager {
+	return &subscriptionManager{
+		srv:           srv,
+		subs:          make(map[uint32]*serverSubscription),
+		queues:        make(map[*ServerSession][]*publishRequest),
+		statusChanges: make(map[*ServerSession][]*ua.PublishResponse),
+	}
+}
+
+// subscription returns the subscription of the session.
+func (m *subscriptionManager) subscription(sess *ServerSession, id uint32) (*serverSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub := m.subs[id]
+	if sub == nil || sub.session != sess {
+		return nil, ua.StatusBadSubscriptionIDInvalid
+	}
+	return sub, nil
+}
+
+// sessionSubscriptions returns the subscriptions of the session.
+func (m *subscriptionManager) sessionSubscriptions(sess *ServerSession) []*serverSubscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var subs []*serverSubscription
+	for _, sub := range m.subs {
+		if sub.session == sess {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// session returns the session of the subscription or nil if the session
+// was closed.
+func (m *subscriptionManager) session(sub *serverSubscription) *ServerSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return sub.session
+}
+
+// popPublishRequest removes the oldest Publish request of the session of
+// the subscription from the queue.
+func (m *subscriptionManager) popPublishRequest(sub *serverSubscription) *publishRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.popLocked(sub.session)
+}
+
+func (m *subscriptionManager) popLocked(sess *ServerSession) *publishRequest {
+	q := m.queues[sess]
+	if sess == nil || len(q) == 0 {
+		return nil
+	}
+	m.queues[sess] = q[1:]
+	return q[0]
+}
+
+// hasPublishRequests returns true if Publish requests are queued for the
+// session of the subscription.
+func (m *subscriptionManager) hasPublishRequests(sub *serverSubscription) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return sub.session != nil && len(m.queues[sub.session]) > 0
+}
+
+// removePublishRequest removes the Publish request from the queue. It
+// returns false if the request was already taken from the queue.
+func (m *subscriptionManager) removePublishRequest(sess *ServerSession, req *publishRequest) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q := m.queues[sess]
+	for i, r := range q {
+		if r == req {
+			m.queues[sess] = append(q[:i:i], q[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// remove deletes the subscription.
+func (m *subscriptionManager) remove(sub *serverSubscription) {
+	m.mu.Lock()
+	sess := sub.session
+	delete(m.subs, sub.id)
+	m.mu.Unlock()
+
+	sub.cancel()
+	if sess != nil && len(m.sessionSubscriptions(sess)) == 0 {
+		m.failPublishRequests(sess, ua.StatusBadNoSubscription)
+	}
+}
+
+// failPublishRequests responds to all queued Publish requests of the
+// session with an error.
+func (m *subscriptionManager) failPublishRequests(sess *ServerSession, err error) {
+	m.mu.Lock()
+	q := m.queues[sess]
+	delete(m.queues, sess)
+	m.mu.Unlock()
+
+	for _, req := range q {
+		req.respond(nil, err)
+	}
+}
+
+// expire deletes the subscription after its lifetime expired and queues
+// a StatusChangeNotification for the session.
+func (m *subscriptionManager) expire(sub *serverSubscription, seq uint32) {
+	debug.Printf("server: subscription %d expired", sub.id)
+
+	m.mu.Lock()
+	if sess := sub.session; sess != nil {
+		m.statusChanges[sess] = append(m.statusChanges[sess], statusChangeResponse(sub.id, seq, ua.StatusBadTimeout))
+	}
+	m.mu.Unlock()
+	m.remove(sub)
+}
+
+func statusChangeResponse(subID, seq uint32, status ua.StatusCode) *ua.PublishResponse {
+	return &ua.PublishResponse{
+		SubscriptionID: subID,
+		NotificationMessage: &ua.NotificationMessage{
+			SequenceNumber: seq,
+			PublishTime:    time.Now(),
+			NotificationData: []*ua.ExtensionObject{
+				ua.NewExtensionObject(&ua.StatusChangeNotification{Status: status, DiagnosticInfo: &ua.DiagnosticInfo{}}),
+			},
+		},
+	}
+}
+
+// sessionClosed deletes the subscriptions of a closed session if the
+// client requested it. Otherwise, the subscriptions remain until their
+// lifetime expires or they are transferred to another session.
+func (m *subscriptionManager) sessionClosed(sess *ServerSession) {
+	m.failPublishRequests(sess, ua.StatusBadSessionClosed)
+
+	m.mu.Lock()
+	delete(m.statusChanges, sess)
+	var subs []*serverSubscription
+	for _, sub := range m.subs {
+		if sub.session != sess {
+			continue
+		}
+		if sess.deleteSubscriptions {
+			delete(m.subs, sub.id)
+			subs = append(subs, sub)
+		} else {
+			sub.session = nil
+		}
+	}
+	m.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.cancel()
+	}
+}
+
+// closeAll deletes all subscriptions.
+func (m *subscriptionManager) closeAll() {
+	m.mu.Lock()
+	subs := m.subs
+	m.subs = make(map[uint32]*serverSubscription)
+	m.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.cancel()
+	}
+}
+
+// serverSubscription is a subscription of a session.
+type serverSubscription struct {
+	id uint32
+	m  *subscriptionManager
+
+	// session is the session which owns the subscription. It is nil
+	// after the session was closed. It is guarded by m.mu.
+	session *ServerSession
+
+	// identity is the user identity of the owning session. It is kept
+	// after the session was closed so that only the same user can
+	// transfer the subscription. It is guarded by m.mu.
+	identity interface{}
+
+	mu sync.Mutex
+
+	interval          time.Duration
+	lifetimeCount     uint32
+	maxKeepAliveCount uint32
+	maxNotifications  uint32
+	publishingEnabled bool
+
+	keepAliveCounter uint32
+	lifetimeCounter  uint32
+
+	// first is set until the first message was sent.
+	first bool
+
+	// seq is the sequence number of the last notification message.
+	seq uint32
+
+	// retransmission contains the sent notification messages which have
+	// not been acknowledged.
+	retransmission []*ua.NotificationMessage
+
+	nextItemID uint32
+	items      map[uint32]*serverMonitoredItem
+
+	// kick signals that a Publish request has arrived.
+	kick chan struct{}
+
+	ctx    context.Context
+	cancel func()
+}
+
+// revise sets the publishing parameters from the requested parameters.
+// The lock must be held.
+//
+// Specification: Part 4, 5.13.2.2
+func (s *serverSubscription) revise(interval float64, lifetimeCount, maxKeepAliveCount, maxNotifications uint32) {
+	d := time.Duration(interval * float64(time.Millisecond))
+	if interval != interval || d < minPublishingInterval {
+		d = minPublishingInterval
+	}
+	if d > maxPublishingInterval {
+		d = maxPublishingInterval
+	}
+	if maxKeepAliveCount == 0 {
+		maxKeepAliveCount = defaultMaxKeepAliveCount
+	}
+	if lifetimeCount < 3*maxKeepAliveCount {
+		lifetimeCount = 3 * maxKeepAliveCount
+	}
+
+	s.interval = d
+	s.lifetimeCount = lifetimeCount
+	s.maxKeepAliveCount = maxKeepAliveCount
+	s.maxNotifications = maxNotifications
+	s.lifetimeCounter = 0
+	s.keepAliveCounter = 0
+}
+
+// run executes the publishing cycles until the subscription is deleted.
+func (s *serverSubscription) run() {
+	s.mu.Lock()
+	t := time.NewTimer(s.interval)
+	s.mu.Unlock()
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.kick:
+			s.publish(false)
+		case <-t.C:
+			if !s.publish(true) {
+				return
+			}
+			s.mu.Lock()
+			t.Reset(s.interval)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// publish sends the notifications or a keep-alive message if a Publish
+// request is available. tick is true when the publishing interval
+// elapsed and false when a Publish request has arrived. publish returns
+// false if the lifetime of the subscription expired.
+//
+// Specification: Part 4, 5.13.1.1
+func (s *serverSubscription) publish(tick bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sent := false
+	if s.publishingEnabled && s.hasNotifications() {
+		for {
+			req := s.m.popPublishRequest(s)
+			if req == nil {
+				break
+			}
+			more := s.sendNotifications(req)
+			sent = true
+			if !more {
+				break
+			}
+		}
+	} else {
+		if tick {
+			s.keepAliveCounter++
+		}
+		if s.first || s.keepAliveCounter >= s.maxKeepAliveCount {
+			if req := s.m.popPublishRequest(s); req != nil {
+				s.sendKeepAlive(req)
+				sent = true
+			}
+		}
+	}
+
+	switch {
+	case sent:
+		s.first = false
+		s.keepAliveCounter = 0
+		s.lifetimeCounter = 0
+	case !tick || s.m.hasPublishRequests(s):
+		s.lifetimeCounter = 0
+	default:
+		s.lifetimeCounter++
+	}
+
+	if s.lifetimeCounter >= s.lifetimeCount {
+		go s.m.expire(s, s.nextSequenceNumber())
+		return false
+	}
+	return true
+}
+
+// nextSequenceNumber returns the sequence number of the next
+// notification message. The lock must be held.
+func (s *serverSubscription) nextSequenceNumber() uint32 {
+	if s.seq == ^uint32(0) {
+		return 1
+	}
+	return s.seq + 1
+}
+
+// availableSequenceNumbers returns the sequence numbers of the messages in
+// the retransmission queue. The lock must be held.
+func (s *serverSubscription) availableSequenceNumbers() []uint32 {
+	seqs := make([]uint32, len(s.retransmission))
+	for i, msg := range s.retransmission {
+		seqs[i] = msg.SequenceNumber
+	}
+	return seqs
+}
+
+func (s *serverSubscription) sendKeepAlive(req *publishRequest) {
+	req.respond(&ua.PublishResponse{
+		SubscriptionID:           s.id,
+		AvailableSequenceNumbers: s.availableSequenceNumbers(),
+		NotificationMessage: &ua.NotificationMessage{
+			SequenceNumber: s.nextSequenceNumber(),
+			PublishTime:    time.Now(),
+		},
+	}, nil)
+}
+
+// sendNotifications sends a notification message and returns true if
+// there are more notifications than fit into the message.
+func (s *serverSubscription) sendNotifications(req *publishRequest) bool {
+	items, more := s.notifications()
+
+	s.seq = s.nextSequenceNumber()
+	msg := &ua.NotificationMessage{
+		SequenceNumber: s.seq,
+		PublishTime:    time.Now(),
+		NotificationData: []*ua.ExtensionObject{
+			ua.NewExtensionObject(&ua.DataChangeNotification{MonitoredItems: items}),
+		},
+	}
+	s.retransmission = append(s.retransmission, msg)
+	if n := len(s.retransmission) - maxRetransmissionQueueSize; n > 0 {
+		s.retransmission = s.retransmission[n:]
+	}
+
+	req.respond(&ua.PublishResponse{
+		SubscriptionID:           s.id,
+		AvailableSequenceNumbers: s.availableSequenceNumbers(),
+		MoreNotifications:        more,
+		NotificationMessage:      msg,
+	}, nil)
+	return more
+}
+
+// acknowledge removes a message from the retransmission queue.
+func (s *serverSubscription) acknowledge(seq uint32) ua.StatusCode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, msg := range s.retransmission {
+		if msg.SequenceNumber == seq {
+			s.retransmission = append(s.retransmission[:i:i], s.retransmission[i+1:]...)
+			return ua.StatusOK
+		}
+	}
+	return ua.StatusBadSequenceNumberUnknown
+}
+
+// sortedItems returns the monitored items ordered by id. The lock must be
+// held.
+func (s *serverSubscription) sortedItems() []*serverMonitoredItem {
+	items := make([]*serverMonitoredItem, 0, len(s.items))
+	for _, it := range s.items {
+		items = append(items, it)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].id < items[j].id })
+	return items
+}
+
+// hasNotifications returns true if a reporting monitored item has queued
+// notifications. The lock must be held.
+func (s *serverSubscription) hasNotifications() bool {
+	for _, it := range s.items {
+		if it.mode == ua.MonitoringModeReporting && len(it.queue) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// notifications removes the queued notifications of the reporting items
+// and of the sampling items which are triggered by them. It returns at
+// most maxNotifications notifications and true if there are more. The
+// lock must be held.
+//
+// Specification: Part 4, 5.12.1.6
+func (s *serverSubscription) notifications() ([]*ua.MonitoredItemNotification, bool) {
+	var res []*ua.MonitoredItemNotification
+	take := func(it *serverMonitoredItem) bool {
+		for len(it.queue) > 0 {
+			if s.maxNotifications > 0 && uint32(len(res)) >= s.maxNotifications {
+				return false
+			}
+			res = append(res, &ua.MonitoredItemNotification{ClientHandle: it.clientHandle, Value: it.queue[0]})
+			it.queue = it.queue[1:]
+		}
+		return true
+	}
+
+	items := s.sortedItems()
+	triggered := map[uint32]bool{}
+	for _, it := range items {
+		if it.mode != ua.MonitoringModeReporting || len(it.queue) == 0 {
+			continue
+		}
+		for id := range it.links {
+			triggered[id] = true
+		}
+		if !take(it) {
+			return res, true
+		}
+	}
+	for _, it := range items {
+		if !triggered[it.id] || it.mode != ua.MonitoringModeSampling {
+			continue
+		}
+		if !take(it) {
+			return res, true
+		}
+	}
+	return res, false
+}
+
+// Server side service handlers
+
+func (s *Server) handleCreateSubscription(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.CreateSubscriptionRequest)
+	m := s.subs
+
+	sub := &serverSubscription{
+		m:                 m,
+		session:           sess,
+		identity:          sess.UserIdentity(),
+		publishingEnabled: r.PublishingEnabled,
+		first:             true,
+		items:             make(map[uint32]*serverMonitoredItem),
+		kick:              make(chan struct{}, 1),
+	}
+	sub.ctx, sub.cancel = context.WithCancel(context.Background())
+	sub.revise(r.RequestedPublishingInterval, r.RequestedLifetimeCount, r.RequestedMaxKeepAliveCount, r.MaxNotificationsPerPublish)
+
+	m.mu.Lock()
+	m.nextID++
+	sub.id = m.nextID
+	m.subs[sub.id] = sub
+	m.mu.Unlock()
+
+	debug.Printf("server: session %s: created subscription %d", sess.id, sub.id)
+	go sub.run()
+
+	return &ua.CreateSubscriptionResponse{
+		SubscriptionID:            sub.id,
+		RevisedPublishingInterval: durationToMillis(sub.interval),
+		RevisedLifetimeCount:      sub.lifetimeCount,
+		RevisedMaxKeepAliveCount:  sub.maxKeepAliveCount,
+	}, nil
+}
+
+func (s *Server) handleModifySubscription(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.ModifySubscriptionRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	sub.revise(r.RequestedPublishingInterval, r.RequestedLifetimeCount, r.RequestedMaxKeepAliveCount, r.MaxNotificationsPerPublish)
+	return &ua.ModifySubscriptionResponse{
+		RevisedPublishingInterval: durationToMillis(sub.interval),
+		RevisedLifetimeCount:      sub.lifetimeCount,
+		RevisedMaxKeepAliveCount:  sub.maxKeepAliveCount,
+	}, nil
+}
+
+func (s *Server) handleSetPublishingMode(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.SetPublishingModeRequest)
+	if err := checkOperations(len(r.SubscriptionIDs), 0); err != nil {
+		return nil, err
+	}
+
+	results := make([]ua.StatusCode, len(r.SubscriptionIDs))
+	for i, id := range r.SubscriptionIDs {
+		sub, err := s.subs.subscription(sess, id)
+		if err != nil {
+			results[i] = ua.StatusBadSubscriptionIDInvalid
+			continue
+		}
+		sub.mu.Lock()
+		sub.publishingEnabled = r.PublishingEnabled
+		sub.lifetimeCounter = 0
+		sub.mu.Unlock()
+	}
+	return &ua.SetPublishingModeResponse{Results: results}, nil
+}
+
+func (s *Server) handleDeleteSubscriptions(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.DeleteSubscriptionsRequest)
+	if err := checkOperations(len(r.SubscriptionIDs), 0); err != nil {
+		return nil, err
+	}
+
+	results := make([]ua.StatusCode, len(r.SubscriptionIDs))
+	for i, id := range r.SubscriptionIDs {
+		sub, err := s.subs.subscription(sess, id)
+		if err != nil {
+			results[i] = ua.StatusBadSubscriptionIDInvalid
+			continue
+		}
+		s.subs.remove(sub)
+		debug.Printf("server: session %s: deleted subscription %d", sess.id, id)
+	}
+	return &ua.DeleteSubscriptionsResponse{Results: results}, nil
+}
+
+func (s *Server) handlePublish(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.PublishRequest)
+	m := s.subs
+
+	results := make([]ua.StatusCode, len(r.SubscriptionAcknowledgements))
+	for i, ack := range r.SubscriptionAcknowledgements {
+		sub, err := m.subscription(sess, ack.SubscriptionID)
+		if err != nil {
+			results[i] = ua.StatusBadSubscriptionIDInvalid
+			continue
+		}
+		results[i] = sub.acknowledge(ack.SequenceNumber)
+	}
+
+	m.mu.Lock()
+	if q := m.statusChanges[sess]; len(q) > 0 {
+		res := q[0]
+		m.statusChanges[sess] = q[1:]
+		m.mu.Unlock()
+		res.Results = results
+		return res, nil
+	}
+	m.mu.Unlock()
+
+	subs := m.sessionSubscriptions(sess)
+	if len(subs) == 0 {
+		return nil, ua.StatusBadNoSubscription
+	}
+
+	pr := newPublishRequest(results)
+	m.mu.Lock()
+	if len(m.queues[sess]) >= maxPublishRequests {
+		m.mu.Unlock()
+		return nil, ua.StatusBadTooManyPublishRequests
+	}
+	m.queues[sess] = append(m.queues[sess], pr)
+	m.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.kick <- struct{}{}:
+		default:
+		}
+	}
+
+	var timeout <-chan time.Time
+	if hint := r.RequestHeader.TimeoutHint; hint > 0 {
+		t := time.NewTimer(time.Duration(hint) * time.Millisecond)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	var err error
+	select {
+	case res := <-pr.ch:
+		return res.res, res.err
+	case <-timeout:
+		err = ua.StatusBadTimeout
+	case <-sess.Context().Done():
+		err = ua.StatusBadSessionClosed
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if m.removePublishRequest(sess, pr) {
+		return nil, err
+	}
+	// the request was taken from the queue and is answered now
+	res := <-pr.ch
+	return res.res, res.err
+}
+
+func (s *Server) handleRepublish(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.RepublishRequest)
+	sub, err := s.subs.subscription(sess, r.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	for _, msg := range sub.retransmission {
+		if msg.SequenceNumber == r.RetransmitSequenceNumber {
+			return &ua.RepublishResponse{NotificationMessage: msg}, nil
+		}
+	}
+	return nil, ua.StatusBadMessageNotAvailable
+}
+
+func (s *Server) handleTransferSubscriptions(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.TransferSubscriptionsRequest)
+	if err := checkOperations(len(r.SubscriptionIDs), 0); err != nil {
+		return nil, err
+	}
+	m := s.subs
+
+	identity := sess.UserIdentity()
+	results := make([]*ua.TransferResult, len(r.SubscriptionIDs))
+	for i, id := range r.SubscriptionIDs {
+		m.mu.Lock()
+		sub := m.subs[id]
+		if sub == nil {
+			m.mu.Unlock()
+			results[i] = &ua.TransferResult{StatusCode: ua.StatusBadSubscriptionIDInvalid}
+			continue
+		}
+		// only the user who owns the subscription can transfer it
+		// (Part 4, 5.13.7)
+		if !sameUserIdentity(sub.identity, identity) {
+			m.mu.Unlock()
+			results[i] = &ua.TransferResult{StatusCode: ua.StatusBadUserAccessDenied}
+			continue
+		}
+		old := sub.session
+		sub.session = sess
+		m.mu.Unlock()
+
+		sub.mu.Lock()
+		if old != nil && old != sess {
+			m.mu.Lock()
+			m.statusChanges[old] = append(m.statusChanges[old], statusChangeResponse(sub.id, sub.nextSequenceNumber(), ua.StatusGoodSubscriptionTransferred))
+			m.mu.Unlock()
+		}
+		if r.SendInitialValues {
+			for _, it := range sub.items {
+				if it.last != nil && len(it.queue) == 0 {
+					it.queue = append(it.queue, it.notificationValue(it.last))
+				}
+			}
+		}
+		sub.lifetimeCounter = 0
+		results[i] = &ua.TransferResult{StatusCode: ua.StatusOK, AvailableSequenceNumbers: sub.availableSequenceNumbers()}
+		sub.mu.Unlock()
+
+		debug.Printf("server: session %s: transferred subscription %d", sess.id, id)
+	}
+	return &ua.TransferSubscriptionsResponse{Results: results}, nil
+}
+
+// durationToMillis returns the duration in milliseconds.
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,298 @@
+package opcua
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+// subscribeTestClient creates a subscription with a short publishing
+// interval.
+func subscribeTestClient(t *testing.T, c *Client) (*Subscription, chan *PublishNotificationData) {
+	t.Helper()
+
+	ch := make(chan *PublishNotificationData, 100)
+	sub, err := c.Subscribe(&SubscriptionParameters{Interval: 20 * time.Millisecond}, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sub.Cancel(context.Background()) })
+	return sub, ch
+}
+
+// nextDataChanges returns the values of the next n monitored item
+// notifications by client handle.
+func nextDataChanges(t *testing.T, ch chan *PublishNotificationData, n int) map[uint32]interface{} {
+	t.Helper()
+
+	values := map[uint32]interface{}{}
+	for i := 0; i < n; {
+		select {
+		case notif := <-ch:
+			if notif.Error != nil {
+				t.Fatal(notif.Error)
+			}
+			dcn, ok := notif.Value.(*ua.DataChangeNotification)
+			if !ok {
+				t.Fatalf("got %T want *ua.DataChangeNotification", notif.Value)
+			}
+			for _, item := range dcn.MonitoredItems {
+				values[item.ClientHandle] = item.Value.Value.Value()
+				i++
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for data change")
+		}
+	}
+	return values
+}
+
+// noDataChange fails if a notification arrives within d.
+func noDataChange(t *testing.T, ch chan *PublishNotificationData, d time.Duration) {
+	t.Helper()
+
+	select {
+	case n := <-ch:
+		t.Fatalf("got unexpected notification %#v", n.Value)
+	case <-time.After(d):
+	}
+}
+
+func setTestValue(t *testing.T, srv *Server, ns uint16, name string, v interface{}) {
+	t.Helper()
+
+	m := srv.AddressSpace().NodeManager(ns).(*MemoryNodeManager)
+	err := m.SetValue(ua.NewStringNodeID(ns, name), &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerSubscription(t *testing.T) {
+	srv := startTestServer(t)
+	ns, counterNS := addTestNodes(t, srv)
+	c := connectTestClient(t, srv)
+	sub, ch := subscribeTestClient(t, c)
+
+	res, err := sub.Monitor(ua.TimestampsToReturnBoth,
+		NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, "Machine.Speed"), ua.AttributeIDValue, 1),
+		NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, "Machine.Name"), ua.AttributeIDValue, 2),
+		NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, "unknown"), ua.AttributeIDValue, 3),
+		NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(counterNS, "counter"), ua.AttributeIDNodeClass, 4),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "status", res.Results[0].StatusCode, ua.StatusOK)
+	verify.Values(t, "sampling interval", res.Results[0].RevisedSamplingInterval, 10.0)
+	verify.Values(t, "queue size", res.Results[0].RevisedQueueSize, uint32(10))
+	verify.Values(t, "unknown node", res.Results[2].StatusCode, ua.StatusBadNodeIDUnknown)
+
+	verify.Values(t, "initial values", nextDataChanges(t, ch, 3), map[uint32]interface{}{
+		1: 1.5,
+		2: "m1",
+		4: int32(ua.NodeClassVariable),
+	})
+
+	setTestValue(t, srv, ns, "Machine.Speed", 2.5)
+	verify.Values(t, "changed value", nextDataChanges(t, ch, 1), map[uint32]interface{}{1: 2.5})
+
+	// an unchanged value is not reported
+	setTestValue(t, srv, ns, "Machine.Speed", 2.5)
+	noDataChange(t, ch, 100*time.Millisecond)
+
+	if _, err := sub.Unmonitor(res.Results[0].MonitoredItemID); err != nil {
+		t.Fatal(err)
+	}
+	setTestValue(t, srv, ns, "Machine.Speed", 3.5)
+	noDataChange(t, ch, 100*time.Millisecond)
+
+	if err := sub.Cancel(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "subscriptions", len(srv.subs.subs), 0)
+}
+
+func TestServerSubscriptionDeadband(t *testing.T) {
+	srv := startTestServer(t)
+	ns, _ := addTestNodes(t, srv)
+	c := connectTestClient(t, srv)
+	sub, ch := subscribeTestClient(t, c)
+
+	item := func(name string, deadbandType ua.DeadbandType, deadband float64) *ua.MonitoredItemCreateRequest {
+		req := NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, name), ua.AttributeIDValue, 1)
+		req.RequestedParameters.Filter = ua.NewExtensionObject(&ua.DataChangeFilter{
+			Trigger:       ua.DataChangeTriggerStatusValue,
+			DeadbandType:  uint32(deadbandType),
+			DeadbandValue: deadband,
+		})
+		return req
+	}
+
+	res, err := sub.Monitor(ua.TimestampsToReturnNeither,
+		item("Machine.Speed", ua.DeadbandTypeAbsolute, 1),
+		item("Machine.Speed", ua.DeadbandTypePercent, 10),
+		item("Machine.Name", ua.DeadbandTypeAbsolute, 1),
+		item("Machine.Speed", ua.DeadbandTypeAbsolute, -1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []ua.StatusCode
+	for _, r := range res.Results {
+		got = append(got, r.StatusCode)
+	}
+	verify.Values(t, "results", got, []ua.StatusCode{
+		ua.StatusOK,
+		ua.StatusBadDeadbandFilterInvalid,
+		ua.StatusBadFilterNotAllowed,
+		ua.StatusBadDeadbandFilterInvalid,
+	})
+
+	verify.Values(t, "initial value", nextDataChanges(t, ch, 1), map[uint32]interface{}{1: 1.5})
+
+	setTestValue(t, srv, ns, "Machine.Speed", 2.0)
+	noDataChange(t, ch, 100*time.Millisecond)
+
+	setTestValue(t, srv, ns, "Machine.Speed", 2.6)
+	verify.Values(t, "changed value", nextDataChanges(t, ch, 1), map[uint32]interface{}{1: 2.6})
+}
+
+func TestServerSubscriptionTriggering(t *testing.T) {
+	srv := startTestServer(t)
+	ns, _ := addTestNodes(t, srv)
+	c := connectTestClient(t, srv)
+	sub, ch := subscribeTestClient(t, c)
+
+	res, err := sub.Monitor(ua.TimestampsToReturnNeither,
+		NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, "Machine.Speed"), ua.AttributeIDValue, 1),
+		NewMonitoredItemCreateRequestWithDefaults(ua.NewStringNodeID(ns, "Machine.Name"), ua.AttributeIDValue, 2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	speedID, nameID := res.Results[0].MonitoredItemID, res.Results[1].MonitoredItemID
+	verify.Values(t, "initial values", nextDataChanges(t, ch, 2), map[uint32]interface{}{1: 1.5, 2: "m1"})
+
+	err = c.Send(&ua.SetMonitoringModeRequest{
+		SubscriptionID:   sub.SubscriptionID,
+		MonitoringMode:   ua.MonitoringModeSampling,
+		MonitoredItemIDs: []uint32{nameID},
+	}, func(v interface{}) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := sub.SetTriggering(speedID, []uint32{nameID, 99}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "add results", tr.AddResults, []ua.StatusCode{ua.StatusOK, ua.StatusBadMonitoredItemIDInvalid})
+
+	// the sampling item is only reported with the triggering item
+	setTestValue(t, srv, ns, "Machine.Name", "m2")
+	noDataChange(t, ch, 100*time.Millisecond)
+
+	setTestValue(t, srv, ns, "Machine.Speed", 2.5)
+	verify.Values(t, "triggered values", nextDataChanges(t, ch, 2), map[uint32]interface{}{1: 2.5, 2: "m2"})
+}
+
+func TestServerPublish(t *testing.T) {
+	srv := startTestServer(t)
+	c := connectTestClient(t, srv)
+
+	publish := func() (*ua.PublishResponse, error) {
+		var res *ua.PublishResponse
+		err := c.Send(&ua.PublishRequest{}, func(v interface{}) error {
+			return safeAssign(v, &res)
+		})
+		return res, err
+	}
+
+	_, err := publish()
+	verify.Values(t, "no subscription", err, ua.StatusBadNoSubscription)
+
+	var cs *ua.CreateSubscriptionResponse
+	err = c.Send(&ua.CreateSubscriptionRequest{
+		RequestedPublishingInterval: 10,
+		RequestedLifetimeCount:      6,
+		RequestedMaxKeepAliveCount:  2,
+		PublishingEnabled:           true,
+	}, func(v interface{}) error {
+		return safeAssign(v, &cs)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "revised lifetime", cs.RevisedLifetimeCount, uint32(6))
+
+	// the first message is a keep-alive
+	res, err := publish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "keep-alive", res.NotificationMessage.NotificationData, []*ua.ExtensionObject(nil))
+	verify.Values(t, "keep-alive subscription", res.SubscriptionID, cs.SubscriptionID)
+	verify.Values(t, "keep-alive sequence number", res.NotificationMessage.SequenceNumber, uint32(1))
+
+	err = c.Send(&ua.RepublishRequest{SubscriptionID: cs.SubscriptionID, RetransmitSequenceNumber: 1}, func(v interface{}) error { return nil })
+	verify.Values(t, "republish", err, ua.StatusBadMessageNotAvailable)
+
+	// without publish requests the subscription expires
+	time.Sleep(200 * time.Millisecond)
+	res, err = publish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "expired", res.NotificationMessage.NotificationData[0].Value, &ua.StatusChangeNotification{
+		Status:         ua.StatusBadTimeout,
+		DiagnosticInfo: &ua.DiagnosticInfo{},
+	})
+
+	_, err = publish()
+	verify.Values(t, "no subscription", err, ua.StatusBadNoSubscription)
+}
+
+func TestServerTransferSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	srv, ep := startUserTestServer(t)
+
+	connect := func(user string) *Client {
+		c := NewClient(srv.Endpoint(), AuthUsername(user, "pass"), SecurityFromEndpoint(ep, ua.UserTokenTypeUserName), AutoReconnect(false))
+		if err := c.Connect(ctx); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { c.CloseWithContext(ctx) })
+		return c
+	}
+	transfer := func(c *Client, subID uint32) ua.StatusCode {
+		t.Helper()
+		var res *ua.TransferSubscriptionsResponse
+		err := c.Send(&ua.TransferSubscriptionsRequest{SubscriptionIDs: []uint32{subID}}, func(v interface{}) error {
+			return safeAssign(v, &res)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0].StatusCode
+	}
+
+	c1 := connect("a")
+	var cs *ua.CreateSubscriptionResponse
+	err := c1.Send(&ua.CreateSubscriptionRequest{
+		RequestedPublishingInterval: 100,
+		RequestedLifetimeCount:      100,
+		RequestedMaxKeepAliveCount:  10,
+	}, func(v interface{}) error {
+		return safeAssign(v, &cs)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verify.Values(t, "other user", transfer(connect("b"), cs.SubscriptionID), ua.StatusBadUserAccessDenied)
+	verify.Values(t, "same user", transfer(connect("a"), cs.SubscriptionID), ua.StatusOK)
+	verify.Values(t, "unknown", transfer(c1, cs.SubscriptionID+1), ua.StatusBadSubscriptionIDInvalid)
+}
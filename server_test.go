@@ -0,0 +1,955 @@
+package opcua
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/imatic-tech/opcua/nodeset"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uapki"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+// newTestCertificate creates a self-signed certificate for tests.
+func newTestCertificate(t *testing.T, appURI string) ([]byte, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uri, err := url.Parse(appURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: appURI},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		URIs:                  []*url.URL{uri},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+// startTestServer starts a server on a random port.
+func startTestServer(t testing.TB, opts ...ServerOption) *Server {
+	t.Helper()
+
+	srv := NewServer("opc.tcp://127.0.0.1:0", opts...)
+	if err := srv.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	return srv
+}
+
+// connectTestClient connects an anonymous client without security.
+func connectTestClient(t testing.TB, srv *Server) *Client {
+	t.Helper()
+
+	ctx := context.Background()
+	c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.CloseWithContext(ctx) })
+	return c
+}
+
+// startUserTestServer starts a server which accepts user names on
+// channels without security and returns its endpoint without security.
+// The server has a certificate to protect the passwords.
+func startUserTestServer(t *testing.T) (*Server, *ua.EndpointDescription) {
+	t.Helper()
+
+	cert, key := newTestCertificate(t, "urn:gopcua:server")
+	srv := startTestServer(t, ServerCertificate(cert), ServerPrivateKey(key), EnableAuthMode(ua.UserTokenTypeUserName))
+	ep := SelectEndpoint(srv.Endpoints(), "None", ua.MessageSecurityModeNone)
+	if ep == nil {
+		t.Fatal("no endpoint without security")
+	}
+	return srv, ep
+}
+
+func TestServerConnect(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+
+	c := NewClient(srv.Endpoint(), SecurityMode(ua.MessageSecurityModeNone), AutoReconnect(false))
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	verify.Values(t, "namespaces", c.Namespaces(), []string{"http://opcfoundation.org/UA/", "urn:gopcua:server"})
+
+	res, err := c.GetEndpointsWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(res.Endpoints), 1; got != want {
+		t.Fatalf("got %d endpoints want %d", got, want)
+	}
+	verify.Values(t, "endpoint", res.Endpoints[0].EndpointURL, srv.Endpoint())
+
+	// services without a handler are rejected
+	srv.Handle(id.BrowseRequest_Encoding_DefaultBinary, nil)
+	_, err = c.BrowseWithContext(ctx, &ua.BrowseRequest{})
+	verify.Values(t, "browse", err, ua.StatusBadServiceUnsupported)
+}
+
+func TestServerHandshakeTimeout(t *testing.T) {
+	srv := startTestServer(t, ServerHandshakeTimeout(100*time.Millisecond))
+
+	// a client which does not send HEL does not block other clients
+	idle, err := net.Dial("tcp", srv.l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idle.Close()
+	connectTestClient(t, srv)
+
+	// the server closes the connection after the timeout
+	idle.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.Copy(ioutil.Discard, idle); err != nil {
+		t.Fatalf("got %v want EOF", err)
+	}
+}
+
+func TestServerRejectsRequestsWithoutSession(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+
+	c := NewClient(srv.Endpoint(), AutoReconnect(false))
+	if err := c.Dial(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer c.CloseWithContext(ctx)
+
+	_, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: ua.NewNumericNodeID(0, id.Server_NamespaceArray)}},
+	})
+	verify.Values(t, "", err, ua.StatusBadSessionIDInvalid)
+}
+
+func TestServerActivateSessionOnOtherChannel(t *testing.T) {
+	ctx := context.Background()
+	srv, ep := startUserTestServer(t)
+
+	dial := func(user string) *Client {
+		c := NewClient(srv.Endpoint(), AuthUsername(user, "pass"), SecurityFromEndpoint(ep, ua.UserTokenTypeUserName), AutoReconnect(false))
+		if err := c.Dial(ctx); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { c.CloseWithContext(ctx) })
+		return c
+	}
+
+	c1 := dial("a")
+	s, err := c1.CreateSessionWithContext(ctx, c1.cfg.session)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first activation must happen on the channel of the session
+	c2 := dial("a")
+	verify.Values(t, "first activation", c2.ActivateSessionWithContext(ctx, s), ua.StatusBadSecureChannelIDInvalid)
+	if err := c1.ActivateSessionWithContext(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c1.DetachSessionWithContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the user cannot change when the session is moved
+	c3 := dial("b")
+	s3 := *s
+	s3.cfg = c3.cfg.session
+	verify.Values(t, "other user", c3.ActivateSessionWithContext(ctx, &s3), ua.StatusBadIdentityChangeNotSupported)
+
+	// the same user can move the session
+	if err := c2.ActivateSessionWithContext(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c2.ReadWithContext(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: ua.NewNumericNodeID(0, id.Server_NamespaceArray), AttributeID: ua.AttributeIDValue}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerSecureConnect(t *testing.T) {
+	ctx := context.Background()
+
+	srvCert, srvKey := newTestCertificate(t, "urn:gopcua:server")
+	srv := startTestServer(t,
+		ServerCertificate(srvCert),
+		ServerPrivateKey(srvKey),
+		EnableSecurity("None", ua.MessageSecurityModeNone),
+		EnableSecurity("Basic256Sha256", ua.MessageSecurityModeSignAndEncrypt),
+		EnableAuthMode(ua.UserTokenTypeUserName),
+		ServerUserValidator(func(tok interface{}) error {
+			if t, ok := tok.(*ua.UserNameIdentityToken); ok && (t.UserName != "user" || string(t.Password) != "pass") {
+				return ua.StatusBadUserAccessDenied
+			}
+			return nil
+		}),
+	)
+
+	eps, err := GetEndpoints(ctx, srv.Endpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ep := SelectEndpoint(eps, "Basic256Sha256", ua.MessageSecurityModeSignAndEncrypt)
+	if ep == nil {
+		t.Fatal("no secure endpoint")
+	}
+
+	cliCert, cliKey := newTestCertificate(t, "urn:gopcua:client")
+	connect := func(pass string) error {
+		c := NewClient(srv.Endpoint(),
+			Certificate(cliCert),
+			PrivateKey(cliKey),
+			AuthUsername("user", pass),
+			SecurityFromEndpoint(ep, ua.UserTokenTypeUserName),
+			AutoReconnect(false),
+		)
+		if err := c.Connect(ctx); err != nil {
+			return err
+		}
+		return c.CloseWithContext(ctx)
+	}
+
+	if err := connect("pass"); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "wrong password", connect("wrong"), ua.StatusBadUserAccessDenied)
+}
+
+func TestServerUserTokensWithoutSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	connect := func(srv *Server, opts ...Option) error {
+		c := NewClient(srv.Endpoint(), append(opts, AutoReconnect(false))...)
+		if err := c.Connect(ctx); err != nil {
+			return err
+		}
+		return c.CloseWithContext(ctx)
+	}
+
+	t.Run("encrypted password", func(t *testing.T) {
+		srv, ep := startUserTestServer(t)
+		verify.Values(t, "token policy", ep.UserIdentityTokens[0].SecurityPolicyURI, ua.SecurityPolicyURIBasic256Sha256)
+		if err := connect(srv, AuthUsername("user", "pass"), SecurityFromEndpoint(ep, ua.UserTokenTypeUserName)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("plaintext password", func(t *testing.T) {
+		srv, ep := startUserTestServer(t)
+		plain := func(cfg *Config) { cfg.session.AuthPolicyURI = ua.SecurityPolicyURINone }
+		err := connect(srv, AuthUsername("user", "pass"), SecurityFromEndpoint(ep, ua.UserTokenTypeUserName), plain)
+		verify.Values(t, "", err, ua.StatusBadIdentityTokenInvalid)
+	})
+
+	t.Run("unsigned certificate", func(t *testing.T) {
+		cert, key := newTestCertificate(t, "urn:gopcua:server")
+		srv := startTestServer(t, ServerCertificate(cert), ServerPrivateKey(key), EnableAuthMode(ua.UserTokenTypeCertificate))
+		ep := SelectEndpoint(srv.Endpoints(), "None", ua.MessageSecurityModeNone)
+		userCert, _ := newTestCertificate(t, "urn:gopcua:user")
+		unsigned := func(cfg *Config) { cfg.session.AuthPolicyURI = ua.SecurityPolicyURINone }
+		err := connect(srv, AuthCertificate(userCert), SecurityFromEndpoint(ep, ua.UserTokenTypeCertificate), unsigned)
+		verify.Values(t, "", err, ua.StatusBadUserSignatureInvalid)
+	})
+
+	t.Run("no server certificate", func(t *testing.T) {
+		srv := startTestServer(t, EnableAuthMode(ua.UserTokenTypeUserName))
+		ep := SelectEndpoint(srv.Endpoints(), "None", ua.MessageSecurityModeNone)
+		verify.Values(t, "user tokens", ep.UserIdentityTokens, []*ua.UserTokenPolicy(nil))
+		err := connect(srv, SecurityMode(ua.MessageSecurityModeNone), AuthUsername("user", "pass"))
+		verify.Values(t, "", err, ua.StatusBadIdentityTokenInvalid)
+	})
+}
+
+func TestServerRejectsUnknownSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	srvCert, srvKey := newTestCertificate(t, "urn:gopcua:server")
+	srv := startTestServer(t, ServerCertificate(srvCert), ServerPrivateKey(srvKey))
+
+	cliCert, cliKey := newTestCertificate(t, "urn:gopcua:client")
+	ep := &ua.EndpointDescription{
+		SecurityPolicyURI: ua.SecurityPolicyURIBasic256Sha256,
+		SecurityMode:      ua.MessageSecurityModeSign,
+		ServerCertificate: srvCert,
+	}
+	c := NewClient(srv.Endpoint(),
+		Certificate(cliCert),
+		PrivateKey(cliKey),
+		SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+		AutoReconnect(false),
+	)
+	if err := c.Dial(ctx); err == nil {
+		c.CloseWithContext(ctx)
+		t.Fatal("got nil want error")
+	}
+}
+
+func TestServerCertificateValidation(t *testing.T) {
+	ctx := context.Background()
+
+	srvStore, err := uapki.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srvCert, srvKey := newTestCertificate(t, "urn:gopcua:server")
+	srv := startTestServer(t,
+		ServerCertificate(srvCert),
+		ServerPrivateKey(srvKey),
+		EnableSecurity("None", ua.MessageSecurityModeNone),
+		EnableSecurity("Basic256Sha256", ua.MessageSecurityModeSign),
+		ServerCertificateValidator(srvStore),
+	)
+
+	eps, err := GetEndpoints(ctx, srv.Endpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ep := SelectEndpoint(eps, "Basic256Sha256", ua.MessageSecurityModeSign)
+	if ep == nil {
+		t.Fatal("no secure endpoint")
+	}
+
+	cliStore, err := uapki.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cliCert, cliKey := newTestCertificate(t, "urn:gopcua:client")
+	dial := func(ep *ua.EndpointDescription) error {
+		c := NewClient(srv.Endpoint(),
+			Certificate(cliCert),
+			PrivateKey(cliKey),
+			SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+			CertificateValidator(cliStore),
+			AutoReconnect(false),
+		)
+		if err := c.Dial(ctx); err != nil {
+			return err
+		}
+		return c.CloseWithContext(ctx)
+	}
+
+	verify.Values(t, "untrusted server", dial(ep), ua.StatusBadCertificateUntrusted)
+
+	if err := cliStore.Trust(srvCert); err != nil {
+		t.Fatal(err)
+	}
+	wrongURI := *ep
+	wrongURI.Server = &ua.ApplicationDescription{ApplicationURI: "urn:other"}
+	verify.Values(t, "wrong application uri", dial(&wrongURI), ua.StatusBadCertificateURIInvalid)
+
+	// the server closes the connection
+	if err := dial(ep); err == nil {
+		t.Fatal("untrusted client: got nil want error")
+	}
+
+	if err := srvStore.Trust(cliCert); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "trusted", dial(ep), nil)
+}
+
+// counterNodeManager is a NodeManager with a single variable which
+// returns the number of reads.
+type counterNodeManager struct {
+	reads uint32
+}
+
+func (m *counterNodeManager) Read(ctx context.Context, sess *ServerSession, rv *ua.ReadValueID) *ua.DataValue {
+	if rv.NodeID.StringID() != "counter" {
+		return &ua.DataValue{EncodingMask: ua.DataValueStatusCode, Status: ua.StatusBadNodeIDUnknown}
+	}
+	switch rv.AttributeID {
+	case ua.AttributeIDValue:
+		m.reads++
+		return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(m.reads)}
+	case ua.AttributeIDNodeClass:
+		return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(int32(ua.NodeClassVariable))}
+	case ua.AttributeIDBrowseName:
+		return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(&ua.QualifiedName{NamespaceIndex: rv.NodeID.Namespace(), Name: "counter"})}
+	default:
+		return &ua.DataValue{EncodingMask: ua.DataValueStatusCode, Status: ua.StatusBadAttributeIDInvalid}
+	}
+}
+
+func (m *counterNodeManager) Write(ctx context.Context, sess *ServerSession, wv *ua.WriteValue) ua.StatusCode {
+	return ua.StatusBadNotWritable
+}
+
+func (m *counterNodeManager) References(ctx context.Context, sess *ServerSession, nodeID *ua.NodeID) ([]*Reference, error) {
+	if nodeID.StringID() != "counter" {
+		return nil, ua.StatusBadNodeIDUnknown
+	}
+	return []*Reference{NewReference(ua.NewNumericNodeID(0, id.Organizes), false, ua.NewNumericNodeID(0, id.ObjectsFolder))}, nil
+}
+
+// addTestNodes adds an object with two variables to a new namespace and
+// a namespace with a counterNodeManager.
+func addTestNodes(t *testing.T, srv *Server) (ns, counterNS uint16) {
+	t.Helper()
+
+	as := srv.AddressSpace()
+	ns = as.AddNamespace("urn:gopcua:test", nil)
+
+	variable := func(name string, dataType uint32, access ua.AccessLevelType, v interface{}) *VariableNode {
+		return &VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewStringNodeID(ns, "Machine."+name),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: name},
+				References: []*Reference{
+					NewReference(ua.NewNumericNodeID(0, id.HasComponent), false, ua.NewStringNodeID(ns, "Machine")),
+					NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.BaseDataVariableType)),
+				},
+			},
+			Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)},
+			DataType:        ua.NewNumericNodeID(0, dataType),
+			ValueRank:       -1,
+			AccessLevel:     access,
+			UserAccessLevel: access,
+		}
+	}
+
+	for _, n := range []ServerNode{
+		&ObjectNode{BaseNode: BaseNode{
+			NodeID:     ua.NewStringNodeID(ns, "Machine"),
+			BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: "Machine"},
+			References: []*Reference{
+				NewReference(ua.NewNumericNodeID(0, id.Organizes), false, ua.NewNumericNodeID(0, id.ObjectsFolder)),
+				NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.BaseObjectType)),
+			},
+		}},
+		variable("Speed", id.Double, ua.AccessLevelTypeCurrentRead|ua.AccessLevelTypeCurrentWrite, 1.5),
+		variable("Name", id.String, ua.AccessLevelTypeCurrentRead, "m1"),
+	} {
+		if err := as.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counterNS = as.AddNamespace("urn:gopcua:counter", &counterNodeManager{})
+	return ns, counterNS
+}
+
+func TestServerReadWrite(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, counterNS := addTestNodes(t, srv)
+	c := connectTestClient(t, srv)
+
+	verify.Values(t, "namespaces", c.Namespaces(), []string{
+		"http://opcfoundation.org/UA/",
+		"urn:gopcua:server",
+		"urn:gopcua:test",
+		"urn:gopcua:counter",
+	})
+
+	read := func(nodeID *ua.NodeID) *ua.DataValue {
+		t.Helper()
+		res, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+			NodesToRead:        []*ua.ReadValueID{{NodeID: nodeID, AttributeID: ua.AttributeIDValue}},
+			TimestampsToReturn: ua.TimestampsToReturnNeither,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0]
+	}
+	write := func(nodeID *ua.NodeID, v interface{}) ua.StatusCode {
+		t.Helper()
+		res, err := c.WriteWithContext(ctx, &ua.WriteRequest{
+			NodesToWrite: []*ua.WriteValue{{
+				NodeID:      nodeID,
+				AttributeID: ua.AttributeIDValue,
+				Value:       &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0]
+	}
+
+	speed := ua.NewStringNodeID(ns, "Machine.Speed")
+	name := ua.NewStringNodeID(ns, "Machine.Name")
+	counter := ua.NewStringNodeID(counterNS, "counter")
+
+	verify.Values(t, "speed", read(speed).Value.Value(), 1.5)
+	verify.Values(t, "write speed", write(speed, 2.5), ua.StatusOK)
+	verify.Values(t, "speed", read(speed).Value.Value(), 2.5)
+	verify.Values(t, "write speed type", write(speed, "fast"), ua.StatusBadTypeMismatch)
+	verify.Values(t, "write name", write(name, "m2"), ua.StatusBadUserAccessDenied)
+	verify.Values(t, "unknown", read(ua.NewStringNodeID(ns, "unknown")).Status, ua.StatusBadNodeIDUnknown)
+	verify.Values(t, "unknown namespace", read(ua.NewStringNodeID(9, "x")).Status, ua.StatusBadNodeIDUnknown)
+	verify.Values(t, "counter", read(counter).Value.Value(), uint32(1))
+	verify.Values(t, "counter", read(counter).Value.Value(), uint32(2))
+	verify.Values(t, "write counter", write(counter, uint32(0)), ua.StatusBadNotWritable)
+
+	v, err := c.Node(speed).AttributeWithContext(ctx, ua.AttributeIDBrowseName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "browse name", v.Value(), &ua.QualifiedName{NamespaceIndex: ns, Name: "Speed"})
+
+	_, err = c.ReadWithContext(ctx, &ua.ReadRequest{})
+	verify.Values(t, "nothing to do", err, ua.StatusBadNothingToDo)
+}
+
+func TestServerWriteDisplayName(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns := srv.AddressSpace().AddNamespace("urn:gopcua:test", nil)
+	nodeID := ua.NewStringNodeID(ns, "Machine")
+	err := srv.AddressSpace().AddNode(&ObjectNode{BaseNode: BaseNode{
+		NodeID:        nodeID,
+		BrowseName:    &ua.QualifiedName{NamespaceIndex: ns, Name: "Machine"},
+		WriteMask:     writeMaskDisplayName,
+		UserWriteMask: writeMaskDisplayName,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := connectTestClient(t, srv)
+
+	write := func(dv *ua.DataValue) ua.StatusCode {
+		t.Helper()
+		res, err := c.WriteWithContext(ctx, &ua.WriteRequest{
+			NodesToWrite: []*ua.WriteValue{{NodeID: nodeID, AttributeID: ua.AttributeIDDisplayName, Value: dv}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0]
+	}
+
+	verify.Values(t, "no value", write(&ua.DataValue{}), ua.StatusBadTypeMismatch)
+	verify.Values(t, "string", write(&ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant("m2")}), ua.StatusBadTypeMismatch)
+	lt := &ua.LocalizedText{EncodingMask: ua.LocalizedTextText, Text: "m2"}
+	verify.Values(t, "text", write(&ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(lt)}), ua.StatusOK)
+
+	v, err := c.Node(nodeID).AttributeWithContext(ctx, ua.AttributeIDDisplayName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "display name", v.Value(), lt)
+}
+
+func TestServerRecoversFromPanic(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	c := connectTestClient(t, srv)
+
+	srv.Handle(id.BrowseRequest_Encoding_DefaultBinary, func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		panic("boom")
+	})
+	_, err := c.BrowseWithContext(ctx, &ua.BrowseRequest{})
+	verify.Values(t, "browse", err, ua.StatusBadInternalError)
+
+	// the channel is still usable
+	if _, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: ua.NewNumericNodeID(0, id.Server_NamespaceArray), AttributeID: ua.AttributeIDValue}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerReadWriteIndexRange(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, _ := addTestNodes(t, srv)
+
+	array := func(name string, valueRank int32, v interface{}) *VariableNode {
+		return &VariableNode{
+			BaseNode: BaseNode{
+				NodeID:     ua.NewStringNodeID(ns, "Machine."+name),
+				BrowseName: &ua.QualifiedName{NamespaceIndex: ns, Name: name},
+			},
+			Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)},
+			DataType:        ua.NewNumericNodeID(0, id.Int32),
+			ValueRank:       valueRank,
+			AccessLevel:     ua.AccessLevelTypeCurrentRead | ua.AccessLevelTypeCurrentWrite,
+			UserAccessLevel: ua.AccessLevelTypeCurrentRead | ua.AccessLevelTypeCurrentWrite,
+		}
+	}
+	for _, n := range []ServerNode{
+		array("Temperatures", 1, []int32{10, 11, 12, 13, 14}),
+		array("Matrix", 2, [][]int32{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}),
+	} {
+		if err := srv.AddressSpace().AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := connectTestClient(t, srv)
+
+	temps := c.Node(ua.NewStringNodeID(ns, "Machine.Temperatures"))
+	matrix := c.Node(ua.NewStringNodeID(ns, "Machine.Matrix"))
+	valueRange := func(n *Node, s string) interface{} {
+		t.Helper()
+		r, err := ua.ParseNumericRange(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := n.ValueRange(ctx, r)
+		if err != nil {
+			return err
+		}
+		return v.Value()
+	}
+	writeValueRange := func(n *Node, s string, v interface{}) error {
+		t.Helper()
+		r, err := ua.ParseNumericRange(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n.WriteValueRange(ctx, r, ua.MustVariant(v))
+	}
+
+	verify.Values(t, "slice", valueRange(temps, "1:3"), []int32{11, 12, 13})
+	verify.Values(t, "upper bound", valueRange(temps, "3:10"), []int32{13, 14})
+	verify.Values(t, "lower bound", valueRange(temps, "5"), ua.StatusBadIndexRangeNoData)
+	verify.Values(t, "block", valueRange(matrix, "1:2,0:1"), [][]int32{{4, 5}, {7, 8}})
+	verify.Values(t, "dimensions", valueRange(temps, "1,1"), ua.StatusBadIndexRangeInvalid)
+
+	verify.Values(t, "write element", writeValueRange(temps, "2", []int32{20}), nil)
+	verify.Values(t, "write slice", writeValueRange(temps, "3:4", []int32{30, 40}), nil)
+	verify.Values(t, "write size", writeValueRange(temps, "0:1", []int32{1}), ua.StatusBadIndexRangeInvalid)
+	verify.Values(t, "write type", writeValueRange(temps, "0", []float64{1}), ua.StatusBadTypeMismatch)
+	v, err := temps.ValueWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "array", v.Value(), []int32{10, 11, 20, 30, 40})
+
+	verify.Values(t, "write block", writeValueRange(matrix, "0:1,2", [][]int32{{0}, {0}}), nil)
+	verify.Values(t, "matrix", valueRange(matrix, "0:2"), [][]int32{{1, 2, 0}, {4, 5, 0}, {7, 8, 9}})
+
+	res, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: temps.ID, AttributeID: ua.AttributeIDValue, IndexRange: "3:1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "invalid range", res.Results[0].Status, ua.StatusBadIndexRangeInvalid)
+
+	// the current value of a variable with OnRead and OnWrite is not
+	// stored in the node
+	device := []int32{1, 2, 3}
+	node := array("Device", 1, nil)
+	node.Value = nil
+	node.OnRead = func(ctx context.Context, sess *ServerSession) *ua.DataValue {
+		return &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(device)}
+	}
+	node.OnWrite = func(ctx context.Context, sess *ServerSession, v *ua.DataValue) ua.StatusCode {
+		device = v.Value.Value().([]int32)
+		return ua.StatusOK
+	}
+	if err := srv.AddressSpace().AddNode(node); err != nil {
+		t.Fatal(err)
+	}
+	dev := c.Node(node.NodeID)
+	verify.Values(t, "write device", writeValueRange(dev, "1", []int32{20}), nil)
+	verify.Values(t, "device", device, []int32{1, 20, 3})
+}
+
+func TestServerBrowse(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, _ := addTestNodes(t, srv)
+	c := connectTestClient(t, srv)
+
+	names := func(refs []*ua.ReferenceDescription) []string {
+		var s []string
+		for _, r := range refs {
+			s = append(s, r.BrowseName.Name)
+		}
+		return s
+	}
+
+	refs, err := c.Node(ua.NewNumericNodeID(0, id.ObjectsFolder)).ReferencesWithContext(ctx, id.HierarchicalReferences, ua.BrowseDirectionForward, ua.NodeClassObject, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "objects", names(refs), []string{"Server", "Machine"})
+	verify.Values(t, "type definition", refs[1].TypeDefinition.NodeID, ua.NewNumericNodeID(0, id.BaseObjectType))
+
+	// page through the references with BrowseNext
+	machine := ua.NewStringNodeID(ns, "Machine")
+	res, err := c.BrowseWithContext(ctx, &ua.BrowseRequest{
+		RequestedMaxReferencesPerNode: 1,
+		NodesToBrowse: []*ua.BrowseDescription{{
+			NodeID:          machine,
+			BrowseDirection: ua.BrowseDirectionForward,
+			ReferenceTypeID: ua.NewNumericNodeID(0, id.HasComponent),
+			ResultMask:      uint32(ua.BrowseResultMaskAll),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "first page", names(res.Results[0].References), []string{"Speed"})
+	next, err := c.BrowseNextWithContext(ctx, &ua.BrowseNextRequest{ContinuationPoints: [][]byte{res.Results[0].ContinuationPoint}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "second page", names(next.Results[0].References), []string{"Name"})
+	verify.Values(t, "no continuation point", len(next.Results[0].ContinuationPoint), 0)
+
+	next, err = c.BrowseNextWithContext(ctx, &ua.BrowseNextRequest{ContinuationPoints: [][]byte{res.Results[0].ContinuationPoint}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "released continuation point", next.Results[0].StatusCode, ua.StatusBadContinuationPointInvalid)
+
+	// inverse references with subtypes
+	refs, err = c.Node(ua.NewStringNodeID(ns, "Machine.Speed")).ReferencesWithContext(ctx, id.HierarchicalReferences, ua.BrowseDirectionInverse, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "parent", names(refs), []string{"Machine"})
+}
+
+func TestServerInvalidNodeSet(t *testing.T) {
+	s := &nodeset.NodeSet{Objects: []*nodeset.Object{{Instance: nodeset.Instance{Node: nodeset.Node{NodeID: "ns=1;i=1", BrowseName: "1:Machine"}}}}}
+	srv := NewServer("opc.tcp://127.0.0.1:0", ServerNodeSet(s))
+	err := srv.Start(context.Background())
+	if err == nil {
+		srv.Close()
+	}
+	if got, want := err, errors.New("cannot load node set: opcua: node ns=1;i=1: opcua: invalid namespace index in ns=1;i=1"); !errors.Equal(got, want) {
+		t.Fatalf("got error %v want %v", got, want)
+	}
+}
+
+func TestServerNodeSetFileNotFound(t *testing.T) {
+	srv := NewServer("opc.tcp://127.0.0.1:0", ServerNodeSetFile("nodeset/testdata/Missing.NodeSet2.xml"))
+	err := srv.Start(context.Background())
+	if err == nil {
+		srv.Close()
+		t.Fatal("got nil want error")
+	}
+	if !strings.HasPrefix(err.Error(), "opcua: cannot read node set nodeset/testdata/Missing.NodeSet2.xml: ") {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestServerNodeSet(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	srv.AddressSpace().AddNamespace("urn:gopcua:other", nil)
+	s, err := nodeset.ReadFile("nodeset/testdata/Test.NodeSet2.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.AddressSpace().LoadNodeSet(s); err != nil {
+		t.Fatal(err)
+	}
+	c := connectTestClient(t, srv)
+
+	verify.Values(t, "namespaces", c.Namespaces(), []string{
+		"http://opcfoundation.org/UA/",
+		"urn:gopcua:server",
+		"urn:gopcua:other",
+		"urn:gopcua:test",
+	})
+	const ns = 3
+
+	v, err := c.Node(ua.NewNumericNodeID(ns, 7002)).ValueWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "speed", v.Value(), 42.5)
+	v, err = c.Node(ua.NewStringNodeID(ns, "Tags")).ValueWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "tags", v.Value(), []string{"a", "b"})
+
+	// the inverse reference of the Organizes reference to the objects
+	// folder which is not part of the node set
+	refs, err := c.Node(ua.NewNumericNodeID(0, id.ObjectsFolder)).ReferencesWithContext(ctx, id.Organizes, ua.BrowseDirectionForward, ua.NodeClassObject, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "objects", refs[len(refs)-1].BrowseName, &ua.QualifiedName{NamespaceIndex: ns, Name: "Machine1"})
+
+	// the inverse reference of a node which is added later
+	refs, err = c.Node(ua.NewNumericNodeID(ns, 7001)).ReferencesWithContext(ctx, id.HasComponent, ua.BrowseDirectionForward, ua.NodeClassVariable, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "components", len(refs), 2)
+
+	v, err = c.Node(ua.NewNumericNodeID(ns, 3003)).AttributeWithContext(ctx, ua.AttributeIDDataTypeDefinition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := v.Value().(*ua.ExtensionObject).Value.(*ua.StructureDefinition)
+	verify.Values(t, "encoding", def.DefaultEncodingID.String(), "ns=3;i=5002")
+	verify.Values(t, "structure type", def.StructureType, ua.StructureTypeStructureWithOptionalFields)
+	verify.Values(t, "field type", def.Fields[1].DataType.String(), "ns=3;i=3001")
+
+	v, err = c.Node(ua.NewNumericNodeID(ns, 3001)).AttributeWithContext(ctx, ua.AttributeIDDataTypeDefinition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enum := v.Value().(*ua.ExtensionObject).Value.(*ua.EnumDefinition)
+	verify.Values(t, "enum fields", len(enum.Fields), 2)
+	verify.Values(t, "enum field", enum.Fields[1].Name, "On")
+}
+
+func TestClientNamespaceURIs(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t, ServerNodeSetFile("nodeset/testdata/Test.NodeSet2.xml"))
+
+	var sent []uint16
+	srv.Handle(id.ReadRequest_Encoding_DefaultBinary, func(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+		for _, rv := range req.(*ua.ReadRequest).NodesToRead {
+			sent = append(sent, rv.NodeID.Namespace())
+		}
+		return srv.handleRead(ctx, sess, req)
+	})
+	c := connectTestClient(t, srv)
+
+	speed, err := c.NodeIDWithNamespaceURI(ua.NewNumericNodeID(2, 7002))
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "node id", speed.String(), "nsu=urn:gopcua:test;i=7002")
+
+	read := func() *ua.DataValue {
+		t.Helper()
+		sent = nil
+		res, err := c.ReadWithContext(ctx, &ua.ReadRequest{
+			NodesToRead: []*ua.ReadValueID{{NodeID: speed, AttributeID: ua.AttributeIDValue}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0]
+	}
+	verify.Values(t, "speed", read().Value.Value(), 42.5)
+	verify.Values(t, "namespace", sent, []uint16{2})
+
+	// the namespace index changes when the namespace array changes
+	ns := c.Namespaces()
+	c.setNamespaces([]string{ns[0], ns[1], "urn:gopcua:other", ns[2]})
+	verify.Values(t, "status", read().Status, ua.StatusBadNodeIDUnknown)
+	verify.Values(t, "namespace", sent, []uint16{3})
+
+	// the node id of the caller is not modified
+	verify.Values(t, "caller namespace", speed.Namespace(), uint16(2))
+
+	if err := c.UpdateNamespacesWithContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "speed", read().Value.Value(), 42.5)
+	verify.Values(t, "namespace", sent, []uint16{2})
+
+	_, err = c.Node(ua.MustParseNodeID("nsu=urn:gopcua:unknown;i=7002")).ValueWithContext(ctx)
+	if err == nil {
+		t.Fatal("got nil want error")
+	}
+}
+
+func TestServerTranslateBrowsePaths(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t)
+	ns, counterNS := addTestNodes(t, srv)
+	c := connectTestClient(t, srv)
+
+	objects := c.Node(ua.NewNumericNodeID(0, id.ObjectsFolder))
+
+	nodeID, err := objects.TranslateBrowsePathInNamespaceToNodeIDWithContext(ctx, ns, "Machine.Speed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "speed", nodeID, ua.NewStringNodeID(ns, "Machine.Speed"))
+
+	nodeID, err = objects.TranslateBrowsePathsToNodeIDsWithContext(ctx, []*ua.QualifiedName{{Name: "Server"}, {Name: "NamespaceArray"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "namespace array", nodeID, ua.NewNumericNodeID(0, id.Server_NamespaceArray))
+
+	_, err = objects.TranslateBrowsePathInNamespaceToNodeIDWithContext(ctx, ns, "Machine.Unknown")
+	verify.Values(t, "no match", err, ua.StatusBadNoMatch)
+
+	// the counter node has only an inverse reference to the objects folder
+	_, err = objects.TranslateBrowsePathInNamespaceToNodeIDWithContext(ctx, counterNS, "counter")
+	verify.Values(t, "counter", err, ua.StatusBadNoMatch)
+}
+
+func TestNodeTranslateRelativePaths(t *testing.T) {
+	ctx := context.Background()
+	srv := startTestServer(t,
+		ServerNodeSetFile("nodeset/testdata/Test.NodeSet2.xml"),
+		ServerOperationLimits(OperationLimits{MaxNodesPerTranslateBrowsePathsToNodeIDs: 2}),
+	)
+	c := connectTestClient(t, srv)
+
+	objects := c.Node(ua.NewNumericNodeID(0, id.ObjectsFolder))
+	res, err := objects.TranslateRelativePaths(ctx,
+		"/2:Machine1.2:Speed",
+		"/2:Machine1<HasTypeDefinition>2:MachineType",
+		"<!Organizes>Root",
+		"/2:Machine1/2:Unknown",
+		"/2:Machine1/",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []interface{}
+	for _, r := range res {
+		if r.StatusCode != ua.StatusOK {
+			got = append(got, r.StatusCode)
+			continue
+		}
+		for _, target := range r.Targets {
+			got = append(got, target.TargetID.NodeID.String(), target.RemainingPathIndex)
+		}
+	}
+	verify.Values(t, "", got, []interface{}{
+		"ns=2;i=7002", uint32(math.MaxUint32),
+		"ns=2;i=1001", uint32(math.MaxUint32),
+		"i=84", uint32(math.MaxUint32),
+		ua.StatusBadNoMatch,
+		ua.StatusBadBrowseNameInvalid,
+	})
+
+	_, err = objects.TranslateRelativePaths(ctx, "/2:Machine1", "Machine1")
+	if err == nil {
+		t.Fatal("got nil want error")
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package opcua
+
+import (
+	"context"
+	"crypto/rand"
+	"math"
+
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// maxBrowseContinuationPoints is the maximum number of browse
+// continuation points per session.
+const maxBrowseContinuationPoints = 10
+
+// browseContinuation contains the remaining references of a Browse
+// operation which returned a continuation point.
+type browseContinuation struct {
+	refs []*ua.ReferenceDescription
+	max  uint32
+}
+
+// Browse returns the references of a node which match the browse
+// description.
+//
+// Specification: Part 4, 5.8.2
+func (as *AddressSpace) Browse(ctx context.Context, sess *ServerSession, bd *ua.BrowseDescription) ([]*ua.ReferenceDescription, ua.StatusCode) {
+	if bd.BrowseDirection >= ua.BrowseDirectionInvalid {
+		return nil, ua.StatusBadBrowseDirectionInvalid
+	}
+	filterType := !isNullNodeID(bd.ReferenceTypeID)
+	if filterType && as.NodeClass(ctx, bd.ReferenceTypeID) != ua.NodeClassReferenceType {
+		return nil, ua.StatusBadReferenceTypeIDInvalid
+	}
+
+	refs, err := as.References(ctx, sess, bd.NodeID)
+	if err != nil {
+		if code, ok := err.(ua.StatusCode); ok {
+			return nil, code
+		}
+		return nil, ua.StatusBadNodeIDUnknown
+	}
+
+	var res []*ua.ReferenceDescription
+	for _, ref := range refs {
+		switch {
+		case bd.BrowseDirection == ua.BrowseDirectionForward && !ref.IsForward:
+			continue
+		case bd.BrowseDirection == ua.BrowseDirectionInverse && ref.IsForward:
+			continue
+		}
+		if filterType && !as.matchReferenceType(ctx, ref.ReferenceTypeID, bd.ReferenceTypeID, bd.IncludeSubtypes) {
+			continue
+		}
+		if rd := as.referenceDescription(ctx, sess, ref, bd.NodeClassMask, ua.BrowseResultMask(bd.ResultMask)); rd != nil {
+			res = append(res, rd)
+		}
+	}
+	return res, ua.StatusOK
+}
+
+func (as *AddressSpace) matchReferenceType(ctx context.Context, refType, filter *ua.NodeID, includeSubtypes bool) bool {
+	if refType.String() == filter.String() {
+		return true
+	}
+	return includeSubtypes && as.IsSubtype(ctx, refType, filter)
+}
+
+// referenceDescription returns the description of the reference with the
+// attributes of the target node in the result mask. It returns nil if the
+// node class of the target node is not in the node class mask.
+func (as *AddressSpace) referenceDescription(ctx context.Context, sess *ServerSession, ref *Reference, classMask uint32, mask ua.BrowseResultMask) *ua.ReferenceDescription {
+	rd := &ua.ReferenceDescription{
+		ReferenceTypeID: ua.NewTwoByteNodeID(0),
+		NodeID:          ref.TargetID,
+		BrowseName:      &ua.QualifiedName{},
+		DisplayName:     &ua.LocalizedText{},
+		TypeDefinition:  ua.NewTwoByteExpandedNodeID(0),
+	}
+	if mask&ua.BrowseResultMaskReferenceTypeID != 0 {
+		rd.ReferenceTypeID = ref.ReferenceTypeID
+	}
+	if mask&ua.BrowseResultMaskIsForward != 0 {
+		rd.IsForward = ref.IsForward
+	}
+
+	// the attributes of nodes on other servers are unknown
+	target := as.localNodeID(ref.TargetID)
+	if target == nil {
+		return rd
+	}
+
+	class := as.NodeClass(ctx, target)
+	if classMask != 0 && class != ua.NodeClassUnspecified && uint32(class)&classMask == 0 {
+		return nil
+	}
+	if mask&ua.BrowseResultMaskNodeClass != 0 {
+		rd.NodeClass = class
+	}
+	if mask&ua.BrowseResultMaskBrowseName != 0 {
+		if v := as.attribute(ctx, sess, target, ua.AttributeIDBrowseName); v != nil {
+			if qn, ok := v.Value().(*ua.QualifiedName); ok {
+				rd.BrowseName = qn
+			}
+		}
+	}
+	if mask&ua.BrowseResultMaskDisplayName != 0 {
+		if v := as.attribute(ctx, sess, target, ua.AttributeIDDisplayName); v != nil {
+			if lt, ok := v.Value().(*ua.LocalizedText); ok {
+				rd.DisplayName = lt
+			}
+		}
+	}
+	if mask&ua.BrowseResultMaskTypeDefinition != 0 && (class == ua.NodeClassObject || class == ua.NodeClassVariable) {
+		if refs, err := as.References(ctx, sess, target); err == nil {
+			if td := typeDefinition(refs); td != nil {
+				rd.TypeDefinition = td
+			}
+		}
+	}
+	return rd
+}
+
+// attribute returns the value of an attribute or nil.
+func (as *AddressSpace) attribute(ctx context.Context, sess *ServerSession, nodeID *ua.NodeID, attr ua.AttributeID) *ua.Variant {
+	dv := as.Read(ctx, sess, &ua.ReadValueID{NodeID: nodeID, AttributeID: attr})
+	if dv.Status != ua.StatusOK {
+		return nil
+	}
+	return dv.Value
+}
+
+// TranslateBrowsePath returns the nodes which are reached by following
+// the relative path from the starting node.
+//
+// Specification: Part 4, 5.8.4
+func (as *AddressSpace) TranslateBrowsePath(ctx context.Context, sess *ServerSession, bp *ua.BrowsePath) *ua.BrowsePathResult {
+	if bp.RelativePath == nil || len(bp.RelativePath.Elements) == 0 {
+		return &ua.BrowsePathResult{StatusCode: ua.StatusBadNothingToDo}
+	}
+	if as.NodeClass(ctx, bp.StartingNode) == ua.NodeClassUnspecified {
+		return &ua.BrowsePathResult{StatusCode: ua.StatusBadNodeIDUnknown}
+	}
+
+	nodes := []*ua.NodeID{bp.StartingNode}
+	for _, el := range bp.RelativePath.Elements {
+		if el.TargetName == nil || el.TargetName.Name == "" {
+			return &ua.BrowsePathResult{StatusCode: ua.StatusBadBrowseNameInvalid}
+		}
+
+		var next []*ua.NodeID
+		seen := map[string]bool{}
+		for _, n := range nodes {
+			refs, err := as.References(ctx, sess, n)
+			if err != nil {
+				continue
+			}
+			for _, ref := range refs {
+				if ref.IsForward == el.IsInverse {
+					continue
+				}
+				if !isNullNodeID(el.ReferenceTypeID) && !as.matchReferenceType(ctx, ref.ReferenceTypeID, el.ReferenceTypeID, el.IncludeSubtypes) {
+					continue
+				}
+				target := as.localNodeID(ref.TargetID)
+				if target == nil || seen[target.String()] {
+					continue
+				}
+				v := as.attribute(ctx, sess, target, ua.AttributeIDBrowseName)
+				if v == nil {
+					continue
+				}
+				if qn, ok := v.Value().(*ua.QualifiedName); !ok || *qn != *el.TargetName {
+					continue
+				}
+				seen[target.String()] = true
+				next = append(next, target)
+			}
+		}
+		if len(next) == 0 {
+			return &ua.BrowsePathResult{StatusCode: ua.StatusBadNoMatch}
+		}
+		nodes = next
+	}
+
+	res := &ua.BrowsePathResult{StatusCode: ua.StatusOK}
+	for _, n := range nodes {
+		res.Targets = append(res.Targets, &ua.BrowsePathTarget{
+			TargetID:           &ua.ExpandedNodeID{NodeID: n},
+			RemainingPathIndex: math.MaxUint32,
+		})
+	}
+	return res
+}
+
+// handleBrowse implements the Browse service.
+//
+// Specification: Part 4, 5.8.2
+func (s *Server) handleBrowse(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.BrowseRequest)
+	if err := checkOperations(len(r.NodesToBrowse), s.cfg.operationLimits.MaxNodesPerBrowse); err != nil {
+		return nil, err
+	}
+	if r.View != nil && !isNullNodeID(r.View.ViewID) && s.as.NodeClass(ctx, r.View.ViewID) != ua.NodeClassView {
+		return nil, ua.StatusBadViewIDUnknown
+	}
+
+	results := make([]*ua.BrowseResult, len(r.NodesToBrowse))
+	for i, bd := range r.NodesToBrowse {
+		refs, status := s.as.Browse(ctx, sess, bd)
+		if status != ua.StatusOK {
+			results[i] = &ua.BrowseResult{StatusCode: status}
+			continue
+		}
+		results[i] = sess.browseResult(refs, r.RequestedMaxReferencesPerNode)
+	}
+	return &ua.BrowseResponse{Results: results}, nil
+}
+
+// handleBrowseNext implements the BrowseNext service.
+//
+// Specification: Part 4, 5.8.3
+func (s *Server) handleBrowseNext(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.BrowseNextRequest)
+	if err := checkOperations(len(r.ContinuationPoints), s.cfg.operationLimits.MaxNodesPerBrowse); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ua.BrowseResult, len(r.ContinuationPoints))
+	for i, cp := range r.ContinuationPoints {
+		c, ok := sess.takeContinuationPoint(cp)
+		switch {
+		case !ok:
+			results[i] = &ua.BrowseResult{StatusCode: ua.StatusBadContinuationPointInvalid}
+		case r.ReleaseContinuationPoints:
+			results[i] = &ua.BrowseResult{StatusCode: ua.StatusOK}
+		default:
+			results[i] = sess.browseResult(c.refs, c.max)
+		}
+	}
+	return &ua.BrowseNextResponse{Results: results}, nil
+}
+
+// browseResult returns at most max references and stores the remaining
+// references for BrowseNext. max == 0 means no limit.
+func (s *ServerSession) browseResult(refs []*ua.ReferenceDescription, max uint32) *ua.BrowseResult {
+	if max == 0 || uint32(len(refs)) <= max {
+		return &ua.BrowseResult{StatusCode: ua.StatusOK, References: refs}
+	}
+
+	cp := make([]byte, 16)
+	if _, err := rand.Read(cp); err != nil {
+		return &ua.BrowseResult{StatusCode: ua.StatusBadInternalError}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.continuationPoints) >= maxBrowseContinuationPoints {
+		return &ua.BrowseResult{StatusCode: ua.StatusBadNoContinuationPoints}
+	}
+	if s.continuationPoints == nil {
+		s.continuationPoints = make(map[string]*browseContinuation)
+	}
+	s.continuationPoints[string(cp)] = &browseContinuation{refs: refs[max:], max: max}
+	return &ua.BrowseResult{StatusCode: ua.StatusOK, ContinuationPoint: cp, References: refs[:max]}
+}
+
+// takeContinuationPoint removes the continuation point from the session.
+func (s *ServerSession) takeContinuationPoint(cp []byte) (*browseContinuation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.continuationPoints[string(cp)]
+	delete(s.continuationPoints, string(cp))
+	return c, ok
+}
+
+// handleTranslateBrowsePathsToNodeIDs implements the
+// TranslateBrowsePathsToNodeIds service.
+//
+// Specification: Part 4, 5.8.4
+func (s *Server) handleTranslateBrowsePathsToNodeIDs(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.TranslateBrowsePathsToNodeIDsRequest)
+	if err := checkOperations(len(r.BrowsePaths), s.cfg.operationLimits.MaxNodesPerTranslateBrowsePathsToNodeIDs); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ua.BrowsePathResult, len(r.BrowsePaths))
+	for i, bp := range r.BrowsePaths {
+		results[i] = s.as.TranslateBrowsePath(ctx, sess, bp)
+	}
+	return &ua.TranslateBrowsePathsToNodeIDsResponse{Results: results}, nil
+}
+
+// handleRegisterNodes implements the RegisterNodes service. The server
+// has no optimized access for registered nodes and returns the node ids
+// unchanged.
+//
+// Specification: Part 4, 5.8.5
+func (s *Server) handleRegisterNodes(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.RegisterNodesRequest)
+	if err := checkOperations(len(r.NodesToRegister), s.cfg.operationLimits.MaxNodesPerRegisterNodes); err != nil {
+		return nil, err
+	}
+	return &ua.RegisterNodesResponse{RegisteredNodeIDs: r.NodesToRegister}, nil
+}
+
+// handleUnregisterNodes implements the UnregisterNodes service.
+//
+// Specification: Part 4, 5.8.6
+func (s *Server) handleUnregisterNodes(ctx context.Context, sess *ServerSession, req ua.Request) (ua.Response, error) {
+	r := req.(*ua.UnregisterNodesRequest)
+	if err := checkOperations(len(r.NodesToUnregister), s.cfg.operationLimits.MaxNodesPerRegisterNodes); err != nil {
+		return nil, err
+	}
+	return &ua.UnregisterNodesResponse{}, nil
+}
@@ -22,6 +22,7 @@ func init() {
 type Stats struct {
 	Client       *expvar.Map
 	Error        *expvar.Map
+	Server       *expvar.Map
 	Subscription *expvar.Map
 }
 
@@ -29,6 +30,7 @@ func NewStats() *Stats {
 	return &Stats{
 		Client:       &expvar.Map{},
 		Error:        &expvar.Map{},
+		Server:       &expvar.Map{},
 		Subscription: &expvar.Map{},
 	}
 }
@@ -37,6 +39,7 @@ func NewStats() *Stats {
 func (s *Stats) Reset() {
 	s.Client.Init()
 	s.Error.Init()
+	s.Server.Init()
 	s.Subscription.Init()
 }
 
@@ -81,6 +84,11 @@ func Error() *expvar.Map {
 	return stats.Error
 }
 
+// Server is the global server statistics map.
+func Server() *expvar.Map {
+	return stats.Server
+}
+
 // Subscription is the global subscription statistics map.
 func Subscription() *expvar.Map {
 	return stats.Subscription
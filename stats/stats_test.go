@@ -27,6 +27,9 @@ func TestConvienienceFuncs(t *testing.T) {
 
 	Subscription().Add("c", 3)
 	verify.Values(t, "", Subscription().Get("c"), newExpVarInt(3))
+
+	Server().Add("d", 4)
+	verify.Values(t, "", Server().Get("d"), newExpVarInt(4))
 }
 
 func TestRecordError(t *testing.T) {
@@ -129,11 +129,7 @@ func (s *Subscription) MonitorWithContext(ctx context.Context, ts ua.TimestampsT
 		ItemsToCreate:      items,
 	}
 
-	var res *ua.CreateMonitoredItemsResponse
-	err := s.c.SendWithContext(ctx, req, func(v interface{}) error {
-		return safeAssign(v, &res)
-	})
-
+	res, err := s.c.createMonitoredItems(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +159,20 @@ func (b *Buffer) ReadBytes() []byte {
 	return d
 }
 
+// readArrayLength reads the length of an array. It returns -1 for null
+// arrays and on errors.
+func (b *Buffer) readArrayLength() int {
+	n := b.ReadUint32()
+	if b.err != nil || n == null {
+		return -1
+	}
+	if n > math.MaxInt32 {
+		b.err = errors.Errorf("array too large: %d", n)
+		return -1
+	}
+	return int(n)
+}
+
 func (b *Buffer) ReadStruct(r interface{}) {
 	if b.err != nil {
 		return
@@ -294,6 +308,20 @@ func (b *Buffer) WriteByteString(d []byte) {
 	b.Write(d)
 }
 
+// writeArrayLength writes the length of an array or null for nil arrays.
+func (b *Buffer) writeArrayLength(n int, isNil bool) {
+	switch {
+	case isNil:
+		b.WriteUint32(null)
+	case n > math.MaxInt32:
+		if b.err == nil {
+			b.err = errors.Errorf("array too large")
+		}
+	default:
+		b.WriteUint32(uint32(n))
+	}
+}
+
 func (b *Buffer) WriteStruct(w interface{}) {
 	if b.err != nil {
 		return
@@ -4,5 +4,7 @@
 
 // Package ua defines the structures, decoders and encoder
 // for built-in data types described in Part 6 Section 5 Data encoding
-// and for services in OPC UA Binary Protocol.
+// and for services in OPC UA Binary Protocol. Values can also be
+// encoded with the XML encoding and the reversible and non-reversible
+// JSON encoding.
 package ua
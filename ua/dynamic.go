@@ -0,0 +1,553 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+)
+
+// DynamicStructure is the value of a structure whose Go type is not known
+// at compile time. It is decoded and encoded with the StructureDefinition
+// of its data type which must be registered with
+// RegisterStructureDescription, e.g. after it was read from the
+// DataTypeDefinition attribute of the data type node.
+//
+// Extension objects with the binary encoding of a registered structure
+// and no registered Go type are decoded into a *DynamicStructure.
+//
+// Specification: Part 6, 5.2.6
+type DynamicStructure struct {
+	// TypeID is the node id of the data type.
+	TypeID *NodeID
+
+	// Fields contains the fields in the order of the definition.
+	// Optional fields which are not set and the fields of a union
+	// which are not selected are omitted.
+	Fields []*DynamicField
+}
+
+// DynamicField is a field of a dynamic structure.
+//
+// The value of a field with a built-in data type has the Go type which
+// a Variant uses for the type, e.g. int32 or *LocalizedText. Enumerations
+// are int32 values and the values of simple types have the type of their
+// built-in type. Structures are either of the registered Go type or
+// *DynamicStructure. Arrays are slices of these types.
+type DynamicField struct {
+	Name  string
+	Value interface{}
+}
+
+// NewDynamicStructure returns an empty structure of the data type.
+func NewDynamicStructure(typeID *NodeID) *DynamicStructure {
+	return &DynamicStructure{TypeID: typeID}
+}
+
+// Value returns the value of the field with the given name or nil if the
+// structure has no such field.
+func (s *DynamicStructure) Value(name string) interface{} {
+	if f := s.field(name); f != nil {
+		return f.Value
+	}
+	return nil
+}
+
+// Set sets the value of the field with the given name.
+func (s *DynamicStructure) Set(name string, v interface{}) {
+	if f := s.field(name); f != nil {
+		f.Value = v
+		return
+	}
+	s.Fields = append(s.Fields, &DynamicField{Name: name, Value: v})
+}
+
+// Map returns the field values by name.
+func (s *DynamicStructure) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(s.Fields))
+	for _, f := range s.Fields {
+		m[f.Name] = f.Value
+	}
+	return m
+}
+
+func (s *DynamicStructure) field(name string) *DynamicField {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// definition returns the definition of the data type of the structure.
+func (s *DynamicStructure) definition() (*StructureDefinition, error) {
+	if s.TypeID == nil {
+		return nil, errors.Errorf("dynamic structure without type id")
+	}
+	d, ok := LookupDataType(s.TypeID).(*StructureDescription)
+	if !ok || d.StructureDefinition == nil {
+		return nil, errors.Errorf("no structure definition for %s", s.TypeID)
+	}
+	return d.StructureDefinition, nil
+}
+
+// Decode implements the codec interface.
+//
+// Specification: Part 6, 5.2.6, 5.2.7 and 5.2.8
+func (s *DynamicStructure) Decode(b []byte) (int, error) {
+	def, err := s.definition()
+	if err != nil {
+		return 0, err
+	}
+	buf := NewBuffer(b)
+	s.Fields = nil
+
+	switch def.StructureType {
+	case StructureTypeStructure:
+		for _, f := range def.Fields {
+			s.Fields = append(s.Fields, &DynamicField{Name: f.Name, Value: decodeDynamicField(buf, f)})
+		}
+
+	case StructureTypeStructureWithOptionalFields:
+		mask, bit := buf.ReadUint32(), uint32(1)
+		for _, f := range def.Fields {
+			if f.IsOptional {
+				set := mask&bit != 0
+				bit <<= 1
+				if !set {
+					continue
+				}
+			}
+			s.Fields = append(s.Fields, &DynamicField{Name: f.Name, Value: decodeDynamicField(buf, f)})
+		}
+
+	case StructureTypeUnion:
+		sw := buf.ReadUint32()
+		if sw > uint32(len(def.Fields)) {
+			return buf.Pos(), errors.Errorf("invalid union switch field %d for %s", sw, s.TypeID)
+		}
+		if sw > 0 {
+			f := def.Fields[sw-1]
+			s.Fields = append(s.Fields, &DynamicField{Name: f.Name, Value: decodeDynamicField(buf, f)})
+		}
+
+	default:
+		return 0, errors.Errorf("unsupported structure type %s of %s", def.StructureType, s.TypeID)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode implements the codec interface.
+func (s *DynamicStructure) Encode() ([]byte, error) {
+	def, err := s.definition()
+	if err != nil {
+		return nil, err
+	}
+	buf := NewBuffer(nil)
+
+	switch def.StructureType {
+	case StructureTypeStructure:
+		for _, f := range def.Fields {
+			v := s.field(f.Name)
+			if v == nil {
+				return nil, errors.Errorf("missing field %s of %s", f.Name, s.TypeID)
+			}
+			encodeDynamicField(buf, f, v.Value)
+		}
+
+	case StructureTypeStructureWithOptionalFields:
+		var mask, bit uint32 = 0, 1
+		for _, f := range def.Fields {
+			if !f.IsOptional {
+				continue
+			}
+			if v := s.field(f.Name); v != nil && v.Value != nil {
+				mask |= bit
+			}
+			bit <<= 1
+		}
+		buf.WriteUint32(mask)
+		for _, f := range def.Fields {
+			v := s.field(f.Name)
+			switch {
+			case f.IsOptional && (v == nil || v.Value == nil):
+				continue
+			case v == nil:
+				return nil, errors.Errorf("missing field %s of %s", f.Name, s.TypeID)
+			}
+			encodeDynamicField(buf, f, v.Value)
+		}
+
+	case StructureTypeUnion:
+		for i, f := range def.Fields {
+			if v := s.field(f.Name); v != nil {
+				buf.WriteUint32(uint32(i + 1))
+				encodeDynamicField(buf, f, v.Value)
+				return buf.Bytes(), buf.Error()
+			}
+		}
+		buf.WriteUint32(0)
+
+	default:
+		return nil, errors.Errorf("unsupported structure type %s of %s", def.StructureType, s.TypeID)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// decodeDynamicField reads the value of a structure field. Errors are
+// stored in the buffer.
+func decodeDynamicField(buf *Buffer, f *StructureField) interface{} {
+	if buf.Error() != nil {
+		return nil
+	}
+	t, d, err := dynamicFieldType(f.DataType)
+	if err != nil {
+		buf.err = errors.Errorf("field %s: %s", f.Name, err)
+		return nil
+	}
+
+	decodeOne := func() interface{} {
+		if d == nil {
+			return (&Variant{mask: byte(t)}).decodeValue(buf)
+		}
+		v := newStructureValue(d)
+		buf.ReadStruct(v)
+		return v
+	}
+
+	switch {
+	case f.ValueRank == -1:
+		return decodeOne()
+
+	case f.ValueRank >= 1:
+		// multi-dimensional arrays are encoded as the dimensions
+		// followed by the flattened values.
+		//
+		// Specification: Part 6, 5.2.5
+		var dims []int
+		n := int(buf.ReadInt32())
+		if f.ValueRank > 1 && n >= 0 {
+			dims = make([]int, n)
+			n = 1
+			for i := range dims {
+				dims[i] = int(buf.ReadInt32())
+				if dims[i] < 0 || dims[i] > MaxVariantArrayLength {
+					buf.err = StatusBadEncodingLimitsExceeded
+					return nil
+				}
+				n *= dims[i]
+			}
+		}
+		if buf.Error() != nil {
+			return nil
+		}
+		if n > MaxVariantArrayLength {
+			buf.err = StatusBadEncodingLimitsExceeded
+			return nil
+		}
+
+		typ := reflect.SliceOf(dynamicElemType(t, d))
+		if t == TypeIDByte {
+			typ = reflect.TypeOf(ByteArray{})
+		}
+		if n < 0 {
+			return reflect.Zero(typ).Interface()
+		}
+		vals := reflect.MakeSlice(typ, n, n)
+		for i := 0; i < n; i++ {
+			v := decodeOne()
+			if buf.Error() != nil {
+				return nil
+			}
+			vals.Index(i).Set(reflect.ValueOf(v))
+		}
+		if len(dims) > 1 {
+			return split(0, 0, n, dims, vals).Interface()
+		}
+		return vals.Interface()
+
+	default:
+		buf.err = errors.Errorf("field %s: unsupported value rank %d", f.Name, f.ValueRank)
+		return nil
+	}
+}
+
+// encodeDynamicField writes the value of a structure field. Errors are
+// stored in the buffer.
+func encodeDynamicField(buf *Buffer, f *StructureField, v interface{}) {
+	if buf.Error() != nil {
+		return
+	}
+	t, d, err := dynamicFieldType(f.DataType)
+	if err != nil {
+		buf.err = errors.Errorf("field %s: %s", f.Name, err)
+		return
+	}
+	typ := dynamicElemType(t, d)
+
+	encodeOne := func(val reflect.Value) {
+		switch {
+		case !val.IsValid():
+			buf.err = errors.Errorf("field %s: missing value", f.Name)
+		case d != nil && (val.Type() == typ || val.Type() == reflect.TypeOf(&DynamicStructure{})):
+			buf.WriteStruct(val.Interface())
+		case d == nil && val.Type() == typ:
+			(&Variant{mask: byte(t)}).encodeValue(buf, val.Interface())
+		case d == nil && val.Kind() == typ.Kind() && val.Type().ConvertibleTo(typ):
+			// enumerations and other named types
+			(&Variant{mask: byte(t)}).encodeValue(buf, val.Convert(typ).Interface())
+		default:
+			buf.err = errors.Errorf("field %s: invalid type %s", f.Name, val.Type())
+		}
+	}
+
+	val := reflect.ValueOf(v)
+	switch {
+	case f.ValueRank == -1:
+		encodeOne(val)
+
+	case f.ValueRank >= 1:
+		if v == nil || (val.Kind() == reflect.Slice && val.IsNil()) {
+			buf.WriteInt32(-1)
+			return
+		}
+		if val.Kind() != reflect.Slice {
+			buf.err = errors.Errorf("field %s: %s is not an array", f.Name, val.Type())
+			return
+		}
+		if f.ValueRank > 1 {
+			_, dims, _, err := sliceDim(val)
+			if err != nil {
+				buf.err = errors.Errorf("field %s: %s", f.Name, err)
+				return
+			}
+			buf.WriteInt32(int32(len(dims)))
+			for _, n := range dims {
+				buf.WriteInt32(n)
+			}
+		} else {
+			buf.WriteInt32(int32(val.Len()))
+		}
+		var walk func(val reflect.Value, level int)
+		walk = func(val reflect.Value, level int) {
+			if level == 0 {
+				encodeOne(val)
+				return
+			}
+			for i := 0; i < val.Len(); i++ {
+				walk(val.Index(i), level-1)
+			}
+		}
+		walk(val, int(f.ValueRank))
+
+	default:
+		buf.err = errors.Errorf("field %s: unsupported value rank %d", f.Name, f.ValueRank)
+	}
+}
+
+// maxDataTypeDepth limits the number of simple types which are resolved
+// to find the built-in type of a data type.
+const maxDataTypeDepth = 32
+
+// dynamicFieldType returns the built-in type of a data type or the
+// description of the structure if the data type is a structure.
+func dynamicFieldType(dataType *NodeID) (TypeID, *StructureDescription, error) {
+	dt := dataType
+	for i := 0; i < maxDataTypeDepth && dt != nil; i++ {
+		if dt.Namespace() == 0 {
+			if n := dt.IntID(); n >= 1 && n <= 25 {
+				return TypeID(n), nil, nil
+			}
+		}
+		switch d := LookupDataType(dt).(type) {
+		case *StructureDescription:
+			return 0, d, nil
+		case *EnumDescription:
+			if d.BuiltInType != 0 {
+				return TypeID(d.BuiltInType), nil, nil
+			}
+			return TypeIDInt32, nil, nil
+		case *SimpleTypeDescription:
+			if d.BuiltInType != 0 {
+				return TypeID(d.BuiltInType), nil, nil
+			}
+			dt = d.BaseDataType
+			continue
+		}
+		break
+	}
+	return 0, nil, errors.Errorf("unknown data type %s", dataType)
+}
+
+// dynamicElemType returns the Go type of a value of a structure field.
+func dynamicElemType(t TypeID, d *StructureDescription) reflect.Type {
+	if d == nil {
+		return variantTypeIDToType[t]
+	}
+	return reflect.TypeOf(newStructureValue(d))
+}
+
+// newStructureValue returns a new value of the structure. Structures
+// with a registered Go type are decoded into that type, all others into
+// a *DynamicStructure.
+func newStructureValue(d *StructureDescription) interface{} {
+	if def := d.StructureDefinition; def != nil && def.DefaultEncodingID != nil {
+		if v := eotypes.New(def.DefaultEncodingID); v != nil {
+			return v
+		}
+	}
+	return NewDynamicStructure(d.DataTypeID)
+}
+
+// dataTypes contains the descriptions of the data types which are
+// decoded with their definition.
+var dataTypes = struct {
+	mu sync.RWMutex
+
+	// types contains the *StructureDescription, *EnumDescription and
+	// *SimpleTypeDescription values by data type id.
+	types map[string]interface{}
+
+	// encodings contains the structure descriptions by the id of their
+	// default binary encoding.
+	encodings map[string]*StructureDescription
+}{
+	types:     make(map[string]interface{}),
+	encodings: make(map[string]*StructureDescription),
+}
+
+// RegisterStructureDescription registers the definition of a structure
+// data type. Extension objects with the DefaultEncodingID of the
+// definition whose type is not registered with RegisterExtensionObject
+// are decoded into a *DynamicStructure.
+//
+// A previous description of the data type is replaced. The node ids are
+// the ids of the server the values are exchanged with.
+func RegisterStructureDescription(d *StructureDescription) {
+	dataTypes.mu.Lock()
+	defer dataTypes.mu.Unlock()
+	dataTypes.types[d.DataTypeID.String()] = d
+	if def := d.StructureDefinition; def != nil && def.DefaultEncodingID != nil {
+		dataTypes.encodings[def.DefaultEncodingID.String()] = d
+	}
+}
+
+// RegisterEnumDescription registers the definition of an enumeration
+// data type which is used by the fields of dynamic structures.
+// A previous description of the data type is replaced.
+func RegisterEnumDescription(d *EnumDescription) {
+	dataTypes.mu.Lock()
+	defer dataTypes.mu.Unlock()
+	dataTypes.types[d.DataTypeID.String()] = d
+}
+
+// RegisterSimpleTypeDescription registers a data type which is derived
+// from a built-in type or another simple type and is used by the fields
+// of dynamic structures. A previous description of the data type is
+// replaced.
+func RegisterSimpleTypeDescription(d *SimpleTypeDescription) {
+	dataTypes.mu.Lock()
+	defer dataTypes.mu.Unlock()
+	dataTypes.types[d.DataTypeID.String()] = d
+}
+
+// LookupDataType returns the registered *StructureDescription,
+// *EnumDescription or *SimpleTypeDescription of the data type or nil.
+//
+// The structures of namespace 0 which have a Go type need not be
+// registered. Their description contains only the default binary
+// encoding.
+func LookupDataType(dataTypeID *NodeID) interface{} {
+	if dataTypeID == nil {
+		return nil
+	}
+	dataTypes.mu.RLock()
+	d, ok := dataTypes.types[dataTypeID.String()]
+	dataTypes.mu.RUnlock()
+	if ok {
+		return d
+	}
+	if d := ns0StructureDescription(dataTypeID); d != nil {
+		return d
+	}
+	return nil
+}
+
+// ns0StructureDescription returns the description of a structure of
+// namespace 0 with a Go type or nil. The binary encoding is found by the
+// name of the data type.
+func ns0StructureDescription(dataTypeID *NodeID) *StructureDescription {
+	switch dataTypeID.Type() {
+	case NodeIDTypeTwoByte, NodeIDTypeFourByte, NodeIDTypeNumeric:
+	default:
+		return nil
+	}
+	if dataTypeID.Namespace() != 0 {
+		return nil
+	}
+	name := id.Name(dataTypeID.IntID())
+	enc := id.ID(name + "_Encoding_DefaultBinary")
+	if enc == 0 || eotypes.New(NewNumericNodeID(0, enc)) == nil {
+		return nil
+	}
+	return &StructureDescription{
+		DataTypeID: dataTypeID,
+		Name:       &QualifiedName{Name: name},
+		StructureDefinition: &StructureDefinition{
+			DefaultEncodingID: NewNumericNodeID(0, enc),
+		},
+	}
+}
+
+// encodingDescription returns the description of the structure with the
+// binary encoding or nil.
+func encodingDescription(encodingID *NodeID) *StructureDescription {
+	dataTypes.mu.RLock()
+	defer dataTypes.mu.RUnlock()
+	return dataTypes.encodings[encodingID.String()]
+}
+
+// The simple types of namespace 0 which are commonly used by the fields
+// of structures are registered so that they need not be read from the
+// server.
+func init() {
+	for dt, t := range map[uint32]TypeID{
+		id.IntegerID:                      TypeIDUint32,
+		id.Counter:                        TypeIDUint32,
+		id.Duration:                       TypeIDDouble,
+		id.NumericRange:                   TypeIDString,
+		id.Time:                           TypeIDString,
+		id.Date:                           TypeIDDateTime,
+		id.UtcTime:                        TypeIDDateTime,
+		id.LocaleID:                       TypeIDString,
+		id.ApplicationInstanceCertificate: TypeIDByteString,
+		id.Index:                          TypeIDUint32,
+		id.VersionTime:                    TypeIDUint32,
+		id.Image:                          TypeIDByteString,
+		id.ImageBMP:                       TypeIDByteString,
+		id.ImageGIF:                       TypeIDByteString,
+		id.ImageJPG:                       TypeIDByteString,
+		id.ImagePNG:                       TypeIDByteString,
+		id.NormalizedString:               TypeIDString,
+		id.DecimalString:                  TypeIDString,
+		id.DurationString:                 TypeIDString,
+		id.TimeString:                     TypeIDString,
+		id.DateString:                     TypeIDString,
+		id.Enumeration:                    TypeIDInt32,
+		id.Number:                         TypeIDVariant,
+		id.Integer:                        TypeIDVariant,
+		id.UInteger:                       TypeIDVariant,
+	} {
+		RegisterSimpleTypeDescription(&SimpleTypeDescription{
+			DataTypeID:  NewNumericNodeID(0, dt),
+			Name:        &QualifiedName{Name: id.Name(dt)},
+			BuiltInType: uint8(t),
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"testing"
+
+	"github.com/imatic-tech/opcua/id"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+// registerDynamicTestTypes registers the data types of the dynamic
+// structure tests in namespace 1.
+func registerDynamicTestTypes() {
+	field := func(name string, dataType *NodeID, valueRank int32, optional bool) *StructureField {
+		return &StructureField{Name: name, DataType: dataType, ValueRank: valueRank, IsOptional: optional}
+	}
+
+	RegisterStructureDescription(&StructureDescription{
+		DataTypeID: NewFourByteNodeID(1, 5001),
+		Name:       &QualifiedName{NamespaceIndex: 1, Name: "Point"},
+		StructureDefinition: &StructureDefinition{
+			DefaultEncodingID: NewFourByteNodeID(1, 5002),
+			StructureType:     StructureTypeStructure,
+			Fields: []*StructureField{
+				field("X", NewTwoByteNodeID(id.Double), -1, false),
+				field("Y", NewTwoByteNodeID(id.Double), -1, false),
+			},
+		},
+	})
+	RegisterEnumDescription(&EnumDescription{
+		DataTypeID: NewFourByteNodeID(1, 5003),
+		Name:       &QualifiedName{NamespaceIndex: 1, Name: "Mode"},
+	})
+	RegisterStructureDescription(&StructureDescription{
+		DataTypeID: NewFourByteNodeID(1, 5004),
+		Name:       &QualifiedName{NamespaceIndex: 1, Name: "Machine"},
+		StructureDefinition: &StructureDefinition{
+			DefaultEncodingID: NewFourByteNodeID(1, 5005),
+			StructureType:     StructureTypeStructureWithOptionalFields,
+			Fields: []*StructureField{
+				field("Name", NewTwoByteNodeID(id.String), -1, false),
+				field("Mode", NewFourByteNodeID(1, 5003), -1, false),
+				field("Cycle", NewFourByteNodeID(0, id.Duration), -1, false),
+				field("Position", NewFourByteNodeID(1, 5001), -1, true),
+				field("Tags", NewTwoByteNodeID(id.String), 1, false),
+				field("Comment", NewTwoByteNodeID(id.LocalizedText), -1, true),
+			},
+		},
+	})
+	RegisterStructureDescription(&StructureDescription{
+		DataTypeID: NewFourByteNodeID(1, 5006),
+		Name:       &QualifiedName{NamespaceIndex: 1, Name: "Value"},
+		StructureDefinition: &StructureDefinition{
+			DefaultEncodingID: NewFourByteNodeID(1, 5007),
+			StructureType:     StructureTypeUnion,
+			Fields: []*StructureField{
+				field("Int", NewTwoByteNodeID(id.Int32), -1, false),
+				field("Text", NewTwoByteNodeID(id.String), -1, false),
+			},
+		},
+	})
+	RegisterStructureDescription(&StructureDescription{
+		DataTypeID: NewFourByteNodeID(1, 5008),
+		Name:       &QualifiedName{NamespaceIndex: 1, Name: "Matrix"},
+		StructureDefinition: &StructureDefinition{
+			DefaultEncodingID: NewFourByteNodeID(1, 5009),
+			StructureType:     StructureTypeStructure,
+			Fields: []*StructureField{
+				field("Values", NewTwoByteNodeID(id.Int32), 2, false),
+			},
+		},
+	})
+}
+
+func TestDynamicStructure(t *testing.T) {
+	registerDynamicTestTypes()
+
+	point := func(x, y float64) *DynamicStructure {
+		return &DynamicStructure{
+			TypeID: NewFourByteNodeID(1, 5001),
+			Fields: []*DynamicField{{Name: "X", Value: x}, {Name: "Y", Value: y}},
+		}
+	}
+
+	cases := []CodecTestCase{
+		{
+			Name:   "structure",
+			Struct: NewExtensionObject(point(1.5, -2)),
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x01, 0x8a, 0x13,
+				// EncodingMask
+				0x01,
+				// Length
+				0x10, 0x00, 0x00, 0x00,
+				// X
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f,
+				// Y
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0,
+			},
+		},
+		{
+			Name: "optional fields",
+			Struct: NewExtensionObject(&DynamicStructure{
+				TypeID: NewFourByteNodeID(1, 5004),
+				Fields: []*DynamicField{
+					{Name: "Name", Value: "m1"},
+					{Name: "Mode", Value: int32(2)},
+					{Name: "Cycle", Value: float64(100)},
+					{Name: "Position", Value: point(1.5, -2)},
+					{Name: "Tags", Value: []string{"a", "b"}},
+				},
+			}),
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x01, 0x8d, 0x13,
+				// EncodingMask
+				0x01,
+				// Length
+				0x34, 0x00, 0x00, 0x00,
+				// EncodingMask of the optional fields
+				0x01, 0x00, 0x00, 0x00,
+				// Name
+				0x02, 0x00, 0x00, 0x00, 'm', '1',
+				// Mode
+				0x02, 0x00, 0x00, 0x00,
+				// Cycle
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x59, 0x40,
+				// Position
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0,
+				// Tags
+				0x02, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x00, 'a',
+				0x01, 0x00, 0x00, 0x00, 'b',
+			},
+		},
+		{
+			Name: "union",
+			Struct: NewExtensionObject(&DynamicStructure{
+				TypeID: NewFourByteNodeID(1, 5006),
+				Fields: []*DynamicField{{Name: "Text", Value: "x"}},
+			}),
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x01, 0x8f, 0x13,
+				// EncodingMask
+				0x01,
+				// Length
+				0x09, 0x00, 0x00, 0x00,
+				// SwitchField
+				0x02, 0x00, 0x00, 0x00,
+				// Text
+				0x01, 0x00, 0x00, 0x00, 'x',
+			},
+		},
+		{
+			Name:   "null union",
+			Struct: NewExtensionObject(&DynamicStructure{TypeID: NewFourByteNodeID(1, 5006)}),
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x01, 0x8f, 0x13,
+				// EncodingMask
+				0x01,
+				// Length
+				0x04, 0x00, 0x00, 0x00,
+				// SwitchField
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			Name: "multi-dimensional array",
+			Struct: NewExtensionObject(&DynamicStructure{
+				TypeID: NewFourByteNodeID(1, 5008),
+				Fields: []*DynamicField{{Name: "Values", Value: [][]int32{{1, 2}, {3, 4}}}},
+			}),
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x01, 0x91, 0x13,
+				// EncodingMask
+				0x01,
+				// Length
+				0x1c, 0x00, 0x00, 0x00,
+				// Dimensions
+				0x02, 0x00, 0x00, 0x00,
+				0x02, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				// Values
+				0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				0x03, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+	RunCodecTest(t, cases)
+}
+
+func TestDynamicStructureMissingField(t *testing.T) {
+	registerDynamicTestTypes()
+
+	s := &DynamicStructure{
+		TypeID: NewFourByteNodeID(1, 5001),
+		Fields: []*DynamicField{{Name: "X", Value: 1.5}},
+	}
+	if _, err := s.Encode(); err == nil {
+		t.Fatal("got nil want error")
+	}
+
+	s.Set("Y", "foo")
+	if _, err := s.Encode(); err == nil {
+		t.Fatal("got nil want error for invalid type")
+	}
+}
+
+func TestExtensionObjectDecodeBody(t *testing.T) {
+	b := []byte{
+		// TypeID
+		0x01, 0x01, 0x93, 0x13,
+		// EncodingMask
+		0x01,
+		// Length
+		0x04, 0x00, 0x00, 0x00,
+		// Count
+		0x07, 0x00, 0x00, 0x00,
+	}
+
+	e := new(ExtensionObject)
+	if _, err := e.Decode(b); err != nil {
+		t.Fatal(err)
+	}
+	if e.Value != nil {
+		t.Fatalf("got %#v want nil for an unknown type", e.Value)
+	}
+	if err := e.DecodeBody(); err != StatusBadDataTypeIDUnknown {
+		t.Fatalf("got error %v want %v", err, StatusBadDataTypeIDUnknown)
+	}
+
+	// unknown types are encoded as is
+	got, err := e.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "", got, b)
+
+	RegisterStructureDescription(&StructureDescription{
+		DataTypeID: NewFourByteNodeID(1, 5010),
+		Name:       &QualifiedName{NamespaceIndex: 1, Name: "Counter"},
+		StructureDefinition: &StructureDefinition{
+			DefaultEncodingID: NewFourByteNodeID(1, 5011),
+			Fields: []*StructureField{
+				{Name: "Count", DataType: NewFourByteNodeID(0, id.Counter), ValueRank: -1},
+			},
+		},
+	})
+	if err := e.DecodeBody(); err != nil {
+		t.Fatal(err)
+	}
+	want := &DynamicStructure{
+		TypeID: NewFourByteNodeID(1, 5010),
+		Fields: []*DynamicField{{Name: "Count", Value: uint32(7)}},
+	}
+	verify.Values(t, "", e.Value, want)
+}
+
+func TestDynamicStructureJSON(t *testing.T) {
+	registerDynamicTestTypes()
+
+	v := NewExtensionObject(&DynamicStructure{
+		TypeID: NewFourByteNodeID(1, 5001),
+		Fields: []*DynamicField{{Name: "X", Value: 1.5}, {Name: "Y", Value: float64(-2)}},
+	})
+	b, err := EncodeJSON(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "", string(b), `{"TypeId":{"Id":5002,"Namespace":1},"Body":{"X":1.5,"Y":-2}}`)
+}
+
+func TestLookupDataTypeNS0(t *testing.T) {
+	d, ok := LookupDataType(NewNumericNodeID(0, id.Range)).(*StructureDescription)
+	if !ok {
+		t.Fatal("no description for Range")
+	}
+	verify.Values(t, "", newStructureValue(d), &Range{})
+
+	if d := LookupDataType(NewTwoByteNodeID(id.Int32)); d != nil {
+		t.Fatalf("got %#v want nil for a built-in type", d)
+	}
+}
@@ -5,12 +5,16 @@
 package ua
 
 import (
+	"github.com/imatic-tech/opcua/errors"
 	"github.com/imatic-tech/opcua/id"
 )
 
 // eotypes contains all known extension objects.
 var eotypes = NewTypeRegistry()
 
+// eoxmltypes contains the known extension objects with an XML encoding.
+var eoxmltypes = NewTypeRegistry()
+
 // RegisterExtensionObject registers a new extension object type.
 // It panics if the type or the id is already registered.
 func RegisterExtensionObject(typeID *NodeID, v interface{}) {
@@ -19,6 +23,15 @@ func RegisterExtensionObject(typeID *NodeID, v interface{}) {
 	}
 }
 
+// RegisterExtensionObjectXML registers the id of the XML encoding of an
+// extension object type. It panics if the type or the id is already
+// registered.
+func RegisterExtensionObjectXML(typeID *NodeID, v interface{}) {
+	if err := eoxmltypes.Register(typeID, v); err != nil {
+		panic("Extension object " + err.Error())
+	}
+}
+
 // These flags define the value type of an ExtensionObject.
 // They cannot be combined.
 const (
@@ -35,6 +48,10 @@ type ExtensionObject struct {
 	EncodingMask uint8
 	TypeID       *ExpandedNodeID
 	Value        interface{}
+
+	// body is the binary encoded value of an unknown type. It is
+	// decoded by DecodeBody and encoded as is.
+	body []byte
 }
 
 func NewExtensionObject(value interface{}) *ExtensionObject {
@@ -46,6 +63,21 @@ func NewExtensionObject(value interface{}) *ExtensionObject {
 	return e
 }
 
+// NewExtensionObjectXML returns an extension object which encodes the
+// value with the XML encoding. The type of the value must be registered
+// with RegisterExtensionObjectXML.
+func NewExtensionObjectXML(value interface{}) *ExtensionObject {
+	e := &ExtensionObject{
+		TypeID:       NewTwoByteExpandedNodeID(0),
+		EncodingMask: ExtensionObjectXML,
+		Value:        value,
+	}
+	if id := eoxmltypes.Lookup(value); id != nil {
+		e.TypeID = &ExpandedNodeID{NodeID: id}
+	}
+	return e
+}
+
 func (e *ExtensionObject) Decode(b []byte) (int, error) {
 	buf := NewBuffer(b)
 	e.TypeID = new(ExpandedNodeID)
@@ -67,14 +99,24 @@ func (e *ExtensionObject) Decode(b []byte) (int, error) {
 	}
 
 	if e.EncodingMask == ExtensionObjectXML {
-		e.Value = new(XMLElement)
-		body.ReadStruct(e.Value)
-		return buf.Pos(), body.Error()
+		// the length of the body is the length of the xml element
+		x := XMLElement(body.Bytes())
+		e.Value = &x
+
+		// decode registered types and keep the xml of unknown types
+		// and of documents which cannot be decoded
+		if v := eoxmltypes.New(e.TypeID.NodeID); v != nil {
+			if err := DecodeXML([]byte(x), v); err == nil {
+				e.Value = v
+			}
+		}
+		return buf.Pos(), nil
 	}
 
-	typeID := e.TypeID.NodeID
-	e.Value = eotypes.New(typeID)
+	e.Value = newExtensionObjectValue(e.TypeID.NodeID)
 	if e.Value == nil {
+		// keep the body of unknown types for DecodeBody
+		e.body = append([]byte(nil), body.Bytes()...)
 		return buf.Pos(), buf.Error()
 	}
 
@@ -82,6 +124,40 @@ func (e *ExtensionObject) Decode(b []byte) (int, error) {
 	return buf.Pos(), body.Error()
 }
 
+// newExtensionObjectValue returns a new value for the binary encoding of
+// a registered type or a dynamic structure. It returns nil if the type
+// is unknown.
+func newExtensionObjectValue(typeID *NodeID) interface{} {
+	if v := eotypes.New(typeID); v != nil {
+		return v
+	}
+	if d := encodingDescription(typeID); d != nil {
+		return newStructureValue(d)
+	}
+	return nil
+}
+
+// DecodeBody decodes the value of an extension object whose type was
+// unknown when it was decoded, e.g. after the data type was registered
+// with RegisterStructureDescription. It returns
+// StatusBadDataTypeIDUnknown if the type is still unknown.
+func (e *ExtensionObject) DecodeBody() error {
+	if e.Value != nil || e.body == nil {
+		return nil
+	}
+	v := newExtensionObjectValue(e.TypeID.NodeID)
+	if v == nil {
+		return StatusBadDataTypeIDUnknown
+	}
+	body := NewBuffer(e.body)
+	body.ReadStruct(v)
+	if body.Error() != nil {
+		return body.Error()
+	}
+	e.Value, e.body = v, nil
+	return nil
+}
+
 func (e *ExtensionObject) Encode() ([]byte, error) {
 	buf := NewBuffer(nil)
 	if e == nil {
@@ -94,7 +170,20 @@ func (e *ExtensionObject) Encode() ([]byte, error) {
 	}
 
 	body := NewBuffer(nil)
-	body.WriteStruct(e.Value)
+	if e.EncodingMask == ExtensionObjectXML {
+		x, err := e.xmlBody()
+		if err != nil {
+			return nil, err
+		}
+		if x == nil {
+			return nil, errors.Errorf("no xml encoding for %T", e.Value)
+		}
+		body.Write(x)
+	} else if e.Value == nil && e.body != nil {
+		body.Write(e.body)
+	} else {
+		body.WriteStruct(e.Value)
+	}
 	if body.Error() != nil {
 		return nil, body.Error()
 	}
@@ -104,17 +193,36 @@ func (e *ExtensionObject) Encode() ([]byte, error) {
 }
 
 func (e *ExtensionObject) UpdateMask() {
-	if e.Value == nil {
+	if e.Value == nil && e.body != nil {
+		e.EncodingMask = ExtensionObjectBinary
+	} else if e.Value == nil {
 		e.EncodingMask = ExtensionObjectEmpty
 	} else if _, ok := e.Value.(*XMLElement); ok {
 		e.EncodingMask = ExtensionObjectXML
+	} else if e.EncodingMask == ExtensionObjectXML && eoxmltypes.Lookup(e.Value) != nil {
+		// keep the xml encoding of registered types
 	} else {
 		e.EncodingMask = ExtensionObjectBinary
 	}
 }
 
+// xmlBody returns the XML encoding of the value or nil if the type of
+// the value has no XML encoding.
+func (e *ExtensionObject) xmlBody() ([]byte, error) {
+	switch v := e.Value.(type) {
+	case *XMLElement:
+		return []byte(*v), nil
+	case XMLElement:
+		return []byte(v), nil
+	}
+	if eoxmltypes.Lookup(e.Value) == nil {
+		return nil, nil
+	}
+	return EncodeXML(e.Value)
+}
+
 func ExtensionObjectTypeID(v interface{}) *ExpandedNodeID {
-	switch v.(type) {
+	switch x := v.(type) {
 	case *AnonymousIdentityToken:
 		return NewFourByteExpandedNodeID(0, id.AnonymousIdentityToken_Encoding_DefaultBinary)
 	case *UserNameIdentityToken:
@@ -125,6 +233,12 @@ func ExtensionObjectTypeID(v interface{}) *ExpandedNodeID {
 		return NewFourByteExpandedNodeID(0, id.IssuedIdentityToken_Encoding_DefaultBinary)
 	case *ServerStatusDataType:
 		return NewFourByteExpandedNodeID(0, id.ServerStatusDataType_Encoding_DefaultBinary)
+	case *DynamicStructure:
+		d, ok := LookupDataType(x.TypeID).(*StructureDescription)
+		if ok && d.StructureDefinition != nil && d.StructureDefinition.DefaultEncodingID != nil {
+			return &ExpandedNodeID{NodeID: d.StructureDefinition.DefaultEncodingID}
+		}
+		return NewTwoByteExpandedNodeID(0)
 	default:
 		if id := eotypes.Lookup(v); id != nil {
 			return &ExpandedNodeID{NodeID: id}
@@ -24,6 +24,61 @@ func TestExtensionObject(t *testing.T) {
 				0x09, 0x00, 0x00, 0x00, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73,
 			},
 		},
+		{
+			Name:   "xml-anonymous-user-identity-token",
+			Struct: NewExtensionObjectXML(&AnonymousIdentityToken{PolicyID: "anonymous"}),
+			Bytes: append([]byte{
+				// TypeID
+				0x01, 0x00, 0x40, 0x01,
+				// EncodingMask
+				0x02,
+				// Length
+				byte(len(anonymousXML)), 0x00, 0x00, 0x00,
+			}, anonymousXML...),
+		},
+		{
+			Name: "xml-invalid-document",
+			Struct: &ExtensionObject{
+				EncodingMask: ExtensionObjectXML,
+				TypeID:       NewFourByteExpandedNodeID(0, 320),
+				Value:        xmlElement("<Foo>"),
+			},
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x00, 0x40, 0x01,
+				// EncodingMask
+				0x02,
+				// Length
+				0x05, 0x00, 0x00, 0x00,
+				// XmlElement
+				'<', 'F', 'o', 'o', '>',
+			},
+		},
+		{
+			Name: "xml-unknown-type",
+			Struct: &ExtensionObject{
+				EncodingMask: ExtensionObjectXML,
+				TypeID:       NewFourByteExpandedNodeID(1, 1000),
+				Value:        xmlElement("<Foo>bar</Foo>"),
+			},
+			Bytes: []byte{
+				// TypeID
+				0x01, 0x01, 0xe8, 0x03,
+				// EncodingMask
+				0x02,
+				// Length
+				0x0e, 0x00, 0x00, 0x00,
+				// XmlElement
+				'<', 'F', 'o', 'o', '>', 'b', 'a', 'r', '<', '/', 'F', 'o', 'o', '>',
+			},
+		},
 	}
 	RunCodecTest(t, cases)
 }
+
+const anonymousXML = `<AnonymousIdentityToken xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><PolicyId>anonymous</PolicyId></AnonymousIdentityToken>`
+
+func xmlElement(s string) *XMLElement {
+	x := XMLElement(s)
+	return &x
+}
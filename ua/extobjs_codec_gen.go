@@ -0,0 +1,9185 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+//go:build !reflectcodec
+// +build !reflectcodec
+
+// Code generated by cmd/service. DO NOT EDIT!
+
+package ua
+
+import "time"
+
+// Encode encodes the structure without reflection.
+func (v *KeyValuePair) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.Key)
+	buf.WriteStruct(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *KeyValuePair) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Key = new(QualifiedName)
+	buf.ReadStruct(v.Key)
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AdditionalParametersType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Parameters), v.Parameters == nil)
+	for _, e := range v.Parameters {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AdditionalParametersType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Parameters = make([]*KeyValuePair, n)
+		for i := range v.Parameters {
+			v.Parameters[i] = new(KeyValuePair)
+			buf.ReadStruct(v.Parameters[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EphemeralKeyType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteByteString(v.PublicKey)
+	buf.WriteByteString(v.Signature)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EphemeralKeyType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.PublicKey = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Signature = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EndpointType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.EndpointURL)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityPolicyURI)
+	buf.WriteString(v.TransportProfileURI)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EndpointType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.EndpointURL = buf.ReadString()
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityPolicyURI = buf.ReadString()
+	v.TransportProfileURI = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *IdentityMappingRuleType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.CriteriaType))
+	buf.WriteString(v.Criteria)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *IdentityMappingRuleType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.CriteriaType = IdentityCriteriaType(buf.ReadUint32())
+	v.Criteria = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TrustListDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedLists)
+	buf.writeArrayLength(len(v.TrustedCertificates), v.TrustedCertificates == nil)
+	for _, e := range v.TrustedCertificates {
+		buf.WriteByteString(e)
+	}
+	buf.writeArrayLength(len(v.TrustedCrls), v.TrustedCrls == nil)
+	for _, e := range v.TrustedCrls {
+		buf.WriteByteString(e)
+	}
+	buf.writeArrayLength(len(v.IssuerCertificates), v.IssuerCertificates == nil)
+	for _, e := range v.IssuerCertificates {
+		buf.WriteByteString(e)
+	}
+	buf.writeArrayLength(len(v.IssuerCrls), v.IssuerCrls == nil)
+	for _, e := range v.IssuerCrls {
+		buf.WriteByteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TrustListDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedLists = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.TrustedCertificates = make([][]byte, n)
+		for i := range v.TrustedCertificates {
+			if n := buf.readArrayLength(); n >= 0 {
+				v.TrustedCertificates[i] = buf.ReadN(n)
+			}
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.TrustedCrls = make([][]byte, n)
+		for i := range v.TrustedCrls {
+			if n := buf.readArrayLength(); n >= 0 {
+				v.TrustedCrls[i] = buf.ReadN(n)
+			}
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.IssuerCertificates = make([][]byte, n)
+		for i := range v.IssuerCertificates {
+			if n := buf.readArrayLength(); n >= 0 {
+				v.IssuerCertificates[i] = buf.ReadN(n)
+			}
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.IssuerCrls = make([][]byte, n)
+		for i := range v.IssuerCrls {
+			if n := buf.readArrayLength(); n >= 0 {
+				v.IssuerCrls[i] = buf.ReadN(n)
+			}
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DecimalDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteInt16(v.Scale)
+	buf.WriteByteString(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DecimalDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Scale = buf.ReadInt16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Value = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataTypeSchemaHeader) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Namespaces), v.Namespaces == nil)
+	for _, e := range v.Namespaces {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.StructureDataTypes), v.StructureDataTypes == nil)
+	for _, e := range v.StructureDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.EnumDataTypes), v.EnumDataTypes == nil)
+	for _, e := range v.EnumDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.SimpleDataTypes), v.SimpleDataTypes == nil)
+	for _, e := range v.SimpleDataTypes {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataTypeSchemaHeader) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Namespaces = make([]string, n)
+		for i := range v.Namespaces {
+			v.Namespaces[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.StructureDataTypes = make([]*StructureDescription, n)
+		for i := range v.StructureDataTypes {
+			v.StructureDataTypes[i] = new(StructureDescription)
+			buf.ReadStruct(v.StructureDataTypes[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EnumDataTypes = make([]*EnumDescription, n)
+		for i := range v.EnumDataTypes {
+			v.EnumDataTypes[i] = new(EnumDescription)
+			buf.ReadStruct(v.EnumDataTypes[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SimpleDataTypes = make([]*SimpleTypeDescription, n)
+		for i := range v.SimpleDataTypes {
+			v.SimpleDataTypes[i] = new(SimpleTypeDescription)
+			buf.ReadStruct(v.SimpleDataTypes[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataTypeDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DataTypeID)
+	buf.WriteStruct(v.Name)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataTypeDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataTypeID = new(NodeID)
+	buf.ReadStruct(v.DataTypeID)
+	v.Name = new(QualifiedName)
+	buf.ReadStruct(v.Name)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *StructureDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DataTypeID)
+	buf.WriteStruct(v.Name)
+	buf.WriteStruct(v.StructureDefinition)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *StructureDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataTypeID = new(NodeID)
+	buf.ReadStruct(v.DataTypeID)
+	v.Name = new(QualifiedName)
+	buf.ReadStruct(v.Name)
+	v.StructureDefinition = new(StructureDefinition)
+	buf.ReadStruct(v.StructureDefinition)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EnumDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DataTypeID)
+	buf.WriteStruct(v.Name)
+	buf.WriteStruct(v.EnumDefinition)
+	buf.WriteUint8(v.BuiltInType)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EnumDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataTypeID = new(NodeID)
+	buf.ReadStruct(v.DataTypeID)
+	v.Name = new(QualifiedName)
+	buf.ReadStruct(v.Name)
+	v.EnumDefinition = new(EnumDefinition)
+	buf.ReadStruct(v.EnumDefinition)
+	v.BuiltInType = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SimpleTypeDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DataTypeID)
+	buf.WriteStruct(v.Name)
+	buf.WriteStruct(v.BaseDataType)
+	buf.WriteUint8(v.BuiltInType)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SimpleTypeDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataTypeID = new(NodeID)
+	buf.ReadStruct(v.DataTypeID)
+	v.Name = new(QualifiedName)
+	buf.ReadStruct(v.Name)
+	v.BaseDataType = new(NodeID)
+	buf.ReadStruct(v.BaseDataType)
+	v.BuiltInType = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UABinaryFileDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Namespaces), v.Namespaces == nil)
+	for _, e := range v.Namespaces {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.StructureDataTypes), v.StructureDataTypes == nil)
+	for _, e := range v.StructureDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.EnumDataTypes), v.EnumDataTypes == nil)
+	for _, e := range v.EnumDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.SimpleDataTypes), v.SimpleDataTypes == nil)
+	for _, e := range v.SimpleDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.WriteString(v.SchemaLocation)
+	buf.writeArrayLength(len(v.FileHeader), v.FileHeader == nil)
+	for _, e := range v.FileHeader {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.Body)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UABinaryFileDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Namespaces = make([]string, n)
+		for i := range v.Namespaces {
+			v.Namespaces[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.StructureDataTypes = make([]*StructureDescription, n)
+		for i := range v.StructureDataTypes {
+			v.StructureDataTypes[i] = new(StructureDescription)
+			buf.ReadStruct(v.StructureDataTypes[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EnumDataTypes = make([]*EnumDescription, n)
+		for i := range v.EnumDataTypes {
+			v.EnumDataTypes[i] = new(EnumDescription)
+			buf.ReadStruct(v.EnumDataTypes[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SimpleDataTypes = make([]*SimpleTypeDescription, n)
+		for i := range v.SimpleDataTypes {
+			v.SimpleDataTypes[i] = new(SimpleTypeDescription)
+			buf.ReadStruct(v.SimpleDataTypes[i])
+		}
+	}
+	v.SchemaLocation = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.FileHeader = make([]*KeyValuePair, n)
+		for i := range v.FileHeader {
+			v.FileHeader[i] = new(KeyValuePair)
+			buf.ReadStruct(v.FileHeader[i])
+		}
+	}
+	v.Body = new(Variant)
+	buf.ReadStruct(v.Body)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetMetaDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Namespaces), v.Namespaces == nil)
+	for _, e := range v.Namespaces {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.StructureDataTypes), v.StructureDataTypes == nil)
+	for _, e := range v.StructureDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.EnumDataTypes), v.EnumDataTypes == nil)
+	for _, e := range v.EnumDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.SimpleDataTypes), v.SimpleDataTypes == nil)
+	for _, e := range v.SimpleDataTypes {
+		buf.WriteStruct(e)
+	}
+	buf.WriteString(v.Name)
+	buf.WriteStruct(v.Description)
+	buf.writeArrayLength(len(v.Fields), v.Fields == nil)
+	for _, e := range v.Fields {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.DataSetClassID)
+	buf.WriteStruct(v.ConfigurationVersion)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetMetaDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Namespaces = make([]string, n)
+		for i := range v.Namespaces {
+			v.Namespaces[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.StructureDataTypes = make([]*StructureDescription, n)
+		for i := range v.StructureDataTypes {
+			v.StructureDataTypes[i] = new(StructureDescription)
+			buf.ReadStruct(v.StructureDataTypes[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EnumDataTypes = make([]*EnumDescription, n)
+		for i := range v.EnumDataTypes {
+			v.EnumDataTypes[i] = new(EnumDescription)
+			buf.ReadStruct(v.EnumDataTypes[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SimpleDataTypes = make([]*SimpleTypeDescription, n)
+		for i := range v.SimpleDataTypes {
+			v.SimpleDataTypes[i] = new(SimpleTypeDescription)
+			buf.ReadStruct(v.SimpleDataTypes[i])
+		}
+	}
+	v.Name = buf.ReadString()
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Fields = make([]*FieldMetaData, n)
+		for i := range v.Fields {
+			v.Fields[i] = new(FieldMetaData)
+			buf.ReadStruct(v.Fields[i])
+		}
+	}
+	v.DataSetClassID = new(GUID)
+	buf.ReadStruct(v.DataSetClassID)
+	v.ConfigurationVersion = new(ConfigurationVersionDataType)
+	buf.ReadStruct(v.ConfigurationVersion)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FieldMetaData) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint16(uint16(v.FieldFlags))
+	buf.WriteUint8(v.BuiltInType)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteUint32(v.MaxStringLength)
+	buf.WriteStruct(v.DataSetFieldID)
+	buf.writeArrayLength(len(v.Properties), v.Properties == nil)
+	for _, e := range v.Properties {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FieldMetaData) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.FieldFlags = DataSetFieldFlags(buf.ReadUint16())
+	v.BuiltInType = buf.ReadByte()
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.MaxStringLength = buf.ReadUint32()
+	v.DataSetFieldID = new(GUID)
+	buf.ReadStruct(v.DataSetFieldID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Properties = make([]*KeyValuePair, n)
+		for i := range v.Properties {
+			v.Properties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.Properties[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ConfigurationVersionDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.MajorVersion)
+	buf.WriteUint32(v.MinorVersion)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ConfigurationVersionDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.MajorVersion = buf.ReadUint32()
+	v.MinorVersion = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishedDataSetDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.writeArrayLength(len(v.DataSetFolder), v.DataSetFolder == nil)
+	for _, e := range v.DataSetFolder {
+		buf.WriteString(e)
+	}
+	buf.WriteStruct(v.DataSetMetaData)
+	buf.writeArrayLength(len(v.ExtensionFields), v.ExtensionFields == nil)
+	for _, e := range v.ExtensionFields {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.DataSetSource)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishedDataSetDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataSetFolder = make([]string, n)
+		for i := range v.DataSetFolder {
+			v.DataSetFolder[i] = buf.ReadString()
+		}
+	}
+	v.DataSetMetaData = new(DataSetMetaDataType)
+	buf.ReadStruct(v.DataSetMetaData)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ExtensionFields = make([]*KeyValuePair, n)
+		for i := range v.ExtensionFields {
+			v.ExtensionFields[i] = new(KeyValuePair)
+			buf.ReadStruct(v.ExtensionFields[i])
+		}
+	}
+	v.DataSetSource = new(ExtensionObject)
+	buf.ReadStruct(v.DataSetSource)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishedDataSetSourceDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishedDataSetSourceDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishedVariableDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.PublishedVariable)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteFloat64(v.SamplingIntervalHint)
+	buf.WriteUint32(v.DeadbandType)
+	buf.WriteFloat64(v.DeadbandValue)
+	buf.WriteString(v.IndexRange)
+	buf.WriteStruct(v.SubstituteValue)
+	buf.writeArrayLength(len(v.MetaDataProperties), v.MetaDataProperties == nil)
+	for _, e := range v.MetaDataProperties {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishedVariableDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PublishedVariable = new(NodeID)
+	buf.ReadStruct(v.PublishedVariable)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.SamplingIntervalHint = buf.ReadFloat64()
+	v.DeadbandType = buf.ReadUint32()
+	v.DeadbandValue = buf.ReadFloat64()
+	v.IndexRange = buf.ReadString()
+	v.SubstituteValue = new(Variant)
+	buf.ReadStruct(v.SubstituteValue)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.MetaDataProperties = make([]*QualifiedName, n)
+		for i := range v.MetaDataProperties {
+			v.MetaDataProperties[i] = new(QualifiedName)
+			buf.ReadStruct(v.MetaDataProperties[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishedDataItemsDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.PublishedData), v.PublishedData == nil)
+	for _, e := range v.PublishedData {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishedDataItemsDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.PublishedData = make([]*PublishedVariableDataType, n)
+		for i := range v.PublishedData {
+			v.PublishedData[i] = new(PublishedVariableDataType)
+			buf.ReadStruct(v.PublishedData[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishedEventsDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.EventNotifier)
+	buf.writeArrayLength(len(v.SelectedFields), v.SelectedFields == nil)
+	for _, e := range v.SelectedFields {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.Filter)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishedEventsDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.EventNotifier = new(NodeID)
+	buf.ReadStruct(v.EventNotifier)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SelectedFields = make([]*SimpleAttributeOperand, n)
+		for i := range v.SelectedFields {
+			v.SelectedFields[i] = new(SimpleAttributeOperand)
+			buf.ReadStruct(v.SelectedFields[i])
+		}
+	}
+	v.Filter = new(ContentFilter)
+	buf.ReadStruct(v.Filter)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetWriterDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteBool(v.Enabled)
+	buf.WriteUint16(v.DataSetWriterID)
+	buf.WriteUint32(uint32(v.DataSetFieldContentMask))
+	buf.WriteUint32(v.KeyFrameCount)
+	buf.WriteString(v.DataSetName)
+	buf.writeArrayLength(len(v.DataSetWriterProperties), v.DataSetWriterProperties == nil)
+	for _, e := range v.DataSetWriterProperties {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.TransportSettings)
+	buf.WriteStruct(v.MessageSettings)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetWriterDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Enabled = buf.ReadBool()
+	v.DataSetWriterID = buf.ReadUint16()
+	v.DataSetFieldContentMask = DataSetFieldContentMask(buf.ReadUint32())
+	v.KeyFrameCount = buf.ReadUint32()
+	v.DataSetName = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataSetWriterProperties = make([]*KeyValuePair, n)
+		for i := range v.DataSetWriterProperties {
+			v.DataSetWriterProperties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.DataSetWriterProperties[i])
+		}
+	}
+	v.TransportSettings = new(ExtensionObject)
+	buf.ReadStruct(v.TransportSettings)
+	v.MessageSettings = new(ExtensionObject)
+	buf.ReadStruct(v.MessageSettings)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetWriterTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetWriterTransportDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetWriterMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetWriterMessageDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *PubSubGroupDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteBool(v.Enabled)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityGroupID)
+	buf.writeArrayLength(len(v.SecurityKeyServices), v.SecurityKeyServices == nil)
+	for _, e := range v.SecurityKeyServices {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint32(v.MaxNetworkMessageSize)
+	buf.writeArrayLength(len(v.GroupProperties), v.GroupProperties == nil)
+	for _, e := range v.GroupProperties {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PubSubGroupDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Enabled = buf.ReadBool()
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityGroupID = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SecurityKeyServices = make([]*EndpointDescription, n)
+		for i := range v.SecurityKeyServices {
+			v.SecurityKeyServices[i] = new(EndpointDescription)
+			buf.ReadStruct(v.SecurityKeyServices[i])
+		}
+	}
+	v.MaxNetworkMessageSize = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.GroupProperties = make([]*KeyValuePair, n)
+		for i := range v.GroupProperties {
+			v.GroupProperties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.GroupProperties[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *WriterGroupDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteBool(v.Enabled)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityGroupID)
+	buf.writeArrayLength(len(v.SecurityKeyServices), v.SecurityKeyServices == nil)
+	for _, e := range v.SecurityKeyServices {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint32(v.MaxNetworkMessageSize)
+	buf.writeArrayLength(len(v.GroupProperties), v.GroupProperties == nil)
+	for _, e := range v.GroupProperties {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.WriterGroupID)
+	buf.WriteFloat64(v.PublishingInterval)
+	buf.WriteFloat64(v.KeepAliveTime)
+	buf.WriteUint8(v.Priority)
+	buf.writeArrayLength(len(v.LocaleIDs), v.LocaleIDs == nil)
+	for _, e := range v.LocaleIDs {
+		buf.WriteString(e)
+	}
+	buf.WriteString(v.HeaderLayoutURI)
+	buf.WriteStruct(v.TransportSettings)
+	buf.WriteStruct(v.MessageSettings)
+	buf.writeArrayLength(len(v.DataSetWriters), v.DataSetWriters == nil)
+	for _, e := range v.DataSetWriters {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *WriterGroupDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Enabled = buf.ReadBool()
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityGroupID = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SecurityKeyServices = make([]*EndpointDescription, n)
+		for i := range v.SecurityKeyServices {
+			v.SecurityKeyServices[i] = new(EndpointDescription)
+			buf.ReadStruct(v.SecurityKeyServices[i])
+		}
+	}
+	v.MaxNetworkMessageSize = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.GroupProperties = make([]*KeyValuePair, n)
+		for i := range v.GroupProperties {
+			v.GroupProperties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.GroupProperties[i])
+		}
+	}
+	v.WriterGroupID = buf.ReadUint16()
+	v.PublishingInterval = buf.ReadFloat64()
+	v.KeepAliveTime = buf.ReadFloat64()
+	v.Priority = buf.ReadByte()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LocaleIDs = make([]string, n)
+		for i := range v.LocaleIDs {
+			v.LocaleIDs[i] = buf.ReadString()
+		}
+	}
+	v.HeaderLayoutURI = buf.ReadString()
+	v.TransportSettings = new(ExtensionObject)
+	buf.ReadStruct(v.TransportSettings)
+	v.MessageSettings = new(ExtensionObject)
+	buf.ReadStruct(v.MessageSettings)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataSetWriters = make([]*DataSetWriterDataType, n)
+		for i := range v.DataSetWriters {
+			v.DataSetWriters[i] = new(DataSetWriterDataType)
+			buf.ReadStruct(v.DataSetWriters[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *WriterGroupTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *WriterGroupTransportDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *WriterGroupMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *WriterGroupMessageDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *PubSubConnectionDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteBool(v.Enabled)
+	buf.WriteStruct(v.PublisherID)
+	buf.WriteString(v.TransportProfileURI)
+	buf.WriteStruct(v.Address)
+	buf.writeArrayLength(len(v.ConnectionProperties), v.ConnectionProperties == nil)
+	for _, e := range v.ConnectionProperties {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.TransportSettings)
+	buf.writeArrayLength(len(v.WriterGroups), v.WriterGroups == nil)
+	for _, e := range v.WriterGroups {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.ReaderGroups), v.ReaderGroups == nil)
+	for _, e := range v.ReaderGroups {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PubSubConnectionDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Enabled = buf.ReadBool()
+	v.PublisherID = new(Variant)
+	buf.ReadStruct(v.PublisherID)
+	v.TransportProfileURI = buf.ReadString()
+	v.Address = new(ExtensionObject)
+	buf.ReadStruct(v.Address)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ConnectionProperties = make([]*KeyValuePair, n)
+		for i := range v.ConnectionProperties {
+			v.ConnectionProperties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.ConnectionProperties[i])
+		}
+	}
+	v.TransportSettings = new(ExtensionObject)
+	buf.ReadStruct(v.TransportSettings)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.WriterGroups = make([]*WriterGroupDataType, n)
+		for i := range v.WriterGroups {
+			v.WriterGroups[i] = new(WriterGroupDataType)
+			buf.ReadStruct(v.WriterGroups[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ReaderGroups = make([]*ReaderGroupDataType, n)
+		for i := range v.ReaderGroups {
+			v.ReaderGroups[i] = new(ReaderGroupDataType)
+			buf.ReadStruct(v.ReaderGroups[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ConnectionTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ConnectionTransportDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *NetworkAddressDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.NetworkInterface)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NetworkAddressDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NetworkInterface = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NetworkAddressURLDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.NetworkInterface)
+	buf.WriteString(v.URL)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NetworkAddressURLDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NetworkInterface = buf.ReadString()
+	v.URL = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReaderGroupDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteBool(v.Enabled)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityGroupID)
+	buf.writeArrayLength(len(v.SecurityKeyServices), v.SecurityKeyServices == nil)
+	for _, e := range v.SecurityKeyServices {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint32(v.MaxNetworkMessageSize)
+	buf.writeArrayLength(len(v.GroupProperties), v.GroupProperties == nil)
+	for _, e := range v.GroupProperties {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.TransportSettings)
+	buf.WriteStruct(v.MessageSettings)
+	buf.writeArrayLength(len(v.DataSetReaders), v.DataSetReaders == nil)
+	for _, e := range v.DataSetReaders {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReaderGroupDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Enabled = buf.ReadBool()
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityGroupID = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SecurityKeyServices = make([]*EndpointDescription, n)
+		for i := range v.SecurityKeyServices {
+			v.SecurityKeyServices[i] = new(EndpointDescription)
+			buf.ReadStruct(v.SecurityKeyServices[i])
+		}
+	}
+	v.MaxNetworkMessageSize = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.GroupProperties = make([]*KeyValuePair, n)
+		for i := range v.GroupProperties {
+			v.GroupProperties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.GroupProperties[i])
+		}
+	}
+	v.TransportSettings = new(ExtensionObject)
+	buf.ReadStruct(v.TransportSettings)
+	v.MessageSettings = new(ExtensionObject)
+	buf.ReadStruct(v.MessageSettings)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataSetReaders = make([]*DataSetReaderDataType, n)
+		for i := range v.DataSetReaders {
+			v.DataSetReaders[i] = new(DataSetReaderDataType)
+			buf.ReadStruct(v.DataSetReaders[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReaderGroupTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReaderGroupTransportDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReaderGroupMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReaderGroupMessageDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetReaderDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteBool(v.Enabled)
+	buf.WriteStruct(v.PublisherID)
+	buf.WriteUint16(v.WriterGroupID)
+	buf.WriteUint16(v.DataSetWriterID)
+	buf.WriteStruct(v.DataSetMetaData)
+	buf.WriteUint32(uint32(v.DataSetFieldContentMask))
+	buf.WriteFloat64(v.MessageReceiveTimeout)
+	buf.WriteUint32(v.KeyFrameCount)
+	buf.WriteString(v.HeaderLayoutURI)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityGroupID)
+	buf.writeArrayLength(len(v.SecurityKeyServices), v.SecurityKeyServices == nil)
+	for _, e := range v.SecurityKeyServices {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DataSetReaderProperties), v.DataSetReaderProperties == nil)
+	for _, e := range v.DataSetReaderProperties {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.TransportSettings)
+	buf.WriteStruct(v.MessageSettings)
+	buf.WriteStruct(v.SubscribedDataSet)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetReaderDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Enabled = buf.ReadBool()
+	v.PublisherID = new(Variant)
+	buf.ReadStruct(v.PublisherID)
+	v.WriterGroupID = buf.ReadUint16()
+	v.DataSetWriterID = buf.ReadUint16()
+	v.DataSetMetaData = new(DataSetMetaDataType)
+	buf.ReadStruct(v.DataSetMetaData)
+	v.DataSetFieldContentMask = DataSetFieldContentMask(buf.ReadUint32())
+	v.MessageReceiveTimeout = buf.ReadFloat64()
+	v.KeyFrameCount = buf.ReadUint32()
+	v.HeaderLayoutURI = buf.ReadString()
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityGroupID = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SecurityKeyServices = make([]*EndpointDescription, n)
+		for i := range v.SecurityKeyServices {
+			v.SecurityKeyServices[i] = new(EndpointDescription)
+			buf.ReadStruct(v.SecurityKeyServices[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataSetReaderProperties = make([]*KeyValuePair, n)
+		for i := range v.DataSetReaderProperties {
+			v.DataSetReaderProperties[i] = new(KeyValuePair)
+			buf.ReadStruct(v.DataSetReaderProperties[i])
+		}
+	}
+	v.TransportSettings = new(ExtensionObject)
+	buf.ReadStruct(v.TransportSettings)
+	v.MessageSettings = new(ExtensionObject)
+	buf.ReadStruct(v.MessageSettings)
+	v.SubscribedDataSet = new(ExtensionObject)
+	buf.ReadStruct(v.SubscribedDataSet)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetReaderTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetReaderTransportDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataSetReaderMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataSetReaderMessageDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *SubscribedDataSetDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SubscribedDataSetDataType) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *TargetVariablesDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.TargetVariables), v.TargetVariables == nil)
+	for _, e := range v.TargetVariables {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TargetVariablesDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.TargetVariables = make([]*FieldTargetDataType, n)
+		for i := range v.TargetVariables {
+			v.TargetVariables[i] = new(FieldTargetDataType)
+			buf.ReadStruct(v.TargetVariables[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FieldTargetDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DataSetFieldID)
+	buf.WriteString(v.ReceiverIndexRange)
+	buf.WriteStruct(v.TargetNodeID)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteString(v.WriteIndexRange)
+	buf.WriteUint32(uint32(v.OverrideValueHandling))
+	buf.WriteStruct(v.OverrideValue)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FieldTargetDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataSetFieldID = new(GUID)
+	buf.ReadStruct(v.DataSetFieldID)
+	v.ReceiverIndexRange = buf.ReadString()
+	v.TargetNodeID = new(NodeID)
+	buf.ReadStruct(v.TargetNodeID)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.WriteIndexRange = buf.ReadString()
+	v.OverrideValueHandling = OverrideValueHandling(buf.ReadUint32())
+	v.OverrideValue = new(Variant)
+	buf.ReadStruct(v.OverrideValue)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SubscribedDataSetMirrorDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ParentNodeName)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SubscribedDataSetMirrorDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ParentNodeName = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PubSubConfigurationDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.PublishedDataSets), v.PublishedDataSets == nil)
+	for _, e := range v.PublishedDataSets {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.Connections), v.Connections == nil)
+	for _, e := range v.Connections {
+		buf.WriteStruct(e)
+	}
+	buf.WriteBool(v.Enabled)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PubSubConfigurationDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.PublishedDataSets = make([]*PublishedDataSetDataType, n)
+		for i := range v.PublishedDataSets {
+			v.PublishedDataSets[i] = new(PublishedDataSetDataType)
+			buf.ReadStruct(v.PublishedDataSets[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Connections = make([]*PubSubConnectionDataType, n)
+		for i := range v.Connections {
+			v.Connections[i] = new(PubSubConnectionDataType)
+			buf.ReadStruct(v.Connections[i])
+		}
+	}
+	v.Enabled = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UADPWriterGroupMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.GroupVersion)
+	buf.WriteUint32(uint32(v.DataSetOrdering))
+	buf.WriteUint32(uint32(v.NetworkMessageContentMask))
+	buf.WriteFloat64(v.SamplingOffset)
+	buf.writeArrayLength(len(v.PublishingOffset), v.PublishingOffset == nil)
+	for _, e := range v.PublishingOffset {
+		buf.WriteFloat64(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UADPWriterGroupMessageDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.GroupVersion = buf.ReadUint32()
+	v.DataSetOrdering = DataSetOrderingType(buf.ReadUint32())
+	v.NetworkMessageContentMask = UADPNetworkMessageContentMask(buf.ReadUint32())
+	v.SamplingOffset = buf.ReadFloat64()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.PublishingOffset = make([]float64, n)
+		for i := range v.PublishingOffset {
+			v.PublishingOffset[i] = buf.ReadFloat64()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UADPDataSetWriterMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.DataSetMessageContentMask))
+	buf.WriteUint16(v.ConfiguredSize)
+	buf.WriteUint16(v.NetworkMessageNumber)
+	buf.WriteUint16(v.DataSetOffset)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UADPDataSetWriterMessageDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataSetMessageContentMask = UADPDataSetMessageContentMask(buf.ReadUint32())
+	v.ConfiguredSize = buf.ReadUint16()
+	v.NetworkMessageNumber = buf.ReadUint16()
+	v.DataSetOffset = buf.ReadUint16()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UADPDataSetReaderMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.GroupVersion)
+	buf.WriteUint16(v.NetworkMessageNumber)
+	buf.WriteUint16(v.DataSetOffset)
+	buf.WriteStruct(v.DataSetClassID)
+	buf.WriteUint32(uint32(v.NetworkMessageContentMask))
+	buf.WriteUint32(uint32(v.DataSetMessageContentMask))
+	buf.WriteFloat64(v.PublishingInterval)
+	buf.WriteFloat64(v.ReceiveOffset)
+	buf.WriteFloat64(v.ProcessingOffset)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UADPDataSetReaderMessageDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.GroupVersion = buf.ReadUint32()
+	v.NetworkMessageNumber = buf.ReadUint16()
+	v.DataSetOffset = buf.ReadUint16()
+	v.DataSetClassID = new(GUID)
+	buf.ReadStruct(v.DataSetClassID)
+	v.NetworkMessageContentMask = UADPNetworkMessageContentMask(buf.ReadUint32())
+	v.DataSetMessageContentMask = UADPDataSetMessageContentMask(buf.ReadUint32())
+	v.PublishingInterval = buf.ReadFloat64()
+	v.ReceiveOffset = buf.ReadFloat64()
+	v.ProcessingOffset = buf.ReadFloat64()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *JSONWriterGroupMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.NetworkMessageContentMask))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *JSONWriterGroupMessageDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NetworkMessageContentMask = JSONNetworkMessageContentMask(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *JSONDataSetWriterMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.DataSetMessageContentMask))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *JSONDataSetWriterMessageDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DataSetMessageContentMask = JSONDataSetMessageContentMask(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *JSONDataSetReaderMessageDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.NetworkMessageContentMask))
+	buf.WriteUint32(uint32(v.DataSetMessageContentMask))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *JSONDataSetReaderMessageDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NetworkMessageContentMask = JSONNetworkMessageContentMask(buf.ReadUint32())
+	v.DataSetMessageContentMask = JSONDataSetMessageContentMask(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DatagramConnectionTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DiscoveryAddress)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DatagramConnectionTransportDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DiscoveryAddress = new(ExtensionObject)
+	buf.ReadStruct(v.DiscoveryAddress)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DatagramWriterGroupTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint8(v.MessageRepeatCount)
+	buf.WriteFloat64(v.MessageRepeatDelay)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DatagramWriterGroupTransportDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.MessageRepeatCount = buf.ReadByte()
+	v.MessageRepeatDelay = buf.ReadFloat64()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrokerConnectionTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ResourceURI)
+	buf.WriteString(v.AuthenticationProfileURI)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrokerConnectionTransportDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResourceURI = buf.ReadString()
+	v.AuthenticationProfileURI = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrokerWriterGroupTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.QueueName)
+	buf.WriteString(v.ResourceURI)
+	buf.WriteString(v.AuthenticationProfileURI)
+	buf.WriteUint32(uint32(v.RequestedDeliveryGuarantee))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrokerWriterGroupTransportDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.QueueName = buf.ReadString()
+	v.ResourceURI = buf.ReadString()
+	v.AuthenticationProfileURI = buf.ReadString()
+	v.RequestedDeliveryGuarantee = BrokerTransportQoS(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrokerDataSetWriterTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.QueueName)
+	buf.WriteString(v.ResourceURI)
+	buf.WriteString(v.AuthenticationProfileURI)
+	buf.WriteUint32(uint32(v.RequestedDeliveryGuarantee))
+	buf.WriteString(v.MetaDataQueueName)
+	buf.WriteFloat64(v.MetaDataUpdateTime)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrokerDataSetWriterTransportDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.QueueName = buf.ReadString()
+	v.ResourceURI = buf.ReadString()
+	v.AuthenticationProfileURI = buf.ReadString()
+	v.RequestedDeliveryGuarantee = BrokerTransportQoS(buf.ReadUint32())
+	v.MetaDataQueueName = buf.ReadString()
+	v.MetaDataUpdateTime = buf.ReadFloat64()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrokerDataSetReaderTransportDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.QueueName)
+	buf.WriteString(v.ResourceURI)
+	buf.WriteString(v.AuthenticationProfileURI)
+	buf.WriteUint32(uint32(v.RequestedDeliveryGuarantee))
+	buf.WriteString(v.MetaDataQueueName)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrokerDataSetReaderTransportDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.QueueName = buf.ReadString()
+	v.ResourceURI = buf.ReadString()
+	v.AuthenticationProfileURI = buf.ReadString()
+	v.RequestedDeliveryGuarantee = BrokerTransportQoS(buf.ReadUint32())
+	v.MetaDataQueueName = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RolePermissionType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RoleID)
+	buf.WriteUint32(uint32(v.Permissions))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RolePermissionType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RoleID = new(NodeID)
+	buf.ReadStruct(v.RoleID)
+	v.Permissions = PermissionType(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *StructureField) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteStruct(v.Description)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteUint32(v.MaxStringLength)
+	buf.WriteBool(v.IsOptional)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *StructureField) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.MaxStringLength = buf.ReadUint32()
+	v.IsOptional = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *StructureDefinition) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.DefaultEncodingID)
+	buf.WriteStruct(v.BaseDataType)
+	buf.WriteUint32(uint32(v.StructureType))
+	buf.writeArrayLength(len(v.Fields), v.Fields == nil)
+	for _, e := range v.Fields {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *StructureDefinition) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.DefaultEncodingID = new(NodeID)
+	buf.ReadStruct(v.DefaultEncodingID)
+	v.BaseDataType = new(NodeID)
+	buf.ReadStruct(v.BaseDataType)
+	v.StructureType = StructureType(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Fields = make([]*StructureField, n)
+		for i := range v.Fields {
+			v.Fields[i] = new(StructureField)
+			buf.ReadStruct(v.Fields[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EnumDefinition) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Fields), v.Fields == nil)
+	for _, e := range v.Fields {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EnumDefinition) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Fields = make([]*EnumField, n)
+		for i := range v.Fields {
+			v.Fields[i] = new(EnumField)
+			buf.ReadStruct(v.Fields[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *Node) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *Node) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *InstanceNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *InstanceNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TypeNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TypeNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ObjectNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint8(v.EventNotifier)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ObjectNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.EventNotifier = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ObjectTypeNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteBool(v.IsAbstract)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ObjectTypeNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.IsAbstract = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *VariableNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.Value)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteUint8(v.AccessLevel)
+	buf.WriteUint8(v.UserAccessLevel)
+	buf.WriteFloat64(v.MinimumSamplingInterval)
+	buf.WriteBool(v.Historizing)
+	buf.WriteUint32(v.AccessLevelEx)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *VariableNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.AccessLevel = buf.ReadByte()
+	v.UserAccessLevel = buf.ReadByte()
+	v.MinimumSamplingInterval = buf.ReadFloat64()
+	v.Historizing = buf.ReadBool()
+	v.AccessLevelEx = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *VariableTypeNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.Value)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteBool(v.IsAbstract)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *VariableTypeNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.IsAbstract = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReferenceTypeNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteBool(v.IsAbstract)
+	buf.WriteBool(v.Symmetric)
+	buf.WriteStruct(v.InverseName)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReferenceTypeNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.IsAbstract = buf.ReadBool()
+	v.Symmetric = buf.ReadBool()
+	v.InverseName = new(LocalizedText)
+	buf.ReadStruct(v.InverseName)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MethodNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteBool(v.Executable)
+	buf.WriteBool(v.UserExecutable)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MethodNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.Executable = buf.ReadBool()
+	v.UserExecutable = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ViewNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteBool(v.ContainsNoLoops)
+	buf.WriteUint8(v.EventNotifier)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ViewNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.ContainsNoLoops = buf.ReadBool()
+	v.EventNotifier = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataTypeNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.RolePermissions), v.RolePermissions == nil)
+	for _, e := range v.RolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.UserRolePermissions), v.UserRolePermissions == nil)
+	for _, e := range v.UserRolePermissions {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint16(v.AccessRestrictions)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	buf.WriteBool(v.IsAbstract)
+	buf.WriteStruct(v.DataTypeDefinition)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataTypeNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RolePermissions = make([]*RolePermissionType, n)
+		for i := range v.RolePermissions {
+			v.RolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.RolePermissions[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserRolePermissions = make([]*RolePermissionType, n)
+		for i := range v.UserRolePermissions {
+			v.UserRolePermissions[i] = new(RolePermissionType)
+			buf.ReadStruct(v.UserRolePermissions[i])
+		}
+	}
+	v.AccessRestrictions = buf.ReadUint16()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceNode, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceNode)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	v.IsAbstract = buf.ReadBool()
+	v.DataTypeDefinition = new(ExtensionObject)
+	buf.ReadStruct(v.DataTypeDefinition)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReferenceNode) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IsInverse)
+	buf.WriteStruct(v.TargetID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReferenceNode) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IsInverse = buf.ReadBool()
+	v.TargetID = new(ExpandedNodeID)
+	buf.ReadStruct(v.TargetID)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *Argument) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Name)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteStruct(v.Description)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *Argument) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Name = buf.ReadString()
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EnumValueType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteInt64(v.Value)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EnumValueType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Value = buf.ReadInt64()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EnumField) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteInt64(v.Value)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteString(v.Name)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EnumField) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Value = buf.ReadInt64()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.Name = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *OptionSet) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteByteString(v.Value)
+	buf.WriteByteString(v.ValidBits)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *OptionSet) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Value = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ValidBits = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *Union) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *Union) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *TimeZoneDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteInt16(v.Offset)
+	buf.WriteBool(v.DaylightSavingInOffset)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TimeZoneDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Offset = buf.ReadInt16()
+	v.DaylightSavingInOffset = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ApplicationDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ApplicationURI)
+	buf.WriteString(v.ProductURI)
+	buf.WriteStruct(v.ApplicationName)
+	buf.WriteUint32(uint32(v.ApplicationType))
+	buf.WriteString(v.GatewayServerURI)
+	buf.WriteString(v.DiscoveryProfileURI)
+	buf.writeArrayLength(len(v.DiscoveryURLs), v.DiscoveryURLs == nil)
+	for _, e := range v.DiscoveryURLs {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ApplicationDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ApplicationURI = buf.ReadString()
+	v.ProductURI = buf.ReadString()
+	v.ApplicationName = new(LocalizedText)
+	buf.ReadStruct(v.ApplicationName)
+	v.ApplicationType = ApplicationType(buf.ReadUint32())
+	v.GatewayServerURI = buf.ReadString()
+	v.DiscoveryProfileURI = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiscoveryURLs = make([]string, n)
+		for i := range v.DiscoveryURLs {
+			v.DiscoveryURLs[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RequestHeader) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.AuthenticationToken)
+	buf.WriteTime(v.Timestamp)
+	buf.WriteUint32(v.RequestHandle)
+	buf.WriteUint32(v.ReturnDiagnostics)
+	buf.WriteString(v.AuditEntryID)
+	buf.WriteUint32(v.TimeoutHint)
+	buf.WriteStruct(v.AdditionalHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RequestHeader) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.AuthenticationToken = new(NodeID)
+	buf.ReadStruct(v.AuthenticationToken)
+	v.Timestamp = buf.ReadTime()
+	v.RequestHandle = buf.ReadUint32()
+	v.ReturnDiagnostics = buf.ReadUint32()
+	v.AuditEntryID = buf.ReadString()
+	v.TimeoutHint = buf.ReadUint32()
+	v.AdditionalHeader = new(ExtensionObject)
+	buf.ReadStruct(v.AdditionalHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ResponseHeader) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteTime(v.Timestamp)
+	buf.WriteUint32(v.RequestHandle)
+	buf.WriteUint32(uint32(v.ServiceResult))
+	buf.WriteStruct(v.ServiceDiagnostics)
+	buf.writeArrayLength(len(v.StringTable), v.StringTable == nil)
+	for _, e := range v.StringTable {
+		buf.WriteString(e)
+	}
+	buf.WriteStruct(v.AdditionalHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ResponseHeader) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Timestamp = buf.ReadTime()
+	v.RequestHandle = buf.ReadUint32()
+	v.ServiceResult = StatusCode(buf.ReadUint32())
+	v.ServiceDiagnostics = new(DiagnosticInfo)
+	buf.ReadStruct(v.ServiceDiagnostics)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.StringTable = make([]string, n)
+		for i := range v.StringTable {
+			v.StringTable[i] = buf.ReadString()
+		}
+	}
+	v.AdditionalHeader = new(ExtensionObject)
+	buf.ReadStruct(v.AdditionalHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ServiceFault) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ServiceFault) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SessionlessInvokeRequestType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.URIsVersion), v.URIsVersion == nil)
+	for _, e := range v.URIsVersion {
+		buf.WriteUint32(e)
+	}
+	buf.writeArrayLength(len(v.NamespaceURIs), v.NamespaceURIs == nil)
+	for _, e := range v.NamespaceURIs {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.ServerURIs), v.ServerURIs == nil)
+	for _, e := range v.ServerURIs {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.LocaleIDs), v.LocaleIDs == nil)
+	for _, e := range v.LocaleIDs {
+		buf.WriteString(e)
+	}
+	buf.WriteUint32(v.ServiceID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SessionlessInvokeRequestType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.URIsVersion = make([]uint32, n)
+		for i := range v.URIsVersion {
+			v.URIsVersion[i] = buf.ReadUint32()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NamespaceURIs = make([]string, n)
+		for i := range v.NamespaceURIs {
+			v.NamespaceURIs[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerURIs = make([]string, n)
+		for i := range v.ServerURIs {
+			v.ServerURIs[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LocaleIDs = make([]string, n)
+		for i := range v.LocaleIDs {
+			v.LocaleIDs[i] = buf.ReadString()
+		}
+	}
+	v.ServiceID = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SessionlessInvokeResponseType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.NamespaceURIs), v.NamespaceURIs == nil)
+	for _, e := range v.NamespaceURIs {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.ServerURIs), v.ServerURIs == nil)
+	for _, e := range v.ServerURIs {
+		buf.WriteString(e)
+	}
+	buf.WriteUint32(v.ServiceID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SessionlessInvokeResponseType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NamespaceURIs = make([]string, n)
+		for i := range v.NamespaceURIs {
+			v.NamespaceURIs[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerURIs = make([]string, n)
+		for i := range v.ServerURIs {
+			v.ServerURIs[i] = buf.ReadString()
+		}
+	}
+	v.ServiceID = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FindServersRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteString(v.EndpointURL)
+	buf.writeArrayLength(len(v.LocaleIDs), v.LocaleIDs == nil)
+	for _, e := range v.LocaleIDs {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.ServerURIs), v.ServerURIs == nil)
+	for _, e := range v.ServerURIs {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FindServersRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.EndpointURL = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LocaleIDs = make([]string, n)
+		for i := range v.LocaleIDs {
+			v.LocaleIDs[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerURIs = make([]string, n)
+		for i := range v.ServerURIs {
+			v.ServerURIs[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FindServersResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Servers), v.Servers == nil)
+	for _, e := range v.Servers {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FindServersResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Servers = make([]*ApplicationDescription, n)
+		for i := range v.Servers {
+			v.Servers[i] = new(ApplicationDescription)
+			buf.ReadStruct(v.Servers[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ServerOnNetwork) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.RecordID)
+	buf.WriteString(v.ServerName)
+	buf.WriteString(v.DiscoveryURL)
+	buf.writeArrayLength(len(v.ServerCapabilities), v.ServerCapabilities == nil)
+	for _, e := range v.ServerCapabilities {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ServerOnNetwork) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RecordID = buf.ReadUint32()
+	v.ServerName = buf.ReadString()
+	v.DiscoveryURL = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerCapabilities = make([]string, n)
+		for i := range v.ServerCapabilities {
+			v.ServerCapabilities[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FindServersOnNetworkRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.StartingRecordID)
+	buf.WriteUint32(v.MaxRecordsToReturn)
+	buf.writeArrayLength(len(v.ServerCapabilityFilter), v.ServerCapabilityFilter == nil)
+	for _, e := range v.ServerCapabilityFilter {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FindServersOnNetworkRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.StartingRecordID = buf.ReadUint32()
+	v.MaxRecordsToReturn = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerCapabilityFilter = make([]string, n)
+		for i := range v.ServerCapabilityFilter {
+			v.ServerCapabilityFilter[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FindServersOnNetworkResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteTime(v.LastCounterResetTime)
+	buf.writeArrayLength(len(v.Servers), v.Servers == nil)
+	for _, e := range v.Servers {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FindServersOnNetworkResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.LastCounterResetTime = buf.ReadTime()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Servers = make([]*ServerOnNetwork, n)
+		for i := range v.Servers {
+			v.Servers[i] = new(ServerOnNetwork)
+			buf.ReadStruct(v.Servers[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UserTokenPolicy) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.PolicyID)
+	buf.WriteUint32(uint32(v.TokenType))
+	buf.WriteString(v.IssuedTokenType)
+	buf.WriteString(v.IssuerEndpointURL)
+	buf.WriteString(v.SecurityPolicyURI)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UserTokenPolicy) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PolicyID = buf.ReadString()
+	v.TokenType = UserTokenType(buf.ReadUint32())
+	v.IssuedTokenType = buf.ReadString()
+	v.IssuerEndpointURL = buf.ReadString()
+	v.SecurityPolicyURI = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EndpointDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.EndpointURL)
+	buf.WriteStruct(v.Server)
+	buf.WriteByteString(v.ServerCertificate)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityPolicyURI)
+	buf.writeArrayLength(len(v.UserIdentityTokens), v.UserIdentityTokens == nil)
+	for _, e := range v.UserIdentityTokens {
+		buf.WriteStruct(e)
+	}
+	buf.WriteString(v.TransportProfileURI)
+	buf.WriteUint8(v.SecurityLevel)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EndpointDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.EndpointURL = buf.ReadString()
+	v.Server = new(ApplicationDescription)
+	buf.ReadStruct(v.Server)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerCertificate = buf.ReadN(n)
+	}
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityPolicyURI = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UserIdentityTokens = make([]*UserTokenPolicy, n)
+		for i := range v.UserIdentityTokens {
+			v.UserIdentityTokens[i] = new(UserTokenPolicy)
+			buf.ReadStruct(v.UserIdentityTokens[i])
+		}
+	}
+	v.TransportProfileURI = buf.ReadString()
+	v.SecurityLevel = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *GetEndpointsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteString(v.EndpointURL)
+	buf.writeArrayLength(len(v.LocaleIDs), v.LocaleIDs == nil)
+	for _, e := range v.LocaleIDs {
+		buf.WriteString(e)
+	}
+	buf.writeArrayLength(len(v.ProfileURIs), v.ProfileURIs == nil)
+	for _, e := range v.ProfileURIs {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *GetEndpointsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.EndpointURL = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LocaleIDs = make([]string, n)
+		for i := range v.LocaleIDs {
+			v.LocaleIDs[i] = buf.ReadString()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ProfileURIs = make([]string, n)
+		for i := range v.ProfileURIs {
+			v.ProfileURIs[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *GetEndpointsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Endpoints), v.Endpoints == nil)
+	for _, e := range v.Endpoints {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *GetEndpointsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Endpoints = make([]*EndpointDescription, n)
+		for i := range v.Endpoints {
+			v.Endpoints[i] = new(EndpointDescription)
+			buf.ReadStruct(v.Endpoints[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisteredServer) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ServerURI)
+	buf.WriteString(v.ProductURI)
+	buf.writeArrayLength(len(v.ServerNames), v.ServerNames == nil)
+	for _, e := range v.ServerNames {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint32(uint32(v.ServerType))
+	buf.WriteString(v.GatewayServerURI)
+	buf.writeArrayLength(len(v.DiscoveryURLs), v.DiscoveryURLs == nil)
+	for _, e := range v.DiscoveryURLs {
+		buf.WriteString(e)
+	}
+	buf.WriteString(v.SemaphoreFilePath)
+	buf.WriteBool(v.IsOnline)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisteredServer) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ServerURI = buf.ReadString()
+	v.ProductURI = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerNames = make([]*LocalizedText, n)
+		for i := range v.ServerNames {
+			v.ServerNames[i] = new(LocalizedText)
+			buf.ReadStruct(v.ServerNames[i])
+		}
+	}
+	v.ServerType = ApplicationType(buf.ReadUint32())
+	v.GatewayServerURI = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiscoveryURLs = make([]string, n)
+		for i := range v.DiscoveryURLs {
+			v.DiscoveryURLs[i] = buf.ReadString()
+		}
+	}
+	v.SemaphoreFilePath = buf.ReadString()
+	v.IsOnline = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisterServerRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.Server)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisterServerRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.Server = new(RegisteredServer)
+	buf.ReadStruct(v.Server)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisterServerResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisterServerResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DiscoveryConfiguration) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DiscoveryConfiguration) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *MdnsDiscoveryConfiguration) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.MdnsServerName)
+	buf.writeArrayLength(len(v.ServerCapabilities), v.ServerCapabilities == nil)
+	for _, e := range v.ServerCapabilities {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MdnsDiscoveryConfiguration) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.MdnsServerName = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerCapabilities = make([]string, n)
+		for i := range v.ServerCapabilities {
+			v.ServerCapabilities[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisterServer2Request) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.Server)
+	buf.writeArrayLength(len(v.DiscoveryConfiguration), v.DiscoveryConfiguration == nil)
+	for _, e := range v.DiscoveryConfiguration {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisterServer2Request) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.Server = new(RegisteredServer)
+	buf.ReadStruct(v.Server)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiscoveryConfiguration = make([]*ExtensionObject, n)
+		for i := range v.DiscoveryConfiguration {
+			v.DiscoveryConfiguration[i] = new(ExtensionObject)
+			buf.ReadStruct(v.DiscoveryConfiguration[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisterServer2Response) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.ConfigurationResults), v.ConfigurationResults == nil)
+	for _, e := range v.ConfigurationResults {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisterServer2Response) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ConfigurationResults = make([]StatusCode, n)
+		for i := range v.ConfigurationResults {
+			v.ConfigurationResults[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ChannelSecurityToken) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.ChannelID)
+	buf.WriteUint32(v.TokenID)
+	buf.WriteTime(v.CreatedAt)
+	buf.WriteUint32(v.RevisedLifetime)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ChannelSecurityToken) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ChannelID = buf.ReadUint32()
+	v.TokenID = buf.ReadUint32()
+	v.CreatedAt = buf.ReadTime()
+	v.RevisedLifetime = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *OpenSecureChannelRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.ClientProtocolVersion)
+	buf.WriteUint32(uint32(v.RequestType))
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteByteString(v.ClientNonce)
+	buf.WriteUint32(v.RequestedLifetime)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *OpenSecureChannelRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.ClientProtocolVersion = buf.ReadUint32()
+	v.RequestType = SecurityTokenRequestType(buf.ReadUint32())
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ClientNonce = buf.ReadN(n)
+	}
+	v.RequestedLifetime = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *OpenSecureChannelResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteUint32(v.ServerProtocolVersion)
+	buf.WriteStruct(v.SecurityToken)
+	buf.WriteByteString(v.ServerNonce)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *OpenSecureChannelResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.ServerProtocolVersion = buf.ReadUint32()
+	v.SecurityToken = new(ChannelSecurityToken)
+	buf.ReadStruct(v.SecurityToken)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerNonce = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CloseSecureChannelRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CloseSecureChannelRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CloseSecureChannelResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CloseSecureChannelResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SignedSoftwareCertificate) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteByteString(v.CertificateData)
+	buf.WriteByteString(v.Signature)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SignedSoftwareCertificate) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.CertificateData = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Signature = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SignatureData) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Algorithm)
+	buf.WriteByteString(v.Signature)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SignatureData) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Algorithm = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Signature = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CreateSessionRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.ClientDescription)
+	buf.WriteString(v.ServerURI)
+	buf.WriteString(v.EndpointURL)
+	buf.WriteString(v.SessionName)
+	buf.WriteByteString(v.ClientNonce)
+	buf.WriteByteString(v.ClientCertificate)
+	buf.WriteFloat64(v.RequestedSessionTimeout)
+	buf.WriteUint32(v.MaxResponseMessageSize)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CreateSessionRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.ClientDescription = new(ApplicationDescription)
+	buf.ReadStruct(v.ClientDescription)
+	v.ServerURI = buf.ReadString()
+	v.EndpointURL = buf.ReadString()
+	v.SessionName = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ClientNonce = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ClientCertificate = buf.ReadN(n)
+	}
+	v.RequestedSessionTimeout = buf.ReadFloat64()
+	v.MaxResponseMessageSize = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CreateSessionResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteStruct(v.SessionID)
+	buf.WriteStruct(v.AuthenticationToken)
+	buf.WriteFloat64(v.RevisedSessionTimeout)
+	buf.WriteByteString(v.ServerNonce)
+	buf.WriteByteString(v.ServerCertificate)
+	buf.writeArrayLength(len(v.ServerEndpoints), v.ServerEndpoints == nil)
+	for _, e := range v.ServerEndpoints {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.ServerSoftwareCertificates), v.ServerSoftwareCertificates == nil)
+	for _, e := range v.ServerSoftwareCertificates {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.ServerSignature)
+	buf.WriteUint32(v.MaxRequestMessageSize)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CreateSessionResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.SessionID = new(NodeID)
+	buf.ReadStruct(v.SessionID)
+	v.AuthenticationToken = new(NodeID)
+	buf.ReadStruct(v.AuthenticationToken)
+	v.RevisedSessionTimeout = buf.ReadFloat64()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerNonce = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerCertificate = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerEndpoints = make([]*EndpointDescription, n)
+		for i := range v.ServerEndpoints {
+			v.ServerEndpoints[i] = new(EndpointDescription)
+			buf.ReadStruct(v.ServerEndpoints[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerSoftwareCertificates = make([]*SignedSoftwareCertificate, n)
+		for i := range v.ServerSoftwareCertificates {
+			v.ServerSoftwareCertificates[i] = new(SignedSoftwareCertificate)
+			buf.ReadStruct(v.ServerSoftwareCertificates[i])
+		}
+	}
+	v.ServerSignature = new(SignatureData)
+	buf.ReadStruct(v.ServerSignature)
+	v.MaxRequestMessageSize = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UserIdentityToken) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.PolicyID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UserIdentityToken) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PolicyID = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AnonymousIdentityToken) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.PolicyID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AnonymousIdentityToken) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PolicyID = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UserNameIdentityToken) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.PolicyID)
+	buf.WriteString(v.UserName)
+	buf.WriteByteString(v.Password)
+	buf.WriteString(v.EncryptionAlgorithm)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UserNameIdentityToken) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PolicyID = buf.ReadString()
+	v.UserName = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Password = buf.ReadN(n)
+	}
+	v.EncryptionAlgorithm = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *X509IdentityToken) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.PolicyID)
+	buf.WriteByteString(v.CertificateData)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *X509IdentityToken) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PolicyID = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.CertificateData = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *IssuedIdentityToken) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.PolicyID)
+	buf.WriteByteString(v.TokenData)
+	buf.WriteString(v.EncryptionAlgorithm)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *IssuedIdentityToken) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.PolicyID = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.TokenData = buf.ReadN(n)
+	}
+	v.EncryptionAlgorithm = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ActivateSessionRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.ClientSignature)
+	buf.writeArrayLength(len(v.ClientSoftwareCertificates), v.ClientSoftwareCertificates == nil)
+	for _, e := range v.ClientSoftwareCertificates {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.LocaleIDs), v.LocaleIDs == nil)
+	for _, e := range v.LocaleIDs {
+		buf.WriteString(e)
+	}
+	buf.WriteStruct(v.UserIdentityToken)
+	buf.WriteStruct(v.UserTokenSignature)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ActivateSessionRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.ClientSignature = new(SignatureData)
+	buf.ReadStruct(v.ClientSignature)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ClientSoftwareCertificates = make([]*SignedSoftwareCertificate, n)
+		for i := range v.ClientSoftwareCertificates {
+			v.ClientSoftwareCertificates[i] = new(SignedSoftwareCertificate)
+			buf.ReadStruct(v.ClientSoftwareCertificates[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LocaleIDs = make([]string, n)
+		for i := range v.LocaleIDs {
+			v.LocaleIDs[i] = buf.ReadString()
+		}
+	}
+	v.UserIdentityToken = new(ExtensionObject)
+	buf.ReadStruct(v.UserIdentityToken)
+	v.UserTokenSignature = new(SignatureData)
+	buf.ReadStruct(v.UserTokenSignature)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ActivateSessionResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteByteString(v.ServerNonce)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ActivateSessionResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ServerNonce = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CloseSessionRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteBool(v.DeleteSubscriptions)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CloseSessionRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.DeleteSubscriptions = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CloseSessionResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CloseSessionResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CancelRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.RequestHandle)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CancelRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.RequestHandle = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CancelResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteUint32(v.CancelCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CancelResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.CancelCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NodeAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NodeAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ObjectAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteUint8(v.EventNotifier)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ObjectAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.EventNotifier = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *VariableAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteStruct(v.Value)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteUint8(v.AccessLevel)
+	buf.WriteUint8(v.UserAccessLevel)
+	buf.WriteFloat64(v.MinimumSamplingInterval)
+	buf.WriteBool(v.Historizing)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *VariableAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.AccessLevel = buf.ReadByte()
+	v.UserAccessLevel = buf.ReadByte()
+	v.MinimumSamplingInterval = buf.ReadFloat64()
+	v.Historizing = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MethodAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteBool(v.Executable)
+	buf.WriteBool(v.UserExecutable)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MethodAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.Executable = buf.ReadBool()
+	v.UserExecutable = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ObjectTypeAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteBool(v.IsAbstract)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ObjectTypeAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.IsAbstract = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *VariableTypeAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteStruct(v.Value)
+	buf.WriteStruct(v.DataType)
+	buf.WriteInt32(v.ValueRank)
+	buf.writeArrayLength(len(v.ArrayDimensions), v.ArrayDimensions == nil)
+	for _, e := range v.ArrayDimensions {
+		buf.WriteUint32(e)
+	}
+	buf.WriteBool(v.IsAbstract)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *VariableTypeAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	v.DataType = new(NodeID)
+	buf.ReadStruct(v.DataType)
+	v.ValueRank = buf.ReadInt32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ArrayDimensions = make([]uint32, n)
+		for i := range v.ArrayDimensions {
+			v.ArrayDimensions[i] = buf.ReadUint32()
+		}
+	}
+	v.IsAbstract = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReferenceTypeAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteBool(v.IsAbstract)
+	buf.WriteBool(v.Symmetric)
+	buf.WriteStruct(v.InverseName)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReferenceTypeAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.IsAbstract = buf.ReadBool()
+	v.Symmetric = buf.ReadBool()
+	v.InverseName = new(LocalizedText)
+	buf.ReadStruct(v.InverseName)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataTypeAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteBool(v.IsAbstract)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataTypeAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.IsAbstract = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ViewAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.WriteBool(v.ContainsNoLoops)
+	buf.WriteUint8(v.EventNotifier)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ViewAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	v.ContainsNoLoops = buf.ReadBool()
+	v.EventNotifier = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *GenericAttributeValue) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteStruct(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *GenericAttributeValue) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *GenericAttributes) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SpecifiedAttributes)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	buf.WriteUint32(v.WriteMask)
+	buf.WriteUint32(v.UserWriteMask)
+	buf.writeArrayLength(len(v.AttributeValues), v.AttributeValues == nil)
+	for _, e := range v.AttributeValues {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *GenericAttributes) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SpecifiedAttributes = buf.ReadUint32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	v.WriteMask = buf.ReadUint32()
+	v.UserWriteMask = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AttributeValues = make([]*GenericAttributeValue, n)
+		for i := range v.AttributeValues {
+			v.AttributeValues[i] = new(GenericAttributeValue)
+			buf.ReadStruct(v.AttributeValues[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddNodesItem) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ParentNodeID)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteStruct(v.RequestedNewNodeID)
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.NodeAttributes)
+	buf.WriteStruct(v.TypeDefinition)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddNodesItem) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ParentNodeID = new(ExpandedNodeID)
+	buf.ReadStruct(v.ParentNodeID)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.RequestedNewNodeID = new(ExpandedNodeID)
+	buf.ReadStruct(v.RequestedNewNodeID)
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.NodeAttributes = new(ExtensionObject)
+	buf.ReadStruct(v.NodeAttributes)
+	v.TypeDefinition = new(ExpandedNodeID)
+	buf.ReadStruct(v.TypeDefinition)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddNodesResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.WriteStruct(v.AddedNodeID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddNodesResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	v.AddedNodeID = new(NodeID)
+	buf.ReadStruct(v.AddedNodeID)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddNodesRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.NodesToAdd), v.NodesToAdd == nil)
+	for _, e := range v.NodesToAdd {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddNodesRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToAdd = make([]*AddNodesItem, n)
+		for i := range v.NodesToAdd {
+			v.NodesToAdd[i] = new(AddNodesItem)
+			buf.ReadStruct(v.NodesToAdd[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddNodesResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddNodesResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*AddNodesResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(AddNodesResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddReferencesItem) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.SourceNodeID)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IsForward)
+	buf.WriteString(v.TargetServerURI)
+	buf.WriteStruct(v.TargetNodeID)
+	buf.WriteUint32(uint32(v.TargetNodeClass))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddReferencesItem) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SourceNodeID = new(NodeID)
+	buf.ReadStruct(v.SourceNodeID)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IsForward = buf.ReadBool()
+	v.TargetServerURI = buf.ReadString()
+	v.TargetNodeID = new(ExpandedNodeID)
+	buf.ReadStruct(v.TargetNodeID)
+	v.TargetNodeClass = NodeClass(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddReferencesRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.ReferencesToAdd), v.ReferencesToAdd == nil)
+	for _, e := range v.ReferencesToAdd {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddReferencesRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ReferencesToAdd = make([]*AddReferencesItem, n)
+		for i := range v.ReferencesToAdd {
+			v.ReferencesToAdd[i] = new(AddReferencesItem)
+			buf.ReadStruct(v.ReferencesToAdd[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AddReferencesResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AddReferencesResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteNodesItem) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteBool(v.DeleteTargetReferences)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteNodesItem) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.DeleteTargetReferences = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteNodesRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.NodesToDelete), v.NodesToDelete == nil)
+	for _, e := range v.NodesToDelete {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteNodesRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToDelete = make([]*DeleteNodesItem, n)
+		for i := range v.NodesToDelete {
+			v.NodesToDelete[i] = new(DeleteNodesItem)
+			buf.ReadStruct(v.NodesToDelete[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteNodesResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteNodesResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteReferencesItem) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.SourceNodeID)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IsForward)
+	buf.WriteStruct(v.TargetNodeID)
+	buf.WriteBool(v.DeleteBidirectional)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteReferencesItem) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SourceNodeID = new(NodeID)
+	buf.ReadStruct(v.SourceNodeID)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IsForward = buf.ReadBool()
+	v.TargetNodeID = new(ExpandedNodeID)
+	buf.ReadStruct(v.TargetNodeID)
+	v.DeleteBidirectional = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteReferencesRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.ReferencesToDelete), v.ReferencesToDelete == nil)
+	for _, e := range v.ReferencesToDelete {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteReferencesRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ReferencesToDelete = make([]*DeleteReferencesItem, n)
+		for i := range v.ReferencesToDelete {
+			v.ReferencesToDelete[i] = new(DeleteReferencesItem)
+			buf.ReadStruct(v.ReferencesToDelete[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteReferencesResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteReferencesResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ViewDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ViewID)
+	buf.WriteTime(v.Timestamp)
+	buf.WriteUint32(v.ViewVersion)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ViewDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ViewID = new(NodeID)
+	buf.ReadStruct(v.ViewID)
+	v.Timestamp = buf.ReadTime()
+	v.ViewVersion = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowseDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.BrowseDirection))
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IncludeSubtypes)
+	buf.WriteUint32(v.NodeClassMask)
+	buf.WriteUint32(v.ResultMask)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowseDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.BrowseDirection = BrowseDirection(buf.ReadUint32())
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IncludeSubtypes = buf.ReadBool()
+	v.NodeClassMask = buf.ReadUint32()
+	v.ResultMask = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReferenceDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IsForward)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteStruct(v.BrowseName)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteUint32(uint32(v.NodeClass))
+	buf.WriteStruct(v.TypeDefinition)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReferenceDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IsForward = buf.ReadBool()
+	v.NodeID = new(ExpandedNodeID)
+	buf.ReadStruct(v.NodeID)
+	v.BrowseName = new(QualifiedName)
+	buf.ReadStruct(v.BrowseName)
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.NodeClass = NodeClass(buf.ReadUint32())
+	v.TypeDefinition = new(ExpandedNodeID)
+	buf.ReadStruct(v.TypeDefinition)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowseResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.WriteByteString(v.ContinuationPoint)
+	buf.writeArrayLength(len(v.References), v.References == nil)
+	for _, e := range v.References {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowseResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ContinuationPoint = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.References = make([]*ReferenceDescription, n)
+		for i := range v.References {
+			v.References[i] = new(ReferenceDescription)
+			buf.ReadStruct(v.References[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowseRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.View)
+	buf.WriteUint32(v.RequestedMaxReferencesPerNode)
+	buf.writeArrayLength(len(v.NodesToBrowse), v.NodesToBrowse == nil)
+	for _, e := range v.NodesToBrowse {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowseRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.View = new(ViewDescription)
+	buf.ReadStruct(v.View)
+	v.RequestedMaxReferencesPerNode = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToBrowse = make([]*BrowseDescription, n)
+		for i := range v.NodesToBrowse {
+			v.NodesToBrowse[i] = new(BrowseDescription)
+			buf.ReadStruct(v.NodesToBrowse[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowseResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowseResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*BrowseResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(BrowseResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowseNextRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteBool(v.ReleaseContinuationPoints)
+	buf.writeArrayLength(len(v.ContinuationPoints), v.ContinuationPoints == nil)
+	for _, e := range v.ContinuationPoints {
+		buf.WriteByteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowseNextRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.ReleaseContinuationPoints = buf.ReadBool()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ContinuationPoints = make([][]byte, n)
+		for i := range v.ContinuationPoints {
+			if n := buf.readArrayLength(); n >= 0 {
+				v.ContinuationPoints[i] = buf.ReadN(n)
+			}
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowseNextResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowseNextResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*BrowseResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(BrowseResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RelativePathElement) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IsInverse)
+	buf.WriteBool(v.IncludeSubtypes)
+	buf.WriteStruct(v.TargetName)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RelativePathElement) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IsInverse = buf.ReadBool()
+	v.IncludeSubtypes = buf.ReadBool()
+	v.TargetName = new(QualifiedName)
+	buf.ReadStruct(v.TargetName)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RelativePath) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Elements), v.Elements == nil)
+	for _, e := range v.Elements {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RelativePath) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Elements = make([]*RelativePathElement, n)
+		for i := range v.Elements {
+			v.Elements[i] = new(RelativePathElement)
+			buf.ReadStruct(v.Elements[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowsePath) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.StartingNode)
+	buf.WriteStruct(v.RelativePath)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowsePath) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StartingNode = new(NodeID)
+	buf.ReadStruct(v.StartingNode)
+	v.RelativePath = new(RelativePath)
+	buf.ReadStruct(v.RelativePath)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowsePathTarget) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.TargetID)
+	buf.WriteUint32(v.RemainingPathIndex)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowsePathTarget) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.TargetID = new(ExpandedNodeID)
+	buf.ReadStruct(v.TargetID)
+	v.RemainingPathIndex = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BrowsePathResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.writeArrayLength(len(v.Targets), v.Targets == nil)
+	for _, e := range v.Targets {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BrowsePathResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Targets = make([]*BrowsePathTarget, n)
+		for i := range v.Targets {
+			v.Targets[i] = new(BrowsePathTarget)
+			buf.ReadStruct(v.Targets[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TranslateBrowsePathsToNodeIDsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.BrowsePaths), v.BrowsePaths == nil)
+	for _, e := range v.BrowsePaths {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TranslateBrowsePathsToNodeIDsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.BrowsePaths = make([]*BrowsePath, n)
+		for i := range v.BrowsePaths {
+			v.BrowsePaths[i] = new(BrowsePath)
+			buf.ReadStruct(v.BrowsePaths[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TranslateBrowsePathsToNodeIDsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TranslateBrowsePathsToNodeIDsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*BrowsePathResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(BrowsePathResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisterNodesRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.NodesToRegister), v.NodesToRegister == nil)
+	for _, e := range v.NodesToRegister {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisterNodesRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToRegister = make([]*NodeID, n)
+		for i := range v.NodesToRegister {
+			v.NodesToRegister[i] = new(NodeID)
+			buf.ReadStruct(v.NodesToRegister[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RegisterNodesResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.RegisteredNodeIDs), v.RegisteredNodeIDs == nil)
+	for _, e := range v.RegisteredNodeIDs {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RegisterNodesResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RegisteredNodeIDs = make([]*NodeID, n)
+		for i := range v.RegisteredNodeIDs {
+			v.RegisteredNodeIDs[i] = new(NodeID)
+			buf.ReadStruct(v.RegisteredNodeIDs[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UnregisterNodesRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.NodesToUnregister), v.NodesToUnregister == nil)
+	for _, e := range v.NodesToUnregister {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UnregisterNodesRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToUnregister = make([]*NodeID, n)
+		for i := range v.NodesToUnregister {
+			v.NodesToUnregister[i] = new(NodeID)
+			buf.ReadStruct(v.NodesToUnregister[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UnregisterNodesResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UnregisterNodesResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EndpointConfiguration) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteInt32(v.OperationTimeout)
+	buf.WriteBool(v.UseBinaryEncoding)
+	buf.WriteInt32(v.MaxStringLength)
+	buf.WriteInt32(v.MaxByteStringLength)
+	buf.WriteInt32(v.MaxArrayLength)
+	buf.WriteInt32(v.MaxMessageSize)
+	buf.WriteInt32(v.MaxBufferSize)
+	buf.WriteInt32(v.ChannelLifetime)
+	buf.WriteInt32(v.SecurityTokenLifetime)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EndpointConfiguration) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.OperationTimeout = buf.ReadInt32()
+	v.UseBinaryEncoding = buf.ReadBool()
+	v.MaxStringLength = buf.ReadInt32()
+	v.MaxByteStringLength = buf.ReadInt32()
+	v.MaxArrayLength = buf.ReadInt32()
+	v.MaxMessageSize = buf.ReadInt32()
+	v.MaxBufferSize = buf.ReadInt32()
+	v.ChannelLifetime = buf.ReadInt32()
+	v.SecurityTokenLifetime = buf.ReadInt32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *QueryDataDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RelativePath)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteString(v.IndexRange)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *QueryDataDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RelativePath = new(RelativePath)
+	buf.ReadStruct(v.RelativePath)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.IndexRange = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NodeTypeDescription) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.TypeDefinitionNode)
+	buf.WriteBool(v.IncludeSubTypes)
+	buf.writeArrayLength(len(v.DataToReturn), v.DataToReturn == nil)
+	for _, e := range v.DataToReturn {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NodeTypeDescription) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.TypeDefinitionNode = new(ExpandedNodeID)
+	buf.ReadStruct(v.TypeDefinitionNode)
+	v.IncludeSubTypes = buf.ReadBool()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataToReturn = make([]*QueryDataDescription, n)
+		for i := range v.DataToReturn {
+			v.DataToReturn[i] = new(QueryDataDescription)
+			buf.ReadStruct(v.DataToReturn[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *QueryDataSet) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteStruct(v.TypeDefinitionNode)
+	buf.writeArrayLength(len(v.Values), v.Values == nil)
+	for _, e := range v.Values {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *QueryDataSet) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(ExpandedNodeID)
+	buf.ReadStruct(v.NodeID)
+	v.TypeDefinitionNode = new(ExpandedNodeID)
+	buf.ReadStruct(v.TypeDefinitionNode)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Values = make([]*Variant, n)
+		for i := range v.Values {
+			v.Values[i] = new(Variant)
+			buf.ReadStruct(v.Values[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NodeReference) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteStruct(v.ReferenceTypeID)
+	buf.WriteBool(v.IsForward)
+	buf.writeArrayLength(len(v.ReferencedNodeIDs), v.ReferencedNodeIDs == nil)
+	for _, e := range v.ReferencedNodeIDs {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NodeReference) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.ReferenceTypeID = new(NodeID)
+	buf.ReadStruct(v.ReferenceTypeID)
+	v.IsForward = buf.ReadBool()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ReferencedNodeIDs = make([]*NodeID, n)
+		for i := range v.ReferencedNodeIDs {
+			v.ReferencedNodeIDs[i] = new(NodeID)
+			buf.ReadStruct(v.ReferencedNodeIDs[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ContentFilterElement) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.FilterOperator))
+	buf.writeArrayLength(len(v.FilterOperands), v.FilterOperands == nil)
+	for _, e := range v.FilterOperands {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ContentFilterElement) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.FilterOperator = FilterOperator(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.FilterOperands = make([]*ExtensionObject, n)
+		for i := range v.FilterOperands {
+			v.FilterOperands[i] = new(ExtensionObject)
+			buf.ReadStruct(v.FilterOperands[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ContentFilter) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Elements), v.Elements == nil)
+	for _, e := range v.Elements {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ContentFilter) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Elements = make([]*ContentFilterElement, n)
+		for i := range v.Elements {
+			v.Elements[i] = new(ContentFilterElement)
+			buf.ReadStruct(v.Elements[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *FilterOperand) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *FilterOperand) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *ElementOperand) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.Index)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ElementOperand) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Index = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *LiteralOperand) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *LiteralOperand) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Value = new(Variant)
+	buf.ReadStruct(v.Value)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AttributeOperand) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteString(v.Alias)
+	buf.WriteStruct(v.BrowsePath)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteString(v.IndexRange)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AttributeOperand) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.Alias = buf.ReadString()
+	v.BrowsePath = new(RelativePath)
+	buf.ReadStruct(v.BrowsePath)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.IndexRange = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SimpleAttributeOperand) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.TypeDefinitionID)
+	buf.writeArrayLength(len(v.BrowsePath), v.BrowsePath == nil)
+	for _, e := range v.BrowsePath {
+		buf.WriteStruct(e)
+	}
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteString(v.IndexRange)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SimpleAttributeOperand) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.TypeDefinitionID = new(NodeID)
+	buf.ReadStruct(v.TypeDefinitionID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.BrowsePath = make([]*QualifiedName, n)
+		for i := range v.BrowsePath {
+			v.BrowsePath[i] = new(QualifiedName)
+			buf.ReadStruct(v.BrowsePath[i])
+		}
+	}
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.IndexRange = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ContentFilterElementResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.writeArrayLength(len(v.OperandStatusCodes), v.OperandStatusCodes == nil)
+	for _, e := range v.OperandStatusCodes {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.OperandDiagnosticInfos), v.OperandDiagnosticInfos == nil)
+	for _, e := range v.OperandDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ContentFilterElementResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.OperandStatusCodes = make([]StatusCode, n)
+		for i := range v.OperandStatusCodes {
+			v.OperandStatusCodes[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.OperandDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.OperandDiagnosticInfos {
+			v.OperandDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.OperandDiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ContentFilterResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.ElementResults), v.ElementResults == nil)
+	for _, e := range v.ElementResults {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.ElementDiagnosticInfos), v.ElementDiagnosticInfos == nil)
+	for _, e := range v.ElementDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ContentFilterResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ElementResults = make([]*ContentFilterElementResult, n)
+		for i := range v.ElementResults {
+			v.ElementResults[i] = new(ContentFilterElementResult)
+			buf.ReadStruct(v.ElementResults[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ElementDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.ElementDiagnosticInfos {
+			v.ElementDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.ElementDiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ParsingResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.writeArrayLength(len(v.DataStatusCodes), v.DataStatusCodes == nil)
+	for _, e := range v.DataStatusCodes {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DataDiagnosticInfos), v.DataDiagnosticInfos == nil)
+	for _, e := range v.DataDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ParsingResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataStatusCodes = make([]StatusCode, n)
+		for i := range v.DataStatusCodes {
+			v.DataStatusCodes[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DataDiagnosticInfos {
+			v.DataDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DataDiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *QueryFirstRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.View)
+	buf.writeArrayLength(len(v.NodeTypes), v.NodeTypes == nil)
+	for _, e := range v.NodeTypes {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.Filter)
+	buf.WriteUint32(v.MaxDataSetsToReturn)
+	buf.WriteUint32(v.MaxReferencesToReturn)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *QueryFirstRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.View = new(ViewDescription)
+	buf.ReadStruct(v.View)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodeTypes = make([]*NodeTypeDescription, n)
+		for i := range v.NodeTypes {
+			v.NodeTypes[i] = new(NodeTypeDescription)
+			buf.ReadStruct(v.NodeTypes[i])
+		}
+	}
+	v.Filter = new(ContentFilter)
+	buf.ReadStruct(v.Filter)
+	v.MaxDataSetsToReturn = buf.ReadUint32()
+	v.MaxReferencesToReturn = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *QueryFirstResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.QueryDataSets), v.QueryDataSets == nil)
+	for _, e := range v.QueryDataSets {
+		buf.WriteStruct(e)
+	}
+	buf.WriteByteString(v.ContinuationPoint)
+	buf.writeArrayLength(len(v.ParsingResults), v.ParsingResults == nil)
+	for _, e := range v.ParsingResults {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.FilterResult)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *QueryFirstResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.QueryDataSets = make([]*QueryDataSet, n)
+		for i := range v.QueryDataSets {
+			v.QueryDataSets[i] = new(QueryDataSet)
+			buf.ReadStruct(v.QueryDataSets[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ContinuationPoint = buf.ReadN(n)
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ParsingResults = make([]*ParsingResult, n)
+		for i := range v.ParsingResults {
+			v.ParsingResults[i] = new(ParsingResult)
+			buf.ReadStruct(v.ParsingResults[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	v.FilterResult = new(ContentFilterResult)
+	buf.ReadStruct(v.FilterResult)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *QueryNextRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteBool(v.ReleaseContinuationPoint)
+	buf.WriteByteString(v.ContinuationPoint)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *QueryNextRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.ReleaseContinuationPoint = buf.ReadBool()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ContinuationPoint = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *QueryNextResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.QueryDataSets), v.QueryDataSets == nil)
+	for _, e := range v.QueryDataSets {
+		buf.WriteStruct(e)
+	}
+	buf.WriteByteString(v.RevisedContinuationPoint)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *QueryNextResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.QueryDataSets = make([]*QueryDataSet, n)
+		for i := range v.QueryDataSets {
+			v.QueryDataSets[i] = new(QueryDataSet)
+			buf.ReadStruct(v.QueryDataSets[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RevisedContinuationPoint = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadValueID) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteString(v.IndexRange)
+	buf.WriteStruct(v.DataEncoding)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadValueID) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.IndexRange = buf.ReadString()
+	v.DataEncoding = new(QualifiedName)
+	buf.ReadStruct(v.DataEncoding)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteFloat64(v.MaxAge)
+	buf.WriteUint32(uint32(v.TimestampsToReturn))
+	buf.writeArrayLength(len(v.NodesToRead), v.NodesToRead == nil)
+	for _, e := range v.NodesToRead {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.MaxAge = buf.ReadFloat64()
+	v.TimestampsToReturn = TimestampsToReturn(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToRead = make([]*ReadValueID, n)
+		for i := range v.NodesToRead {
+			v.NodesToRead[i] = new(ReadValueID)
+			buf.ReadStruct(v.NodesToRead[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*DataValue, n)
+		for i := range v.Results {
+			v.Results[i] = new(DataValue)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryReadValueID) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteString(v.IndexRange)
+	buf.WriteStruct(v.DataEncoding)
+	buf.WriteByteString(v.ContinuationPoint)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryReadValueID) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.IndexRange = buf.ReadString()
+	v.DataEncoding = new(QualifiedName)
+	buf.ReadStruct(v.DataEncoding)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ContinuationPoint = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryReadResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.WriteByteString(v.ContinuationPoint)
+	buf.WriteStruct(v.HistoryData)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryReadResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ContinuationPoint = buf.ReadN(n)
+	}
+	v.HistoryData = new(ExtensionObject)
+	buf.ReadStruct(v.HistoryData)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryReadDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryReadDetails) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadEventDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.NumValuesPerNode)
+	buf.WriteTime(v.StartTime)
+	buf.WriteTime(v.EndTime)
+	buf.WriteStruct(v.Filter)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadEventDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NumValuesPerNode = buf.ReadUint32()
+	v.StartTime = buf.ReadTime()
+	v.EndTime = buf.ReadTime()
+	v.Filter = new(EventFilter)
+	buf.ReadStruct(v.Filter)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadRawModifiedDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteBool(v.IsReadModified)
+	buf.WriteTime(v.StartTime)
+	buf.WriteTime(v.EndTime)
+	buf.WriteUint32(v.NumValuesPerNode)
+	buf.WriteBool(v.ReturnBounds)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadRawModifiedDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.IsReadModified = buf.ReadBool()
+	v.StartTime = buf.ReadTime()
+	v.EndTime = buf.ReadTime()
+	v.NumValuesPerNode = buf.ReadUint32()
+	v.ReturnBounds = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadProcessedDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteTime(v.StartTime)
+	buf.WriteTime(v.EndTime)
+	buf.WriteFloat64(v.ProcessingInterval)
+	buf.writeArrayLength(len(v.AggregateType), v.AggregateType == nil)
+	for _, e := range v.AggregateType {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.AggregateConfiguration)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadProcessedDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StartTime = buf.ReadTime()
+	v.EndTime = buf.ReadTime()
+	v.ProcessingInterval = buf.ReadFloat64()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AggregateType = make([]*NodeID, n)
+		for i := range v.AggregateType {
+			v.AggregateType[i] = new(NodeID)
+			buf.ReadStruct(v.AggregateType[i])
+		}
+	}
+	v.AggregateConfiguration = new(AggregateConfiguration)
+	buf.ReadStruct(v.AggregateConfiguration)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ReadAtTimeDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.ReqTimes), v.ReqTimes == nil)
+	for _, e := range v.ReqTimes {
+		buf.WriteTime(e)
+	}
+	buf.WriteBool(v.UseSimpleBounds)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ReadAtTimeDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ReqTimes = make([]time.Time, n)
+		for i := range v.ReqTimes {
+			v.ReqTimes[i] = buf.ReadTime()
+		}
+	}
+	v.UseSimpleBounds = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryData) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.DataValues), v.DataValues == nil)
+	for _, e := range v.DataValues {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryData) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataValues = make([]*DataValue, n)
+		for i := range v.DataValues {
+			v.DataValues[i] = new(DataValue)
+			buf.ReadStruct(v.DataValues[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ModificationInfo) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteTime(v.ModificationTime)
+	buf.WriteUint32(uint32(v.UpdateType))
+	buf.WriteString(v.UserName)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ModificationInfo) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ModificationTime = buf.ReadTime()
+	v.UpdateType = HistoryUpdateType(buf.ReadUint32())
+	v.UserName = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryModifiedData) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.DataValues), v.DataValues == nil)
+	for _, e := range v.DataValues {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.ModificationInfos), v.ModificationInfos == nil)
+	for _, e := range v.ModificationInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryModifiedData) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DataValues = make([]*DataValue, n)
+		for i := range v.DataValues {
+			v.DataValues[i] = new(DataValue)
+			buf.ReadStruct(v.DataValues[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ModificationInfos = make([]*ModificationInfo, n)
+		for i := range v.ModificationInfos {
+			v.ModificationInfos[i] = new(ModificationInfo)
+			buf.ReadStruct(v.ModificationInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryEvent) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Events), v.Events == nil)
+	for _, e := range v.Events {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryEvent) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Events = make([]*HistoryEventFieldList, n)
+		for i := range v.Events {
+			v.Events[i] = new(HistoryEventFieldList)
+			buf.ReadStruct(v.Events[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryReadRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteStruct(v.HistoryReadDetails)
+	buf.WriteUint32(uint32(v.TimestampsToReturn))
+	buf.WriteBool(v.ReleaseContinuationPoints)
+	buf.writeArrayLength(len(v.NodesToRead), v.NodesToRead == nil)
+	for _, e := range v.NodesToRead {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryReadRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.HistoryReadDetails = new(ExtensionObject)
+	buf.ReadStruct(v.HistoryReadDetails)
+	v.TimestampsToReturn = TimestampsToReturn(buf.ReadUint32())
+	v.ReleaseContinuationPoints = buf.ReadBool()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToRead = make([]*HistoryReadValueID, n)
+		for i := range v.NodesToRead {
+			v.NodesToRead[i] = new(HistoryReadValueID)
+			buf.ReadStruct(v.NodesToRead[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryReadResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryReadResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*HistoryReadResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(HistoryReadResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *WriteValue) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.AttributeID))
+	buf.WriteString(v.IndexRange)
+	buf.WriteStruct(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *WriteValue) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.AttributeID = AttributeID(buf.ReadUint32())
+	v.IndexRange = buf.ReadString()
+	v.Value = new(DataValue)
+	buf.ReadStruct(v.Value)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *WriteRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.NodesToWrite), v.NodesToWrite == nil)
+	for _, e := range v.NodesToWrite {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *WriteRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NodesToWrite = make([]*WriteValue, n)
+		for i := range v.NodesToWrite {
+			v.NodesToWrite[i] = new(WriteValue)
+			buf.ReadStruct(v.NodesToWrite[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *WriteResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *WriteResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryUpdateDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryUpdateDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UpdateDataDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.PerformInsertReplace))
+	buf.writeArrayLength(len(v.UpdateValues), v.UpdateValues == nil)
+	for _, e := range v.UpdateValues {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UpdateDataDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.PerformInsertReplace = PerformUpdateType(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UpdateValues = make([]*DataValue, n)
+		for i := range v.UpdateValues {
+			v.UpdateValues[i] = new(DataValue)
+			buf.ReadStruct(v.UpdateValues[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UpdateStructureDataDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.PerformInsertReplace))
+	buf.writeArrayLength(len(v.UpdateValues), v.UpdateValues == nil)
+	for _, e := range v.UpdateValues {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UpdateStructureDataDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.PerformInsertReplace = PerformUpdateType(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.UpdateValues = make([]*DataValue, n)
+		for i := range v.UpdateValues {
+			v.UpdateValues[i] = new(DataValue)
+			buf.ReadStruct(v.UpdateValues[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *UpdateEventDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteUint32(uint32(v.PerformInsertReplace))
+	buf.WriteStruct(v.Filter)
+	buf.writeArrayLength(len(v.EventData), v.EventData == nil)
+	for _, e := range v.EventData {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *UpdateEventDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.PerformInsertReplace = PerformUpdateType(buf.ReadUint32())
+	v.Filter = new(EventFilter)
+	buf.ReadStruct(v.Filter)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EventData = make([]*HistoryEventFieldList, n)
+		for i := range v.EventData {
+			v.EventData[i] = new(HistoryEventFieldList)
+			buf.ReadStruct(v.EventData[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteRawModifiedDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.WriteBool(v.IsDeleteModified)
+	buf.WriteTime(v.StartTime)
+	buf.WriteTime(v.EndTime)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteRawModifiedDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	v.IsDeleteModified = buf.ReadBool()
+	v.StartTime = buf.ReadTime()
+	v.EndTime = buf.ReadTime()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteAtTimeDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.writeArrayLength(len(v.ReqTimes), v.ReqTimes == nil)
+	for _, e := range v.ReqTimes {
+		buf.WriteTime(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteAtTimeDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ReqTimes = make([]time.Time, n)
+		for i := range v.ReqTimes {
+			v.ReqTimes[i] = buf.ReadTime()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteEventDetails) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.NodeID)
+	buf.writeArrayLength(len(v.EventIDs), v.EventIDs == nil)
+	for _, e := range v.EventIDs {
+		buf.WriteByteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteEventDetails) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NodeID = new(NodeID)
+	buf.ReadStruct(v.NodeID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EventIDs = make([][]byte, n)
+		for i := range v.EventIDs {
+			if n := buf.readArrayLength(); n >= 0 {
+				v.EventIDs[i] = buf.ReadN(n)
+			}
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryUpdateResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.writeArrayLength(len(v.OperationResults), v.OperationResults == nil)
+	for _, e := range v.OperationResults {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryUpdateResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.OperationResults = make([]StatusCode, n)
+		for i := range v.OperationResults {
+			v.OperationResults[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryUpdateRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.HistoryUpdateDetails), v.HistoryUpdateDetails == nil)
+	for _, e := range v.HistoryUpdateDetails {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryUpdateRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.HistoryUpdateDetails = make([]*ExtensionObject, n)
+		for i := range v.HistoryUpdateDetails {
+			v.HistoryUpdateDetails[i] = new(ExtensionObject)
+			buf.ReadStruct(v.HistoryUpdateDetails[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryUpdateResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryUpdateResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*HistoryUpdateResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(HistoryUpdateResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CallMethodRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ObjectID)
+	buf.WriteStruct(v.MethodID)
+	buf.writeArrayLength(len(v.InputArguments), v.InputArguments == nil)
+	for _, e := range v.InputArguments {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CallMethodRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ObjectID = new(NodeID)
+	buf.ReadStruct(v.ObjectID)
+	v.MethodID = new(NodeID)
+	buf.ReadStruct(v.MethodID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.InputArguments = make([]*Variant, n)
+		for i := range v.InputArguments {
+			v.InputArguments[i] = new(Variant)
+			buf.ReadStruct(v.InputArguments[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CallMethodResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.writeArrayLength(len(v.InputArgumentResults), v.InputArgumentResults == nil)
+	for _, e := range v.InputArgumentResults {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.InputArgumentDiagnosticInfos), v.InputArgumentDiagnosticInfos == nil)
+	for _, e := range v.InputArgumentDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.OutputArguments), v.OutputArguments == nil)
+	for _, e := range v.OutputArguments {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CallMethodResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.InputArgumentResults = make([]StatusCode, n)
+		for i := range v.InputArgumentResults {
+			v.InputArgumentResults[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.InputArgumentDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.InputArgumentDiagnosticInfos {
+			v.InputArgumentDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.InputArgumentDiagnosticInfos[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.OutputArguments = make([]*Variant, n)
+		for i := range v.OutputArguments {
+			v.OutputArguments[i] = new(Variant)
+			buf.ReadStruct(v.OutputArguments[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CallRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.MethodsToCall), v.MethodsToCall == nil)
+	for _, e := range v.MethodsToCall {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CallRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.MethodsToCall = make([]*CallMethodRequest, n)
+		for i := range v.MethodsToCall {
+			v.MethodsToCall[i] = new(CallMethodRequest)
+			buf.ReadStruct(v.MethodsToCall[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CallResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CallResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*CallMethodResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(CallMethodResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoringFilter) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoringFilter) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataChangeFilter) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.Trigger))
+	buf.WriteUint32(v.DeadbandType)
+	buf.WriteFloat64(v.DeadbandValue)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataChangeFilter) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Trigger = DataChangeTrigger(buf.ReadUint32())
+	v.DeadbandType = buf.ReadUint32()
+	v.DeadbandValue = buf.ReadFloat64()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EventFilter) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.SelectClauses), v.SelectClauses == nil)
+	for _, e := range v.SelectClauses {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.WhereClause)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EventFilter) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SelectClauses = make([]*SimpleAttributeOperand, n)
+		for i := range v.SelectClauses {
+			v.SelectClauses[i] = new(SimpleAttributeOperand)
+			buf.ReadStruct(v.SelectClauses[i])
+		}
+	}
+	v.WhereClause = new(ContentFilter)
+	buf.ReadStruct(v.WhereClause)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AggregateConfiguration) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteBool(v.UseServerCapabilitiesDefaults)
+	buf.WriteBool(v.TreatUncertainAsBad)
+	buf.WriteUint8(v.PercentDataBad)
+	buf.WriteUint8(v.PercentDataGood)
+	buf.WriteBool(v.UseSlopedExtrapolation)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AggregateConfiguration) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.UseServerCapabilitiesDefaults = buf.ReadBool()
+	v.TreatUncertainAsBad = buf.ReadBool()
+	v.PercentDataBad = buf.ReadByte()
+	v.PercentDataGood = buf.ReadByte()
+	v.UseSlopedExtrapolation = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AggregateFilter) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteTime(v.StartTime)
+	buf.WriteStruct(v.AggregateType)
+	buf.WriteFloat64(v.ProcessingInterval)
+	buf.WriteStruct(v.AggregateConfiguration)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AggregateFilter) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StartTime = buf.ReadTime()
+	v.AggregateType = new(NodeID)
+	buf.ReadStruct(v.AggregateType)
+	v.ProcessingInterval = buf.ReadFloat64()
+	v.AggregateConfiguration = new(AggregateConfiguration)
+	buf.ReadStruct(v.AggregateConfiguration)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoringFilterResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoringFilterResult) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *EventFilterResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.SelectClauseResults), v.SelectClauseResults == nil)
+	for _, e := range v.SelectClauseResults {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.SelectClauseDiagnosticInfos), v.SelectClauseDiagnosticInfos == nil)
+	for _, e := range v.SelectClauseDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	buf.WriteStruct(v.WhereClauseResult)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EventFilterResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SelectClauseResults = make([]StatusCode, n)
+		for i := range v.SelectClauseResults {
+			v.SelectClauseResults[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SelectClauseDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.SelectClauseDiagnosticInfos {
+			v.SelectClauseDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.SelectClauseDiagnosticInfos[i])
+		}
+	}
+	v.WhereClauseResult = new(ContentFilterResult)
+	buf.ReadStruct(v.WhereClauseResult)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AggregateFilterResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteTime(v.RevisedStartTime)
+	buf.WriteFloat64(v.RevisedProcessingInterval)
+	buf.WriteStruct(v.RevisedAggregateConfiguration)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AggregateFilterResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RevisedStartTime = buf.ReadTime()
+	v.RevisedProcessingInterval = buf.ReadFloat64()
+	v.RevisedAggregateConfiguration = new(AggregateConfiguration)
+	buf.ReadStruct(v.RevisedAggregateConfiguration)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoringParameters) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.ClientHandle)
+	buf.WriteFloat64(v.SamplingInterval)
+	buf.WriteStruct(v.Filter)
+	buf.WriteUint32(v.QueueSize)
+	buf.WriteBool(v.DiscardOldest)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoringParameters) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ClientHandle = buf.ReadUint32()
+	v.SamplingInterval = buf.ReadFloat64()
+	v.Filter = new(ExtensionObject)
+	buf.ReadStruct(v.Filter)
+	v.QueueSize = buf.ReadUint32()
+	v.DiscardOldest = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoredItemCreateRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ItemToMonitor)
+	buf.WriteUint32(uint32(v.MonitoringMode))
+	buf.WriteStruct(v.RequestedParameters)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoredItemCreateRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ItemToMonitor = new(ReadValueID)
+	buf.ReadStruct(v.ItemToMonitor)
+	v.MonitoringMode = MonitoringMode(buf.ReadUint32())
+	v.RequestedParameters = new(MonitoringParameters)
+	buf.ReadStruct(v.RequestedParameters)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoredItemCreateResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.WriteUint32(v.MonitoredItemID)
+	buf.WriteFloat64(v.RevisedSamplingInterval)
+	buf.WriteUint32(v.RevisedQueueSize)
+	buf.WriteStruct(v.FilterResult)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoredItemCreateResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	v.MonitoredItemID = buf.ReadUint32()
+	v.RevisedSamplingInterval = buf.ReadFloat64()
+	v.RevisedQueueSize = buf.ReadUint32()
+	v.FilterResult = new(ExtensionObject)
+	buf.ReadStruct(v.FilterResult)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CreateMonitoredItemsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint32(uint32(v.TimestampsToReturn))
+	buf.writeArrayLength(len(v.ItemsToCreate), v.ItemsToCreate == nil)
+	for _, e := range v.ItemsToCreate {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CreateMonitoredItemsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.TimestampsToReturn = TimestampsToReturn(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ItemsToCreate = make([]*MonitoredItemCreateRequest, n)
+		for i := range v.ItemsToCreate {
+			v.ItemsToCreate[i] = new(MonitoredItemCreateRequest)
+			buf.ReadStruct(v.ItemsToCreate[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CreateMonitoredItemsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CreateMonitoredItemsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*MonitoredItemCreateResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(MonitoredItemCreateResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoredItemModifyRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.MonitoredItemID)
+	buf.WriteStruct(v.RequestedParameters)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoredItemModifyRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.MonitoredItemID = buf.ReadUint32()
+	v.RequestedParameters = new(MonitoringParameters)
+	buf.ReadStruct(v.RequestedParameters)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoredItemModifyResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.WriteFloat64(v.RevisedSamplingInterval)
+	buf.WriteUint32(v.RevisedQueueSize)
+	buf.WriteStruct(v.FilterResult)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoredItemModifyResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	v.RevisedSamplingInterval = buf.ReadFloat64()
+	v.RevisedQueueSize = buf.ReadUint32()
+	v.FilterResult = new(ExtensionObject)
+	buf.ReadStruct(v.FilterResult)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ModifyMonitoredItemsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint32(uint32(v.TimestampsToReturn))
+	buf.writeArrayLength(len(v.ItemsToModify), v.ItemsToModify == nil)
+	for _, e := range v.ItemsToModify {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ModifyMonitoredItemsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.TimestampsToReturn = TimestampsToReturn(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ItemsToModify = make([]*MonitoredItemModifyRequest, n)
+		for i := range v.ItemsToModify {
+			v.ItemsToModify[i] = new(MonitoredItemModifyRequest)
+			buf.ReadStruct(v.ItemsToModify[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ModifyMonitoredItemsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ModifyMonitoredItemsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*MonitoredItemModifyResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(MonitoredItemModifyResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SetMonitoringModeRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint32(uint32(v.MonitoringMode))
+	buf.writeArrayLength(len(v.MonitoredItemIDs), v.MonitoredItemIDs == nil)
+	for _, e := range v.MonitoredItemIDs {
+		buf.WriteUint32(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SetMonitoringModeRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.MonitoringMode = MonitoringMode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.MonitoredItemIDs = make([]uint32, n)
+		for i := range v.MonitoredItemIDs {
+			v.MonitoredItemIDs[i] = buf.ReadUint32()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SetMonitoringModeResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SetMonitoringModeResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SetTriggeringRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint32(v.TriggeringItemID)
+	buf.writeArrayLength(len(v.LinksToAdd), v.LinksToAdd == nil)
+	for _, e := range v.LinksToAdd {
+		buf.WriteUint32(e)
+	}
+	buf.writeArrayLength(len(v.LinksToRemove), v.LinksToRemove == nil)
+	for _, e := range v.LinksToRemove {
+		buf.WriteUint32(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SetTriggeringRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.TriggeringItemID = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LinksToAdd = make([]uint32, n)
+		for i := range v.LinksToAdd {
+			v.LinksToAdd[i] = buf.ReadUint32()
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LinksToRemove = make([]uint32, n)
+		for i := range v.LinksToRemove {
+			v.LinksToRemove[i] = buf.ReadUint32()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SetTriggeringResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.AddResults), v.AddResults == nil)
+	for _, e := range v.AddResults {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.AddDiagnosticInfos), v.AddDiagnosticInfos == nil)
+	for _, e := range v.AddDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.RemoveResults), v.RemoveResults == nil)
+	for _, e := range v.RemoveResults {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.RemoveDiagnosticInfos), v.RemoveDiagnosticInfos == nil)
+	for _, e := range v.RemoveDiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SetTriggeringResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AddResults = make([]StatusCode, n)
+		for i := range v.AddResults {
+			v.AddResults[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AddDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.AddDiagnosticInfos {
+			v.AddDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.AddDiagnosticInfos[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RemoveResults = make([]StatusCode, n)
+		for i := range v.RemoveResults {
+			v.RemoveResults[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.RemoveDiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.RemoveDiagnosticInfos {
+			v.RemoveDiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.RemoveDiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteMonitoredItemsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.writeArrayLength(len(v.MonitoredItemIDs), v.MonitoredItemIDs == nil)
+	for _, e := range v.MonitoredItemIDs {
+		buf.WriteUint32(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteMonitoredItemsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.MonitoredItemIDs = make([]uint32, n)
+		for i := range v.MonitoredItemIDs {
+			v.MonitoredItemIDs[i] = buf.ReadUint32()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteMonitoredItemsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteMonitoredItemsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CreateSubscriptionRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteFloat64(v.RequestedPublishingInterval)
+	buf.WriteUint32(v.RequestedLifetimeCount)
+	buf.WriteUint32(v.RequestedMaxKeepAliveCount)
+	buf.WriteUint32(v.MaxNotificationsPerPublish)
+	buf.WriteBool(v.PublishingEnabled)
+	buf.WriteUint8(v.Priority)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CreateSubscriptionRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.RequestedPublishingInterval = buf.ReadFloat64()
+	v.RequestedLifetimeCount = buf.ReadUint32()
+	v.RequestedMaxKeepAliveCount = buf.ReadUint32()
+	v.MaxNotificationsPerPublish = buf.ReadUint32()
+	v.PublishingEnabled = buf.ReadBool()
+	v.Priority = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *CreateSubscriptionResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteFloat64(v.RevisedPublishingInterval)
+	buf.WriteUint32(v.RevisedLifetimeCount)
+	buf.WriteUint32(v.RevisedMaxKeepAliveCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *CreateSubscriptionResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.RevisedPublishingInterval = buf.ReadFloat64()
+	v.RevisedLifetimeCount = buf.ReadUint32()
+	v.RevisedMaxKeepAliveCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ModifySubscriptionRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteFloat64(v.RequestedPublishingInterval)
+	buf.WriteUint32(v.RequestedLifetimeCount)
+	buf.WriteUint32(v.RequestedMaxKeepAliveCount)
+	buf.WriteUint32(v.MaxNotificationsPerPublish)
+	buf.WriteUint8(v.Priority)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ModifySubscriptionRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.RequestedPublishingInterval = buf.ReadFloat64()
+	v.RequestedLifetimeCount = buf.ReadUint32()
+	v.RequestedMaxKeepAliveCount = buf.ReadUint32()
+	v.MaxNotificationsPerPublish = buf.ReadUint32()
+	v.Priority = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ModifySubscriptionResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteFloat64(v.RevisedPublishingInterval)
+	buf.WriteUint32(v.RevisedLifetimeCount)
+	buf.WriteUint32(v.RevisedMaxKeepAliveCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ModifySubscriptionResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.RevisedPublishingInterval = buf.ReadFloat64()
+	v.RevisedLifetimeCount = buf.ReadUint32()
+	v.RevisedMaxKeepAliveCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SetPublishingModeRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteBool(v.PublishingEnabled)
+	buf.writeArrayLength(len(v.SubscriptionIDs), v.SubscriptionIDs == nil)
+	for _, e := range v.SubscriptionIDs {
+		buf.WriteUint32(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SetPublishingModeRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.PublishingEnabled = buf.ReadBool()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SubscriptionIDs = make([]uint32, n)
+		for i := range v.SubscriptionIDs {
+			v.SubscriptionIDs[i] = buf.ReadUint32()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SetPublishingModeResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SetPublishingModeResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NotificationMessage) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SequenceNumber)
+	buf.WriteTime(v.PublishTime)
+	buf.writeArrayLength(len(v.NotificationData), v.NotificationData == nil)
+	for _, e := range v.NotificationData {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NotificationMessage) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SequenceNumber = buf.ReadUint32()
+	v.PublishTime = buf.ReadTime()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NotificationData = make([]*ExtensionObject, n)
+		for i := range v.NotificationData {
+			v.NotificationData[i] = new(ExtensionObject)
+			buf.ReadStruct(v.NotificationData[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NotificationData) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NotificationData) Decode(b []byte) (int, error) {
+	return 0, nil
+}
+
+// Encode encodes the structure without reflection.
+func (v *DataChangeNotification) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.MonitoredItems), v.MonitoredItems == nil)
+	for _, e := range v.MonitoredItems {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DataChangeNotification) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.MonitoredItems = make([]*MonitoredItemNotification, n)
+		for i := range v.MonitoredItems {
+			v.MonitoredItems[i] = new(MonitoredItemNotification)
+			buf.ReadStruct(v.MonitoredItems[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *MonitoredItemNotification) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.ClientHandle)
+	buf.WriteStruct(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *MonitoredItemNotification) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ClientHandle = buf.ReadUint32()
+	v.Value = new(DataValue)
+	buf.ReadStruct(v.Value)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EventNotificationList) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.Events), v.Events == nil)
+	for _, e := range v.Events {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EventNotificationList) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Events = make([]*EventFieldList, n)
+		for i := range v.Events {
+			v.Events[i] = new(EventFieldList)
+			buf.ReadStruct(v.Events[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EventFieldList) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.ClientHandle)
+	buf.writeArrayLength(len(v.EventFields), v.EventFields == nil)
+	for _, e := range v.EventFields {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EventFieldList) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ClientHandle = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EventFields = make([]*Variant, n)
+		for i := range v.EventFields {
+			v.EventFields[i] = new(Variant)
+			buf.ReadStruct(v.EventFields[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *HistoryEventFieldList) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.EventFields), v.EventFields == nil)
+	for _, e := range v.EventFields {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *HistoryEventFieldList) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EventFields = make([]*Variant, n)
+		for i := range v.EventFields {
+			v.EventFields[i] = new(Variant)
+			buf.ReadStruct(v.EventFields[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *StatusChangeNotification) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.Status))
+	buf.WriteStruct(v.DiagnosticInfo)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *StatusChangeNotification) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Status = StatusCode(buf.ReadUint32())
+	v.DiagnosticInfo = new(DiagnosticInfo)
+	buf.ReadStruct(v.DiagnosticInfo)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SubscriptionAcknowledgement) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint32(v.SequenceNumber)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SubscriptionAcknowledgement) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SubscriptionID = buf.ReadUint32()
+	v.SequenceNumber = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.SubscriptionAcknowledgements), v.SubscriptionAcknowledgements == nil)
+	for _, e := range v.SubscriptionAcknowledgements {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SubscriptionAcknowledgements = make([]*SubscriptionAcknowledgement, n)
+		for i := range v.SubscriptionAcknowledgements {
+			v.SubscriptionAcknowledgements[i] = new(SubscriptionAcknowledgement)
+			buf.ReadStruct(v.SubscriptionAcknowledgements[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *PublishResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.writeArrayLength(len(v.AvailableSequenceNumbers), v.AvailableSequenceNumbers == nil)
+	for _, e := range v.AvailableSequenceNumbers {
+		buf.WriteUint32(e)
+	}
+	buf.WriteBool(v.MoreNotifications)
+	buf.WriteStruct(v.NotificationMessage)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *PublishResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AvailableSequenceNumbers = make([]uint32, n)
+		for i := range v.AvailableSequenceNumbers {
+			v.AvailableSequenceNumbers[i] = buf.ReadUint32()
+		}
+	}
+	v.MoreNotifications = buf.ReadBool()
+	v.NotificationMessage = new(NotificationMessage)
+	buf.ReadStruct(v.NotificationMessage)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RepublishRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint32(v.RetransmitSequenceNumber)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RepublishRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	v.SubscriptionID = buf.ReadUint32()
+	v.RetransmitSequenceNumber = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RepublishResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.WriteStruct(v.NotificationMessage)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RepublishResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	v.NotificationMessage = new(NotificationMessage)
+	buf.ReadStruct(v.NotificationMessage)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TransferResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.writeArrayLength(len(v.AvailableSequenceNumbers), v.AvailableSequenceNumbers == nil)
+	for _, e := range v.AvailableSequenceNumbers {
+		buf.WriteUint32(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TransferResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AvailableSequenceNumbers = make([]uint32, n)
+		for i := range v.AvailableSequenceNumbers {
+			v.AvailableSequenceNumbers[i] = buf.ReadUint32()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TransferSubscriptionsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.SubscriptionIDs), v.SubscriptionIDs == nil)
+	for _, e := range v.SubscriptionIDs {
+		buf.WriteUint32(e)
+	}
+	buf.WriteBool(v.SendInitialValues)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TransferSubscriptionsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SubscriptionIDs = make([]uint32, n)
+		for i := range v.SubscriptionIDs {
+			v.SubscriptionIDs[i] = buf.ReadUint32()
+		}
+	}
+	v.SendInitialValues = buf.ReadBool()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *TransferSubscriptionsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *TransferSubscriptionsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]*TransferResult, n)
+		for i := range v.Results {
+			v.Results[i] = new(TransferResult)
+			buf.ReadStruct(v.Results[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteSubscriptionsRequest) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.RequestHeader)
+	buf.writeArrayLength(len(v.SubscriptionIDs), v.SubscriptionIDs == nil)
+	for _, e := range v.SubscriptionIDs {
+		buf.WriteUint32(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteSubscriptionsRequest) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.RequestHeader = new(RequestHeader)
+	buf.ReadStruct(v.RequestHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.SubscriptionIDs = make([]uint32, n)
+		for i := range v.SubscriptionIDs {
+			v.SubscriptionIDs[i] = buf.ReadUint32()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DeleteSubscriptionsResponse) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.ResponseHeader)
+	buf.writeArrayLength(len(v.Results), v.Results == nil)
+	for _, e := range v.Results {
+		buf.WriteUint32(uint32(e))
+	}
+	buf.writeArrayLength(len(v.DiagnosticInfos), v.DiagnosticInfos == nil)
+	for _, e := range v.DiagnosticInfos {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DeleteSubscriptionsResponse) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ResponseHeader = new(ResponseHeader)
+	buf.ReadStruct(v.ResponseHeader)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.Results = make([]StatusCode, n)
+		for i := range v.Results {
+			v.Results[i] = StatusCode(buf.ReadUint32())
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.DiagnosticInfos = make([]*DiagnosticInfo, n)
+		for i := range v.DiagnosticInfos {
+			v.DiagnosticInfos[i] = new(DiagnosticInfo)
+			buf.ReadStruct(v.DiagnosticInfos[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *BuildInfo) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ProductURI)
+	buf.WriteString(v.ManufacturerName)
+	buf.WriteString(v.ProductName)
+	buf.WriteString(v.SoftwareVersion)
+	buf.WriteString(v.BuildNumber)
+	buf.WriteTime(v.BuildDate)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *BuildInfo) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ProductURI = buf.ReadString()
+	v.ManufacturerName = buf.ReadString()
+	v.ProductName = buf.ReadString()
+	v.SoftwareVersion = buf.ReadString()
+	v.BuildNumber = buf.ReadString()
+	v.BuildDate = buf.ReadTime()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *RedundantServerDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ServerID)
+	buf.WriteUint8(v.ServiceLevel)
+	buf.WriteUint32(uint32(v.ServerState))
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *RedundantServerDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ServerID = buf.ReadString()
+	v.ServiceLevel = buf.ReadByte()
+	v.ServerState = ServerState(buf.ReadUint32())
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EndpointURLListDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.writeArrayLength(len(v.EndpointURLList), v.EndpointURLList == nil)
+	for _, e := range v.EndpointURLList {
+		buf.WriteString(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EndpointURLListDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.EndpointURLList = make([]string, n)
+		for i := range v.EndpointURLList {
+			v.EndpointURLList[i] = buf.ReadString()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *NetworkGroupDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.ServerURI)
+	buf.writeArrayLength(len(v.NetworkPaths), v.NetworkPaths == nil)
+	for _, e := range v.NetworkPaths {
+		buf.WriteStruct(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *NetworkGroupDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ServerURI = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.NetworkPaths = make([]*EndpointURLListDataType, n)
+		for i := range v.NetworkPaths {
+			v.NetworkPaths[i] = new(EndpointURLListDataType)
+			buf.ReadStruct(v.NetworkPaths[i])
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SamplingIntervalDiagnosticsDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteFloat64(v.SamplingInterval)
+	buf.WriteUint32(v.MonitoredItemCount)
+	buf.WriteUint32(v.MaxMonitoredItemCount)
+	buf.WriteUint32(v.DisabledMonitoredItemCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SamplingIntervalDiagnosticsDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SamplingInterval = buf.ReadFloat64()
+	v.MonitoredItemCount = buf.ReadUint32()
+	v.MaxMonitoredItemCount = buf.ReadUint32()
+	v.DisabledMonitoredItemCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ServerDiagnosticsSummaryDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.ServerViewCount)
+	buf.WriteUint32(v.CurrentSessionCount)
+	buf.WriteUint32(v.CumulatedSessionCount)
+	buf.WriteUint32(v.SecurityRejectedSessionCount)
+	buf.WriteUint32(v.RejectedSessionCount)
+	buf.WriteUint32(v.SessionTimeoutCount)
+	buf.WriteUint32(v.SessionAbortCount)
+	buf.WriteUint32(v.CurrentSubscriptionCount)
+	buf.WriteUint32(v.CumulatedSubscriptionCount)
+	buf.WriteUint32(v.PublishingIntervalCount)
+	buf.WriteUint32(v.SecurityRejectedRequestsCount)
+	buf.WriteUint32(v.RejectedRequestsCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ServerDiagnosticsSummaryDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.ServerViewCount = buf.ReadUint32()
+	v.CurrentSessionCount = buf.ReadUint32()
+	v.CumulatedSessionCount = buf.ReadUint32()
+	v.SecurityRejectedSessionCount = buf.ReadUint32()
+	v.RejectedSessionCount = buf.ReadUint32()
+	v.SessionTimeoutCount = buf.ReadUint32()
+	v.SessionAbortCount = buf.ReadUint32()
+	v.CurrentSubscriptionCount = buf.ReadUint32()
+	v.CumulatedSubscriptionCount = buf.ReadUint32()
+	v.PublishingIntervalCount = buf.ReadUint32()
+	v.SecurityRejectedRequestsCount = buf.ReadUint32()
+	v.RejectedRequestsCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ServerStatusDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteTime(v.StartTime)
+	buf.WriteTime(v.CurrentTime)
+	buf.WriteUint32(uint32(v.State))
+	buf.WriteStruct(v.BuildInfo)
+	buf.WriteUint32(v.SecondsTillShutdown)
+	buf.WriteStruct(v.ShutdownReason)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ServerStatusDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StartTime = buf.ReadTime()
+	v.CurrentTime = buf.ReadTime()
+	v.State = ServerState(buf.ReadUint32())
+	v.BuildInfo = new(BuildInfo)
+	buf.ReadStruct(v.BuildInfo)
+	v.SecondsTillShutdown = buf.ReadUint32()
+	v.ShutdownReason = new(LocalizedText)
+	buf.ReadStruct(v.ShutdownReason)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SessionDiagnosticsDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.SessionID)
+	buf.WriteString(v.SessionName)
+	buf.WriteStruct(v.ClientDescription)
+	buf.WriteString(v.ServerURI)
+	buf.WriteString(v.EndpointURL)
+	buf.writeArrayLength(len(v.LocaleIDs), v.LocaleIDs == nil)
+	for _, e := range v.LocaleIDs {
+		buf.WriteString(e)
+	}
+	buf.WriteFloat64(v.ActualSessionTimeout)
+	buf.WriteUint32(v.MaxResponseMessageSize)
+	buf.WriteTime(v.ClientConnectionTime)
+	buf.WriteTime(v.ClientLastContactTime)
+	buf.WriteUint32(v.CurrentSubscriptionsCount)
+	buf.WriteUint32(v.CurrentMonitoredItemsCount)
+	buf.WriteUint32(v.CurrentPublishRequestsInQueue)
+	buf.WriteStruct(v.TotalRequestCount)
+	buf.WriteUint32(v.UnauthorizedRequestCount)
+	buf.WriteStruct(v.ReadCount)
+	buf.WriteStruct(v.HistoryReadCount)
+	buf.WriteStruct(v.WriteCount)
+	buf.WriteStruct(v.HistoryUpdateCount)
+	buf.WriteStruct(v.CallCount)
+	buf.WriteStruct(v.CreateMonitoredItemsCount)
+	buf.WriteStruct(v.ModifyMonitoredItemsCount)
+	buf.WriteStruct(v.SetMonitoringModeCount)
+	buf.WriteStruct(v.SetTriggeringCount)
+	buf.WriteStruct(v.DeleteMonitoredItemsCount)
+	buf.WriteStruct(v.CreateSubscriptionCount)
+	buf.WriteStruct(v.ModifySubscriptionCount)
+	buf.WriteStruct(v.SetPublishingModeCount)
+	buf.WriteStruct(v.PublishCount)
+	buf.WriteStruct(v.RepublishCount)
+	buf.WriteStruct(v.TransferSubscriptionsCount)
+	buf.WriteStruct(v.DeleteSubscriptionsCount)
+	buf.WriteStruct(v.AddNodesCount)
+	buf.WriteStruct(v.AddReferencesCount)
+	buf.WriteStruct(v.DeleteNodesCount)
+	buf.WriteStruct(v.DeleteReferencesCount)
+	buf.WriteStruct(v.BrowseCount)
+	buf.WriteStruct(v.BrowseNextCount)
+	buf.WriteStruct(v.TranslateBrowsePathsToNodeIDsCount)
+	buf.WriteStruct(v.QueryFirstCount)
+	buf.WriteStruct(v.QueryNextCount)
+	buf.WriteStruct(v.RegisterNodesCount)
+	buf.WriteStruct(v.UnregisterNodesCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SessionDiagnosticsDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SessionID = new(NodeID)
+	buf.ReadStruct(v.SessionID)
+	v.SessionName = buf.ReadString()
+	v.ClientDescription = new(ApplicationDescription)
+	buf.ReadStruct(v.ClientDescription)
+	v.ServerURI = buf.ReadString()
+	v.EndpointURL = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LocaleIDs = make([]string, n)
+		for i := range v.LocaleIDs {
+			v.LocaleIDs[i] = buf.ReadString()
+		}
+	}
+	v.ActualSessionTimeout = buf.ReadFloat64()
+	v.MaxResponseMessageSize = buf.ReadUint32()
+	v.ClientConnectionTime = buf.ReadTime()
+	v.ClientLastContactTime = buf.ReadTime()
+	v.CurrentSubscriptionsCount = buf.ReadUint32()
+	v.CurrentMonitoredItemsCount = buf.ReadUint32()
+	v.CurrentPublishRequestsInQueue = buf.ReadUint32()
+	v.TotalRequestCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.TotalRequestCount)
+	v.UnauthorizedRequestCount = buf.ReadUint32()
+	v.ReadCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.ReadCount)
+	v.HistoryReadCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.HistoryReadCount)
+	v.WriteCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.WriteCount)
+	v.HistoryUpdateCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.HistoryUpdateCount)
+	v.CallCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.CallCount)
+	v.CreateMonitoredItemsCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.CreateMonitoredItemsCount)
+	v.ModifyMonitoredItemsCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.ModifyMonitoredItemsCount)
+	v.SetMonitoringModeCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.SetMonitoringModeCount)
+	v.SetTriggeringCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.SetTriggeringCount)
+	v.DeleteMonitoredItemsCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.DeleteMonitoredItemsCount)
+	v.CreateSubscriptionCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.CreateSubscriptionCount)
+	v.ModifySubscriptionCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.ModifySubscriptionCount)
+	v.SetPublishingModeCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.SetPublishingModeCount)
+	v.PublishCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.PublishCount)
+	v.RepublishCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.RepublishCount)
+	v.TransferSubscriptionsCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.TransferSubscriptionsCount)
+	v.DeleteSubscriptionsCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.DeleteSubscriptionsCount)
+	v.AddNodesCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.AddNodesCount)
+	v.AddReferencesCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.AddReferencesCount)
+	v.DeleteNodesCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.DeleteNodesCount)
+	v.DeleteReferencesCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.DeleteReferencesCount)
+	v.BrowseCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.BrowseCount)
+	v.BrowseNextCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.BrowseNextCount)
+	v.TranslateBrowsePathsToNodeIDsCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.TranslateBrowsePathsToNodeIDsCount)
+	v.QueryFirstCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.QueryFirstCount)
+	v.QueryNextCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.QueryNextCount)
+	v.RegisterNodesCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.RegisterNodesCount)
+	v.UnregisterNodesCount = new(ServiceCounterDataType)
+	buf.ReadStruct(v.UnregisterNodesCount)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SessionSecurityDiagnosticsDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.SessionID)
+	buf.WriteString(v.ClientUserIDOfSession)
+	buf.writeArrayLength(len(v.ClientUserIDHistory), v.ClientUserIDHistory == nil)
+	for _, e := range v.ClientUserIDHistory {
+		buf.WriteString(e)
+	}
+	buf.WriteString(v.AuthenticationMechanism)
+	buf.WriteString(v.Encoding)
+	buf.WriteString(v.TransportProtocol)
+	buf.WriteUint32(uint32(v.SecurityMode))
+	buf.WriteString(v.SecurityPolicyURI)
+	buf.WriteByteString(v.ClientCertificate)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SessionSecurityDiagnosticsDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SessionID = new(NodeID)
+	buf.ReadStruct(v.SessionID)
+	v.ClientUserIDOfSession = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ClientUserIDHistory = make([]string, n)
+		for i := range v.ClientUserIDHistory {
+			v.ClientUserIDHistory[i] = buf.ReadString()
+		}
+	}
+	v.AuthenticationMechanism = buf.ReadString()
+	v.Encoding = buf.ReadString()
+	v.TransportProtocol = buf.ReadString()
+	v.SecurityMode = MessageSecurityMode(buf.ReadUint32())
+	v.SecurityPolicyURI = buf.ReadString()
+	if n := buf.readArrayLength(); n >= 0 {
+		v.ClientCertificate = buf.ReadN(n)
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ServiceCounterDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(v.TotalCount)
+	buf.WriteUint32(v.ErrorCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ServiceCounterDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.TotalCount = buf.ReadUint32()
+	v.ErrorCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *StatusResult) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint32(uint32(v.StatusCode))
+	buf.WriteStruct(v.DiagnosticInfo)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *StatusResult) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.StatusCode = StatusCode(buf.ReadUint32())
+	v.DiagnosticInfo = new(DiagnosticInfo)
+	buf.ReadStruct(v.DiagnosticInfo)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SubscriptionDiagnosticsDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.SessionID)
+	buf.WriteUint32(v.SubscriptionID)
+	buf.WriteUint8(v.Priority)
+	buf.WriteFloat64(v.PublishingInterval)
+	buf.WriteUint32(v.MaxKeepAliveCount)
+	buf.WriteUint32(v.MaxLifetimeCount)
+	buf.WriteUint32(v.MaxNotificationsPerPublish)
+	buf.WriteBool(v.PublishingEnabled)
+	buf.WriteUint32(v.ModifyCount)
+	buf.WriteUint32(v.EnableCount)
+	buf.WriteUint32(v.DisableCount)
+	buf.WriteUint32(v.RepublishRequestCount)
+	buf.WriteUint32(v.RepublishMessageRequestCount)
+	buf.WriteUint32(v.RepublishMessageCount)
+	buf.WriteUint32(v.TransferRequestCount)
+	buf.WriteUint32(v.TransferredToAltClientCount)
+	buf.WriteUint32(v.TransferredToSameClientCount)
+	buf.WriteUint32(v.PublishRequestCount)
+	buf.WriteUint32(v.DataChangeNotificationsCount)
+	buf.WriteUint32(v.EventNotificationsCount)
+	buf.WriteUint32(v.NotificationsCount)
+	buf.WriteUint32(v.LatePublishRequestCount)
+	buf.WriteUint32(v.CurrentKeepAliveCount)
+	buf.WriteUint32(v.CurrentLifetimeCount)
+	buf.WriteUint32(v.UnacknowledgedMessageCount)
+	buf.WriteUint32(v.DiscardedMessageCount)
+	buf.WriteUint32(v.MonitoredItemCount)
+	buf.WriteUint32(v.DisabledMonitoredItemCount)
+	buf.WriteUint32(v.MonitoringQueueOverflowCount)
+	buf.WriteUint32(v.NextSequenceNumber)
+	buf.WriteUint32(v.EventQueueOverFlowCount)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SubscriptionDiagnosticsDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.SessionID = new(NodeID)
+	buf.ReadStruct(v.SessionID)
+	v.SubscriptionID = buf.ReadUint32()
+	v.Priority = buf.ReadByte()
+	v.PublishingInterval = buf.ReadFloat64()
+	v.MaxKeepAliveCount = buf.ReadUint32()
+	v.MaxLifetimeCount = buf.ReadUint32()
+	v.MaxNotificationsPerPublish = buf.ReadUint32()
+	v.PublishingEnabled = buf.ReadBool()
+	v.ModifyCount = buf.ReadUint32()
+	v.EnableCount = buf.ReadUint32()
+	v.DisableCount = buf.ReadUint32()
+	v.RepublishRequestCount = buf.ReadUint32()
+	v.RepublishMessageRequestCount = buf.ReadUint32()
+	v.RepublishMessageCount = buf.ReadUint32()
+	v.TransferRequestCount = buf.ReadUint32()
+	v.TransferredToAltClientCount = buf.ReadUint32()
+	v.TransferredToSameClientCount = buf.ReadUint32()
+	v.PublishRequestCount = buf.ReadUint32()
+	v.DataChangeNotificationsCount = buf.ReadUint32()
+	v.EventNotificationsCount = buf.ReadUint32()
+	v.NotificationsCount = buf.ReadUint32()
+	v.LatePublishRequestCount = buf.ReadUint32()
+	v.CurrentKeepAliveCount = buf.ReadUint32()
+	v.CurrentLifetimeCount = buf.ReadUint32()
+	v.UnacknowledgedMessageCount = buf.ReadUint32()
+	v.DiscardedMessageCount = buf.ReadUint32()
+	v.MonitoredItemCount = buf.ReadUint32()
+	v.DisabledMonitoredItemCount = buf.ReadUint32()
+	v.MonitoringQueueOverflowCount = buf.ReadUint32()
+	v.NextSequenceNumber = buf.ReadUint32()
+	v.EventQueueOverFlowCount = buf.ReadUint32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ModelChangeStructureDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.Affected)
+	buf.WriteStruct(v.AffectedType)
+	buf.WriteUint8(v.Verb)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ModelChangeStructureDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Affected = new(NodeID)
+	buf.ReadStruct(v.Affected)
+	v.AffectedType = new(NodeID)
+	buf.ReadStruct(v.AffectedType)
+	v.Verb = buf.ReadByte()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *SemanticChangeStructureDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.Affected)
+	buf.WriteStruct(v.AffectedType)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *SemanticChangeStructureDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Affected = new(NodeID)
+	buf.ReadStruct(v.Affected)
+	v.AffectedType = new(NodeID)
+	buf.ReadStruct(v.AffectedType)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *Range) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteFloat64(v.Low)
+	buf.WriteFloat64(v.High)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *Range) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Low = buf.ReadFloat64()
+	v.High = buf.ReadFloat64()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *EUInformation) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.NamespaceURI)
+	buf.WriteInt32(v.UnitID)
+	buf.WriteStruct(v.DisplayName)
+	buf.WriteStruct(v.Description)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *EUInformation) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.NamespaceURI = buf.ReadString()
+	v.UnitID = buf.ReadInt32()
+	v.DisplayName = new(LocalizedText)
+	buf.ReadStruct(v.DisplayName)
+	v.Description = new(LocalizedText)
+	buf.ReadStruct(v.Description)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ComplexNumberType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteFloat32(v.Real)
+	buf.WriteFloat32(v.Imaginary)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ComplexNumberType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Real = buf.ReadFloat32()
+	v.Imaginary = buf.ReadFloat32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *DoubleComplexNumberType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteFloat64(v.Real)
+	buf.WriteFloat64(v.Imaginary)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *DoubleComplexNumberType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Real = buf.ReadFloat64()
+	v.Imaginary = buf.ReadFloat64()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *AxisInformation) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.EngineeringUnits)
+	buf.WriteStruct(v.EURange)
+	buf.WriteStruct(v.Title)
+	buf.WriteUint32(uint32(v.AxisScaleType))
+	buf.writeArrayLength(len(v.AxisSteps), v.AxisSteps == nil)
+	for _, e := range v.AxisSteps {
+		buf.WriteFloat64(e)
+	}
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *AxisInformation) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.EngineeringUnits = new(EUInformation)
+	buf.ReadStruct(v.EngineeringUnits)
+	v.EURange = new(Range)
+	buf.ReadStruct(v.EURange)
+	v.Title = new(LocalizedText)
+	buf.ReadStruct(v.Title)
+	v.AxisScaleType = AxisScaleEnumeration(buf.ReadUint32())
+	if n := buf.readArrayLength(); n >= 0 {
+		v.AxisSteps = make([]float64, n)
+		for i := range v.AxisSteps {
+			v.AxisSteps[i] = buf.ReadFloat64()
+		}
+	}
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *XVType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteFloat64(v.X)
+	buf.WriteFloat32(v.Value)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *XVType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.X = buf.ReadFloat64()
+	v.Value = buf.ReadFloat32()
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ProgramDiagnosticDataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.CreateSessionID)
+	buf.WriteString(v.CreateClientName)
+	buf.WriteTime(v.InvocationCreationTime)
+	buf.WriteTime(v.LastTransitionTime)
+	buf.WriteString(v.LastMethodCall)
+	buf.WriteStruct(v.LastMethodSessionID)
+	buf.writeArrayLength(len(v.LastMethodInputArguments), v.LastMethodInputArguments == nil)
+	for _, e := range v.LastMethodInputArguments {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.LastMethodOutputArguments), v.LastMethodOutputArguments == nil)
+	for _, e := range v.LastMethodOutputArguments {
+		buf.WriteStruct(e)
+	}
+	buf.WriteTime(v.LastMethodCallTime)
+	buf.WriteStruct(v.LastMethodReturnStatus)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ProgramDiagnosticDataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.CreateSessionID = new(NodeID)
+	buf.ReadStruct(v.CreateSessionID)
+	v.CreateClientName = buf.ReadString()
+	v.InvocationCreationTime = buf.ReadTime()
+	v.LastTransitionTime = buf.ReadTime()
+	v.LastMethodCall = buf.ReadString()
+	v.LastMethodSessionID = new(NodeID)
+	buf.ReadStruct(v.LastMethodSessionID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LastMethodInputArguments = make([]*Argument, n)
+		for i := range v.LastMethodInputArguments {
+			v.LastMethodInputArguments[i] = new(Argument)
+			buf.ReadStruct(v.LastMethodInputArguments[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LastMethodOutputArguments = make([]*Argument, n)
+		for i := range v.LastMethodOutputArguments {
+			v.LastMethodOutputArguments[i] = new(Argument)
+			buf.ReadStruct(v.LastMethodOutputArguments[i])
+		}
+	}
+	v.LastMethodCallTime = buf.ReadTime()
+	v.LastMethodReturnStatus = new(StatusResult)
+	buf.ReadStruct(v.LastMethodReturnStatus)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *ProgramDiagnostic2DataType) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteStruct(v.CreateSessionID)
+	buf.WriteString(v.CreateClientName)
+	buf.WriteTime(v.InvocationCreationTime)
+	buf.WriteTime(v.LastTransitionTime)
+	buf.WriteString(v.LastMethodCall)
+	buf.WriteStruct(v.LastMethodSessionID)
+	buf.writeArrayLength(len(v.LastMethodInputArguments), v.LastMethodInputArguments == nil)
+	for _, e := range v.LastMethodInputArguments {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.LastMethodOutputArguments), v.LastMethodOutputArguments == nil)
+	for _, e := range v.LastMethodOutputArguments {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.LastMethodInputValues), v.LastMethodInputValues == nil)
+	for _, e := range v.LastMethodInputValues {
+		buf.WriteStruct(e)
+	}
+	buf.writeArrayLength(len(v.LastMethodOutputValues), v.LastMethodOutputValues == nil)
+	for _, e := range v.LastMethodOutputValues {
+		buf.WriteStruct(e)
+	}
+	buf.WriteTime(v.LastMethodCallTime)
+	buf.WriteStruct(v.LastMethodReturnStatus)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *ProgramDiagnostic2DataType) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.CreateSessionID = new(NodeID)
+	buf.ReadStruct(v.CreateSessionID)
+	v.CreateClientName = buf.ReadString()
+	v.InvocationCreationTime = buf.ReadTime()
+	v.LastTransitionTime = buf.ReadTime()
+	v.LastMethodCall = buf.ReadString()
+	v.LastMethodSessionID = new(NodeID)
+	buf.ReadStruct(v.LastMethodSessionID)
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LastMethodInputArguments = make([]*Argument, n)
+		for i := range v.LastMethodInputArguments {
+			v.LastMethodInputArguments[i] = new(Argument)
+			buf.ReadStruct(v.LastMethodInputArguments[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LastMethodOutputArguments = make([]*Argument, n)
+		for i := range v.LastMethodOutputArguments {
+			v.LastMethodOutputArguments[i] = new(Argument)
+			buf.ReadStruct(v.LastMethodOutputArguments[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LastMethodInputValues = make([]*Variant, n)
+		for i := range v.LastMethodInputValues {
+			v.LastMethodInputValues[i] = new(Variant)
+			buf.ReadStruct(v.LastMethodInputValues[i])
+		}
+	}
+	if n := buf.readArrayLength(); n >= 0 {
+		v.LastMethodOutputValues = make([]*Variant, n)
+		for i := range v.LastMethodOutputValues {
+			v.LastMethodOutputValues[i] = new(Variant)
+			buf.ReadStruct(v.LastMethodOutputValues[i])
+		}
+	}
+	v.LastMethodCallTime = buf.ReadTime()
+	v.LastMethodReturnStatus = new(StatusResult)
+	buf.ReadStruct(v.LastMethodReturnStatus)
+	return buf.Pos(), buf.Error()
+}
+
+// Encode encodes the structure without reflection.
+func (v *Annotation) Encode() ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteString(v.Message)
+	buf.WriteString(v.UserName)
+	buf.WriteTime(v.AnnotationTime)
+	return buf.Bytes(), buf.Error()
+}
+
+// Decode decodes the structure without reflection.
+func (v *Annotation) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	v.Message = buf.ReadString()
+	v.UserName = buf.ReadString()
+	v.AnnotationTime = buf.ReadTime()
+	return buf.Pos(), buf.Error()
+}
@@ -0,0 +1,1287 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+// JSON encoding ids of the NodeID identifier types.
+//
+// Specification: Part 6, 5.4.2.10
+const (
+	jsonIDTypeNumeric = 0
+	jsonIDTypeString  = 1
+	jsonIDTypeGUID    = 2
+	jsonIDTypeOpaque  = 3
+)
+
+var (
+	byteStringType      = reflect.TypeOf([]byte{})
+	statusCodeType      = reflect.TypeOf(StatusCode(0))
+	nodeIDType          = reflect.TypeOf(NodeID{})
+	expandedNodeIDType  = reflect.TypeOf(ExpandedNodeID{})
+	guidType            = reflect.TypeOf(GUID{})
+	qualifiedNameType   = reflect.TypeOf(QualifiedName{})
+	localizedTextType   = reflect.TypeOf(LocalizedText{})
+	extensionObjectType = reflect.TypeOf(ExtensionObject{})
+	variantType         = reflect.TypeOf(Variant{})
+	dataValueType       = reflect.TypeOf(DataValue{})
+	diagnosticInfoType  = reflect.TypeOf(DiagnosticInfo{})
+	dynamicStructType   = reflect.TypeOf(DynamicStructure{})
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// specNames reverts the renaming of identifiers in the generated Go
+// names to get the names of the specification which are used in the
+// JSON and XML encodings. See cmd/service/goname.
+var specNames = strings.NewReplacer(
+	"GUID", "Guid",
+	"ID", "Id",
+	"JSON", "Json",
+	"QoS", "QualityOfService",
+	"TCP", "Tcp",
+	"UADP", "Uadp",
+	"URI", "Uri",
+	"URL", "Url",
+	"XML", "Xml",
+)
+
+// specName returns the name of a type, field or enum value in the
+// specification.
+func specName(s string) string {
+	return specNames.Replace(s)
+}
+
+// EncodeJSON returns the reversible JSON encoding of v. The value can
+// be decoded with DecodeJSON.
+//
+// Specification: Part 6, 5.4
+func EncodeJSON(v interface{}) ([]byte, error) {
+	return encodeJSON(v, true)
+}
+
+// EncodeJSONNonReversible returns the non-reversible JSON encoding of v.
+// The encoding is meant for consumers which do not know the OPC UA type
+// system. Type information is dropped and cannot be restored by
+// DecodeJSON.
+//
+// Specification: Part 6, 5.4
+func EncodeJSONNonReversible(v interface{}) ([]byte, error) {
+	return encodeJSON(v, false)
+}
+
+func encodeJSON(v interface{}, reversible bool) ([]byte, error) {
+	e := &jsonEncoder{reversible: reversible}
+	val := reflect.ValueOf(v)
+	name := "<nil>"
+	if val.IsValid() {
+		name = val.Type().String()
+	}
+	if err := e.encode(val, name); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+type jsonEncoder struct {
+	buf        bytes.Buffer
+	reversible bool
+}
+
+// jsonObject writes the fields of a JSON object.
+type jsonObject struct {
+	e *jsonEncoder
+	n int
+}
+
+func (e *jsonEncoder) object() *jsonObject {
+	e.buf.WriteByte('{')
+	return &jsonObject{e: e}
+}
+
+func (o *jsonObject) key(name string) {
+	if o.n > 0 {
+		o.e.buf.WriteByte(',')
+	}
+	o.n++
+	o.e.writeString(name)
+	o.e.buf.WriteByte(':')
+}
+
+func (o *jsonObject) close() {
+	o.e.buf.WriteByte('}')
+}
+
+func (e *jsonEncoder) writeNull() {
+	e.buf.WriteString("null")
+}
+
+func (e *jsonEncoder) writeString(s string) {
+	b, _ := json.Marshal(s)
+	e.buf.Write(b)
+}
+
+func (e *jsonEncoder) writeInt(v int64) {
+	e.buf.WriteString(strconv.FormatInt(v, 10))
+}
+
+func (e *jsonEncoder) writeUint(v uint64) {
+	e.buf.WriteString(strconv.FormatUint(v, 10))
+}
+
+func (e *jsonEncoder) writeFloat(v float64, bits int) {
+	switch {
+	case math.IsNaN(v):
+		e.writeString("NaN")
+	case math.IsInf(v, 1):
+		e.writeString("Infinity")
+	case math.IsInf(v, -1):
+		e.writeString("-Infinity")
+	default:
+		e.buf.WriteString(strconv.FormatFloat(v, 'g', -1, bits))
+	}
+}
+
+func (e *jsonEncoder) writeTime(t time.Time) {
+	e.writeString(t.UTC().Format(time.RFC3339Nano))
+}
+
+func (e *jsonEncoder) encode(val reflect.Value, name string) error {
+	if !val.IsValid() {
+		e.writeNull()
+		return nil
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			e.writeNull()
+			return nil
+		}
+		return e.encode(val.Elem(), name)
+	}
+
+	switch typ := val.Type(); {
+	case typ == timeType:
+		e.writeTime(val.Interface().(time.Time))
+	case typ == statusCodeType:
+		e.writeStatusCode(StatusCode(val.Uint()))
+	case typ == byteStringType:
+		if val.IsNil() {
+			e.writeNull()
+			return nil
+		}
+		e.writeString(base64.StdEncoding.EncodeToString(val.Bytes()))
+	case typ == nodeIDType:
+		n := addr(val).Interface().(*NodeID)
+		o := e.object()
+		if err := e.writeNodeID(o, n); err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		if n.Namespace() != 0 {
+			o.key("Namespace")
+			e.writeUint(uint64(n.Namespace()))
+		}
+		o.close()
+	case typ == expandedNodeIDType:
+		return e.writeExpandedNodeID(addr(val).Interface().(*ExpandedNodeID), name)
+	case typ == guidType:
+		e.writeString(addr(val).Interface().(*GUID).String())
+	case typ == qualifiedNameType:
+		e.writeQualifiedName(addr(val).Interface().(*QualifiedName))
+	case typ == localizedTextType:
+		e.writeLocalizedText(addr(val).Interface().(*LocalizedText))
+	case typ == extensionObjectType:
+		return e.writeExtensionObject(addr(val).Interface().(*ExtensionObject), name)
+	case typ == variantType:
+		return e.writeVariant(addr(val).Interface().(*Variant), name)
+	case typ == dataValueType:
+		return e.writeDataValue(addr(val).Interface().(*DataValue), name)
+	case typ == diagnosticInfoType:
+		e.writeDiagnosticInfo(addr(val).Interface().(*DiagnosticInfo))
+	case typ == dynamicStructType:
+		return e.writeDynamicStructure(addr(val).Interface().(*DynamicStructure), name)
+	case isJSONEnum(typ):
+		e.writeEnum(val)
+	default:
+		switch val.Kind() {
+		case reflect.Bool:
+			e.buf.WriteString(strconv.FormatBool(val.Bool()))
+		case reflect.Int8, reflect.Int16, reflect.Int32:
+			e.writeInt(val.Int())
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			e.writeUint(val.Uint())
+		case reflect.Int64:
+			e.writeString(strconv.FormatInt(val.Int(), 10))
+		case reflect.Uint64:
+			e.writeString(strconv.FormatUint(val.Uint(), 10))
+		case reflect.Float32:
+			e.writeFloat(val.Float(), 32)
+		case reflect.Float64:
+			e.writeFloat(val.Float(), 64)
+		case reflect.String:
+			e.writeString(val.String())
+		case reflect.Slice:
+			if val.IsNil() {
+				e.writeNull()
+				return nil
+			}
+			return e.writeArray(val, 1, name)
+		case reflect.Struct:
+			return e.writeStruct(val, name)
+		default:
+			return errors.Errorf("unsupported type: %s", val.Type())
+		}
+	}
+	return nil
+}
+
+func (e *jsonEncoder) writeStruct(val reflect.Value, name string) error {
+	o := e.object()
+	valt := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		ft := valt.Field(i)
+		if ft.PkgPath != "" {
+			continue
+		}
+		f := val.Field(i)
+		if isJSONNull(f) {
+			continue
+		}
+		o.key(specName(ft.Name))
+		if err := e.encode(f, name+"."+ft.Name); err != nil {
+			return err
+		}
+	}
+	o.close()
+	return nil
+}
+
+// writeDynamicStructure writes the fields of a dynamic structure like the
+// fields of a Go struct.
+func (e *jsonEncoder) writeDynamicStructure(s *DynamicStructure, name string) error {
+	o := e.object()
+	for _, f := range s.Fields {
+		if f.Value == nil || isJSONNull(reflect.ValueOf(f.Value)) {
+			continue
+		}
+		o.key(f.Name)
+		if err := e.encode(reflect.ValueOf(f.Value), name+"."+f.Name); err != nil {
+			return err
+		}
+	}
+	o.close()
+	return nil
+}
+
+// writeArray writes a one or multi-dimensional array as nested JSON
+// arrays.
+func (e *jsonEncoder) writeArray(val reflect.Value, levels int, name string) error {
+	e.buf.WriteByte('[')
+	for i := 0; i < val.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		ename := fmt.Sprintf("%s[%d]", name, i)
+		var err error
+		if levels > 1 {
+			err = e.writeArray(val.Index(i), levels-1, ename)
+		} else {
+			err = e.encode(val.Index(i), ename)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+func (e *jsonEncoder) writeStatusCode(s StatusCode) {
+	if e.reversible {
+		e.writeUint(uint64(s))
+		return
+	}
+	o := e.object()
+	o.key("Code")
+	e.writeUint(uint64(s))
+	if sym := statusCodeSymbol(s); sym != "" {
+		o.key("Symbol")
+		e.writeString(sym)
+	}
+	o.close()
+}
+
+func (e *jsonEncoder) writeEnum(val reflect.Value) {
+	if e.reversible {
+		e.writeInt(enumValue(val))
+		return
+	}
+	if sym := enumSymbol(val); sym != "" {
+		e.writeString(sym)
+		return
+	}
+	e.writeInt(enumValue(val))
+}
+
+// writeNodeID writes the identifier fields of the node id to o. The
+// caller writes the namespace.
+//
+// Specification: Part 6, 5.4.2.10
+func (e *jsonEncoder) writeNodeID(o *jsonObject, n *NodeID) error {
+	switch n.Type() {
+	case NodeIDTypeTwoByte, NodeIDTypeFourByte, NodeIDTypeNumeric:
+		o.key("Id")
+		e.writeUint(uint64(n.IntID()))
+	case NodeIDTypeString:
+		o.key("IdType")
+		e.writeInt(jsonIDTypeString)
+		o.key("Id")
+		e.writeString(n.StringID())
+	case NodeIDTypeGUID:
+		o.key("IdType")
+		e.writeInt(jsonIDTypeGUID)
+		o.key("Id")
+		e.writeString(n.StringID())
+	case NodeIDTypeByteString:
+		o.key("IdType")
+		e.writeInt(jsonIDTypeOpaque)
+		o.key("Id")
+		e.writeString(n.StringID())
+	default:
+		return errors.Errorf("invalid node id type %v", n.Type())
+	}
+	return nil
+}
+
+// writeExpandedNodeID writes the node id with the namespace uri and the
+// server index.
+//
+// Specification: Part 6, 5.4.2.11
+func (e *jsonEncoder) writeExpandedNodeID(n *ExpandedNodeID, name string) error {
+	if n.NodeID == nil {
+		e.writeNull()
+		return nil
+	}
+	o := e.object()
+	if err := e.writeNodeID(o, n.NodeID); err != nil {
+		return errors.Errorf("%s: %s", name, err)
+	}
+	switch {
+	case n.HasNamespaceURI():
+		o.key("Namespace")
+		e.writeString(n.NamespaceURI)
+	case n.NodeID.Namespace() != 0:
+		o.key("Namespace")
+		e.writeUint(uint64(n.NodeID.Namespace()))
+	}
+	if n.HasServerIndex() {
+		o.key("ServerUri")
+		e.writeUint(uint64(n.ServerIndex))
+	}
+	o.close()
+	return nil
+}
+
+// writeQualifiedName writes the name and the namespace index.
+//
+// Specification: Part 6, 5.4.2.14
+func (e *jsonEncoder) writeQualifiedName(q *QualifiedName) {
+	o := e.object()
+	o.key("Name")
+	e.writeString(q.Name)
+	if q.NamespaceIndex != 0 {
+		o.key("Uri")
+		e.writeUint(uint64(q.NamespaceIndex))
+	}
+	o.close()
+}
+
+// writeLocalizedText writes the locale and the text. The non-reversible
+// encoding contains only the text.
+//
+// Specification: Part 6, 5.4.2.15
+func (e *jsonEncoder) writeLocalizedText(l *LocalizedText) {
+	if !e.reversible {
+		e.writeString(l.Text)
+		return
+	}
+	o := e.object()
+	if l.Has(LocalizedTextLocale) {
+		o.key("Locale")
+		e.writeString(l.Locale)
+	}
+	if l.Has(LocalizedTextText) {
+		o.key("Text")
+		e.writeString(l.Text)
+	}
+	o.close()
+}
+
+// writeExtensionObject writes the type id, the encoding and the body.
+// The body of known types is encoded as a JSON object. The
+// non-reversible encoding contains only the body.
+//
+// Specification: Part 6, 5.4.2.16
+func (e *jsonEncoder) writeExtensionObject(x *ExtensionObject, name string) error {
+	if x.EncodingMask == ExtensionObjectEmpty || x.Value == nil {
+		e.writeNull()
+		return nil
+	}
+
+	if !e.reversible {
+		return e.encode(reflect.ValueOf(x.Value), name+".Body")
+	}
+
+	o := e.object()
+	if x.TypeID != nil && x.TypeID.NodeID != nil {
+		o.key("TypeId")
+		to := e.object()
+		if err := e.writeNodeID(to, x.TypeID.NodeID); err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		if ns := x.TypeID.NodeID.Namespace(); ns != 0 {
+			to.key("Namespace")
+			e.writeUint(uint64(ns))
+		}
+		to.close()
+	}
+	if x.EncodingMask == ExtensionObjectXML {
+		b, err := x.xmlBody()
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		if b != nil {
+			o.key("Encoding")
+			e.writeInt(ExtensionObjectXML)
+			o.key("Body")
+			e.writeString(string(b))
+			o.close()
+			return nil
+		}
+	}
+	o.key("Body")
+	if err := e.encode(reflect.ValueOf(x.Value), name+".Body"); err != nil {
+		return err
+	}
+	o.close()
+	return nil
+}
+
+// writeVariant writes the type, the body and the dimensions of
+// multi-dimensional arrays. The reversible encoding flattens
+// multi-dimensional arrays and the non-reversible encoding writes them as
+// nested arrays without type information.
+//
+// Specification: Part 6, 5.4.2.17
+func (e *jsonEncoder) writeVariant(v *Variant, name string) error {
+	if v.Type() == TypeIDNull && !v.Has(VariantArrayValues) {
+		e.writeNull()
+		return nil
+	}
+
+	val := reflect.ValueOf(v.Value())
+	levels := 0
+	if v.Has(VariantArrayValues) {
+		levels = 1
+	}
+	if v.Has(VariantArrayDimensions) {
+		levels = len(v.ArrayDimensions())
+	}
+
+	if !e.reversible {
+		if levels == 0 {
+			return e.encode(val, name)
+		}
+		return e.writeArray(val, levels, name)
+	}
+
+	o := e.object()
+	o.key("Type")
+	e.writeInt(int64(v.Type()))
+	o.key("Body")
+	switch levels {
+	case 0:
+		if err := e.encode(val, name); err != nil {
+			return err
+		}
+	case 1:
+		if err := e.writeArray(val, 1, name); err != nil {
+			return err
+		}
+	default:
+		typ := val.Type()
+		for i := 1; i < levels; i++ {
+			typ = typ.Elem()
+		}
+		flat := flattenArray(reflect.MakeSlice(typ, 0, int(v.ArrayLength())), val, levels)
+		if err := e.writeArray(flat, 1, name); err != nil {
+			return err
+		}
+		o.key("Dimensions")
+		if err := e.writeArray(reflect.ValueOf(v.ArrayDimensions()), 1, name+".Dimensions"); err != nil {
+			return err
+		}
+	}
+	o.close()
+	return nil
+}
+
+// flattenArray appends the elements of a multi-dimensional array to a.
+func flattenArray(a, val reflect.Value, levels int) reflect.Value {
+	if levels == 1 {
+		return reflect.AppendSlice(a, val)
+	}
+	for i := 0; i < val.Len(); i++ {
+		a = flattenArray(a, val.Index(i), levels-1)
+	}
+	return a
+}
+
+// writeDataValue writes the fields of the data value which are set in
+// the encoding mask.
+//
+// Specification: Part 6, 5.4.2.18
+func (e *jsonEncoder) writeDataValue(d *DataValue, name string) error {
+	o := e.object()
+	if d.Has(DataValueValue) && !isJSONNull(reflect.ValueOf(d.Value)) {
+		o.key("Value")
+		if err := e.writeVariant(d.Value, name+".Value"); err != nil {
+			return err
+		}
+	}
+	if d.Has(DataValueStatusCode) {
+		o.key("Status")
+		e.writeStatusCode(d.Status)
+	}
+	if d.Has(DataValueSourceTimestamp) {
+		o.key("SourceTimestamp")
+		e.writeTime(d.SourceTimestamp)
+	}
+	if d.Has(DataValueSourcePicoseconds) {
+		o.key("SourcePicoseconds")
+		e.writeUint(uint64(d.SourcePicoseconds))
+	}
+	if d.Has(DataValueServerTimestamp) {
+		o.key("ServerTimestamp")
+		e.writeTime(d.ServerTimestamp)
+	}
+	if d.Has(DataValueServerPicoseconds) {
+		o.key("ServerPicoseconds")
+		e.writeUint(uint64(d.ServerPicoseconds))
+	}
+	o.close()
+	return nil
+}
+
+// writeDiagnosticInfo writes the fields of the diagnostic info which are
+// set in the encoding mask.
+//
+// Specification: Part 6, 5.4.2.12
+func (e *jsonEncoder) writeDiagnosticInfo(d *DiagnosticInfo) {
+	o := e.object()
+	if d.Has(DiagnosticInfoSymbolicID) {
+		o.key("SymbolicId")
+		e.writeInt(int64(d.SymbolicID))
+	}
+	if d.Has(DiagnosticInfoNamespaceURI) {
+		o.key("NamespaceUri")
+		e.writeInt(int64(d.NamespaceURI))
+	}
+	if d.Has(DiagnosticInfoLocale) {
+		o.key("Locale")
+		e.writeInt(int64(d.Locale))
+	}
+	if d.Has(DiagnosticInfoLocalizedText) {
+		o.key("LocalizedText")
+		e.writeInt(int64(d.LocalizedText))
+	}
+	if d.Has(DiagnosticInfoAdditionalInfo) {
+		o.key("AdditionalInfo")
+		e.writeString(d.AdditionalInfo)
+	}
+	if d.Has(DiagnosticInfoInnerStatusCode) {
+		o.key("InnerStatusCode")
+		e.writeStatusCode(d.InnerStatusCode)
+	}
+	if d.Has(DiagnosticInfoInnerDiagnosticInfo) && d.InnerDiagnosticInfo != nil {
+		o.key("InnerDiagnosticInfo")
+		e.writeDiagnosticInfo(d.InnerDiagnosticInfo)
+	}
+	o.close()
+}
+
+// addr returns a pointer to the value. Values which are not addressable
+// are copied.
+func addr(val reflect.Value) reflect.Value {
+	if val.CanAddr() {
+		return val.Addr()
+	}
+	p := reflect.New(val.Type())
+	p.Elem().Set(val)
+	return p
+}
+
+// isJSONNull returns true if the value is encoded as JSON null. Struct
+// fields with null values are omitted.
+func isJSONNull(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		if val.IsNil() {
+			return true
+		}
+	default:
+		return false
+	}
+
+	switch v := val.Interface().(type) {
+	case *ExtensionObject:
+		return v.EncodingMask == ExtensionObjectEmpty || v.Value == nil
+	case *Variant:
+		return v.Type() == TypeIDNull && !v.Has(VariantArrayValues)
+	default:
+		return false
+	}
+}
+
+// isJSONEnum returns true if typ is one of the generated enumeration types.
+func isJSONEnum(typ reflect.Type) bool {
+	if typ.PkgPath() != statusCodeType.PkgPath() || typ == statusCodeType || !typ.Implements(stringerType) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func enumValue(val reflect.Value) int64 {
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int()
+	default:
+		return int64(val.Uint())
+	}
+}
+
+// enumSymbol returns the 'Symbol_Value' representation of an enum value
+// or an empty string if the value is unknown.
+func enumSymbol(val reflect.Value) string {
+	// the stringer names are prefixed with the type name and
+	// unknown values are formatted as 'Type(n)'.
+	sym := strings.TrimPrefix(val.Interface().(fmt.Stringer).String(), val.Type().Name())
+	if sym == "" || strings.HasPrefix(sym, "(") {
+		return ""
+	}
+	return fmt.Sprintf("%s_%d", specName(sym), enumValue(val))
+}
+
+// statusCodeSymbol returns the symbolic name of the status code, e.g.
+// BadNodeIDUnknown, or an empty string if the code is unknown.
+func statusCodeSymbol(s StatusCode) string {
+	d, ok := StatusCodes[s]
+	if !ok {
+		// ignore the info bits
+		if d, ok = StatusCodes[s&0xffff0000]; !ok {
+			return ""
+		}
+	}
+	if s == StatusOK {
+		return "Good"
+	}
+	return strings.TrimPrefix(d.Name, "Status")
+}
+
+// DecodeJSON decodes the reversible JSON encoding of a value into v
+// which must be a non-nil pointer.
+//
+// Specification: Part 6, 5.4
+func DecodeJSON(b []byte, v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.Errorf("invalid argument: %T is not a non-nil pointer", v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var x interface{}
+	if err := dec.Decode(&x); err != nil {
+		return err
+	}
+	return decodeJSON(x, val.Elem(), val.Type().String())
+}
+
+// decodeJSON decodes the generic JSON value x into val. Numbers in x
+// are of type json.Number.
+func decodeJSON(x interface{}, val reflect.Value, name string) error {
+	if x == nil {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		return decodeJSON(x, val.Elem(), name)
+	}
+
+	switch typ := val.Type(); {
+	case typ == timeType:
+		t, err := jsonTime(x, name)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(t))
+	case typ == statusCodeType:
+		s, err := jsonStatusCode(x, name)
+		if err != nil {
+			return err
+		}
+		val.SetUint(uint64(s))
+	case typ == byteStringType:
+		b, err := jsonByteString(x, name)
+		if err != nil {
+			return err
+		}
+		val.SetBytes(b)
+	case typ == nodeIDType:
+		n, _, err := jsonNodeID(x, false, name)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(*n))
+	case typ == expandedNodeIDType:
+		n, err := jsonExpandedNodeID(x, name)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(*n))
+	case typ == guidType:
+		s, ok := x.(string)
+		g := NewGUID(s)
+		if !ok || g == nil {
+			return jsonTypeError(x, val, name)
+		}
+		val.Set(reflect.ValueOf(*g))
+	case typ == qualifiedNameType:
+		return decodeJSONQualifiedName(x, val.Addr().Interface().(*QualifiedName), name)
+	case typ == localizedTextType:
+		return decodeJSONLocalizedText(x, val.Addr().Interface().(*LocalizedText), name)
+	case typ == extensionObjectType:
+		return decodeJSONExtensionObject(x, val.Addr().Interface().(*ExtensionObject), name)
+	case typ == variantType:
+		return decodeJSONVariant(x, val.Addr().Interface().(*Variant), name)
+	case typ == dataValueType:
+		return decodeJSONDataValue(x, val.Addr().Interface().(*DataValue), name)
+	case typ == diagnosticInfoType:
+		return decodeJSONDiagnosticInfo(x, val.Addr().Interface().(*DiagnosticInfo), name)
+	case isJSONEnum(typ):
+		// accept the non-reversible 'Symbol_Value' format as well
+		if s, ok := x.(string); ok {
+			x = s[strings.LastIndex(s, "_")+1:]
+		}
+		return decodeJSONNumber(x, val, name)
+	default:
+		switch val.Kind() {
+		case reflect.Bool:
+			b, ok := x.(bool)
+			if !ok {
+				return jsonTypeError(x, val, name)
+			}
+			val.SetBool(b)
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return decodeJSONNumber(x, val, name)
+		case reflect.String:
+			s, ok := x.(string)
+			if !ok {
+				return jsonTypeError(x, val, name)
+			}
+			val.SetString(s)
+		case reflect.Slice:
+			return decodeJSONSlice(x, val, name)
+		case reflect.Struct:
+			return decodeJSONStruct(x, val, name)
+		default:
+			return errors.Errorf("unsupported type %s", val.Type())
+		}
+	}
+	return nil
+}
+
+func decodeJSONStruct(x interface{}, val reflect.Value, name string) error {
+	m, ok := x.(map[string]interface{})
+	if !ok {
+		return jsonTypeError(x, val, name)
+	}
+	valt := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		ft := valt.Field(i)
+		if ft.PkgPath != "" {
+			continue
+		}
+		fx, ok := m[specName(ft.Name)]
+		if !ok {
+			fx = m[ft.Name]
+		}
+		if err := decodeJSON(fx, val.Field(i), name+"."+ft.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeJSONSlice(x interface{}, val reflect.Value, name string) error {
+	a, ok := x.([]interface{})
+	if !ok {
+		return jsonTypeError(x, val, name)
+	}
+	s := reflect.MakeSlice(val.Type(), len(a), len(a))
+	for i := range a {
+		if err := decodeJSON(a[i], s.Index(i), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+			return err
+		}
+	}
+	val.Set(s)
+	return nil
+}
+
+// decodeJSONNumber decodes a JSON number into an integer or float value.
+// Int64, UInt64 and special float values are encoded as strings.
+func decodeJSONNumber(x interface{}, val reflect.Value, name string) error {
+	var s string
+	switch v := x.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return jsonTypeError(x, val, name)
+	}
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch s {
+		case "NaN":
+			f = math.NaN()
+		case "Infinity":
+			f = math.Inf(1)
+		case "-Infinity":
+			f = math.Inf(-1)
+		default:
+			var err error
+			if f, err = strconv.ParseFloat(s, val.Type().Bits()); err != nil {
+				return errors.Errorf("%s: %s", name, err)
+			}
+		}
+		val.SetFloat(f)
+	default:
+		return jsonTypeError(x, val, name)
+	}
+	return nil
+}
+
+func jsonTypeError(x interface{}, val reflect.Value, name string) error {
+	return errors.Errorf("%s: cannot decode JSON value %v into %s", name, x, val.Type())
+}
+
+// jsonObj returns the fields of a JSON object.
+func jsonObj(x interface{}, typ reflect.Type, name string) (map[string]interface{}, error) {
+	m, ok := x.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("%s: cannot decode JSON value %v into %s", name, x, typ)
+	}
+	return m, nil
+}
+
+// jsonField decodes the field of a JSON object into v if it is present
+// and returns whether it was present.
+func jsonField(m map[string]interface{}, key string, v interface{}, name string) (bool, error) {
+	x, ok := m[key]
+	if !ok || x == nil {
+		return false, nil
+	}
+	return true, decodeJSON(x, reflect.ValueOf(v).Elem(), name+"."+key)
+}
+
+func jsonTime(x interface{}, name string) (time.Time, error) {
+	s, ok := x.(string)
+	if !ok {
+		return time.Time{}, errors.Errorf("%s: cannot decode JSON value %v into time.Time", name, x)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%s: %s", name, err)
+	}
+	return t.UTC(), nil
+}
+
+// jsonStatusCode decodes the reversible and the non-reversible encoding
+// of a status code.
+func jsonStatusCode(x interface{}, name string) (StatusCode, error) {
+	if m, ok := x.(map[string]interface{}); ok {
+		x = m["Code"]
+	}
+	var s StatusCode
+	err := decodeJSONNumber(x, reflect.ValueOf(&s).Elem(), name)
+	return s, err
+}
+
+func jsonByteString(x interface{}, name string) ([]byte, error) {
+	s, ok := x.(string)
+	if !ok {
+		return nil, errors.Errorf("%s: cannot decode JSON value %v into []byte", name, x)
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Errorf("%s: %s", name, err)
+	}
+	return b, nil
+}
+
+// jsonNodeID decodes a node id. If expanded is true the namespace can be
+// an uri which is returned separately.
+func jsonNodeID(x interface{}, expanded bool, name string) (*NodeID, string, error) {
+	m, err := jsonObj(x, nodeIDType, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var idType uint8
+	if _, err := jsonField(m, "IdType", &idType, name); err != nil {
+		return nil, "", err
+	}
+
+	var ns uint16
+	var uri string
+	if s, ok := m["Namespace"].(string); ok {
+		if !expanded {
+			return nil, "", errors.Errorf("%s: namespace uris are not supported. use ExpandedNodeID", name)
+		}
+		uri = s
+	} else if _, err := jsonField(m, "Namespace", &ns, name); err != nil {
+		return nil, "", err
+	}
+
+	switch idType {
+	case jsonIDTypeNumeric:
+		var id uint32
+		if _, err := jsonField(m, "Id", &id, name); err != nil {
+			return nil, "", err
+		}
+		switch {
+		case ns == 0 && id < 256:
+			return NewTwoByteNodeID(byte(id)), uri, nil
+		case ns < 256 && id < math.MaxUint16:
+			return NewFourByteNodeID(byte(ns), uint16(id)), uri, nil
+		default:
+			return NewNumericNodeID(ns, id), uri, nil
+		}
+
+	case jsonIDTypeString:
+		var id string
+		if _, err := jsonField(m, "Id", &id, name); err != nil {
+			return nil, "", err
+		}
+		return NewStringNodeID(ns, id), uri, nil
+
+	case jsonIDTypeGUID:
+		var id string
+		if _, err := jsonField(m, "Id", &id, name); err != nil {
+			return nil, "", err
+		}
+		n := NewGUIDNodeID(ns, id)
+		if n.StringID() == "" {
+			return nil, "", errors.Errorf("%s: invalid guid %q", name, id)
+		}
+		return n, uri, nil
+
+	case jsonIDTypeOpaque:
+		var id []byte
+		if _, err := jsonField(m, "Id", &id, name); err != nil {
+			return nil, "", err
+		}
+		return NewByteStringNodeID(ns, id), uri, nil
+
+	default:
+		return nil, "", errors.Errorf("%s: invalid node id type %d", name, idType)
+	}
+}
+
+func jsonExpandedNodeID(x interface{}, name string) (*ExpandedNodeID, error) {
+	n, uri, err := jsonNodeID(x, true, name)
+	if err != nil {
+		return nil, err
+	}
+	var idx uint32
+	if _, err := jsonField(x.(map[string]interface{}), "ServerUri", &idx, name); err != nil {
+		return nil, err
+	}
+	return NewExpandedNodeID(n, uri, idx), nil
+}
+
+func decodeJSONQualifiedName(x interface{}, q *QualifiedName, name string) error {
+	m, err := jsonObj(x, qualifiedNameType, name)
+	if err != nil {
+		return err
+	}
+	*q = QualifiedName{}
+	if _, err := jsonField(m, "Name", &q.Name, name); err != nil {
+		return err
+	}
+	_, err = jsonField(m, "Uri", &q.NamespaceIndex, name)
+	return err
+}
+
+// decodeJSONLocalizedText decodes a localized text. The non-reversible
+// encoding is accepted as well.
+func decodeJSONLocalizedText(x interface{}, l *LocalizedText, name string) error {
+	if s, ok := x.(string); ok {
+		*l = *NewLocalizedText(s)
+		return nil
+	}
+	m, err := jsonObj(x, localizedTextType, name)
+	if err != nil {
+		return err
+	}
+	*l = LocalizedText{}
+	ok, err := jsonField(m, "Locale", &l.Locale, name)
+	if err != nil {
+		return err
+	}
+	if ok {
+		l.EncodingMask |= LocalizedTextLocale
+	}
+	if ok, err = jsonField(m, "Text", &l.Text, name); err != nil {
+		return err
+	}
+	if ok {
+		l.EncodingMask |= LocalizedTextText
+	}
+	return nil
+}
+
+// decodeJSONExtensionObject decodes an extension object. The body of
+// unknown types is dropped like in the binary decoder.
+func decodeJSONExtensionObject(x interface{}, e *ExtensionObject, name string) error {
+	m, err := jsonObj(x, extensionObjectType, name)
+	if err != nil {
+		return err
+	}
+	*e = ExtensionObject{EncodingMask: ExtensionObjectBinary}
+
+	typeID := NewTwoByteNodeID(0)
+	if tx, ok := m["TypeId"]; ok && tx != nil {
+		if typeID, _, err = jsonNodeID(tx, false, name+".TypeId"); err != nil {
+			return err
+		}
+	}
+	e.TypeID = NewExpandedNodeID(typeID, "", 0)
+
+	var enc uint8
+	if _, err := jsonField(m, "Encoding", &enc, name); err != nil {
+		return err
+	}
+	body, ok := m["Body"]
+	if !ok || body == nil {
+		e.EncodingMask = ExtensionObjectEmpty
+		return nil
+	}
+
+	switch enc {
+	case 0:
+		e.Value = eotypes.New(typeID)
+		if e.Value == nil {
+			return nil
+		}
+		return decodeJSON(body, reflect.ValueOf(e.Value), name+".Body")
+
+	case ExtensionObjectBinary:
+		b, err := jsonByteString(body, name+".Body")
+		if err != nil {
+			return err
+		}
+		e.Value = eotypes.New(typeID)
+		if e.Value == nil {
+			return nil
+		}
+		_, err = Decode(b, e.Value)
+		return err
+
+	case ExtensionObjectXML:
+		e.EncodingMask = ExtensionObjectXML
+		s, ok := body.(string)
+		if !ok {
+			return errors.Errorf("%s: cannot decode JSON value %v into ua.XMLElement", name, body)
+		}
+		v := XMLElement(s)
+		e.Value = &v
+		if x := eoxmltypes.New(typeID); x != nil {
+			if err := DecodeXML([]byte(s), x); err != nil {
+				return err
+			}
+			e.Value = x
+		}
+		return nil
+
+	default:
+		return errors.Errorf("%s: invalid extension object encoding %d", name, enc)
+	}
+}
+
+// decodeJSONVariant decodes a variant with a scalar value or a one or
+// multi-dimensional array.
+func decodeJSONVariant(x interface{}, v *Variant, name string) error {
+	m, err := jsonObj(x, variantType, name)
+	if err != nil {
+		return err
+	}
+	*v = Variant{}
+
+	var typeID TypeID
+	if _, err := jsonField(m, "Type", &typeID, name); err != nil {
+		return err
+	}
+	if typeID == TypeIDNull {
+		return nil
+	}
+	typ, ok := variantTypeIDToType[typeID]
+	if !ok {
+		return errors.Errorf("%s: invalid variant type %d", name, typeID)
+	}
+
+	a, ok := m["Body"].([]interface{})
+	if !ok {
+		val := reflect.New(typ).Elem()
+		if err := decodeJSON(m["Body"], val, name+".Body"); err != nil {
+			return err
+		}
+		return v.set(val.Interface())
+	}
+
+	// arrays of Byte are not ByteStrings
+	sliceType := reflect.SliceOf(typ)
+	if typeID == TypeIDByte {
+		sliceType = reflect.TypeOf(ByteArray{})
+	}
+	vals := reflect.New(sliceType).Elem()
+	if err := decodeJSONSlice(a, vals, name+".Body"); err != nil {
+		return err
+	}
+
+	var dims []int32
+	if _, err := jsonField(m, "Dimensions", &dims, name); err != nil {
+		return err
+	}
+	if len(dims) < 2 {
+		return v.set(vals.Interface())
+	}
+
+	n := 1
+	idims := make([]int, len(dims))
+	for i, d := range dims {
+		if d < 0 {
+			return errors.Errorf("%s: invalid array dimensions %v", name, dims)
+		}
+		idims[i] = int(d)
+		n *= idims[i]
+	}
+	if n != vals.Len() {
+		return errors.Errorf("%s: array dimensions %v do not match %d elements", name, dims, vals.Len())
+	}
+	return v.set(split(0, 0, vals.Len(), idims, vals).Interface())
+}
+
+func decodeJSONDataValue(x interface{}, d *DataValue, name string) error {
+	m, err := jsonObj(x, dataValueType, name)
+	if err != nil {
+		return err
+	}
+	*d = DataValue{}
+
+	fields := []struct {
+		key  string
+		v    interface{}
+		mask byte
+	}{
+		{"Value", &d.Value, DataValueValue},
+		{"Status", &d.Status, DataValueStatusCode},
+		{"SourceTimestamp", &d.SourceTimestamp, DataValueSourceTimestamp},
+		{"SourcePicoseconds", &d.SourcePicoseconds, DataValueSourcePicoseconds},
+		{"ServerTimestamp", &d.ServerTimestamp, DataValueServerTimestamp},
+		{"ServerPicoseconds", &d.ServerPicoseconds, DataValueServerPicoseconds},
+	}
+	for _, f := range fields {
+		ok, err := jsonField(m, f.key, f.v, name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			d.EncodingMask |= f.mask
+		}
+	}
+	return nil
+}
+
+func decodeJSONDiagnosticInfo(x interface{}, d *DiagnosticInfo, name string) error {
+	m, err := jsonObj(x, diagnosticInfoType, name)
+	if err != nil {
+		return err
+	}
+	*d = DiagnosticInfo{}
+
+	fields := []struct {
+		key  string
+		v    interface{}
+		mask byte
+	}{
+		{"SymbolicId", &d.SymbolicID, DiagnosticInfoSymbolicID},
+		{"NamespaceUri", &d.NamespaceURI, DiagnosticInfoNamespaceURI},
+		{"Locale", &d.Locale, DiagnosticInfoLocale},
+		{"LocalizedText", &d.LocalizedText, DiagnosticInfoLocalizedText},
+		{"AdditionalInfo", &d.AdditionalInfo, DiagnosticInfoAdditionalInfo},
+		{"InnerStatusCode", &d.InnerStatusCode, DiagnosticInfoInnerStatusCode},
+		{"InnerDiagnosticInfo", &d.InnerDiagnosticInfo, DiagnosticInfoInnerDiagnosticInfo},
+	}
+	for _, f := range fields {
+		ok, err := jsonField(m, f.key, f.v, name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			d.EncodingMask |= f.mask
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestJSON(t *testing.T) {
+	ts := time.Date(2021, 2, 3, 4, 5, 6, 7000, time.UTC)
+
+	cases := []struct {
+		Name   string
+		Struct interface{}
+		JSON   string
+	}{
+		{
+			Name:   "numeric node id",
+			Struct: MustParseNodeID("i=2253"),
+			JSON:   `{"Id":2253}`,
+		},
+		{
+			Name:   "string node id",
+			Struct: MustParseNodeID("ns=2;s=foo"),
+			JSON:   `{"IdType":1,"Id":"foo","Namespace":2}`,
+		},
+		{
+			Name:   "guid node id",
+			Struct: MustParseNodeID("ns=1;g=72962B91-FA75-4AE6-8D28-B404DC7DAF63"),
+			JSON:   `{"IdType":2,"Id":"72962B91-FA75-4AE6-8D28-B404DC7DAF63","Namespace":1}`,
+		},
+		{
+			Name:   "opaque node id",
+			Struct: MustParseNodeID("ns=1;b=YWJj"),
+			JSON:   `{"IdType":3,"Id":"YWJj","Namespace":1}`,
+		},
+		{
+			Name:   "expanded node id",
+			Struct: NewExpandedNodeID(NewStringNodeID(0, "foo"), "urn:foo", 2),
+			JSON:   `{"IdType":1,"Id":"foo","Namespace":"urn:foo","ServerUri":2}`,
+		},
+		{
+			Name:   "qualified name",
+			Struct: &QualifiedName{NamespaceIndex: 2, Name: "foo"},
+			JSON:   `{"Name":"foo","Uri":2}`,
+		},
+		{
+			Name:   "localized text",
+			Struct: NewLocalizedTextWithLocale("foo", "en"),
+			JSON:   `{"Locale":"en","Text":"foo"}`,
+		},
+		{
+			Name:   "int64 variant",
+			Struct: MustVariant(int64(-5)),
+			JSON:   `{"Type":8,"Body":"-5"}`,
+		},
+		{
+			Name:   "double variant",
+			Struct: MustVariant(math.Inf(-1)),
+			JSON:   `{"Type":11,"Body":"-Infinity"}`,
+		},
+		{
+			Name:   "byte string variant",
+			Struct: MustVariant([]byte{1, 2, 3}),
+			JSON:   `{"Type":15,"Body":"AQID"}`,
+		},
+		{
+			Name:   "byte array variant",
+			Struct: MustVariant(ByteArray{1, 2, 3}),
+			JSON:   `{"Type":3,"Body":[1,2,3]}`,
+		},
+		{
+			Name:   "multi-dimensional variant",
+			Struct: MustVariant([][]int32{{1, 2, 3}, {4, 5, 6}}),
+			JSON:   `{"Type":6,"Body":[1,2,3,4,5,6],"Dimensions":[2,3]}`,
+		},
+		{
+			Name:   "localized text array variant",
+			Struct: MustVariant([]*LocalizedText{NewLocalizedText("foo")}),
+			JSON:   `{"Type":21,"Body":[{"Text":"foo"}]}`,
+		},
+		{
+			Name: "data value",
+			Struct: &DataValue{
+				EncodingMask:      0x0f,
+				Value:             MustVariant(true),
+				Status:            StatusBadNodeIDUnknown,
+				SourceTimestamp:   ts,
+				SourcePicoseconds: 0,
+				ServerTimestamp:   ts,
+			},
+			JSON: `{"Value":{"Type":1,"Body":true},"Status":2150891520,"SourceTimestamp":"2021-02-03T04:05:06.000007Z","ServerTimestamp":"2021-02-03T04:05:06.000007Z"}`,
+		},
+		{
+			Name: "diagnostic info",
+			Struct: &DiagnosticInfo{
+				EncodingMask:    0x61,
+				SymbolicID:      1,
+				InnerStatusCode: StatusBadTimeout,
+				InnerDiagnosticInfo: &DiagnosticInfo{
+					EncodingMask:   0x10,
+					AdditionalInfo: "foo",
+				},
+			},
+			JSON: `{"SymbolicId":1,"InnerStatusCode":2148139008,"InnerDiagnosticInfo":{"AdditionalInfo":"foo"}}`,
+		},
+		{
+			Name:   "extension object",
+			Struct: NewExtensionObject(&AnonymousIdentityToken{PolicyID: "anonymous"}),
+			JSON:   `{"TypeId":{"Id":321},"Body":{"PolicyId":"anonymous"}}`,
+		},
+		{
+			Name: "read request",
+			Struct: &ReadRequest{
+				RequestHeader: &RequestHeader{
+					AuthenticationToken: NewTwoByteNodeID(0),
+					Timestamp:           ts,
+					RequestHandle:       1,
+				},
+				MaxAge:             100,
+				TimestampsToReturn: TimestampsToReturnBoth,
+				NodesToRead: []*ReadValueID{
+					{
+						NodeID:       MustParseNodeID("ns=2;s=foo"),
+						AttributeID:  AttributeIDValue,
+						DataEncoding: &QualifiedName{},
+					},
+				},
+			},
+			JSON: `{"RequestHeader":{"AuthenticationToken":{"Id":0},"Timestamp":"2021-02-03T04:05:06.000007Z","RequestHandle":1,"ReturnDiagnostics":0,"AuditEntryId":"","TimeoutHint":0},` +
+				`"MaxAge":100,"TimestampsToReturn":2,"NodesToRead":[{"NodeId":{"IdType":1,"Id":"foo","Namespace":2},"AttributeId":13,"IndexRange":"","DataEncoding":{"Name":""}}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Run("encode", func(t *testing.T) {
+				b, err := EncodeJSON(c.Struct)
+				if err != nil {
+					t.Fatal(err)
+				}
+				verify.Values(t, "", string(b), c.JSON)
+			})
+
+			t.Run("decode", func(t *testing.T) {
+				v := reflect.New(reflect.TypeOf(c.Struct).Elem())
+				if err := DecodeJSON([]byte(c.JSON), v.Interface()); err != nil {
+					t.Fatal(err)
+				}
+				verify.Values(t, "", v.Interface(), c.Struct)
+			})
+		})
+	}
+}
+
+func TestJSONNonReversible(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Struct interface{}
+		JSON   string
+	}{
+		{
+			Name:   "localized text",
+			Struct: NewLocalizedTextWithLocale("foo", "en"),
+			JSON:   `"foo"`,
+		},
+		{
+			Name:   "status code",
+			Struct: StatusBadNodeIDUnknown,
+			JSON:   `{"Code":2150891520,"Symbol":"BadNodeIDUnknown"}`,
+		},
+		{
+			Name:   "enum",
+			Struct: NodeClassVariable,
+			JSON:   `"Variable_2"`,
+		},
+		{
+			Name:   "multi-dimensional variant",
+			Struct: MustVariant([][]int32{{1, 2}, {3, 4}}),
+			JSON:   `[[1,2],[3,4]]`,
+		},
+		{
+			Name:   "extension object",
+			Struct: NewExtensionObject(&AnonymousIdentityToken{PolicyID: "anonymous"}),
+			JSON:   `{"PolicyId":"anonymous"}`,
+		},
+		{
+			Name: "data value",
+			Struct: &DataValue{
+				EncodingMask: 0x03,
+				Value:        MustVariant(NewLocalizedText("foo")),
+				Status:       StatusOK,
+			},
+			JSON: `{"Value":"foo","Status":{"Code":0,"Symbol":"Good"}}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := EncodeJSONNonReversible(c.Struct)
+			if err != nil {
+				t.Fatal(err)
+			}
+			verify.Values(t, "", string(b), c.JSON)
+		})
+	}
+}
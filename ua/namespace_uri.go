@@ -0,0 +1,166 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"reflect"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+// ErrNamespaceURI is returned when a node id with a namespace uri is
+// encoded. The binary encoding of a node id only has the namespace index
+// and the node id has to be resolved with ResolveNamespaceURIs first.
+var ErrNamespaceURI = errors.New("node id with namespace uri must be resolved before it is encoded")
+
+// ResolveNamespaceURIs returns a copy of v in which the node ids with a
+// namespace uri are replaced with node ids which have the index of the
+// uri in the namespace array ns. v is usually a request and the node ids
+// in its exported fields, slices, extension objects and variants are
+// resolved. v and its node ids are not modified. Only the values on the
+// path to a resolved node id are copied and v is returned as is if it
+// has no node ids with a namespace uri. It returns an error if a
+// namespace uri is not in ns.
+func ResolveNamespaceURIs(v interface{}, ns []string) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	val, ok, err := resolveNamespaceURIs(reflect.ValueOf(v), ns)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return v, nil
+	}
+	return val.Interface(), nil
+}
+
+// resolveNamespaceURIs returns a copy of val with the node ids resolved
+// and true if val contains a node id with a namespace uri. Otherwise, it
+// returns false and val must be used as is.
+func resolveNamespaceURIs(val reflect.Value, ns []string) (reflect.Value, bool, error) {
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return val, false, nil
+		}
+		switch x := val.Interface().(type) {
+		case *NodeID:
+			if x.uri == "" {
+				return val, false, nil
+			}
+			n, err := x.resolveNamespaceURI(ns)
+			if err != nil {
+				return val, false, err
+			}
+			return reflect.ValueOf(n), true, nil
+		case *Variant:
+			if x.Value() == nil {
+				return val, false, nil
+			}
+			v, ok, err := resolveNamespaceURIs(reflect.ValueOf(x.Value()), ns)
+			if err != nil || !ok {
+				return val, false, err
+			}
+			nv, err := NewVariant(v.Interface())
+			if err != nil {
+				return val, false, err
+			}
+			return reflect.ValueOf(nv), true, nil
+		}
+		v, ok, err := resolveNamespaceURIs(val.Elem(), ns)
+		if err != nil || !ok {
+			return val, false, err
+		}
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p, true, nil
+
+	case reflect.Interface:
+		if val.IsNil() {
+			return val, false, nil
+		}
+		return resolveNamespaceURIs(val.Elem(), ns)
+
+	case reflect.Struct:
+		typ := val.Type()
+		if typ == nodeIDType || typ == variantType {
+			p := reflect.New(typ)
+			p.Elem().Set(val)
+			v, ok, err := resolveNamespaceURIs(p, ns)
+			if err != nil || !ok {
+				return val, false, err
+			}
+			return v.Elem(), true, nil
+		}
+		var cp reflect.Value
+		for i := 0; i < val.NumField(); i++ {
+			if typ.Field(i).PkgPath != "" {
+				continue
+			}
+			v, ok, err := resolveNamespaceURIs(val.Field(i), ns)
+			if err != nil {
+				return val, false, err
+			}
+			if !ok {
+				continue
+			}
+			if !cp.IsValid() {
+				cp = reflect.New(typ).Elem()
+				cp.Set(val)
+			}
+			cp.Field(i).Set(v)
+		}
+		if !cp.IsValid() {
+			return val, false, nil
+		}
+		return cp, true, nil
+
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return val, false, nil
+		}
+		var cp reflect.Value
+		for i := 0; i < val.Len(); i++ {
+			v, ok, err := resolveNamespaceURIs(val.Index(i), ns)
+			if err != nil {
+				return val, false, err
+			}
+			if !ok {
+				continue
+			}
+			if !cp.IsValid() {
+				if val.Kind() == reflect.Slice {
+					cp = reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+					reflect.Copy(cp, val)
+				} else {
+					cp = reflect.New(val.Type()).Elem()
+					cp.Set(val)
+				}
+			}
+			cp.Index(i).Set(v)
+		}
+		if !cp.IsValid() {
+			return val, false, nil
+		}
+		return cp, true, nil
+
+	default:
+		return val, false, nil
+	}
+}
+
+// resolveNamespaceURI returns a copy of the node id without the namespace
+// uri and with the index of the uri in ns.
+func (n *NodeID) resolveNamespaceURI(ns []string) (*NodeID, error) {
+	for i, uri := range ns {
+		if uri == n.uri {
+			id := *n
+			id.ns, id.uri = uint16(i), ""
+			return &id, nil
+		}
+	}
+	return nil, errors.Errorf("namespace uri %s not found in the server NamespaceArray", n.uri)
+}
@@ -0,0 +1,101 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+func TestResolveNamespaceURIs(t *testing.T) {
+	newRequest := func() *WriteRequest {
+		return &WriteRequest{
+			NodesToWrite: []*WriteValue{
+				{
+					NodeID:      MustParseNodeID("nsu=urn:a;i=1"),
+					AttributeID: AttributeIDValue,
+					Value: &DataValue{
+						Value: MustVariant([]*NodeID{MustParseNodeID("nsu=urn:b;s=x")}),
+					},
+				},
+				{
+					NodeID:      MustParseNodeID("ns=1;i=2"),
+					AttributeID: AttributeIDValue,
+					Value: &DataValue{
+						Value: MustVariant(NewExtensionObject(&ReadValueID{NodeID: MustParseNodeID("nsu=urn:b;i=3")})),
+					},
+				},
+			},
+		}
+	}
+	namespaces := func(req *WriteRequest) []uint16 {
+		w0, w1 := req.NodesToWrite[0], req.NodesToWrite[1]
+		return []uint16{
+			w0.NodeID.Namespace(),
+			w0.Value.Value.Value().([]*NodeID)[0].Namespace(),
+			w1.NodeID.Namespace(),
+			w1.Value.Value.Value().(*ExtensionObject).Value.(*ReadValueID).NodeID.Namespace(),
+		}
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		req := newRequest()
+		before := newRequest()
+
+		v, err := ResolveNamespaceURIs(req, []string{"http://opcfoundation.org/UA/", "urn:a", "urn:b"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := namespaces(v.(*WriteRequest)), []uint16{1, 2, 1, 2}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v want %v", got, want)
+		}
+		if got, want := v.(*WriteRequest).NodesToWrite[1].NodeID, req.NodesToWrite[1].NodeID; got != want {
+			t.Fatalf("got %p want the unchanged node id %p", got, want)
+		}
+		if _, err := Encode(v); err != nil {
+			t.Fatal(err)
+		}
+
+		// the request is not modified and is resolved again when the
+		// namespaces are reordered
+		if got, want := req, before; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v want %#v", got, want)
+		}
+		v, err = ResolveNamespaceURIs(req, []string{"http://opcfoundation.org/UA/", "urn:b", "urn:c", "urn:a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := namespaces(v.(*WriteRequest)), []uint16{3, 1, 1, 1}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v want %v", got, want)
+		}
+	})
+
+	t.Run("no namespace uris", func(t *testing.T) {
+		req := &ReadRequest{NodesToRead: []*ReadValueID{{NodeID: NewNumericNodeID(1, 1)}}}
+		v, err := ResolveNamespaceURIs(req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := v.(*ReadRequest), req; got != want {
+			t.Fatalf("got %p want %p", got, want)
+		}
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		_, err := Encode(newRequest())
+		if got, want := err, ErrNamespaceURI; got != want {
+			t.Fatalf("got error %v want %v", got, want)
+		}
+	})
+
+	t.Run("unknown uri", func(t *testing.T) {
+		_, err := ResolveNamespaceURIs(newRequest(), []string{"http://opcfoundation.org/UA/", "urn:a"})
+		if got, want := err, errors.New("namespace uri urn:b not found in the server NamespaceArray"); !errors.Equal(got, want) {
+			t.Fatalf("got error %v want %v", got, want)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/imatic-tech/opcua/errors"
 )
@@ -23,6 +24,10 @@ type NodeID struct {
 	nid  uint32
 	bid  []byte
 	gid  *GUID
+
+	// uri is the namespace uri of the node id. If it is set the node id
+	// has to be resolved with ResolveNamespaceURIs before it is encoded.
+	uri string
 }
 
 // NewTwoByteNodeID returns a new two byte node id.
@@ -90,16 +95,21 @@ func MustParseNodeID(s string) *NodeID {
 }
 
 // ParseNodeID returns a node id from a string definition of the format
-// 'ns=<namespace>;{s,i,b,g}=<identifier>'.
+// '{ns,nsu}=<namespace>;{s,i,b,g}=<identifier>'.
 //
 // The 's=' prefix can be omitted for string node ids in namespace 0.
 //
 // For numeric ids the smallest possible type which can store the namespace
 // and id value is returned.
 //
-// Namespace URIs are not supported since NodeID cannot store them. If you need
-// to support namespace URIs use ParseExpandedNodeID.
+// Node ids with a namespace uri are always numeric, string, guid or opaque
+// node ids. The client resolves the namespace index when it sends the
+// node id. See ResolveNamespaceURIs.
 func ParseNodeID(s string) (*NodeID, error) {
+	if strings.HasPrefix(s, "nsu=") {
+		return parseNodeIDWithURI(s)
+	}
+
 	id, err := ParseExpandedNodeID(s, nil)
 	if err != nil {
 		return nil, err
@@ -113,6 +123,26 @@ func ParseNodeID(s string) (*NodeID, error) {
 	return id.NodeID, nil
 }
 
+// parseNodeIDWithURI parses a node id of the format
+// 'nsu=<uri>;{s,i,b,g}=<identifier>'.
+func parseNodeIDWithURI(s string) (*NodeID, error) {
+	i := strings.Index(s, ";")
+	if i < 0 {
+		return nil, errors.Errorf("invalid node id: %s", s)
+	}
+	uri, idval := s[len("nsu="):i], s[i+1:]
+	if uri == "" || idval == "" || strings.Contains(idval, ";") {
+		return nil, errors.Errorf("invalid node id: %s", s)
+	}
+
+	id, err := ParseNodeID(idval)
+	if err != nil {
+		return nil, err
+	}
+	id.SetNamespaceURI(uri)
+	return id, nil
+}
+
 // EncodingMask returns the encoding mask field including the
 // type information and additional flags.
 func (n *NodeID) EncodingMask() NodeIDType {
@@ -176,6 +206,28 @@ func (n *NodeID) SetNamespace(v uint16) error {
 	}
 }
 
+// NamespaceURI returns the namespace uri or an empty string if the
+// namespace is only identified by its index.
+func (n *NodeID) NamespaceURI() string {
+	return n.uri
+}
+
+// SetNamespaceURI sets the namespace uri. The client sends a copy of the
+// node id with the index of the uri in the namespace array of the server
+// so that the node id stays valid when the server reorders its
+// namespaces. Two byte and four byte node ids are converted to numeric
+// node ids since the namespace index is not known yet. An empty uri
+// removes the namespace uri and keeps the index.
+func (n *NodeID) SetNamespaceURI(uri string) {
+	if uri != "" {
+		switch n.Type() {
+		case NodeIDTypeTwoByte, NodeIDTypeFourByte:
+			n.mask = n.mask&^NodeIDType(0xf) | NodeIDTypeNumeric
+		}
+	}
+	n.uri = uri
+}
+
 // IntID returns the identifier value if the type is
 // TwoByte, FourByte or Numeric. For all other types IntID
 // returns 0.
@@ -261,6 +313,12 @@ func (n *NodeID) SetStringID(v string) error {
 // String returns the string representation of the NodeID
 // in the format described by ParseNodeID.
 func (n *NodeID) String() string {
+	if n.uri != "" {
+		id := *n
+		id.ns, id.uri = 0, ""
+		return "nsu=" + n.uri + ";" + id.String()
+	}
+
 	switch n.Type() {
 	case NodeIDTypeTwoByte:
 		return fmt.Sprintf("i=%d", n.nid)
@@ -337,7 +395,12 @@ func (n *NodeID) Decode(b []byte) (int, error) {
 	}
 }
 
+// Encode encodes the node id. It returns ErrNamespaceURI if the node id
+// has a namespace uri.
 func (n *NodeID) Encode() ([]byte, error) {
+	if n.uri != "" {
+		return nil, ErrNamespaceURI
+	}
 	buf := NewBuffer(nil)
 	buf.WriteByte(byte(n.mask))
 
@@ -152,12 +152,18 @@ func TestParseNodeID(t *testing.T) {
 		{s: "ns=1;b=YWJj", n: NewByteStringNodeID(1, []byte{'a', 'b', 'c'})},
 		{s: "ns=1;s=a", n: NewStringNodeID(1, "a")},
 		{s: "ns=1;a", n: NewStringNodeID(1, "a")},
+		{s: "nsu=urn:a;i=1", n: nodeIDWithURI(NewNumericNodeID(0, 1), "urn:a")},
+		{s: "nsu=urn:a;i=70000", n: nodeIDWithURI(NewNumericNodeID(0, 70000), "urn:a")},
+		{s: "nsu=urn:a;s=b", n: nodeIDWithURI(NewStringNodeID(0, "b"), "urn:a")},
+		{s: "nsu=urn:a;b", n: nodeIDWithURI(NewStringNodeID(0, "b"), "urn:a")},
 
 		// error flows
 		{s: "abc=0;i=2", err: errors.New("invalid node id: abc=0;i=2")},
 		{s: "ns=0;i=1;s=2", err: errors.New("invalid node id: ns=0;i=1;s=2")},
 		{s: "ns=0", err: errors.New("invalid node id: ns=0")},
-		{s: "nsu=abc;i=1", err: errors.New("namespace urls require a server NamespaceArray")},
+		{s: "nsu=abc", err: errors.New("invalid node id: nsu=abc")},
+		{s: "nsu=;i=1", err: errors.New("invalid node id: nsu=;i=1")},
+		{s: "nsu=abc;ns=1;i=1", err: errors.New("invalid node id: nsu=abc;ns=1;i=1")},
 		{s: "ns=65536;i=1", err: errors.New("namespace id out of range (0..65535): ns=65536;i=1")},
 		{s: "ns=abc;i=1", err: errors.New("invalid namespace id: ns=abc;i=1")},
 		{s: "ns=1;i=abc", err: errors.New("invalid numeric id: ns=1;i=abc")},
@@ -179,6 +185,11 @@ func TestParseNodeID(t *testing.T) {
 	}
 }
 
+func nodeIDWithURI(n *NodeID, uri string) *NodeID {
+	n.SetNamespaceURI(uri)
+	return n
+}
+
 func TestStringID(t *testing.T) {
 	cases := []struct {
 		name string
@@ -437,6 +448,24 @@ func TestNodeIDJSON(t *testing.T) {
 		}
 	})
 
+	t.Run("namespace uri", func(t *testing.T) {
+		n := MustParseNodeID(`nsu=urn:a;s=abc`)
+		b, err := json.Marshal(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(b), `"nsu=urn:a;s=abc"`; got != want {
+			t.Fatalf("got %s want %s", got, want)
+		}
+		var nn NodeID
+		if err := json.Unmarshal(b, &nn); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := &nn, n; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v want %#v", got, want)
+		}
+	})
+
 	t.Run("nil", func(t *testing.T) {
 		var n *NodeID
 		b, err := json.Marshal(n)
@@ -498,6 +527,11 @@ func TestNodeIDToString(t *testing.T) {
 		{"ns=1;s=123", "ns=1;s=123"},
 		{"ns=1;b=MTIz", "ns=1;b=MTIz"},
 		{"ns=1;g=F11BD41E-2DF5-41D3-8CE2-A37D22D1E469", "ns=1;g=F11BD41E-2DF5-41D3-8CE2-A37D22D1E469"},
+
+		{"nsu=urn:a;i=1", "nsu=urn:a;i=1"},
+		{"nsu=urn:a;s=123", "nsu=urn:a;s=123"},
+		{"nsu=urn:a;b=MTIz", "nsu=urn:a;b=MTIz"},
+		{"nsu=urn:a;g=F11BD41E-2DF5-41D3-8CE2-A37D22D1E469", "nsu=urn:a;g=F11BD41E-2DF5-41D3-8CE2-A37D22D1E469"},
 	}
 
 	for _, tt := range tests {
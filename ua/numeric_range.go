@@ -0,0 +1,235 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+// NumericRange selects the elements of an array value with one range of
+// indexes per dimension, e.g. "1:2,0:3" for the rows 1 to 2 and the
+// columns 0 to 3 of a matrix. String and ByteString values are treated
+// as arrays of bytes and an additional dimension selects the bytes of
+// the elements of an array of strings.
+//
+// Specification: Part 4, 7.22
+type NumericRange []NumericRangeDimension
+
+// NumericRangeDimension contains the first and the last index of a
+// dimension. Both are equal for a single index.
+type NumericRangeDimension struct {
+	Low  uint32
+	High uint32
+}
+
+// ParseNumericRange parses a numeric range of the format
+// '<index>' or '<low>:<high>' with one range per dimension separated by
+// ','. low must be less than high. An empty string returns a nil range
+// which selects the whole value.
+//
+// Specification: Part 4, 7.22
+func ParseNumericRange(s string) (NumericRange, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var r NumericRange
+	for _, dim := range strings.Split(s, ",") {
+		lo, hi := dim, ""
+		i := strings.Index(dim, ":")
+		if i >= 0 {
+			lo, hi = dim[:i], dim[i+1:]
+		}
+		low, err := parseNumericRangeIndex(s, lo)
+		if err != nil {
+			return nil, err
+		}
+		high := low
+		if i >= 0 {
+			if high, err = parseNumericRangeIndex(s, hi); err != nil {
+				return nil, err
+			}
+			if low >= high {
+				return nil, errors.Errorf("invalid numeric range %q: %d is not less than %d", s, low, high)
+			}
+		}
+		r = append(r, NumericRangeDimension{Low: low, High: high})
+	}
+	return r, nil
+}
+
+// parseNumericRangeIndex parses an index of the range s which must only
+// contain digits.
+func parseNumericRangeIndex(s, idx string) (uint32, error) {
+	if idx == "" {
+		return 0, errors.Errorf("invalid numeric range %q: missing index", s)
+	}
+	for i := 0; i < len(idx); i++ {
+		if idx[i] < '0' || idx[i] > '9' {
+			return 0, errors.Errorf("invalid numeric range %q: invalid index %q", s, idx)
+		}
+	}
+	n, err := strconv.ParseUint(idx, 10, 32)
+	if err != nil {
+		return 0, errors.Errorf("invalid numeric range %q: index %q out of range", s, idx)
+	}
+	return uint32(n), nil
+}
+
+// String returns the numeric range in the format of ParseNumericRange.
+func (r NumericRange) String() string {
+	var b strings.Builder
+	for i, d := range r {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatUint(uint64(d.Low), 10))
+		if d.High != d.Low {
+			b.WriteByte(':')
+			b.WriteString(strconv.FormatUint(uint64(d.High), 10))
+		}
+	}
+	return b.String()
+}
+
+// Slice returns the elements of the array, matrix, string or byte string
+// value of v which are selected by the range. The upper bounds are
+// limited to the size of the value. It returns StatusBadIndexRangeNoData
+// if a lower bound is out of range and StatusBadIndexRangeInvalid if the
+// range has more dimensions than the value.
+func (r NumericRange) Slice(v *Variant) (*Variant, error) {
+	if len(r) == 0 {
+		return v, nil
+	}
+	if v == nil || v.Value() == nil {
+		return nil, StatusBadIndexRangeNoData
+	}
+	val, err := sliceRange(reflect.ValueOf(v.Value()), r)
+	if err != nil {
+		return nil, err
+	}
+	return NewVariant(val.Interface())
+}
+
+func sliceRange(v reflect.Value, r NumericRange) (reflect.Value, error) {
+	d := r[0]
+	switch {
+	case v.Kind() == reflect.String:
+		if len(r) > 1 {
+			return reflect.Value{}, StatusBadIndexRangeInvalid
+		}
+		if int64(d.Low) >= int64(v.Len()) {
+			return reflect.Value{}, StatusBadIndexRangeNoData
+		}
+		return v.Slice(int(d.Low), rangeEnd(d, v.Len())), nil
+
+	case v.Kind() == reflect.Slice:
+		if v.Type() == byteStringType && len(r) > 1 {
+			return reflect.Value{}, StatusBadIndexRangeInvalid
+		}
+		if int64(d.Low) >= int64(v.Len()) {
+			return reflect.Value{}, StatusBadIndexRangeNoData
+		}
+		// the elements are copied so that the result does not share
+		// the array with the value
+		v = v.Slice(int(d.Low), rangeEnd(d, v.Len()))
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if len(r) == 1 {
+			reflect.Copy(out, v)
+			return out, nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			el, err := sliceRange(v.Index(i), r[1:])
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(el)
+		}
+		return out, nil
+
+	default:
+		return reflect.Value{}, StatusBadIndexRangeInvalid
+	}
+}
+
+// rangeEnd returns the end of the slice expression for the dimension
+// which is limited to n.
+func rangeEnd(d NumericRangeDimension, n int) int {
+	if int64(d.High) >= int64(n) {
+		return n
+	}
+	return int(d.High) + 1
+}
+
+// Replace returns a copy of the value of dst where the elements which are
+// selected by the range are replaced with the value of src. src must have
+// the same type as dst and exactly the size of the range. It returns
+// StatusBadIndexRangeNoData if the range exceeds the size of dst,
+// StatusBadIndexRangeInvalid if the size of src does not match and
+// StatusBadTypeMismatch if the types differ.
+func (r NumericRange) Replace(dst, src *Variant) (*Variant, error) {
+	if len(r) == 0 {
+		return src, nil
+	}
+	if dst == nil || dst.Value() == nil {
+		return nil, StatusBadIndexRangeNoData
+	}
+	if src == nil || src.Value() == nil {
+		return nil, StatusBadTypeMismatch
+	}
+	val, err := replaceRange(reflect.ValueOf(dst.Value()), reflect.ValueOf(src.Value()), r)
+	if err != nil {
+		return nil, err
+	}
+	return NewVariant(val.Interface())
+}
+
+func replaceRange(dst, src reflect.Value, r NumericRange) (reflect.Value, error) {
+	if dst.Type() != src.Type() {
+		return reflect.Value{}, StatusBadTypeMismatch
+	}
+	if dst.Kind() != reflect.String && dst.Kind() != reflect.Slice {
+		return reflect.Value{}, StatusBadIndexRangeInvalid
+	}
+	d := r[0]
+	if int64(d.High) >= int64(dst.Len()) {
+		return reflect.Value{}, StatusBadIndexRangeNoData
+	}
+	lo, hi := int(d.Low), int(d.High)+1
+	if src.Len() != hi-lo {
+		return reflect.Value{}, StatusBadIndexRangeInvalid
+	}
+
+	switch {
+	case dst.Kind() == reflect.String:
+		if len(r) > 1 {
+			return reflect.Value{}, StatusBadIndexRangeInvalid
+		}
+		s := dst.String()
+		return reflect.ValueOf(s[:lo] + src.String() + s[hi:]).Convert(dst.Type()), nil
+
+	case dst.Kind() == reflect.Slice && (dst.Type() != byteStringType || len(r) == 1):
+		out := reflect.MakeSlice(dst.Type(), dst.Len(), dst.Len())
+		reflect.Copy(out, dst)
+		for i := lo; i < hi; i++ {
+			el := src.Index(i - lo)
+			if len(r) > 1 {
+				var err error
+				if el, err = replaceRange(dst.Index(i), el, r[1:]); err != nil {
+					return reflect.Value{}, err
+				}
+			}
+			out.Index(i).Set(el)
+		}
+		return out, nil
+
+	default:
+		return reflect.Value{}, StatusBadIndexRangeInvalid
+	}
+}
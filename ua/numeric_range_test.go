@@ -0,0 +1,160 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+func TestParseNumericRange(t *testing.T) {
+	cases := []struct {
+		s   string
+		r   NumericRange
+		err error
+	}{
+		// happy flows
+		{s: "", r: nil},
+		{s: "6", r: NumericRange{{6, 6}}},
+		{s: "5:7", r: NumericRange{{5, 7}}},
+		{s: "0:4294967295", r: NumericRange{{0, 4294967295}}},
+		{s: "1:2,0:3", r: NumericRange{{1, 2}, {0, 3}}},
+		{s: "1,0:3,2", r: NumericRange{{1, 1}, {0, 3}, {2, 2}}},
+
+		// error flows
+		{s: "7:5", err: errors.New(`invalid numeric range "7:5": 7 is not less than 5`)},
+		{s: "5:5", err: errors.New(`invalid numeric range "5:5": 5 is not less than 5`)},
+		{s: "1,", err: errors.New(`invalid numeric range "1,": missing index`)},
+		{s: ":3", err: errors.New(`invalid numeric range ":3": missing index`)},
+		{s: "1:2:3", err: errors.New(`invalid numeric range "1:2:3": invalid index "2:3"`)},
+		{s: "-1", err: errors.New(`invalid numeric range "-1": invalid index "-1"`)},
+		{s: " 1", err: errors.New(`invalid numeric range " 1": invalid index " 1"`)},
+		{s: "4294967296", err: errors.New(`invalid numeric range "4294967296": index "4294967296" out of range`)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.s, func(t *testing.T) {
+			r, err := ParseNumericRange(c.s)
+			if got, want := err, c.err; !errors.Equal(got, want) {
+				t.Fatalf("got error %v want %v", got, want)
+			}
+			if got, want := r, c.r; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v want %v", got, want)
+			}
+			if c.err == nil {
+				if got, want := r.String(), c.s; got != want {
+					t.Fatalf("got %s want %s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNumericRangeSlice(t *testing.T) {
+	matrix := [][]int32{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+
+	cases := []struct {
+		name string
+		r    string
+		v    interface{}
+		want interface{}
+		err  error
+	}{
+		{name: "array", r: "1:2", v: []float64{1, 2, 3, 4}, want: []float64{2, 3}},
+		{name: "array element", r: "3", v: []float64{1, 2, 3, 4}, want: []float64{4}},
+		{name: "array upper bound", r: "2:10", v: []float64{1, 2, 3, 4}, want: []float64{3, 4}},
+		{name: "byte array", r: "0:1", v: ByteArray{1, 2, 3}, want: ByteArray{1, 2}},
+		{name: "matrix", r: "1:2,0:1", v: matrix, want: [][]int32{{4, 5}, {7, 8}}},
+		{name: "matrix row", r: "0", v: matrix, want: [][]int32{{1, 2, 3}}},
+		{name: "string", r: "1:2", v: "abcde", want: "bc"},
+		{name: "byte string", r: "1", v: []byte("abc"), want: []byte("b")},
+		{name: "string array", r: "0:1,1:2", v: []string{"abc", "def", "ghi"}, want: []string{"bc", "ef"}},
+
+		{name: "lower bound", r: "4:5", v: []float64{1, 2, 3, 4}, err: StatusBadIndexRangeNoData},
+		{name: "string lower bound", r: "3", v: "abc", err: StatusBadIndexRangeNoData},
+		{name: "scalar", r: "0", v: 1.5, err: StatusBadIndexRangeInvalid},
+		{name: "dimensions", r: "0,0", v: []float64{1, 2}, err: StatusBadIndexRangeInvalid},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := ParseNumericRange(c.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := r.Slice(MustVariant(c.v))
+			if got, want := err, c.err; got != want {
+				t.Fatalf("got error %v want %v", got, want)
+			}
+			if c.err != nil {
+				return
+			}
+			if got, want := v.Value(), c.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %#v want %#v", got, want)
+			}
+		})
+	}
+
+	t.Run("copy", func(t *testing.T) {
+		a := []float64{1, 2, 3}
+		v, err := NumericRange{{0, 1}}.Slice(MustVariant(a))
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.Value().([]float64)[0] = 5
+		if got, want := a, []float64{1, 2, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v want %v", got, want)
+		}
+	})
+}
+
+func TestNumericRangeReplace(t *testing.T) {
+	matrix := [][]int32{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+
+	cases := []struct {
+		name     string
+		r        string
+		dst, src interface{}
+		want     interface{}
+		err      error
+	}{
+		{name: "array", r: "1:2", dst: []float64{1, 2, 3, 4}, src: []float64{5, 6}, want: []float64{1, 5, 6, 4}},
+		{name: "array element", r: "3", dst: []float64{1, 2, 3, 4}, src: []float64{5}, want: []float64{1, 2, 3, 5}},
+		{name: "matrix", r: "0:1,1:2", dst: matrix, src: [][]int32{{0, 0}, {0, 0}}, want: [][]int32{{1, 0, 0}, {4, 0, 0}, {7, 8, 9}}},
+		{name: "string", r: "1:2", dst: "abcde", src: "xy", want: "axyde"},
+		{name: "byte string", r: "0", dst: []byte("abc"), src: []byte("x"), want: []byte("xbc")},
+		{name: "string array", r: "1,0", dst: []string{"abc", "def"}, src: []string{"x"}, want: []string{"abc", "xef"}},
+
+		{name: "upper bound", r: "3:4", dst: []float64{1, 2, 3, 4}, src: []float64{5, 6}, err: StatusBadIndexRangeNoData},
+		{name: "size", r: "1:2", dst: []float64{1, 2, 3, 4}, src: []float64{5}, err: StatusBadIndexRangeInvalid},
+		{name: "type", r: "1", dst: []float64{1, 2, 3, 4}, src: []int32{5}, err: StatusBadTypeMismatch},
+		{name: "scalar", r: "0", dst: 1.5, src: 2.5, err: StatusBadIndexRangeInvalid},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := ParseNumericRange(c.r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := r.Replace(MustVariant(c.dst), MustVariant(c.src))
+			if got, want := err, c.err; got != want {
+				t.Fatalf("got error %v want %v", got, want)
+			}
+			if c.err != nil {
+				return
+			}
+			if got, want := v.Value(), c.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %#v want %#v", got, want)
+			}
+		})
+	}
+
+	if got, want := matrix, [][]int32{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
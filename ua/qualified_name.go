@@ -12,3 +12,20 @@ type QualifiedName struct {
 	NamespaceIndex uint16
 	Name           string
 }
+
+func (q *QualifiedName) Decode(b []byte) (int, error) {
+	buf := NewBuffer(b)
+	q.NamespaceIndex = buf.ReadUint16()
+	q.Name = buf.ReadString()
+	return buf.Pos(), buf.Error()
+}
+
+func (q *QualifiedName) Encode() ([]byte, error) {
+	if q == nil {
+		return nil, nil
+	}
+	buf := NewBuffer(nil)
+	buf.WriteUint16(q.NamespaceIndex)
+	buf.WriteString(q.Name)
+	return buf.Bytes(), buf.Error()
+}
@@ -63,3 +63,46 @@ func TestReadRequest(t *testing.T) {
 	}
 	RunCodecTest(t, cases)
 }
+
+func benchmarkReadRequest(n int) *ReadRequest {
+	r := &ReadRequest{
+		RequestHeader: &RequestHeader{
+			AuthenticationToken: NewTwoByteNodeID(0),
+			Timestamp:           time.Date(2018, time.August, 10, 23, 0, 0, 0, time.UTC),
+			RequestHandle:       1,
+			AdditionalHeader:    NewExtensionObject(nil),
+		},
+		TimestampsToReturn: TimestampsToReturnBoth,
+	}
+	for i := 0; i < n; i++ {
+		r.NodesToRead = append(r.NodesToRead, &ReadValueID{
+			NodeID:       NewNumericNodeID(2, uint32(i)),
+			AttributeID:  AttributeIDValue,
+			DataEncoding: &QualifiedName{},
+		})
+	}
+	return r
+}
+
+func BenchmarkReadRequestEncode(b *testing.B) {
+	r := benchmarkReadRequest(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadRequestDecode(b *testing.B) {
+	data, err := Encode(benchmarkReadRequest(100))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(data, new(ReadRequest)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
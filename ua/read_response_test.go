@@ -56,3 +56,53 @@ func TestReadResponse(t *testing.T) {
 	}
 	RunCodecTest(t, cases)
 }
+
+// benchmarkReadResponse returns a read response with n values.
+//
+// Compare the generated codec with the reflection based one with
+//
+//	go test -run - -bench ReadResponse ./ua
+//	go test -run - -bench ReadResponse -tags reflectcodec ./ua
+func benchmarkReadResponse(n int) *ReadResponse {
+	r := &ReadResponse{
+		ResponseHeader: &ResponseHeader{
+			Timestamp:          time.Date(2018, time.August, 10, 23, 0, 0, 0, time.UTC),
+			RequestHandle:      1,
+			ServiceDiagnostics: &DiagnosticInfo{},
+			StringTable:        []string{},
+			AdditionalHeader:   NewExtensionObject(nil),
+		},
+		DiagnosticInfos: []*DiagnosticInfo{},
+	}
+	for i := 0; i < n; i++ {
+		r.Results = append(r.Results, &DataValue{
+			EncodingMask:    DataValueValue | DataValueSourceTimestamp,
+			Value:           MustVariant(float64(i)),
+			SourceTimestamp: time.Date(2018, time.August, 10, 23, 0, 0, 0, time.UTC),
+		})
+	}
+	return r
+}
+
+func BenchmarkReadResponseEncode(b *testing.B) {
+	r := benchmarkReadResponse(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Encode(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadResponseDecode(b *testing.B) {
+	data, err := Encode(benchmarkReadResponse(100))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(data, new(ReadResponse)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
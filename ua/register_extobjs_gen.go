@@ -12,287 +12,571 @@ import (
 
 func init() {
 	RegisterExtensionObject(NewNumericNodeID(0, id.KeyValuePair_Encoding_DefaultBinary), new(KeyValuePair))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.KeyValuePair_Encoding_DefaultXML), new(KeyValuePair))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AdditionalParametersType_Encoding_DefaultBinary), new(AdditionalParametersType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AdditionalParametersType_Encoding_DefaultXML), new(AdditionalParametersType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EphemeralKeyType_Encoding_DefaultBinary), new(EphemeralKeyType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EphemeralKeyType_Encoding_DefaultXML), new(EphemeralKeyType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EndpointType_Encoding_DefaultBinary), new(EndpointType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EndpointType_Encoding_DefaultXML), new(EndpointType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.IdentityMappingRuleType_Encoding_DefaultBinary), new(IdentityMappingRuleType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.IdentityMappingRuleType_Encoding_DefaultXML), new(IdentityMappingRuleType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TrustListDataType_Encoding_DefaultBinary), new(TrustListDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TrustListDataType_Encoding_DefaultXML), new(TrustListDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DecimalDataType_Encoding_DefaultBinary), new(DecimalDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DecimalDataType_Encoding_DefaultXML), new(DecimalDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataTypeSchemaHeader_Encoding_DefaultBinary), new(DataTypeSchemaHeader))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataTypeSchemaHeader_Encoding_DefaultXML), new(DataTypeSchemaHeader))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataTypeDescription_Encoding_DefaultBinary), new(DataTypeDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataTypeDescription_Encoding_DefaultXML), new(DataTypeDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.StructureDescription_Encoding_DefaultBinary), new(StructureDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.StructureDescription_Encoding_DefaultXML), new(StructureDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EnumDescription_Encoding_DefaultBinary), new(EnumDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EnumDescription_Encoding_DefaultXML), new(EnumDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SimpleTypeDescription_Encoding_DefaultBinary), new(SimpleTypeDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SimpleTypeDescription_Encoding_DefaultXML), new(SimpleTypeDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UABinaryFileDataType_Encoding_DefaultBinary), new(UABinaryFileDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UABinaryFileDataType_Encoding_DefaultXML), new(UABinaryFileDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetMetaDataType_Encoding_DefaultBinary), new(DataSetMetaDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetMetaDataType_Encoding_DefaultXML), new(DataSetMetaDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FieldMetaData_Encoding_DefaultBinary), new(FieldMetaData))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FieldMetaData_Encoding_DefaultXML), new(FieldMetaData))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ConfigurationVersionDataType_Encoding_DefaultBinary), new(ConfigurationVersionDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ConfigurationVersionDataType_Encoding_DefaultXML), new(ConfigurationVersionDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishedDataSetDataType_Encoding_DefaultBinary), new(PublishedDataSetDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishedDataSetDataType_Encoding_DefaultXML), new(PublishedDataSetDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishedDataSetSourceDataType_Encoding_DefaultBinary), new(PublishedDataSetSourceDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishedDataSetSourceDataType_Encoding_DefaultXML), new(PublishedDataSetSourceDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishedVariableDataType_Encoding_DefaultBinary), new(PublishedVariableDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishedVariableDataType_Encoding_DefaultXML), new(PublishedVariableDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishedDataItemsDataType_Encoding_DefaultBinary), new(PublishedDataItemsDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishedDataItemsDataType_Encoding_DefaultXML), new(PublishedDataItemsDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishedEventsDataType_Encoding_DefaultBinary), new(PublishedEventsDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishedEventsDataType_Encoding_DefaultXML), new(PublishedEventsDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetWriterDataType_Encoding_DefaultBinary), new(DataSetWriterDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetWriterDataType_Encoding_DefaultXML), new(DataSetWriterDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetWriterTransportDataType_Encoding_DefaultBinary), new(DataSetWriterTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetWriterTransportDataType_Encoding_DefaultXML), new(DataSetWriterTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetWriterMessageDataType_Encoding_DefaultBinary), new(DataSetWriterMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetWriterMessageDataType_Encoding_DefaultXML), new(DataSetWriterMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PubSubGroupDataType_Encoding_DefaultBinary), new(PubSubGroupDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PubSubGroupDataType_Encoding_DefaultXML), new(PubSubGroupDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.WriterGroupDataType_Encoding_DefaultBinary), new(WriterGroupDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.WriterGroupDataType_Encoding_DefaultXML), new(WriterGroupDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.WriterGroupTransportDataType_Encoding_DefaultBinary), new(WriterGroupTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.WriterGroupTransportDataType_Encoding_DefaultXML), new(WriterGroupTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.WriterGroupMessageDataType_Encoding_DefaultBinary), new(WriterGroupMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.WriterGroupMessageDataType_Encoding_DefaultXML), new(WriterGroupMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PubSubConnectionDataType_Encoding_DefaultBinary), new(PubSubConnectionDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PubSubConnectionDataType_Encoding_DefaultXML), new(PubSubConnectionDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ConnectionTransportDataType_Encoding_DefaultBinary), new(ConnectionTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ConnectionTransportDataType_Encoding_DefaultXML), new(ConnectionTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NetworkAddressDataType_Encoding_DefaultBinary), new(NetworkAddressDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NetworkAddressDataType_Encoding_DefaultXML), new(NetworkAddressDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NetworkAddressURLDataType_Encoding_DefaultBinary), new(NetworkAddressURLDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NetworkAddressURLDataType_Encoding_DefaultXML), new(NetworkAddressURLDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReaderGroupDataType_Encoding_DefaultBinary), new(ReaderGroupDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReaderGroupDataType_Encoding_DefaultXML), new(ReaderGroupDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReaderGroupTransportDataType_Encoding_DefaultBinary), new(ReaderGroupTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReaderGroupTransportDataType_Encoding_DefaultXML), new(ReaderGroupTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReaderGroupMessageDataType_Encoding_DefaultBinary), new(ReaderGroupMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReaderGroupMessageDataType_Encoding_DefaultXML), new(ReaderGroupMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetReaderDataType_Encoding_DefaultBinary), new(DataSetReaderDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetReaderDataType_Encoding_DefaultXML), new(DataSetReaderDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetReaderTransportDataType_Encoding_DefaultBinary), new(DataSetReaderTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetReaderTransportDataType_Encoding_DefaultXML), new(DataSetReaderTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataSetReaderMessageDataType_Encoding_DefaultBinary), new(DataSetReaderMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataSetReaderMessageDataType_Encoding_DefaultXML), new(DataSetReaderMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SubscribedDataSetDataType_Encoding_DefaultBinary), new(SubscribedDataSetDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SubscribedDataSetDataType_Encoding_DefaultXML), new(SubscribedDataSetDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TargetVariablesDataType_Encoding_DefaultBinary), new(TargetVariablesDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TargetVariablesDataType_Encoding_DefaultXML), new(TargetVariablesDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FieldTargetDataType_Encoding_DefaultBinary), new(FieldTargetDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FieldTargetDataType_Encoding_DefaultXML), new(FieldTargetDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SubscribedDataSetMirrorDataType_Encoding_DefaultBinary), new(SubscribedDataSetMirrorDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SubscribedDataSetMirrorDataType_Encoding_DefaultXML), new(SubscribedDataSetMirrorDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PubSubConfigurationDataType_Encoding_DefaultBinary), new(PubSubConfigurationDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PubSubConfigurationDataType_Encoding_DefaultXML), new(PubSubConfigurationDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UADPWriterGroupMessageDataType_Encoding_DefaultBinary), new(UADPWriterGroupMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UADPWriterGroupMessageDataType_Encoding_DefaultXML), new(UADPWriterGroupMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UADPDataSetWriterMessageDataType_Encoding_DefaultBinary), new(UADPDataSetWriterMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UADPDataSetWriterMessageDataType_Encoding_DefaultXML), new(UADPDataSetWriterMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UADPDataSetReaderMessageDataType_Encoding_DefaultBinary), new(UADPDataSetReaderMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UADPDataSetReaderMessageDataType_Encoding_DefaultXML), new(UADPDataSetReaderMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.JSONWriterGroupMessageDataType_Encoding_DefaultBinary), new(JSONWriterGroupMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.JSONWriterGroupMessageDataType_Encoding_DefaultXML), new(JSONWriterGroupMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.JSONDataSetWriterMessageDataType_Encoding_DefaultBinary), new(JSONDataSetWriterMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.JSONDataSetWriterMessageDataType_Encoding_DefaultXML), new(JSONDataSetWriterMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.JSONDataSetReaderMessageDataType_Encoding_DefaultBinary), new(JSONDataSetReaderMessageDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.JSONDataSetReaderMessageDataType_Encoding_DefaultXML), new(JSONDataSetReaderMessageDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DatagramConnectionTransportDataType_Encoding_DefaultBinary), new(DatagramConnectionTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DatagramConnectionTransportDataType_Encoding_DefaultXML), new(DatagramConnectionTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DatagramWriterGroupTransportDataType_Encoding_DefaultBinary), new(DatagramWriterGroupTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DatagramWriterGroupTransportDataType_Encoding_DefaultXML), new(DatagramWriterGroupTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrokerConnectionTransportDataType_Encoding_DefaultBinary), new(BrokerConnectionTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrokerConnectionTransportDataType_Encoding_DefaultXML), new(BrokerConnectionTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrokerWriterGroupTransportDataType_Encoding_DefaultBinary), new(BrokerWriterGroupTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrokerWriterGroupTransportDataType_Encoding_DefaultXML), new(BrokerWriterGroupTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrokerDataSetWriterTransportDataType_Encoding_DefaultBinary), new(BrokerDataSetWriterTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrokerDataSetWriterTransportDataType_Encoding_DefaultXML), new(BrokerDataSetWriterTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrokerDataSetReaderTransportDataType_Encoding_DefaultBinary), new(BrokerDataSetReaderTransportDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrokerDataSetReaderTransportDataType_Encoding_DefaultXML), new(BrokerDataSetReaderTransportDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RolePermissionType_Encoding_DefaultBinary), new(RolePermissionType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RolePermissionType_Encoding_DefaultXML), new(RolePermissionType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.StructureField_Encoding_DefaultBinary), new(StructureField))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.StructureField_Encoding_DefaultXML), new(StructureField))
 	RegisterExtensionObject(NewNumericNodeID(0, id.StructureDefinition_Encoding_DefaultBinary), new(StructureDefinition))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.StructureDefinition_Encoding_DefaultXML), new(StructureDefinition))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EnumDefinition_Encoding_DefaultBinary), new(EnumDefinition))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EnumDefinition_Encoding_DefaultXML), new(EnumDefinition))
 	RegisterExtensionObject(NewNumericNodeID(0, id.Node_Encoding_DefaultBinary), new(Node))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.Node_Encoding_DefaultXML), new(Node))
 	RegisterExtensionObject(NewNumericNodeID(0, id.InstanceNode_Encoding_DefaultBinary), new(InstanceNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.InstanceNode_Encoding_DefaultXML), new(InstanceNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TypeNode_Encoding_DefaultBinary), new(TypeNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TypeNode_Encoding_DefaultXML), new(TypeNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ObjectNode_Encoding_DefaultBinary), new(ObjectNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ObjectNode_Encoding_DefaultXML), new(ObjectNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ObjectTypeNode_Encoding_DefaultBinary), new(ObjectTypeNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ObjectTypeNode_Encoding_DefaultXML), new(ObjectTypeNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.VariableNode_Encoding_DefaultBinary), new(VariableNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.VariableNode_Encoding_DefaultXML), new(VariableNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.VariableTypeNode_Encoding_DefaultBinary), new(VariableTypeNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.VariableTypeNode_Encoding_DefaultXML), new(VariableTypeNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReferenceTypeNode_Encoding_DefaultBinary), new(ReferenceTypeNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReferenceTypeNode_Encoding_DefaultXML), new(ReferenceTypeNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MethodNode_Encoding_DefaultBinary), new(MethodNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MethodNode_Encoding_DefaultXML), new(MethodNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ViewNode_Encoding_DefaultBinary), new(ViewNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ViewNode_Encoding_DefaultXML), new(ViewNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataTypeNode_Encoding_DefaultBinary), new(DataTypeNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataTypeNode_Encoding_DefaultXML), new(DataTypeNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReferenceNode_Encoding_DefaultBinary), new(ReferenceNode))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReferenceNode_Encoding_DefaultXML), new(ReferenceNode))
 	RegisterExtensionObject(NewNumericNodeID(0, id.Argument_Encoding_DefaultBinary), new(Argument))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.Argument_Encoding_DefaultXML), new(Argument))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EnumValueType_Encoding_DefaultBinary), new(EnumValueType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EnumValueType_Encoding_DefaultXML), new(EnumValueType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EnumField_Encoding_DefaultBinary), new(EnumField))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EnumField_Encoding_DefaultXML), new(EnumField))
 	RegisterExtensionObject(NewNumericNodeID(0, id.OptionSet_Encoding_DefaultBinary), new(OptionSet))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.OptionSet_Encoding_DefaultXML), new(OptionSet))
 	RegisterExtensionObject(NewNumericNodeID(0, id.Union_Encoding_DefaultBinary), new(Union))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.Union_Encoding_DefaultXML), new(Union))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TimeZoneDataType_Encoding_DefaultBinary), new(TimeZoneDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TimeZoneDataType_Encoding_DefaultXML), new(TimeZoneDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ApplicationDescription_Encoding_DefaultBinary), new(ApplicationDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ApplicationDescription_Encoding_DefaultXML), new(ApplicationDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RequestHeader_Encoding_DefaultBinary), new(RequestHeader))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RequestHeader_Encoding_DefaultXML), new(RequestHeader))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ResponseHeader_Encoding_DefaultBinary), new(ResponseHeader))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ResponseHeader_Encoding_DefaultXML), new(ResponseHeader))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ServiceFault_Encoding_DefaultBinary), new(ServiceFault))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ServiceFault_Encoding_DefaultXML), new(ServiceFault))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SessionlessInvokeRequestType_Encoding_DefaultBinary), new(SessionlessInvokeRequestType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SessionlessInvokeRequestType_Encoding_DefaultXML), new(SessionlessInvokeRequestType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SessionlessInvokeResponseType_Encoding_DefaultBinary), new(SessionlessInvokeResponseType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SessionlessInvokeResponseType_Encoding_DefaultXML), new(SessionlessInvokeResponseType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FindServersRequest_Encoding_DefaultBinary), new(FindServersRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FindServersRequest_Encoding_DefaultXML), new(FindServersRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FindServersResponse_Encoding_DefaultBinary), new(FindServersResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FindServersResponse_Encoding_DefaultXML), new(FindServersResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ServerOnNetwork_Encoding_DefaultBinary), new(ServerOnNetwork))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ServerOnNetwork_Encoding_DefaultXML), new(ServerOnNetwork))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FindServersOnNetworkRequest_Encoding_DefaultBinary), new(FindServersOnNetworkRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FindServersOnNetworkRequest_Encoding_DefaultXML), new(FindServersOnNetworkRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FindServersOnNetworkResponse_Encoding_DefaultBinary), new(FindServersOnNetworkResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FindServersOnNetworkResponse_Encoding_DefaultXML), new(FindServersOnNetworkResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UserTokenPolicy_Encoding_DefaultBinary), new(UserTokenPolicy))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UserTokenPolicy_Encoding_DefaultXML), new(UserTokenPolicy))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EndpointDescription_Encoding_DefaultBinary), new(EndpointDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EndpointDescription_Encoding_DefaultXML), new(EndpointDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.GetEndpointsRequest_Encoding_DefaultBinary), new(GetEndpointsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.GetEndpointsRequest_Encoding_DefaultXML), new(GetEndpointsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.GetEndpointsResponse_Encoding_DefaultBinary), new(GetEndpointsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.GetEndpointsResponse_Encoding_DefaultXML), new(GetEndpointsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisteredServer_Encoding_DefaultBinary), new(RegisteredServer))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisteredServer_Encoding_DefaultXML), new(RegisteredServer))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisterServerRequest_Encoding_DefaultBinary), new(RegisterServerRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisterServerRequest_Encoding_DefaultXML), new(RegisterServerRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisterServerResponse_Encoding_DefaultBinary), new(RegisterServerResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisterServerResponse_Encoding_DefaultXML), new(RegisterServerResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DiscoveryConfiguration_Encoding_DefaultBinary), new(DiscoveryConfiguration))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DiscoveryConfiguration_Encoding_DefaultXML), new(DiscoveryConfiguration))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MdnsDiscoveryConfiguration_Encoding_DefaultBinary), new(MdnsDiscoveryConfiguration))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MdnsDiscoveryConfiguration_Encoding_DefaultXML), new(MdnsDiscoveryConfiguration))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisterServer2Request_Encoding_DefaultBinary), new(RegisterServer2Request))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisterServer2Request_Encoding_DefaultXML), new(RegisterServer2Request))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisterServer2Response_Encoding_DefaultBinary), new(RegisterServer2Response))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisterServer2Response_Encoding_DefaultXML), new(RegisterServer2Response))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ChannelSecurityToken_Encoding_DefaultBinary), new(ChannelSecurityToken))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ChannelSecurityToken_Encoding_DefaultXML), new(ChannelSecurityToken))
 	RegisterExtensionObject(NewNumericNodeID(0, id.OpenSecureChannelRequest_Encoding_DefaultBinary), new(OpenSecureChannelRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.OpenSecureChannelRequest_Encoding_DefaultXML), new(OpenSecureChannelRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.OpenSecureChannelResponse_Encoding_DefaultBinary), new(OpenSecureChannelResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.OpenSecureChannelResponse_Encoding_DefaultXML), new(OpenSecureChannelResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CloseSecureChannelRequest_Encoding_DefaultBinary), new(CloseSecureChannelRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CloseSecureChannelRequest_Encoding_DefaultXML), new(CloseSecureChannelRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CloseSecureChannelResponse_Encoding_DefaultBinary), new(CloseSecureChannelResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CloseSecureChannelResponse_Encoding_DefaultXML), new(CloseSecureChannelResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SignedSoftwareCertificate_Encoding_DefaultBinary), new(SignedSoftwareCertificate))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SignedSoftwareCertificate_Encoding_DefaultXML), new(SignedSoftwareCertificate))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SignatureData_Encoding_DefaultBinary), new(SignatureData))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SignatureData_Encoding_DefaultXML), new(SignatureData))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CreateSessionRequest_Encoding_DefaultBinary), new(CreateSessionRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CreateSessionRequest_Encoding_DefaultXML), new(CreateSessionRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CreateSessionResponse_Encoding_DefaultBinary), new(CreateSessionResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CreateSessionResponse_Encoding_DefaultXML), new(CreateSessionResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UserIdentityToken_Encoding_DefaultBinary), new(UserIdentityToken))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UserIdentityToken_Encoding_DefaultXML), new(UserIdentityToken))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AnonymousIdentityToken_Encoding_DefaultBinary), new(AnonymousIdentityToken))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AnonymousIdentityToken_Encoding_DefaultXML), new(AnonymousIdentityToken))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UserNameIdentityToken_Encoding_DefaultBinary), new(UserNameIdentityToken))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UserNameIdentityToken_Encoding_DefaultXML), new(UserNameIdentityToken))
 	RegisterExtensionObject(NewNumericNodeID(0, id.X509IdentityToken_Encoding_DefaultBinary), new(X509IdentityToken))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.X509IdentityToken_Encoding_DefaultXML), new(X509IdentityToken))
 	RegisterExtensionObject(NewNumericNodeID(0, id.IssuedIdentityToken_Encoding_DefaultBinary), new(IssuedIdentityToken))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.IssuedIdentityToken_Encoding_DefaultXML), new(IssuedIdentityToken))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ActivateSessionRequest_Encoding_DefaultBinary), new(ActivateSessionRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ActivateSessionRequest_Encoding_DefaultXML), new(ActivateSessionRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ActivateSessionResponse_Encoding_DefaultBinary), new(ActivateSessionResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ActivateSessionResponse_Encoding_DefaultXML), new(ActivateSessionResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CloseSessionRequest_Encoding_DefaultBinary), new(CloseSessionRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CloseSessionRequest_Encoding_DefaultXML), new(CloseSessionRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CloseSessionResponse_Encoding_DefaultBinary), new(CloseSessionResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CloseSessionResponse_Encoding_DefaultXML), new(CloseSessionResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CancelRequest_Encoding_DefaultBinary), new(CancelRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CancelRequest_Encoding_DefaultXML), new(CancelRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CancelResponse_Encoding_DefaultBinary), new(CancelResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CancelResponse_Encoding_DefaultXML), new(CancelResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NodeAttributes_Encoding_DefaultBinary), new(NodeAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NodeAttributes_Encoding_DefaultXML), new(NodeAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ObjectAttributes_Encoding_DefaultBinary), new(ObjectAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ObjectAttributes_Encoding_DefaultXML), new(ObjectAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.VariableAttributes_Encoding_DefaultBinary), new(VariableAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.VariableAttributes_Encoding_DefaultXML), new(VariableAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MethodAttributes_Encoding_DefaultBinary), new(MethodAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MethodAttributes_Encoding_DefaultXML), new(MethodAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ObjectTypeAttributes_Encoding_DefaultBinary), new(ObjectTypeAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ObjectTypeAttributes_Encoding_DefaultXML), new(ObjectTypeAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.VariableTypeAttributes_Encoding_DefaultBinary), new(VariableTypeAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.VariableTypeAttributes_Encoding_DefaultXML), new(VariableTypeAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReferenceTypeAttributes_Encoding_DefaultBinary), new(ReferenceTypeAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReferenceTypeAttributes_Encoding_DefaultXML), new(ReferenceTypeAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataTypeAttributes_Encoding_DefaultBinary), new(DataTypeAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataTypeAttributes_Encoding_DefaultXML), new(DataTypeAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ViewAttributes_Encoding_DefaultBinary), new(ViewAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ViewAttributes_Encoding_DefaultXML), new(ViewAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.GenericAttributeValue_Encoding_DefaultBinary), new(GenericAttributeValue))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.GenericAttributeValue_Encoding_DefaultXML), new(GenericAttributeValue))
 	RegisterExtensionObject(NewNumericNodeID(0, id.GenericAttributes_Encoding_DefaultBinary), new(GenericAttributes))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.GenericAttributes_Encoding_DefaultXML), new(GenericAttributes))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddNodesItem_Encoding_DefaultBinary), new(AddNodesItem))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddNodesItem_Encoding_DefaultXML), new(AddNodesItem))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddNodesResult_Encoding_DefaultBinary), new(AddNodesResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddNodesResult_Encoding_DefaultXML), new(AddNodesResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddNodesRequest_Encoding_DefaultBinary), new(AddNodesRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddNodesRequest_Encoding_DefaultXML), new(AddNodesRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddNodesResponse_Encoding_DefaultBinary), new(AddNodesResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddNodesResponse_Encoding_DefaultXML), new(AddNodesResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddReferencesItem_Encoding_DefaultBinary), new(AddReferencesItem))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddReferencesItem_Encoding_DefaultXML), new(AddReferencesItem))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddReferencesRequest_Encoding_DefaultBinary), new(AddReferencesRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddReferencesRequest_Encoding_DefaultXML), new(AddReferencesRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AddReferencesResponse_Encoding_DefaultBinary), new(AddReferencesResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AddReferencesResponse_Encoding_DefaultXML), new(AddReferencesResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteNodesItem_Encoding_DefaultBinary), new(DeleteNodesItem))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteNodesItem_Encoding_DefaultXML), new(DeleteNodesItem))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteNodesRequest_Encoding_DefaultBinary), new(DeleteNodesRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteNodesRequest_Encoding_DefaultXML), new(DeleteNodesRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteNodesResponse_Encoding_DefaultBinary), new(DeleteNodesResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteNodesResponse_Encoding_DefaultXML), new(DeleteNodesResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteReferencesItem_Encoding_DefaultBinary), new(DeleteReferencesItem))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteReferencesItem_Encoding_DefaultXML), new(DeleteReferencesItem))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteReferencesRequest_Encoding_DefaultBinary), new(DeleteReferencesRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteReferencesRequest_Encoding_DefaultXML), new(DeleteReferencesRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteReferencesResponse_Encoding_DefaultBinary), new(DeleteReferencesResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteReferencesResponse_Encoding_DefaultXML), new(DeleteReferencesResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ViewDescription_Encoding_DefaultBinary), new(ViewDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ViewDescription_Encoding_DefaultXML), new(ViewDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowseDescription_Encoding_DefaultBinary), new(BrowseDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowseDescription_Encoding_DefaultXML), new(BrowseDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReferenceDescription_Encoding_DefaultBinary), new(ReferenceDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReferenceDescription_Encoding_DefaultXML), new(ReferenceDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowseResult_Encoding_DefaultBinary), new(BrowseResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowseResult_Encoding_DefaultXML), new(BrowseResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowseRequest_Encoding_DefaultBinary), new(BrowseRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowseRequest_Encoding_DefaultXML), new(BrowseRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowseResponse_Encoding_DefaultBinary), new(BrowseResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowseResponse_Encoding_DefaultXML), new(BrowseResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowseNextRequest_Encoding_DefaultBinary), new(BrowseNextRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowseNextRequest_Encoding_DefaultXML), new(BrowseNextRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowseNextResponse_Encoding_DefaultBinary), new(BrowseNextResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowseNextResponse_Encoding_DefaultXML), new(BrowseNextResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RelativePathElement_Encoding_DefaultBinary), new(RelativePathElement))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RelativePathElement_Encoding_DefaultXML), new(RelativePathElement))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RelativePath_Encoding_DefaultBinary), new(RelativePath))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RelativePath_Encoding_DefaultXML), new(RelativePath))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowsePath_Encoding_DefaultBinary), new(BrowsePath))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowsePath_Encoding_DefaultXML), new(BrowsePath))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowsePathTarget_Encoding_DefaultBinary), new(BrowsePathTarget))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowsePathTarget_Encoding_DefaultXML), new(BrowsePathTarget))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BrowsePathResult_Encoding_DefaultBinary), new(BrowsePathResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BrowsePathResult_Encoding_DefaultXML), new(BrowsePathResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TranslateBrowsePathsToNodeIDsRequest_Encoding_DefaultBinary), new(TranslateBrowsePathsToNodeIDsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TranslateBrowsePathsToNodeIDsRequest_Encoding_DefaultXML), new(TranslateBrowsePathsToNodeIDsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TranslateBrowsePathsToNodeIDsResponse_Encoding_DefaultBinary), new(TranslateBrowsePathsToNodeIDsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TranslateBrowsePathsToNodeIDsResponse_Encoding_DefaultXML), new(TranslateBrowsePathsToNodeIDsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisterNodesRequest_Encoding_DefaultBinary), new(RegisterNodesRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisterNodesRequest_Encoding_DefaultXML), new(RegisterNodesRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RegisterNodesResponse_Encoding_DefaultBinary), new(RegisterNodesResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RegisterNodesResponse_Encoding_DefaultXML), new(RegisterNodesResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UnregisterNodesRequest_Encoding_DefaultBinary), new(UnregisterNodesRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UnregisterNodesRequest_Encoding_DefaultXML), new(UnregisterNodesRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UnregisterNodesResponse_Encoding_DefaultBinary), new(UnregisterNodesResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UnregisterNodesResponse_Encoding_DefaultXML), new(UnregisterNodesResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EndpointConfiguration_Encoding_DefaultBinary), new(EndpointConfiguration))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EndpointConfiguration_Encoding_DefaultXML), new(EndpointConfiguration))
 	RegisterExtensionObject(NewNumericNodeID(0, id.QueryDataDescription_Encoding_DefaultBinary), new(QueryDataDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.QueryDataDescription_Encoding_DefaultXML), new(QueryDataDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NodeTypeDescription_Encoding_DefaultBinary), new(NodeTypeDescription))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NodeTypeDescription_Encoding_DefaultXML), new(NodeTypeDescription))
 	RegisterExtensionObject(NewNumericNodeID(0, id.QueryDataSet_Encoding_DefaultBinary), new(QueryDataSet))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.QueryDataSet_Encoding_DefaultXML), new(QueryDataSet))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NodeReference_Encoding_DefaultBinary), new(NodeReference))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NodeReference_Encoding_DefaultXML), new(NodeReference))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ContentFilterElement_Encoding_DefaultBinary), new(ContentFilterElement))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ContentFilterElement_Encoding_DefaultXML), new(ContentFilterElement))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ContentFilter_Encoding_DefaultBinary), new(ContentFilter))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ContentFilter_Encoding_DefaultXML), new(ContentFilter))
 	RegisterExtensionObject(NewNumericNodeID(0, id.FilterOperand_Encoding_DefaultBinary), new(FilterOperand))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.FilterOperand_Encoding_DefaultXML), new(FilterOperand))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ElementOperand_Encoding_DefaultBinary), new(ElementOperand))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ElementOperand_Encoding_DefaultXML), new(ElementOperand))
 	RegisterExtensionObject(NewNumericNodeID(0, id.LiteralOperand_Encoding_DefaultBinary), new(LiteralOperand))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.LiteralOperand_Encoding_DefaultXML), new(LiteralOperand))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AttributeOperand_Encoding_DefaultBinary), new(AttributeOperand))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AttributeOperand_Encoding_DefaultXML), new(AttributeOperand))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SimpleAttributeOperand_Encoding_DefaultBinary), new(SimpleAttributeOperand))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SimpleAttributeOperand_Encoding_DefaultXML), new(SimpleAttributeOperand))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ContentFilterElementResult_Encoding_DefaultBinary), new(ContentFilterElementResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ContentFilterElementResult_Encoding_DefaultXML), new(ContentFilterElementResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ContentFilterResult_Encoding_DefaultBinary), new(ContentFilterResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ContentFilterResult_Encoding_DefaultXML), new(ContentFilterResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ParsingResult_Encoding_DefaultBinary), new(ParsingResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ParsingResult_Encoding_DefaultXML), new(ParsingResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.QueryFirstRequest_Encoding_DefaultBinary), new(QueryFirstRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.QueryFirstRequest_Encoding_DefaultXML), new(QueryFirstRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.QueryFirstResponse_Encoding_DefaultBinary), new(QueryFirstResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.QueryFirstResponse_Encoding_DefaultXML), new(QueryFirstResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.QueryNextRequest_Encoding_DefaultBinary), new(QueryNextRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.QueryNextRequest_Encoding_DefaultXML), new(QueryNextRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.QueryNextResponse_Encoding_DefaultBinary), new(QueryNextResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.QueryNextResponse_Encoding_DefaultXML), new(QueryNextResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadValueID_Encoding_DefaultBinary), new(ReadValueID))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadValueID_Encoding_DefaultXML), new(ReadValueID))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadRequest_Encoding_DefaultBinary), new(ReadRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadRequest_Encoding_DefaultXML), new(ReadRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadResponse_Encoding_DefaultBinary), new(ReadResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadResponse_Encoding_DefaultXML), new(ReadResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryReadValueID_Encoding_DefaultBinary), new(HistoryReadValueID))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryReadValueID_Encoding_DefaultXML), new(HistoryReadValueID))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryReadResult_Encoding_DefaultBinary), new(HistoryReadResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryReadResult_Encoding_DefaultXML), new(HistoryReadResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryReadDetails_Encoding_DefaultBinary), new(HistoryReadDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryReadDetails_Encoding_DefaultXML), new(HistoryReadDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadEventDetails_Encoding_DefaultBinary), new(ReadEventDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadEventDetails_Encoding_DefaultXML), new(ReadEventDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadRawModifiedDetails_Encoding_DefaultBinary), new(ReadRawModifiedDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadRawModifiedDetails_Encoding_DefaultXML), new(ReadRawModifiedDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadProcessedDetails_Encoding_DefaultBinary), new(ReadProcessedDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadProcessedDetails_Encoding_DefaultXML), new(ReadProcessedDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ReadAtTimeDetails_Encoding_DefaultBinary), new(ReadAtTimeDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ReadAtTimeDetails_Encoding_DefaultXML), new(ReadAtTimeDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryData_Encoding_DefaultBinary), new(HistoryData))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryData_Encoding_DefaultXML), new(HistoryData))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ModificationInfo_Encoding_DefaultBinary), new(ModificationInfo))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ModificationInfo_Encoding_DefaultXML), new(ModificationInfo))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryModifiedData_Encoding_DefaultBinary), new(HistoryModifiedData))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryModifiedData_Encoding_DefaultXML), new(HistoryModifiedData))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryEvent_Encoding_DefaultBinary), new(HistoryEvent))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryEvent_Encoding_DefaultXML), new(HistoryEvent))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryReadRequest_Encoding_DefaultBinary), new(HistoryReadRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryReadRequest_Encoding_DefaultXML), new(HistoryReadRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryReadResponse_Encoding_DefaultBinary), new(HistoryReadResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryReadResponse_Encoding_DefaultXML), new(HistoryReadResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.WriteValue_Encoding_DefaultBinary), new(WriteValue))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.WriteValue_Encoding_DefaultXML), new(WriteValue))
 	RegisterExtensionObject(NewNumericNodeID(0, id.WriteRequest_Encoding_DefaultBinary), new(WriteRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.WriteRequest_Encoding_DefaultXML), new(WriteRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.WriteResponse_Encoding_DefaultBinary), new(WriteResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.WriteResponse_Encoding_DefaultXML), new(WriteResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryUpdateDetails_Encoding_DefaultBinary), new(HistoryUpdateDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryUpdateDetails_Encoding_DefaultXML), new(HistoryUpdateDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UpdateDataDetails_Encoding_DefaultBinary), new(UpdateDataDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UpdateDataDetails_Encoding_DefaultXML), new(UpdateDataDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UpdateStructureDataDetails_Encoding_DefaultBinary), new(UpdateStructureDataDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UpdateStructureDataDetails_Encoding_DefaultXML), new(UpdateStructureDataDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.UpdateEventDetails_Encoding_DefaultBinary), new(UpdateEventDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.UpdateEventDetails_Encoding_DefaultXML), new(UpdateEventDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteRawModifiedDetails_Encoding_DefaultBinary), new(DeleteRawModifiedDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteRawModifiedDetails_Encoding_DefaultXML), new(DeleteRawModifiedDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteAtTimeDetails_Encoding_DefaultBinary), new(DeleteAtTimeDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteAtTimeDetails_Encoding_DefaultXML), new(DeleteAtTimeDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteEventDetails_Encoding_DefaultBinary), new(DeleteEventDetails))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteEventDetails_Encoding_DefaultXML), new(DeleteEventDetails))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryUpdateResult_Encoding_DefaultBinary), new(HistoryUpdateResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryUpdateResult_Encoding_DefaultXML), new(HistoryUpdateResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryUpdateRequest_Encoding_DefaultBinary), new(HistoryUpdateRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryUpdateRequest_Encoding_DefaultXML), new(HistoryUpdateRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryUpdateResponse_Encoding_DefaultBinary), new(HistoryUpdateResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryUpdateResponse_Encoding_DefaultXML), new(HistoryUpdateResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CallMethodRequest_Encoding_DefaultBinary), new(CallMethodRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CallMethodRequest_Encoding_DefaultXML), new(CallMethodRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CallMethodResult_Encoding_DefaultBinary), new(CallMethodResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CallMethodResult_Encoding_DefaultXML), new(CallMethodResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CallRequest_Encoding_DefaultBinary), new(CallRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CallRequest_Encoding_DefaultXML), new(CallRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CallResponse_Encoding_DefaultBinary), new(CallResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CallResponse_Encoding_DefaultXML), new(CallResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoringFilter_Encoding_DefaultBinary), new(MonitoringFilter))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoringFilter_Encoding_DefaultXML), new(MonitoringFilter))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataChangeFilter_Encoding_DefaultBinary), new(DataChangeFilter))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataChangeFilter_Encoding_DefaultXML), new(DataChangeFilter))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EventFilter_Encoding_DefaultBinary), new(EventFilter))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EventFilter_Encoding_DefaultXML), new(EventFilter))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AggregateConfiguration_Encoding_DefaultBinary), new(AggregateConfiguration))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AggregateConfiguration_Encoding_DefaultXML), new(AggregateConfiguration))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AggregateFilter_Encoding_DefaultBinary), new(AggregateFilter))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AggregateFilter_Encoding_DefaultXML), new(AggregateFilter))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoringFilterResult_Encoding_DefaultBinary), new(MonitoringFilterResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoringFilterResult_Encoding_DefaultXML), new(MonitoringFilterResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EventFilterResult_Encoding_DefaultBinary), new(EventFilterResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EventFilterResult_Encoding_DefaultXML), new(EventFilterResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AggregateFilterResult_Encoding_DefaultBinary), new(AggregateFilterResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AggregateFilterResult_Encoding_DefaultXML), new(AggregateFilterResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoringParameters_Encoding_DefaultBinary), new(MonitoringParameters))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoringParameters_Encoding_DefaultXML), new(MonitoringParameters))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoredItemCreateRequest_Encoding_DefaultBinary), new(MonitoredItemCreateRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoredItemCreateRequest_Encoding_DefaultXML), new(MonitoredItemCreateRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoredItemCreateResult_Encoding_DefaultBinary), new(MonitoredItemCreateResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoredItemCreateResult_Encoding_DefaultXML), new(MonitoredItemCreateResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CreateMonitoredItemsRequest_Encoding_DefaultBinary), new(CreateMonitoredItemsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CreateMonitoredItemsRequest_Encoding_DefaultXML), new(CreateMonitoredItemsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CreateMonitoredItemsResponse_Encoding_DefaultBinary), new(CreateMonitoredItemsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CreateMonitoredItemsResponse_Encoding_DefaultXML), new(CreateMonitoredItemsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoredItemModifyRequest_Encoding_DefaultBinary), new(MonitoredItemModifyRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoredItemModifyRequest_Encoding_DefaultXML), new(MonitoredItemModifyRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoredItemModifyResult_Encoding_DefaultBinary), new(MonitoredItemModifyResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoredItemModifyResult_Encoding_DefaultXML), new(MonitoredItemModifyResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ModifyMonitoredItemsRequest_Encoding_DefaultBinary), new(ModifyMonitoredItemsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ModifyMonitoredItemsRequest_Encoding_DefaultXML), new(ModifyMonitoredItemsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ModifyMonitoredItemsResponse_Encoding_DefaultBinary), new(ModifyMonitoredItemsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ModifyMonitoredItemsResponse_Encoding_DefaultXML), new(ModifyMonitoredItemsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SetMonitoringModeRequest_Encoding_DefaultBinary), new(SetMonitoringModeRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SetMonitoringModeRequest_Encoding_DefaultXML), new(SetMonitoringModeRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SetMonitoringModeResponse_Encoding_DefaultBinary), new(SetMonitoringModeResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SetMonitoringModeResponse_Encoding_DefaultXML), new(SetMonitoringModeResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SetTriggeringRequest_Encoding_DefaultBinary), new(SetTriggeringRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SetTriggeringRequest_Encoding_DefaultXML), new(SetTriggeringRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SetTriggeringResponse_Encoding_DefaultBinary), new(SetTriggeringResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SetTriggeringResponse_Encoding_DefaultXML), new(SetTriggeringResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteMonitoredItemsRequest_Encoding_DefaultBinary), new(DeleteMonitoredItemsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteMonitoredItemsRequest_Encoding_DefaultXML), new(DeleteMonitoredItemsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteMonitoredItemsResponse_Encoding_DefaultBinary), new(DeleteMonitoredItemsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteMonitoredItemsResponse_Encoding_DefaultXML), new(DeleteMonitoredItemsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CreateSubscriptionRequest_Encoding_DefaultBinary), new(CreateSubscriptionRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CreateSubscriptionRequest_Encoding_DefaultXML), new(CreateSubscriptionRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.CreateSubscriptionResponse_Encoding_DefaultBinary), new(CreateSubscriptionResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.CreateSubscriptionResponse_Encoding_DefaultXML), new(CreateSubscriptionResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ModifySubscriptionRequest_Encoding_DefaultBinary), new(ModifySubscriptionRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ModifySubscriptionRequest_Encoding_DefaultXML), new(ModifySubscriptionRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ModifySubscriptionResponse_Encoding_DefaultBinary), new(ModifySubscriptionResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ModifySubscriptionResponse_Encoding_DefaultXML), new(ModifySubscriptionResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SetPublishingModeRequest_Encoding_DefaultBinary), new(SetPublishingModeRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SetPublishingModeRequest_Encoding_DefaultXML), new(SetPublishingModeRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SetPublishingModeResponse_Encoding_DefaultBinary), new(SetPublishingModeResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SetPublishingModeResponse_Encoding_DefaultXML), new(SetPublishingModeResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NotificationMessage_Encoding_DefaultBinary), new(NotificationMessage))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NotificationMessage_Encoding_DefaultXML), new(NotificationMessage))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NotificationData_Encoding_DefaultBinary), new(NotificationData))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NotificationData_Encoding_DefaultXML), new(NotificationData))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DataChangeNotification_Encoding_DefaultBinary), new(DataChangeNotification))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DataChangeNotification_Encoding_DefaultXML), new(DataChangeNotification))
 	RegisterExtensionObject(NewNumericNodeID(0, id.MonitoredItemNotification_Encoding_DefaultBinary), new(MonitoredItemNotification))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.MonitoredItemNotification_Encoding_DefaultXML), new(MonitoredItemNotification))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EventNotificationList_Encoding_DefaultBinary), new(EventNotificationList))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EventNotificationList_Encoding_DefaultXML), new(EventNotificationList))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EventFieldList_Encoding_DefaultBinary), new(EventFieldList))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EventFieldList_Encoding_DefaultXML), new(EventFieldList))
 	RegisterExtensionObject(NewNumericNodeID(0, id.HistoryEventFieldList_Encoding_DefaultBinary), new(HistoryEventFieldList))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.HistoryEventFieldList_Encoding_DefaultXML), new(HistoryEventFieldList))
 	RegisterExtensionObject(NewNumericNodeID(0, id.StatusChangeNotification_Encoding_DefaultBinary), new(StatusChangeNotification))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.StatusChangeNotification_Encoding_DefaultXML), new(StatusChangeNotification))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SubscriptionAcknowledgement_Encoding_DefaultBinary), new(SubscriptionAcknowledgement))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SubscriptionAcknowledgement_Encoding_DefaultXML), new(SubscriptionAcknowledgement))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishRequest_Encoding_DefaultBinary), new(PublishRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishRequest_Encoding_DefaultXML), new(PublishRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.PublishResponse_Encoding_DefaultBinary), new(PublishResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.PublishResponse_Encoding_DefaultXML), new(PublishResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RepublishRequest_Encoding_DefaultBinary), new(RepublishRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RepublishRequest_Encoding_DefaultXML), new(RepublishRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RepublishResponse_Encoding_DefaultBinary), new(RepublishResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RepublishResponse_Encoding_DefaultXML), new(RepublishResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TransferResult_Encoding_DefaultBinary), new(TransferResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TransferResult_Encoding_DefaultXML), new(TransferResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TransferSubscriptionsRequest_Encoding_DefaultBinary), new(TransferSubscriptionsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TransferSubscriptionsRequest_Encoding_DefaultXML), new(TransferSubscriptionsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.TransferSubscriptionsResponse_Encoding_DefaultBinary), new(TransferSubscriptionsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.TransferSubscriptionsResponse_Encoding_DefaultXML), new(TransferSubscriptionsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteSubscriptionsRequest_Encoding_DefaultBinary), new(DeleteSubscriptionsRequest))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteSubscriptionsRequest_Encoding_DefaultXML), new(DeleteSubscriptionsRequest))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DeleteSubscriptionsResponse_Encoding_DefaultBinary), new(DeleteSubscriptionsResponse))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DeleteSubscriptionsResponse_Encoding_DefaultXML), new(DeleteSubscriptionsResponse))
 	RegisterExtensionObject(NewNumericNodeID(0, id.BuildInfo_Encoding_DefaultBinary), new(BuildInfo))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.BuildInfo_Encoding_DefaultXML), new(BuildInfo))
 	RegisterExtensionObject(NewNumericNodeID(0, id.RedundantServerDataType_Encoding_DefaultBinary), new(RedundantServerDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.RedundantServerDataType_Encoding_DefaultXML), new(RedundantServerDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EndpointURLListDataType_Encoding_DefaultBinary), new(EndpointURLListDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EndpointURLListDataType_Encoding_DefaultXML), new(EndpointURLListDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.NetworkGroupDataType_Encoding_DefaultBinary), new(NetworkGroupDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.NetworkGroupDataType_Encoding_DefaultXML), new(NetworkGroupDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SamplingIntervalDiagnosticsDataType_Encoding_DefaultBinary), new(SamplingIntervalDiagnosticsDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SamplingIntervalDiagnosticsDataType_Encoding_DefaultXML), new(SamplingIntervalDiagnosticsDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ServerDiagnosticsSummaryDataType_Encoding_DefaultBinary), new(ServerDiagnosticsSummaryDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ServerDiagnosticsSummaryDataType_Encoding_DefaultXML), new(ServerDiagnosticsSummaryDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ServerStatusDataType_Encoding_DefaultBinary), new(ServerStatusDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ServerStatusDataType_Encoding_DefaultXML), new(ServerStatusDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SessionDiagnosticsDataType_Encoding_DefaultBinary), new(SessionDiagnosticsDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SessionDiagnosticsDataType_Encoding_DefaultXML), new(SessionDiagnosticsDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SessionSecurityDiagnosticsDataType_Encoding_DefaultBinary), new(SessionSecurityDiagnosticsDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SessionSecurityDiagnosticsDataType_Encoding_DefaultXML), new(SessionSecurityDiagnosticsDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ServiceCounterDataType_Encoding_DefaultBinary), new(ServiceCounterDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ServiceCounterDataType_Encoding_DefaultXML), new(ServiceCounterDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.StatusResult_Encoding_DefaultBinary), new(StatusResult))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.StatusResult_Encoding_DefaultXML), new(StatusResult))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SubscriptionDiagnosticsDataType_Encoding_DefaultBinary), new(SubscriptionDiagnosticsDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SubscriptionDiagnosticsDataType_Encoding_DefaultXML), new(SubscriptionDiagnosticsDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ModelChangeStructureDataType_Encoding_DefaultBinary), new(ModelChangeStructureDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ModelChangeStructureDataType_Encoding_DefaultXML), new(ModelChangeStructureDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.SemanticChangeStructureDataType_Encoding_DefaultBinary), new(SemanticChangeStructureDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.SemanticChangeStructureDataType_Encoding_DefaultXML), new(SemanticChangeStructureDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.Range_Encoding_DefaultBinary), new(Range))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.Range_Encoding_DefaultXML), new(Range))
 	RegisterExtensionObject(NewNumericNodeID(0, id.EUInformation_Encoding_DefaultBinary), new(EUInformation))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.EUInformation_Encoding_DefaultXML), new(EUInformation))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ComplexNumberType_Encoding_DefaultBinary), new(ComplexNumberType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ComplexNumberType_Encoding_DefaultXML), new(ComplexNumberType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.DoubleComplexNumberType_Encoding_DefaultBinary), new(DoubleComplexNumberType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.DoubleComplexNumberType_Encoding_DefaultXML), new(DoubleComplexNumberType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.AxisInformation_Encoding_DefaultBinary), new(AxisInformation))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.AxisInformation_Encoding_DefaultXML), new(AxisInformation))
 	RegisterExtensionObject(NewNumericNodeID(0, id.XVType_Encoding_DefaultBinary), new(XVType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.XVType_Encoding_DefaultXML), new(XVType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ProgramDiagnosticDataType_Encoding_DefaultBinary), new(ProgramDiagnosticDataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ProgramDiagnosticDataType_Encoding_DefaultXML), new(ProgramDiagnosticDataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.ProgramDiagnostic2DataType_Encoding_DefaultBinary), new(ProgramDiagnostic2DataType))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.ProgramDiagnostic2DataType_Encoding_DefaultXML), new(ProgramDiagnostic2DataType))
 	RegisterExtensionObject(NewNumericNodeID(0, id.Annotation_Encoding_DefaultBinary), new(Annotation))
+	RegisterExtensionObjectXML(NewNumericNodeID(0, id.Annotation_Encoding_DefaultXML), new(Annotation))
 }
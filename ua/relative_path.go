@@ -0,0 +1,249 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+)
+
+// ParseRelativePath parses a relative path in the text format, e.g.
+// "/2:Block&.Output" or "<!HasChild>1:Truck". The reference types must
+// be defined in namespace 0. Use ParseRelativePathFunc for reference
+// types of other namespaces.
+//
+// Specification: Part 4, A.2
+func ParseRelativePath(s string) (*RelativePath, error) {
+	return ParseRelativePathFunc(s, nil)
+}
+
+// ParseRelativePathFunc parses a relative path like ParseRelativePath
+// and calls lookup for the names of the reference types which are not
+// defined in namespace 0. lookup can be nil.
+//
+// The elements of the path are
+//
+//	/name           follows hierarchical references and their subtypes
+//	.name           follows aggregates references and their subtypes
+//	<refType>name   follows refType references and their subtypes
+//	<#refType>name  follows refType references without subtypes
+//	<!refType>name  follows inverse refType references
+//
+// where name is a browse name with an optional namespace index prefix,
+// e.g. "2:Block". The name of the last element can be empty to match all
+// targets. The reserved characters '/', '.', '<', '>', ':', '#', '!' and
+// '&' in names are escaped with '&'.
+//
+// Specification: Part 4, A.2
+func ParseRelativePathFunc(s string, lookup func(name *QualifiedName) (*NodeID, error)) (*RelativePath, error) {
+	p := &relativePathParser{s: s, lookup: lookup}
+	path := &RelativePath{}
+	for p.pos < len(s) {
+		el, err := p.element()
+		if err != nil {
+			return nil, err
+		}
+		path.Elements = append(path.Elements, el)
+	}
+	if len(path.Elements) == 0 {
+		return nil, p.errorf("no elements")
+	}
+	for _, el := range path.Elements[:len(path.Elements)-1] {
+		if el.TargetName.Name == "" {
+			return nil, p.errorf("missing browse name")
+		}
+	}
+	return path, nil
+}
+
+type relativePathParser struct {
+	s      string
+	pos    int
+	lookup func(name *QualifiedName) (*NodeID, error)
+}
+
+func (p *relativePathParser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("invalid relative path %q: "+format, append([]interface{}{p.s}, args...)...)
+}
+
+// element parses the reference type and the target name of an element.
+func (p *relativePathParser) element() (*RelativePathElement, error) {
+	el := &RelativePathElement{IncludeSubtypes: true}
+	switch c := p.s[p.pos]; c {
+	case '/':
+		p.pos++
+		el.ReferenceTypeID = NewNumericNodeID(0, id.HierarchicalReferences)
+	case '.':
+		p.pos++
+		el.ReferenceTypeID = NewNumericNodeID(0, id.Aggregates)
+	case '<':
+		p.pos++
+		if err := p.referenceType(el); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf("unexpected %q at %d", c, p.pos)
+	}
+
+	name, err := p.name("/.<")
+	if err != nil {
+		return nil, err
+	}
+	el.TargetName = name
+	return el, nil
+}
+
+// referenceType parses the part of a "<...>" element after the '<'.
+func (p *relativePathParser) referenceType(el *RelativePathElement) error {
+flags:
+	for ; p.pos < len(p.s); p.pos++ {
+		switch p.s[p.pos] {
+		case '#':
+			el.IncludeSubtypes = false
+		case '!':
+			el.IsInverse = true
+		default:
+			break flags
+		}
+	}
+
+	qn, err := p.name(">")
+	if err != nil {
+		return err
+	}
+	if p.pos == len(p.s) || p.s[p.pos] != '>' {
+		return p.errorf("missing '>' at %d", p.pos)
+	}
+	p.pos++
+	if qn.Name == "" {
+		return p.errorf("missing reference type at %d", p.pos)
+	}
+
+	if qn.NamespaceIndex == 0 {
+		if v := id.ID(qn.Name); v != 0 && id.IsReferenceType(v) {
+			el.ReferenceTypeID = NewNumericNodeID(0, v)
+			return nil
+		}
+	}
+	if p.lookup == nil {
+		return p.errorf("unknown reference type %s", formatQualifiedName(qn))
+	}
+	refType, err := p.lookup(qn)
+	if err != nil {
+		return p.errorf("reference type %s: %s", formatQualifiedName(qn), err)
+	}
+	el.ReferenceTypeID = refType
+	return nil
+}
+
+// name parses a qualified name up to the next unescaped character of
+// stop or the end of the string.
+func (p *relativePathParser) name(stop string) (*QualifiedName, error) {
+	var (
+		b     strings.Builder
+		ns    uint16
+		hasNS bool
+	)
+	for ; p.pos < len(p.s); p.pos++ {
+		c := p.s[p.pos]
+		switch {
+		case c == '&':
+			p.pos++
+			if p.pos == len(p.s) {
+				return nil, p.errorf("incomplete escape sequence at %d", p.pos)
+			}
+			b.WriteByte(p.s[p.pos])
+		case strings.IndexByte(stop, c) >= 0:
+			return &QualifiedName{NamespaceIndex: ns, Name: b.String()}, nil
+		case c == ':' && !hasNS:
+			n, err := strconv.ParseUint(b.String(), 10, 16)
+			if err != nil {
+				return nil, p.errorf("invalid namespace index %q at %d", b.String(), p.pos)
+			}
+			ns, hasNS = uint16(n), true
+			b.Reset()
+		case strings.IndexByte("/.<>:#!", c) >= 0:
+			return nil, p.errorf("unescaped %q at %d", c, p.pos)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return &QualifiedName{NamespaceIndex: ns, Name: b.String()}, nil
+}
+
+// FormatRelativePath returns the text format of the relative path. The
+// reference types other than HierarchicalReferences and Aggregates are
+// written with their names in namespace 0 or with their node ids.
+//
+// Specification: Part 4, A.2
+func FormatRelativePath(path *RelativePath) string {
+	var b strings.Builder
+	for _, el := range path.Elements {
+		refType := el.ReferenceTypeID
+		switch {
+		case isNumericNodeID(refType, 0, id.HierarchicalReferences) && el.IncludeSubtypes && !el.IsInverse:
+			b.WriteByte('/')
+		case isNumericNodeID(refType, 0, id.Aggregates) && el.IncludeSubtypes && !el.IsInverse:
+			b.WriteByte('.')
+		default:
+			b.WriteByte('<')
+			if !el.IncludeSubtypes {
+				b.WriteByte('#')
+			}
+			if el.IsInverse {
+				b.WriteByte('!')
+			}
+			name := ""
+			if refType != nil && refType.Namespace() == 0 && refType.Type() != NodeIDTypeString {
+				name = id.Name(refType.IntID())
+			}
+			if name == "" && refType != nil {
+				name = refType.String()
+			}
+			b.WriteString(escapeRelativePathName(name))
+			b.WriteByte('>')
+		}
+		if el.TargetName != nil {
+			b.WriteString(formatQualifiedName(el.TargetName))
+		}
+	}
+	return b.String()
+}
+
+func isNumericNodeID(n *NodeID, ns uint16, v uint32) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case NodeIDTypeTwoByte, NodeIDTypeFourByte, NodeIDTypeNumeric:
+		return n.Namespace() == ns && n.IntID() == v
+	default:
+		return false
+	}
+}
+
+// formatQualifiedName returns the escaped name with the namespace index
+// prefix if it is not zero.
+func formatQualifiedName(qn *QualifiedName) string {
+	name := escapeRelativePathName(qn.Name)
+	if qn.NamespaceIndex == 0 {
+		return name
+	}
+	return strconv.Itoa(int(qn.NamespaceIndex)) + ":" + name
+}
+
+func escapeRelativePathName(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte("/.<>:#!&", s[i]) >= 0 {
+			b.WriteByte('&')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
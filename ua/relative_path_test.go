@@ -0,0 +1,114 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"testing"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestParseRelativePath(t *testing.T) {
+	hierarchical := NewNumericNodeID(0, id.HierarchicalReferences)
+	aggregates := NewNumericNodeID(0, id.Aggregates)
+	custom := NewNumericNodeID(2, 4001)
+	lookup := func(qn *QualifiedName) (*NodeID, error) {
+		if *qn == (QualifiedName{NamespaceIndex: 2, Name: "FlowTo"}) {
+			return custom, nil
+		}
+		return nil, StatusBadNoMatch
+	}
+
+	tests := []struct {
+		s      string
+		path   *RelativePath
+		format string
+		err    bool
+	}{
+		{
+			s: "/2:Block&.Output",
+			path: &RelativePath{Elements: []*RelativePathElement{
+				{ReferenceTypeID: hierarchical, IncludeSubtypes: true, TargetName: &QualifiedName{NamespaceIndex: 2, Name: "Block.Output"}},
+			}},
+		},
+		{
+			s: "/2:Truck.0:NodeVersion",
+			path: &RelativePath{Elements: []*RelativePathElement{
+				{ReferenceTypeID: hierarchical, IncludeSubtypes: true, TargetName: &QualifiedName{NamespaceIndex: 2, Name: "Truck"}},
+				{ReferenceTypeID: aggregates, IncludeSubtypes: true, TargetName: &QualifiedName{Name: "NodeVersion"}},
+			}},
+			format: "/2:Truck.NodeVersion",
+		},
+		{
+			s:   "<1:ConnectedTo>1:Boiler/1:HeatSensor",
+			err: true,
+		},
+		{
+			s:   "<Server>Boiler",
+			err: true,
+		},
+		{
+			s: "<2:FlowTo>1:Boiler/",
+			path: &RelativePath{Elements: []*RelativePathElement{
+				{ReferenceTypeID: custom, IncludeSubtypes: true, TargetName: &QualifiedName{NamespaceIndex: 1, Name: "Boiler"}},
+				{ReferenceTypeID: hierarchical, IncludeSubtypes: true, TargetName: &QualifiedName{}},
+			}},
+			format: "<ns=2;i=4001>1:Boiler/",
+		},
+		{
+			s: "<#HasComponent>Speed<!HasChild>Truck",
+			path: &RelativePath{Elements: []*RelativePathElement{
+				{ReferenceTypeID: NewNumericNodeID(0, id.HasComponent), TargetName: &QualifiedName{Name: "Speed"}},
+				{ReferenceTypeID: NewNumericNodeID(0, id.HasChild), IsInverse: true, IncludeSubtypes: true, TargetName: &QualifiedName{Name: "Truck"}},
+			}},
+		},
+		{
+			s: "<!#HasChild>A&&B&:C&#&!&<&>",
+			path: &RelativePath{Elements: []*RelativePathElement{
+				{ReferenceTypeID: NewNumericNodeID(0, id.HasChild), IsInverse: true, TargetName: &QualifiedName{Name: "A&B:C#!<>"}},
+			}},
+			format: "<#!HasChild>A&&B&:C&#&!&<&>",
+		},
+		{s: "", err: true},
+		{s: "Truck", err: true},
+		{s: "//Truck", err: true},
+		{s: "/x:Truck", err: true},
+		{s: "/Tr#uck", err: true},
+		{s: "/Truck&", err: true},
+		{s: "<HasChild", err: true},
+		{s: "<>Truck", err: true},
+		{s: "<NoSuchReference>Truck", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			path, err := ParseRelativePathFunc(tt.s, lookup)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("got %v want error", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			verify.Values(t, "path", path, tt.path)
+
+			format := tt.format
+			if format == "" {
+				format = tt.s
+			}
+			verify.Values(t, "format", FormatRelativePath(path), format)
+		})
+	}
+}
+
+func TestParseRelativePathLookupError(t *testing.T) {
+	_, err := ParseRelativePath("<2:FlowTo>Boiler")
+	if err == nil || !errors.Equal(err, errors.Errorf(`invalid relative path "<2:FlowTo>Boiler": unknown reference type 2:FlowTo`)) {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -0,0 +1,899 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imatic-tech/opcua/errors"
+)
+
+// XMLNamespace is the namespace of the built-in types in the XML encoding.
+const XMLNamespace = "http://opcfoundation.org/UA/2008/02/Types.xsd"
+
+var xmlElementType = reflect.TypeOf(XMLElement(""))
+
+// xmlTypeNames contains the element names of the built-in types.
+//
+// Specification: Part 6, 5.3.1
+var xmlTypeNames = map[TypeID]string{
+	TypeIDBoolean:         "Boolean",
+	TypeIDSByte:           "SByte",
+	TypeIDByte:            "Byte",
+	TypeIDInt16:           "Int16",
+	TypeIDUint16:          "UInt16",
+	TypeIDInt32:           "Int32",
+	TypeIDUint32:          "UInt32",
+	TypeIDInt64:           "Int64",
+	TypeIDUint64:          "UInt64",
+	TypeIDFloat:           "Float",
+	TypeIDDouble:          "Double",
+	TypeIDString:          "String",
+	TypeIDDateTime:        "DateTime",
+	TypeIDGUID:            "Guid",
+	TypeIDByteString:      "ByteString",
+	TypeIDXMLElement:      "XmlElement",
+	TypeIDNodeID:          "NodeId",
+	TypeIDExpandedNodeID:  "ExpandedNodeId",
+	TypeIDStatusCode:      "StatusCode",
+	TypeIDQualifiedName:   "QualifiedName",
+	TypeIDLocalizedText:   "LocalizedText",
+	TypeIDExtensionObject: "ExtensionObject",
+	TypeIDDataValue:       "DataValue",
+	TypeIDVariant:         "Variant",
+	TypeIDDiagnosticInfo:  "DiagnosticInfo",
+}
+
+var xmlTypeIDs = map[string]TypeID{}
+
+func init() {
+	for id, name := range xmlTypeNames {
+		xmlTypeIDs[name] = id
+	}
+}
+
+// xmlTypeName returns the element name for values of the type.
+func xmlTypeName(typ reflect.Type) string {
+	if id, ok := variantTypeToTypeID[typ]; ok {
+		return xmlTypeNames[id]
+	}
+	if typ.Kind() == reflect.Ptr {
+		return xmlTypeName(typ.Elem())
+	}
+	if id, ok := variantTypeToTypeID[reflect.PtrTo(typ)]; ok {
+		return xmlTypeNames[id]
+	}
+	return specName(typ.Name())
+}
+
+// EncodeXML returns the XML encoding of v. The root element is named
+// after the type of v.
+//
+// Specification: Part 6, 5.3
+func EncodeXML(v interface{}) ([]byte, error) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return nil, errors.Errorf("cannot encode nil value")
+	}
+	e := &xmlEncoder{}
+	tag := xmlTypeName(val.Type())
+	e.buf.WriteString("<" + tag + ` xmlns="` + XMLNamespace + `">`)
+	if err := e.encode(val, val.Type().String()); err != nil {
+		return nil, err
+	}
+	e.end(tag)
+	return e.buf.Bytes(), nil
+}
+
+type xmlEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *xmlEncoder) start(tag string) {
+	e.buf.WriteString("<" + tag + ">")
+}
+
+func (e *xmlEncoder) end(tag string) {
+	e.buf.WriteString("</" + tag + ">")
+}
+
+func (e *xmlEncoder) text(s string) {
+	xml.EscapeText(&e.buf, []byte(s))
+}
+
+// element writes the value as element with the tag name.
+func (e *xmlEncoder) element(tag string, val reflect.Value, name string) error {
+	e.start(tag)
+	if err := e.encode(val, name); err != nil {
+		return err
+	}
+	e.end(tag)
+	return nil
+}
+
+// textElement writes an element with text content.
+func (e *xmlEncoder) textElement(tag, s string) {
+	e.start(tag)
+	e.text(s)
+	e.end(tag)
+}
+
+func (e *xmlEncoder) writeFloat(v float64, bits int) {
+	switch {
+	case math.IsNaN(v):
+		e.text("NaN")
+	case math.IsInf(v, 1):
+		e.text("INF")
+	case math.IsInf(v, -1):
+		e.text("-INF")
+	default:
+		e.text(strconv.FormatFloat(v, 'g', -1, bits))
+	}
+}
+
+// encode writes the content of the element for the value.
+func (e *xmlEncoder) encode(val reflect.Value, name string) error {
+	if !val.IsValid() {
+		return nil
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+		return e.encode(val.Elem(), name)
+	}
+
+	switch typ := val.Type(); {
+	case typ == timeType:
+		e.text(val.Interface().(time.Time).UTC().Format(time.RFC3339Nano))
+	case typ == statusCodeType:
+		e.textElement("Code", strconv.FormatUint(val.Uint(), 10))
+	case typ == byteStringType:
+		e.text(base64.StdEncoding.EncodeToString(val.Bytes()))
+	case typ == xmlElementType:
+		e.buf.WriteString(val.String())
+	case typ == nodeIDType:
+		e.textElement("Identifier", addr(val).Interface().(*NodeID).String())
+	case typ == expandedNodeIDType:
+		n := addr(val).Interface().(*ExpandedNodeID)
+		if n.NodeID == nil {
+			return nil
+		}
+		e.textElement("Identifier", xmlExpandedNodeID(n))
+	case typ == guidType:
+		e.textElement("String", addr(val).Interface().(*GUID).String())
+	case typ == qualifiedNameType:
+		q := addr(val).Interface().(*QualifiedName)
+		e.textElement("NamespaceIndex", strconv.FormatUint(uint64(q.NamespaceIndex), 10))
+		e.textElement("Name", q.Name)
+	case typ == localizedTextType:
+		l := addr(val).Interface().(*LocalizedText)
+		if l.Has(LocalizedTextLocale) {
+			e.textElement("Locale", l.Locale)
+		}
+		if l.Has(LocalizedTextText) {
+			e.textElement("Text", l.Text)
+		}
+	case typ == extensionObjectType:
+		return e.writeExtensionObject(addr(val).Interface().(*ExtensionObject), name)
+	case typ == variantType:
+		return e.writeVariant(addr(val).Interface().(*Variant), name)
+	case typ == dataValueType:
+		return e.writeDataValue(addr(val).Interface().(*DataValue), name)
+	case typ == diagnosticInfoType:
+		e.writeDiagnosticInfo(addr(val).Interface().(*DiagnosticInfo))
+	case isJSONEnum(typ):
+		if sym := enumSymbol(val); sym != "" {
+			e.text(sym)
+		} else {
+			e.text(strconv.FormatInt(enumValue(val), 10))
+		}
+	default:
+		switch val.Kind() {
+		case reflect.Bool:
+			e.text(strconv.FormatBool(val.Bool()))
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			e.text(strconv.FormatInt(val.Int(), 10))
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			e.text(strconv.FormatUint(val.Uint(), 10))
+		case reflect.Float32:
+			e.writeFloat(val.Float(), 32)
+		case reflect.Float64:
+			e.writeFloat(val.Float(), 64)
+		case reflect.String:
+			e.text(val.String())
+		case reflect.Slice:
+			return e.writeList(val, name)
+		case reflect.Struct:
+			return e.writeStruct(val, name)
+		default:
+			return errors.Errorf("unsupported type: %s", val.Type())
+		}
+	}
+	return nil
+}
+
+func (e *xmlEncoder) writeStruct(val reflect.Value, name string) error {
+	valt := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		ft := valt.Field(i)
+		if ft.PkgPath != "" {
+			continue
+		}
+		f := val.Field(i)
+		if isJSONNull(f) {
+			continue
+		}
+		if err := e.element(specName(ft.Name), f, name+"."+ft.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeList writes the elements of the slice as child elements named
+// after the element type.
+func (e *xmlEncoder) writeList(val reflect.Value, name string) error {
+	tag := xmlTypeName(val.Type().Elem())
+	for i := 0; i < val.Len(); i++ {
+		if err := e.element(tag, val.Index(i), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xmlExpandedNodeID returns the 'svr=<index>;nsu=<uri>;<id>' string
+// representation of the expanded node id.
+func xmlExpandedNodeID(n *ExpandedNodeID) string {
+	var s string
+	if n.HasServerIndex() {
+		s = fmt.Sprintf("svr=%d;", n.ServerIndex)
+	}
+	id := n.NodeID.String()
+	if n.HasNamespaceURI() {
+		if i := strings.Index(id, ";"); strings.HasPrefix(id, "ns=") && i > 0 {
+			id = id[i+1:]
+		}
+		s += "nsu=" + n.NamespaceURI + ";"
+	}
+	return s + id
+}
+
+// writeExtensionObject writes the type id and the body. Registered types
+// with an XML encoding are written as XML. The other types are written
+// as binary encoded ByteString.
+//
+// Specification: Part 6, 5.3.2.16
+func (e *xmlEncoder) writeExtensionObject(x *ExtensionObject, name string) error {
+	if x.EncodingMask == ExtensionObjectEmpty || x.Value == nil {
+		if x.TypeID != nil && x.TypeID.NodeID != nil {
+			e.start("TypeId")
+			e.textElement("Identifier", x.TypeID.NodeID.String())
+			e.end("TypeId")
+		}
+		return nil
+	}
+
+	typeID := x.TypeID
+	body, err := x.xmlBody()
+	if err != nil {
+		return errors.Errorf("%s: %s", name, err)
+	}
+	if body == nil {
+		b, err := Encode(x.Value)
+		if err != nil {
+			return err
+		}
+		typeID = ExtensionObjectTypeID(x.Value)
+		body = []byte("<ByteString>" + base64.StdEncoding.EncodeToString(b) + "</ByteString>")
+	} else if id := eoxmltypes.Lookup(x.Value); id != nil {
+		typeID = NewExpandedNodeID(id, "", 0)
+	}
+
+	if typeID != nil && typeID.NodeID != nil {
+		e.start("TypeId")
+		e.textElement("Identifier", typeID.NodeID.String())
+		e.end("TypeId")
+	}
+	e.start("Body")
+	e.buf.Write(body)
+	e.end("Body")
+	return nil
+}
+
+// writeVariant writes the value as element named after the type, arrays
+// as ListOf element and multi-dimensional arrays as Matrix element.
+//
+// Specification: Part 6, 5.3.1.17
+func (e *xmlEncoder) writeVariant(v *Variant, name string) error {
+	if v.Type() == TypeIDNull {
+		return nil
+	}
+	tag := xmlTypeNames[v.Type()]
+	val := reflect.ValueOf(v.Value())
+
+	switch {
+	case v.Has(VariantArrayDimensions):
+		levels := len(v.ArrayDimensions())
+		typ := val.Type()
+		for i := 1; i < levels; i++ {
+			typ = typ.Elem()
+		}
+		flat := flattenArray(reflect.MakeSlice(typ, 0, int(v.ArrayLength())), val, levels)
+
+		e.start("Matrix")
+		e.start("Dimensions")
+		for _, d := range v.ArrayDimensions() {
+			e.textElement("Int32", strconv.Itoa(int(d)))
+		}
+		e.end("Dimensions")
+		e.start("Elements")
+		for i := 0; i < flat.Len(); i++ {
+			if err := e.element(tag, flat.Index(i), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+				return err
+			}
+		}
+		e.end("Elements")
+		e.end("Matrix")
+
+	case v.Has(VariantArrayValues):
+		e.start("ListOf" + tag)
+		for i := 0; i < val.Len(); i++ {
+			if err := e.element(tag, val.Index(i), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+				return err
+			}
+		}
+		e.end("ListOf" + tag)
+
+	default:
+		return e.element(tag, val, name)
+	}
+	return nil
+}
+
+// Specification: Part 6, 5.3.1.18
+func (e *xmlEncoder) writeDataValue(d *DataValue, name string) error {
+	if d.Has(DataValueValue) && d.Value != nil {
+		if err := e.element("Value", reflect.ValueOf(d.Value), name+".Value"); err != nil {
+			return err
+		}
+	}
+	if d.Has(DataValueStatusCode) {
+		e.start("StatusCode")
+		e.textElement("Code", strconv.FormatUint(uint64(d.Status), 10))
+		e.end("StatusCode")
+	}
+	if d.Has(DataValueSourceTimestamp) {
+		e.textElement("SourceTimestamp", d.SourceTimestamp.UTC().Format(time.RFC3339Nano))
+	}
+	if d.Has(DataValueSourcePicoseconds) {
+		e.textElement("SourcePicoseconds", strconv.Itoa(int(d.SourcePicoseconds)))
+	}
+	if d.Has(DataValueServerTimestamp) {
+		e.textElement("ServerTimestamp", d.ServerTimestamp.UTC().Format(time.RFC3339Nano))
+	}
+	if d.Has(DataValueServerPicoseconds) {
+		e.textElement("ServerPicoseconds", strconv.Itoa(int(d.ServerPicoseconds)))
+	}
+	return nil
+}
+
+// Specification: Part 6, 5.3.1.12
+func (e *xmlEncoder) writeDiagnosticInfo(d *DiagnosticInfo) {
+	if d.Has(DiagnosticInfoSymbolicID) {
+		e.textElement("SymbolicId", strconv.Itoa(int(d.SymbolicID)))
+	}
+	if d.Has(DiagnosticInfoNamespaceURI) {
+		e.textElement("NamespaceUri", strconv.Itoa(int(d.NamespaceURI)))
+	}
+	if d.Has(DiagnosticInfoLocale) {
+		e.textElement("Locale", strconv.Itoa(int(d.Locale)))
+	}
+	if d.Has(DiagnosticInfoLocalizedText) {
+		e.textElement("LocalizedText", strconv.Itoa(int(d.LocalizedText)))
+	}
+	if d.Has(DiagnosticInfoAdditionalInfo) {
+		e.textElement("AdditionalInfo", d.AdditionalInfo)
+	}
+	if d.Has(DiagnosticInfoInnerStatusCode) {
+		e.start("InnerStatusCode")
+		e.textElement("Code", strconv.FormatUint(uint64(d.InnerStatusCode), 10))
+		e.end("InnerStatusCode")
+	}
+	if d.Has(DiagnosticInfoInnerDiagnosticInfo) && d.InnerDiagnosticInfo != nil {
+		e.start("InnerDiagnosticInfo")
+		e.writeDiagnosticInfo(d.InnerDiagnosticInfo)
+		e.end("InnerDiagnosticInfo")
+	}
+}
+
+// xmlNode is an element of a parsed XML document.
+type xmlNode struct {
+	name     string
+	text     string
+	inner    []byte
+	children []*xmlNode
+}
+
+// child returns the first child element with the name or nil.
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// first returns the first child element or nil.
+func (n *xmlNode) first() *xmlNode {
+	if n == nil || len(n.children) == 0 {
+		return nil
+	}
+	return n.children[0]
+}
+
+// parseXML parses the document into a tree of elements. Namespaces are
+// ignored.
+func parseXML(b []byte) (*xmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+
+	var root *xmlNode
+	var stack []*xmlNode
+	var starts []int64
+	for {
+		off := d.InputOffset()
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local}
+			if len(stack) > 0 {
+				p := stack[len(stack)-1]
+				p.children = append(p.children, n)
+			} else if root == nil {
+				root = n
+			} else {
+				return nil, errors.Errorf("multiple root elements")
+			}
+			stack = append(stack, n)
+			starts = append(starts, d.InputOffset())
+
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.inner = b[starts[len(starts)-1]:off]
+			stack, starts = stack[:len(stack)-1], starts[:len(starts)-1]
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.Errorf("missing root element")
+	}
+	return root, nil
+}
+
+// DecodeXML decodes the XML encoding of a value into v which must be a
+// non-nil pointer. The name of the root element is not checked.
+//
+// Specification: Part 6, 5.3
+func DecodeXML(b []byte, v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.Errorf("invalid argument: %T is not a non-nil pointer", v)
+	}
+	n, err := parseXML(b)
+	if err != nil {
+		return err
+	}
+	return decodeXML(n, val.Elem(), val.Type().String())
+}
+
+// decodeXML decodes the content of the element into val. A nil element
+// sets the zero value.
+func decodeXML(n *xmlNode, val reflect.Value, name string) error {
+	if n == nil {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		return decodeXML(n, val.Elem(), name)
+	}
+
+	text := strings.TrimSpace(n.text)
+	switch typ := val.Type(); {
+	case typ == timeType:
+		t, err := time.Parse(time.RFC3339Nano, text)
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.Set(reflect.ValueOf(t.UTC()))
+	case typ == statusCodeType:
+		return decodeXMLNumber(n.child("Code"), val, name)
+	case typ == byteStringType:
+		b, err := xmlByteString(n, name)
+		if err != nil {
+			return err
+		}
+		val.SetBytes(b)
+	case typ == xmlElementType:
+		val.SetString(string(bytes.TrimSpace(n.inner)))
+	case typ == nodeIDType:
+		id, err := ParseNodeID(strings.TrimSpace(n.child("Identifier").textOrEmpty()))
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.Set(reflect.ValueOf(*id))
+	case typ == expandedNodeIDType:
+		id, err := parseXMLExpandedNodeID(strings.TrimSpace(n.child("Identifier").textOrEmpty()))
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.Set(reflect.ValueOf(*id))
+	case typ == guidType:
+		g := NewGUID(strings.TrimSpace(n.child("String").textOrEmpty()))
+		if g == nil {
+			return errors.Errorf("%s: invalid guid", name)
+		}
+		val.Set(reflect.ValueOf(*g))
+	case typ == qualifiedNameType:
+		q := QualifiedName{Name: n.child("Name").textOrEmpty()}
+		if c := n.child("NamespaceIndex"); c != nil {
+			if err := decodeXMLNumber(c, reflect.ValueOf(&q.NamespaceIndex).Elem(), name); err != nil {
+				return err
+			}
+		}
+		val.Set(reflect.ValueOf(q))
+	case typ == localizedTextType:
+		var l LocalizedText
+		if c := n.child("Locale"); c != nil {
+			l.Locale = c.text
+			l.EncodingMask |= LocalizedTextLocale
+		}
+		if c := n.child("Text"); c != nil {
+			l.Text = c.text
+			l.EncodingMask |= LocalizedTextText
+		}
+		val.Set(reflect.ValueOf(l))
+	case typ == extensionObjectType:
+		return decodeXMLExtensionObject(n, val.Addr().Interface().(*ExtensionObject), name)
+	case typ == variantType:
+		return decodeXMLVariant(n, val.Addr().Interface().(*Variant), name)
+	case typ == dataValueType:
+		return decodeXMLDataValue(n, val.Addr().Interface().(*DataValue), name)
+	case typ == diagnosticInfoType:
+		return decodeXMLDiagnosticInfo(n, val.Addr().Interface().(*DiagnosticInfo), name)
+	case isJSONEnum(typ):
+		// accept the 'Symbol_Value' format and plain numbers
+		n := &xmlNode{text: text[strings.LastIndex(text, "_")+1:]}
+		return decodeXMLNumber(n, val, name)
+	default:
+		switch val.Kind() {
+		case reflect.Bool:
+			switch text {
+			case "true", "1":
+				val.SetBool(true)
+			case "false", "0":
+				val.SetBool(false)
+			default:
+				return errors.Errorf("%s: invalid boolean %q", name, text)
+			}
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return decodeXMLNumber(n, val, name)
+		case reflect.String:
+			val.SetString(n.text)
+		case reflect.Slice:
+			s := reflect.MakeSlice(val.Type(), len(n.children), len(n.children))
+			for i, c := range n.children {
+				if err := decodeXML(c, s.Index(i), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+					return err
+				}
+			}
+			val.Set(s)
+		case reflect.Struct:
+			valt := val.Type()
+			for i := 0; i < val.NumField(); i++ {
+				ft := valt.Field(i)
+				if ft.PkgPath != "" {
+					continue
+				}
+				if err := decodeXML(n.child(specName(ft.Name)), val.Field(i), name+"."+ft.Name); err != nil {
+					return err
+				}
+			}
+		default:
+			return errors.Errorf("unsupported type %s", val.Type())
+		}
+	}
+	return nil
+}
+
+func (n *xmlNode) textOrEmpty() string {
+	if n == nil {
+		return ""
+	}
+	return n.text
+}
+
+func decodeXMLNumber(n *xmlNode, val reflect.Value, name string) error {
+	s := strings.TrimSpace(n.textOrEmpty())
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		val.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch s {
+		case "NaN":
+			f = math.NaN()
+		case "INF":
+			f = math.Inf(1)
+		case "-INF":
+			f = math.Inf(-1)
+		default:
+			var err error
+			if f, err = strconv.ParseFloat(s, val.Type().Bits()); err != nil {
+				return errors.Errorf("%s: %s", name, err)
+			}
+		}
+		val.SetFloat(f)
+	default:
+		return errors.Errorf("%s: cannot decode number into %s", name, val.Type())
+	}
+	return nil
+}
+
+func xmlByteString(n *xmlNode, name string) ([]byte, error) {
+	// base64 data can be wrapped over multiple lines
+	s := strings.Join(strings.Fields(n.text), "")
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Errorf("%s: %s", name, err)
+	}
+	return b, nil
+}
+
+// parseXMLExpandedNodeID parses the 'svr=<index>;nsu=<uri>;<id>' string
+// representation of an expanded node id.
+func parseXMLExpandedNodeID(s string) (*ExpandedNodeID, error) {
+	var svr uint32
+	if strings.HasPrefix(s, "svr=") {
+		p := strings.SplitN(s, ";", 2)
+		if len(p) != 2 {
+			return nil, errors.Errorf("invalid expanded node id: %s", s)
+		}
+		v, err := strconv.ParseUint(p[0][len("svr="):], 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("invalid server index: %s", s)
+		}
+		svr, s = uint32(v), p[1]
+	}
+
+	var uri string
+	if strings.HasPrefix(s, "nsu=") {
+		i := strings.LastIndex(s, ";")
+		if i < 0 {
+			return nil, errors.Errorf("invalid expanded node id: %s", s)
+		}
+		uri, s = s[len("nsu="):i], s[i+1:]
+	}
+
+	id, err := ParseNodeID(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewExpandedNodeID(id, uri, svr), nil
+}
+
+// decodeXMLExtensionObject decodes an extension object. Bodies of types
+// without a registered XML encoding are kept as XMLElement.
+func decodeXMLExtensionObject(n *xmlNode, e *ExtensionObject, name string) error {
+	*e = ExtensionObject{TypeID: NewTwoByteExpandedNodeID(0)}
+	if c := n.child("TypeId").child("Identifier"); c != nil {
+		id, err := ParseNodeID(strings.TrimSpace(c.text))
+		if err != nil {
+			return errors.Errorf("%s: %s", name, err)
+		}
+		e.TypeID = NewExpandedNodeID(id, "", 0)
+	}
+
+	body := n.child("Body").first()
+	if body == nil {
+		return nil
+	}
+
+	if body.name == "ByteString" {
+		e.EncodingMask = ExtensionObjectBinary
+		b, err := xmlByteString(body, name)
+		if err != nil {
+			return err
+		}
+		if e.Value = eotypes.New(e.TypeID.NodeID); e.Value == nil {
+			return nil
+		}
+		_, err = Decode(b, e.Value)
+		return err
+	}
+
+	e.EncodingMask = ExtensionObjectXML
+	if e.Value = eoxmltypes.New(e.TypeID.NodeID); e.Value == nil {
+		v := XMLElement(bytes.TrimSpace(n.child("Body").inner))
+		e.Value = &v
+		return nil
+	}
+	return decodeXML(body, reflect.ValueOf(e.Value), name+".Body")
+}
+
+// decodeXMLVariant decodes the element of the value, the ListOf element
+// or the Matrix element of a variant.
+func decodeXMLVariant(n *xmlNode, v *Variant, name string) error {
+	*v = Variant{}
+	c := n.first()
+	if c == nil {
+		return nil
+	}
+
+	var elems []*xmlNode
+	var dims []int32
+	var typeName string
+	switch {
+	case c.name == "Matrix":
+		for _, d := range c.child("Dimensions").children {
+			var dim int32
+			if err := decodeXMLNumber(d, reflect.ValueOf(&dim).Elem(), name); err != nil {
+				return err
+			}
+			dims = append(dims, dim)
+		}
+		elems = c.child("Elements").children
+		if len(elems) == 0 {
+			return errors.Errorf("%s: cannot decode empty matrix", name)
+		}
+		typeName = elems[0].name
+
+	case strings.HasPrefix(c.name, "ListOf"):
+		elems = c.children
+		typeName = strings.TrimPrefix(c.name, "ListOf")
+
+	default:
+		typeID, ok := xmlTypeIDs[c.name]
+		if !ok {
+			return errors.Errorf("%s: invalid variant type %s", name, c.name)
+		}
+		val := reflect.New(variantTypeIDToType[typeID]).Elem()
+		if err := decodeXML(c, val, name); err != nil {
+			return err
+		}
+		return v.set(val.Interface())
+	}
+
+	typeID, ok := xmlTypeIDs[typeName]
+	if !ok {
+		return errors.Errorf("%s: invalid variant type %s", name, typeName)
+	}
+
+	// arrays of Byte are not ByteStrings
+	sliceType := reflect.SliceOf(variantTypeIDToType[typeID])
+	if typeID == TypeIDByte {
+		sliceType = reflect.TypeOf(ByteArray{})
+	}
+	vals := reflect.MakeSlice(sliceType, len(elems), len(elems))
+	for i, el := range elems {
+		if err := decodeXML(el, vals.Index(i), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+			return err
+		}
+	}
+	if len(dims) < 2 {
+		return v.set(vals.Interface())
+	}
+
+	n2 := 1
+	idims := make([]int, len(dims))
+	for i, d := range dims {
+		if d < 0 {
+			return errors.Errorf("%s: invalid array dimensions %v", name, dims)
+		}
+		idims[i] = int(d)
+		n2 *= idims[i]
+	}
+	if n2 != vals.Len() {
+		return errors.Errorf("%s: array dimensions %v do not match %d elements", name, dims, vals.Len())
+	}
+	return v.set(split(0, 0, vals.Len(), idims, vals).Interface())
+}
+
+func decodeXMLDataValue(n *xmlNode, d *DataValue, name string) error {
+	*d = DataValue{}
+	fields := []struct {
+		tag  string
+		v    interface{}
+		mask byte
+	}{
+		{"Value", &d.Value, DataValueValue},
+		{"StatusCode", &d.Status, DataValueStatusCode},
+		{"SourceTimestamp", &d.SourceTimestamp, DataValueSourceTimestamp},
+		{"SourcePicoseconds", &d.SourcePicoseconds, DataValueSourcePicoseconds},
+		{"ServerTimestamp", &d.ServerTimestamp, DataValueServerTimestamp},
+		{"ServerPicoseconds", &d.ServerPicoseconds, DataValueServerPicoseconds},
+	}
+	for _, f := range fields {
+		c := n.child(f.tag)
+		if c == nil {
+			continue
+		}
+		if err := decodeXML(c, reflect.ValueOf(f.v).Elem(), name+"."+f.tag); err != nil {
+			return err
+		}
+		d.EncodingMask |= f.mask
+	}
+	return nil
+}
+
+func decodeXMLDiagnosticInfo(n *xmlNode, d *DiagnosticInfo, name string) error {
+	*d = DiagnosticInfo{}
+	fields := []struct {
+		tag  string
+		v    interface{}
+		mask byte
+	}{
+		{"SymbolicId", &d.SymbolicID, DiagnosticInfoSymbolicID},
+		{"NamespaceUri", &d.NamespaceURI, DiagnosticInfoNamespaceURI},
+		{"Locale", &d.Locale, DiagnosticInfoLocale},
+		{"LocalizedText", &d.LocalizedText, DiagnosticInfoLocalizedText},
+		{"AdditionalInfo", &d.AdditionalInfo, DiagnosticInfoAdditionalInfo},
+		{"InnerStatusCode", &d.InnerStatusCode, DiagnosticInfoInnerStatusCode},
+		{"InnerDiagnosticInfo", &d.InnerDiagnosticInfo, DiagnosticInfoInnerDiagnosticInfo},
+	}
+	for _, f := range fields {
+		c := n.child(f.tag)
+		if c == nil {
+			continue
+		}
+		if err := decodeXML(c, reflect.ValueOf(f.v).Elem(), name+"."+f.tag); err != nil {
+			return err
+		}
+		d.EncodingMask |= f.mask
+	}
+	return nil
+}
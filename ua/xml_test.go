@@ -0,0 +1,148 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ua
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pascaldekloe/goe/verify"
+)
+
+func TestXML(t *testing.T) {
+	ts := time.Date(2021, 2, 3, 4, 5, 6, 7000, time.UTC)
+
+	cases := []struct {
+		Name   string
+		Struct interface{}
+		XML    string
+	}{
+		{
+			Name:   "node id",
+			Struct: MustParseNodeID("ns=2;s=foo"),
+			XML:    `<NodeId xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><Identifier>ns=2;s=foo</Identifier></NodeId>`,
+		},
+		{
+			Name:   "expanded node id",
+			Struct: NewExpandedNodeID(NewStringNodeID(0, "foo"), "urn:foo", 2),
+			XML:    `<ExpandedNodeId xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><Identifier>svr=2;nsu=urn:foo;s=foo</Identifier></ExpandedNodeId>`,
+		},
+		{
+			Name:   "guid",
+			Struct: NewGUID("72962B91-FA75-4AE6-8D28-B404DC7DAF63"),
+			XML:    `<Guid xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><String>72962B91-FA75-4AE6-8D28-B404DC7DAF63</String></Guid>`,
+		},
+		{
+			Name:   "qualified name",
+			Struct: &QualifiedName{NamespaceIndex: 2, Name: "a<b"},
+			XML:    `<QualifiedName xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><NamespaceIndex>2</NamespaceIndex><Name>a&lt;b</Name></QualifiedName>`,
+		},
+		{
+			Name:   "localized text",
+			Struct: NewLocalizedTextWithLocale("foo", "en"),
+			XML:    `<LocalizedText xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><Locale>en</Locale><Text>foo</Text></LocalizedText>`,
+		},
+		{
+			Name:   "double variant",
+			Struct: MustVariant(math.Inf(1)),
+			XML:    `<Variant xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><Double>INF</Double></Variant>`,
+		},
+		{
+			Name:   "byte string variant",
+			Struct: MustVariant([]byte{1, 2, 3}),
+			XML:    `<Variant xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><ByteString>AQID</ByteString></Variant>`,
+		},
+		{
+			Name:   "array variant",
+			Struct: MustVariant([]string{"a", "b"}),
+			XML:    `<Variant xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><ListOfString><String>a</String><String>b</String></ListOfString></Variant>`,
+		},
+		{
+			Name:   "multi-dimensional variant",
+			Struct: MustVariant([][]int32{{1, 2}, {3, 4}}),
+			XML: `<Variant xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><Matrix><Dimensions><Int32>2</Int32><Int32>2</Int32></Dimensions>` +
+				`<Elements><Int32>1</Int32><Int32>2</Int32><Int32>3</Int32><Int32>4</Int32></Elements></Matrix></Variant>`,
+		},
+		{
+			Name: "data value",
+			Struct: &DataValue{
+				EncodingMask:    0x07,
+				Value:           MustVariant(uint16(5)),
+				Status:          StatusBadNodeIDUnknown,
+				SourceTimestamp: ts,
+			},
+			XML: `<DataValue xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><Value><UInt16>5</UInt16></Value>` +
+				`<StatusCode><Code>2150891520</Code></StatusCode><SourceTimestamp>2021-02-03T04:05:06.000007Z</SourceTimestamp></DataValue>`,
+		},
+		{
+			Name: "diagnostic info",
+			Struct: &DiagnosticInfo{
+				EncodingMask:   0x11,
+				SymbolicID:     1,
+				AdditionalInfo: "foo",
+			},
+			XML: `<DiagnosticInfo xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><SymbolicId>1</SymbolicId><AdditionalInfo>foo</AdditionalInfo></DiagnosticInfo>`,
+		},
+		{
+			Name:   "extension object",
+			Struct: NewExtensionObjectXML(&AnonymousIdentityToken{PolicyID: "anonymous"}),
+			XML: `<ExtensionObject xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><TypeId><Identifier>i=320</Identifier></TypeId>` +
+				`<Body><AnonymousIdentityToken xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><PolicyId>anonymous</PolicyId></AnonymousIdentityToken></Body></ExtensionObject>`,
+		},
+		{
+			Name: "read value id",
+			Struct: &ReadValueID{
+				NodeID:       MustParseNodeID("ns=2;i=5"),
+				AttributeID:  AttributeIDValue,
+				DataEncoding: &QualifiedName{},
+			},
+			XML: `<ReadValueId xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><NodeId><Identifier>ns=2;i=5</Identifier></NodeId>` +
+				`<AttributeId>Value_13</AttributeId><IndexRange></IndexRange><DataEncoding><NamespaceIndex>0</NamespaceIndex><Name></Name></DataEncoding></ReadValueId>`,
+		},
+		{
+			Name: "trust list",
+			Struct: &TrustListDataType{
+				SpecifiedLists:      1,
+				TrustedCertificates: [][]byte{{1, 2, 3}},
+			},
+			XML: `<TrustListDataType xmlns="http://opcfoundation.org/UA/2008/02/Types.xsd"><SpecifiedLists>1</SpecifiedLists>` +
+				`<TrustedCertificates><ByteString>AQID</ByteString></TrustedCertificates></TrustListDataType>`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Run("encode", func(t *testing.T) {
+				b, err := EncodeXML(c.Struct)
+				if err != nil {
+					t.Fatal(err)
+				}
+				verify.Values(t, "", string(b), c.XML)
+			})
+
+			t.Run("decode", func(t *testing.T) {
+				v := reflect.New(reflect.TypeOf(c.Struct).Elem())
+				if err := DecodeXML([]byte(c.XML), v.Interface()); err != nil {
+					t.Fatal(err)
+				}
+				verify.Values(t, "", v.Interface(), c.Struct)
+			})
+		})
+	}
+}
+
+func TestDecodeXMLExtensionObjectByteString(t *testing.T) {
+	x := `<ExtensionObject><TypeId><Identifier>i=321</Identifier></TypeId><Body><ByteString>
+		CQAAAGFub255bW91cw==
+	</ByteString></Body></ExtensionObject>`
+
+	var got ExtensionObject
+	if err := DecodeXML([]byte(x), &got); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "", &got, NewExtensionObject(&AnonymousIdentityToken{PolicyID: "anonymous"}))
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/imatic-tech/opcua/debug"
 	"github.com/imatic-tech/opcua/errors"
@@ -129,6 +130,11 @@ func Listen(endpoint string, ack *Acknowledge) (*Listener, error) {
 	if err != nil {
 		return nil, err
 	}
+	// report the port chosen by the system in the endpoint URL
+	// so that clients can connect to it.
+	if laddr.Port == 0 {
+		endpoint = endpointWithPort(endpoint, l.Addr().(*net.TCPAddr).Port)
+	}
 	return &Listener{
 		l:        l,
 		ack:      ack,
@@ -136,21 +142,59 @@ func Listen(endpoint string, ack *Acknowledge) (*Listener, error) {
 	}, nil
 }
 
-// Accept accepts the next incoming call and returns the new connection.
+// Accept accepts the next incoming call and returns the new connection
+// after the HEL/ACK handshake.
 //
 // The first param ctx is to be passed to monitor(), which monitors and handles
 // incoming messages automatically in another goroutine.
+//
+// Accept blocks until the client has sent its HEL message. Servers which
+// accept connections in a loop should use AcceptConn and Handshake.
 func (l *Listener) Accept(ctx context.Context) (*Conn, error) {
+	c, err := l.AcceptConn()
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Handshake(c, 0); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AcceptConn accepts the next incoming call and returns the new connection
+// without the HEL/ACK handshake. The caller must call Handshake before
+// the connection is used.
+func (l *Listener) AcceptConn() (*Conn, error) {
 	c, err := l.l.AcceptTCP()
 	if err != nil {
 		return nil, err
 	}
-	conn := &Conn{TCPConn: c, id: nextid(), ack: l.ack}
-	if err := conn.srvhandshake(l.endpoint); err != nil {
+	return &Conn{TCPConn: c, id: nextid(), ack: l.ack}, nil
+}
+
+// Handshake waits for the HEL message of the client on a connection which
+// was returned by AcceptConn and responds with the ACK message. The
+// handshake fails if the client does not send the HEL message within the
+// timeout. Zero means no timeout. The connection is closed if the
+// handshake fails.
+func (l *Listener) Handshake(c *Conn, timeout time.Duration) error {
+	if timeout > 0 {
+		if err := c.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			c.Close()
+			return err
+		}
+	}
+	if err := c.srvhandshake(l.endpoint); err != nil {
 		c.Close()
-		return nil, err
+		return err
 	}
-	return conn, nil
+	if timeout > 0 {
+		if err := c.SetReadDeadline(time.Time{}); err != nil {
+			c.Close()
+			return err
+		}
+	}
+	return nil
 }
 
 // Close closes the Listener.
@@ -173,6 +217,9 @@ type Conn struct {
 	id  uint32
 	ack *Acknowledge
 
+	// bufs contains the receive buffers which have been released.
+	bufs sync.Pool
+
 	closeOnce sync.Once
 }
 
@@ -235,6 +282,7 @@ func (c *Conn) Handshake(endpoint string) error {
 	if err != nil {
 		return err
 	}
+	defer c.Release(b)
 
 	msgtyp := string(b[:4])
 	switch msgtyp {
@@ -278,8 +326,8 @@ func (c *Conn) srvhandshake(endpoint string) error {
 		c.SendError(ua.StatusBadTCPInternalError)
 		return err
 	}
+	defer c.Release(b)
 
-	// HEL or RHE?
 	msgtyp := string(b[:4])
 	msg := b[hdrlen:]
 	switch msgtyp {
@@ -293,6 +341,7 @@ func (c *Conn) srvhandshake(endpoint string) error {
 			c.SendError(ua.StatusBadTCPEndpointURLInvalid)
 			return errors.Errorf("uacp: invalid endpoint url %s", hel.EndpointURL)
 		}
+		c.ack = reviseACK(c.ack, hel)
 		if err := c.Send("ACKF", c.ack); err != nil {
 			c.SendError(ua.StatusBadTCPInternalError)
 			return err
@@ -300,27 +349,6 @@ func (c *Conn) srvhandshake(endpoint string) error {
 		debug.Printf("uacp %d: recv %#v", c.id, hel)
 		return nil
 
-	case "RHEF":
-		rhe := new(ReverseHello)
-		if _, err := rhe.Decode(msg); err != nil {
-			c.SendError(ua.StatusBadTCPInternalError)
-			return err
-		}
-		if rhe.EndpointURL != endpoint {
-			c.SendError(ua.StatusBadTCPEndpointURLInvalid)
-			return errors.Errorf("uacp: invalid endpoint url %s", rhe.EndpointURL)
-		}
-		debug.Printf("uacp %d: connecting to %s", c.id, rhe.ServerURI)
-		c.Close()
-		var dialer net.Dialer
-		c2, err := dialer.DialContext(context.Background(), "tcp", rhe.ServerURI)
-		if err != nil {
-			return err
-		}
-		c.TCPConn = c2.(*net.TCPConn)
-		debug.Printf("uacp %d: recv %#v", c.id, rhe)
-		return nil
-
 	case "ERRF":
 		errf := new(Error)
 		if _, err := errf.Decode(b[hdrlen:]); err != nil {
@@ -335,18 +363,38 @@ func (c *Conn) srvhandshake(endpoint string) error {
 	}
 }
 
+// reviseACK returns the connection parameters the server acknowledges
+// for the given HEL message. The buffer sizes are limited to what both
+// sides support.
+func reviseACK(ack *Acknowledge, hel *Hello) *Acknowledge {
+	a := *ack
+	if hel.SendBufSize > 0 && hel.SendBufSize < a.ReceiveBufSize {
+		a.ReceiveBufSize = hel.SendBufSize
+	}
+	if hel.ReceiveBufSize > 0 && hel.ReceiveBufSize < a.SendBufSize {
+		a.SendBufSize = hel.ReceiveBufSize
+	}
+	if hel.MaxMessageSize > 0 && (a.MaxMessageSize == 0 || hel.MaxMessageSize < a.MaxMessageSize) {
+		a.MaxMessageSize = hel.MaxMessageSize
+	}
+	if hel.MaxChunkCount > 0 && (a.MaxChunkCount == 0 || hel.MaxChunkCount < a.MaxChunkCount) {
+		a.MaxChunkCount = hel.MaxChunkCount
+	}
+	return &a
+}
+
 // hdrlen is the size of the uacp header
 const hdrlen = 8
 
 // Receive reads a full UACP message from the underlying connection.
-// The size of b must be at least ReceiveBufSize. Otherwise,
-// the function returns an error.
+// The returned slice is taken from a pool of buffers of ReceiveBufSize
+// bytes. The caller may return it with Release once neither the
+// message nor any value decoded from it is used anymore.
 func (c *Conn) Receive() ([]byte, error) {
-	// TODO(kung-foo): allow user-specified buffer
-	// TODO(kung-foo): sync.Pool
-	b := make([]byte, c.ack.ReceiveBufSize)
+	b := c.buffer()
 
 	if _, err := io.ReadFull(c, b[:hdrlen]); err != nil {
+		c.Release(b)
 		// todo(fs): do not wrap this error since it hides io.EOF
 		// todo(fs): use golang.org/x/xerrors
 		return nil, err
@@ -354,14 +402,17 @@ func (c *Conn) Receive() ([]byte, error) {
 
 	var h Header
 	if _, err := h.Decode(b[:hdrlen]); err != nil {
+		c.Release(b)
 		return nil, errors.Errorf("uacp: header decode failed: %s", err)
 	}
 
 	if h.MessageSize > c.ack.ReceiveBufSize {
+		c.Release(b)
 		return nil, errors.Errorf("uacp: message too large: %d > %d bytes", h.MessageSize, c.ack.ReceiveBufSize)
 	}
 
 	if _, err := io.ReadFull(c, b[hdrlen:h.MessageSize]); err != nil {
+		c.Release(b)
 		// todo(fs): do not wrap this error since it hides io.EOF
 		// todo(fs): use golang.org/x/xerrors
 		return nil, err
@@ -370,6 +421,7 @@ func (c *Conn) Receive() ([]byte, error) {
 	debug.Printf("uacp %d: recv %s%c with %d bytes", c.id, h.MessageType, h.ChunkType, h.MessageSize)
 
 	if h.MessageType == "ERR" {
+		defer c.Release(b)
 		errf := new(Error)
 		if _, err := errf.Decode(b[hdrlen:h.MessageSize]); err != nil {
 			return nil, errors.Errorf("uacp: failed to decode ERRF message: %s", err)
@@ -379,6 +431,25 @@ func (c *Conn) Receive() ([]byte, error) {
 	return b[:h.MessageSize], nil
 }
 
+// buffer returns a receive buffer from the pool or allocates a new one.
+func (c *Conn) buffer() []byte {
+	n := int(c.ack.ReceiveBufSize)
+	if b, ok := c.bufs.Get().([]byte); ok && cap(b) >= n {
+		return b[:n]
+	}
+	return make([]byte, n)
+}
+
+// Release returns a buffer received with Receive to the pool. The buffer
+// and all values which reference it must not be used after that. Buffers
+// which are not released are garbage collected as usual.
+func (c *Conn) Release(b []byte) {
+	if b == nil || cap(b) < int(c.ack.ReceiveBufSize) {
+		return
+	}
+	c.bufs.Put(b[:0])
+}
+
 func (c *Conn) Send(typ string, msg interface{}) error {
 	if len(typ) != 4 {
 		return errors.Errorf("invalid msg type: %s", typ)
@@ -6,6 +6,7 @@ package uacp
 
 import (
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/imatic-tech/opcua/errors"
@@ -33,3 +34,18 @@ func ResolveEndpoint(endpoint string) (network string, addr *net.TCPAddr, err er
 	}
 	return
 }
+
+// endpointWithPort returns the endpoint with the port of the address
+// replaced by port.
+func endpointWithPort(endpoint string, port int) string {
+	elems := strings.SplitN(endpoint, "/", 4)
+	if len(elems) < 3 {
+		return endpoint
+	}
+	host, _, err := net.SplitHostPort(elems[2])
+	if err != nil {
+		host = elems[2]
+	}
+	elems[2] = net.JoinHostPort(host, strconv.Itoa(port))
+	return strings.Join(elems, "/")
+}
@@ -74,3 +74,22 @@ func TestResolveEndpoint(t *testing.T) {
 		}
 	}
 }
+
+func TestEndpointWithPort(t *testing.T) {
+	cases := []struct {
+		input string
+		port  int
+		want  string
+	}{
+		{"opc.tcp://127.0.0.1:0/foo/bar", 4841, "opc.tcp://127.0.0.1:4841/foo/bar"},
+		{"opc.tcp://127.0.0.1:0", 4841, "opc.tcp://127.0.0.1:4841"},
+		{"opc.tcp://localhost", 4841, "opc.tcp://localhost:4841"},
+		{"opc.tcp://[::1]:0/foo", 4841, "opc.tcp://[::1]:4841/foo"},
+	}
+
+	for _, c := range cases {
+		if got, want := endpointWithPort(c.input, c.port), c.want; got != want {
+			t.Errorf("got %s want %s", got, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package uacp
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// ReverseListener is a OPC UA Connection Protocol network listener for
+// Reverse Connect. The servers open the connections to the client and
+// announce themselves with a ReverseHello message. The client keeps the
+// connection and continues with the Hello/Acknowledge handshake.
+//
+// Specification: Part 6, 7.1.3
+type ReverseListener struct {
+	l        *net.TCPListener
+	ack      *Acknowledge
+	endpoint string
+}
+
+// ListenReverse acts like net.Listen for the Reverse Connect of OPC UA
+// Connection Protocol networks.
+//
+// The endpoint must be in "opc.tcp://<addr[:port]>" format. If the
+// Port field of the address is 0, a port number is automatically chosen.
+func ListenReverse(endpoint string, ack *Acknowledge) (*ReverseListener, error) {
+	if ack == nil {
+		ack = DefaultClientACK
+	}
+	network, laddr, err := ResolveEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.ListenTCP(network, laddr)
+	if err != nil {
+		return nil, err
+	}
+	if laddr.Port == 0 {
+		endpoint = endpointWithPort(endpoint, l.Addr().(*net.TCPAddr).Port)
+	}
+	return &ReverseListener{
+		l:        l,
+		ack:      ack,
+		endpoint: endpoint,
+	}, nil
+}
+
+// Accept accepts the next connection of a server and returns it with the
+// ReverseHello message of the server. Connections without a valid
+// ReverseHello message are closed. The caller checks the server and
+// completes the handshake with Handshake or rejects the connection with
+// SendError.
+//
+// The deadline of ctx limits the time to receive the ReverseHello
+// message. Close the listener to unblock Accept.
+func (l *ReverseListener) Accept(ctx context.Context) (*Conn, *ReverseHello, error) {
+	for {
+		c, err := l.l.AcceptTCP()
+		if err != nil {
+			return nil, nil, err
+		}
+		conn := &Conn{TCPConn: c, id: nextid(), ack: l.ack}
+
+		if d, ok := ctx.Deadline(); ok {
+			conn.SetReadDeadline(d)
+		}
+		rhe, err := conn.receiveReverseHello()
+		if err == nil {
+			err = conn.SetReadDeadline(time.Time{})
+		}
+		if err != nil {
+			debug.Printf("uacp %d: reverse hello failed: %s", conn.id, err)
+			conn.Close()
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			continue
+		}
+		return conn, rhe, nil
+	}
+}
+
+// ReverseHandshake completes the handshake of a connection returned by
+// ReverseListener.Accept with the connection limits of ack. If ack is nil
+// the limits of the listener are used.
+func (c *Conn) ReverseHandshake(endpoint string, ack *Acknowledge) error {
+	if ack != nil {
+		c.ack = ack
+	}
+	return c.Handshake(endpoint)
+}
+
+// Close closes the ReverseListener.
+func (l *ReverseListener) Close() error {
+	return l.l.Close()
+}
+
+// Addr returns the listener's network address.
+func (l *ReverseListener) Addr() net.Addr {
+	return l.l.Addr()
+}
+
+// Endpoint returns the endpoint URL the servers connect to.
+func (l *ReverseListener) Endpoint() string {
+	return l.endpoint
+}
+
+// receiveReverseHello receives the ReverseHello message of the server.
+func (c *Conn) receiveReverseHello() (*ReverseHello, error) {
+	b, err := c.Receive()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Release(b)
+
+	msgtyp := string(b[:4])
+	if msgtyp != "RHEF" {
+		c.SendError(ua.StatusBadTCPMessageTypeInvalid)
+		return nil, errors.Errorf("uacp: invalid reverse hello packet %q", msgtyp)
+	}
+	rhe := new(ReverseHello)
+	if _, err := rhe.Decode(b[hdrlen:]); err != nil {
+		c.SendError(ua.StatusBadTCPInternalError)
+		return nil, err
+	}
+	debug.Printf("uacp %d: recv %#v", c.id, rhe)
+	return rhe, nil
+}
+
+// DialReverse connects a server to a client which waits for Reverse
+// Connect at clientEndpoint. The server announces itself with its
+// application uri and the endpoint url the client uses for the
+// Hello/Acknowledge handshake.
+//
+// Specification: Part 6, 7.1.3
+func DialReverse(ctx context.Context, clientEndpoint, serverURI, endpoint string, ack *Acknowledge) (*Conn, error) {
+	if ack == nil {
+		ack = DefaultServerACK
+	}
+	debug.Printf("uacp: reverse connecting to %s", clientEndpoint)
+	_, raddr, err := ResolveEndpoint(clientEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var dl net.Dialer
+	c, err := dl.DialContext(ctx, "tcp", raddr.String())
+	if err != nil {
+		return nil, err
+	}
+	conn := &Conn{TCPConn: c.(*net.TCPConn), id: nextid(), ack: ack}
+
+	rhe := &ReverseHello{ServerURI: serverURI, EndpointURL: endpoint}
+	if err := conn.Send("RHEF", rhe); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if d, ok := ctx.Deadline(); ok {
+		conn.SetReadDeadline(d)
+	}
+	if err := conn.srvhandshake(endpoint); err != nil {
+		debug.Printf("uacp %d: reverse HEL/ACK handshake failed: %s", conn.id, err)
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
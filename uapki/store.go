@@ -0,0 +1,375 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package uapki implements a file based certificate store which
+// validates the certificates of OPC UA applications as defined in
+// Part 4, 6.1.3 and Part 12, 7.3 of the OPC-UA specifications.
+package uapki
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/ua"
+)
+
+// Directories of the store relative to its root directory.
+const (
+	// OwnCertsDir contains the certificates of the application.
+	OwnCertsDir = "own/certs"
+
+	// OwnPrivateDir contains the private keys of the application.
+	OwnPrivateDir = "own/private"
+
+	// TrustedCertsDir contains the trusted application and CA
+	// certificates.
+	TrustedCertsDir = "trusted/certs"
+
+	// TrustedCRLDir contains the revocation lists of the trusted CAs.
+	TrustedCRLDir = "trusted/crl"
+
+	// IssuersCertsDir contains the CA certificates which are needed to
+	// build certificate chains but which are not trusted themselves.
+	IssuersCertsDir = "issuers/certs"
+
+	// IssuersCRLDir contains the revocation lists of the issuer CAs.
+	IssuersCRLDir = "issuers/crl"
+
+	// RejectedCertsDir contains the certificates which failed the
+	// validation. An administrator can trust a rejected certificate by
+	// moving it to the trusted certificates.
+	RejectedCertsDir = "rejected/certs"
+)
+
+var storeDirs = []string{
+	OwnCertsDir,
+	OwnPrivateDir,
+	TrustedCertsDir,
+	TrustedCRLDir,
+	IssuersCertsDir,
+	IssuersCRLDir,
+	RejectedCertsDir,
+}
+
+// Store is a file based certificate store with the directory layout of
+// the OPC UA specification. Certificates and revocation lists are DER or
+// PEM encoded files.
+//
+// The files are read on every validation so that changes to the store
+// take effect without a restart. A missing directory is treated as empty
+// and is created again when a certificate is written to it.
+type Store struct {
+	dir string
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+// NewStore returns a store with the root directory dir. The directories
+// of the store are created if they do not exist.
+func NewStore(dir string) (*Store, error) {
+	for _, d := range storeDirs {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0700); err != nil {
+			return nil, errors.Errorf("uapki: %s", err)
+		}
+	}
+	return &Store{dir: dir, now: time.Now}, nil
+}
+
+// Dir returns the root directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Trust adds the DER encoded certificate to the trusted certificates.
+func (s *Store) Trust(cert []byte) error {
+	return s.writeCert(TrustedCertsDir, cert)
+}
+
+// Validate implements uasc.CertificateValidator.
+//
+// A certificate is trusted if the certificate itself or one of the CA
+// certificates of its chain is a trusted certificate. A trusted
+// certificate is accepted without building its chain. Otherwise, the
+// chain is built from the trusted and the issuer certificates and the
+// certificates which follow cert. Every CA of the chain must have a
+// revocation list.
+//
+// Certificates which fail the validation are copied to the rejected
+// certificates.
+func (s *Store) Validate(cert []byte, applicationURI string) error {
+	err := s.validate(cert, applicationURI)
+	if err == ua.StatusBadCertificateInvalid || err == ua.StatusBadCertificateURIInvalid {
+		return err
+	}
+	if err != nil {
+		if werr := s.writeCert(RejectedCertsDir, leafCert(cert)); werr != nil {
+			return werr
+		}
+	}
+	return err
+}
+
+func (s *Store) validate(b []byte, applicationURI string) error {
+	certs, err := x509.ParseCertificates(b)
+	if err != nil || len(certs) == 0 {
+		return ua.StatusBadCertificateInvalid
+	}
+	cert := certs[0]
+
+	now := s.now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return ua.StatusBadCertificateTimeInvalid
+	}
+	if applicationURI != "" && !hasURI(cert, applicationURI) {
+		return ua.StatusBadCertificateURIInvalid
+	}
+
+	trusted, err := s.readCerts(TrustedCertsDir)
+	if err != nil {
+		return err
+	}
+	if containsCert(trusted, cert) {
+		return nil
+	}
+
+	// an untrusted self-signed certificate has no chain
+	if isSelfSigned(cert) {
+		return ua.StatusBadCertificateUntrusted
+	}
+
+	issuers, err := s.readCerts(IssuersCertsDir)
+	if err != nil {
+		return err
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, c := range append(append(trusted, issuers...), certs[1:]...) {
+		if !c.IsCA {
+			continue
+		}
+		if isSelfSigned(c) {
+			roots.AddCert(c)
+		} else {
+			intermediates.AddCert(c)
+		}
+	}
+
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return verifyStatus(err)
+	}
+
+	crls, err := s.readCRLs()
+	if err != nil {
+		return err
+	}
+
+	status := ua.StatusBadCertificateUntrusted
+	for _, chain := range chains {
+		if !containsAny(trusted, chain) {
+			continue
+		}
+		if err := checkRevocation(chain, crls, now); err != nil {
+			status = err.(ua.StatusCode)
+			continue
+		}
+		return nil
+	}
+	return status
+}
+
+// verifyStatus returns the status code for an error of
+// x509.Certificate.Verify.
+func verifyStatus(err error) error {
+	switch x := err.(type) {
+	case x509.UnknownAuthorityError:
+		return ua.StatusBadCertificateChainIncomplete
+	case x509.CertificateInvalidError:
+		switch x.Reason {
+		case x509.Expired:
+			return ua.StatusBadCertificateIssuerTimeInvalid
+		case x509.NotAuthorizedToSign, x509.IncompatibleUsage:
+			return ua.StatusBadCertificateIssuerUseNotAllowed
+		}
+	}
+	return ua.StatusBadCertificateInvalid
+}
+
+// checkRevocation checks that no certificate of the chain is revoked by
+// its issuer.
+func checkRevocation(chain []*x509.Certificate, crls []*pkix.CertificateList, now time.Time) error {
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		revoked, unknown := ua.StatusBadCertificateRevoked, ua.StatusBadCertificateRevocationUnknown
+		if i > 0 {
+			revoked, unknown = ua.StatusBadCertificateIssuerRevoked, ua.StatusBadCertificateIssuerRevocationUnknown
+		}
+
+		crl := findCRL(crls, issuer, now)
+		if crl == nil {
+			return unknown
+		}
+		for _, rc := range crl.TBSCertList.RevokedCertificates {
+			if rc.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return revoked
+			}
+		}
+	}
+	return nil
+}
+
+// findCRL returns the current revocation list signed by the issuer.
+func findCRL(crls []*pkix.CertificateList, issuer *x509.Certificate, now time.Time) *pkix.CertificateList {
+	for _, crl := range crls {
+		name, err := asn1.Marshal(crl.TBSCertList.Issuer)
+		if err != nil || !bytes.Equal(name, issuer.RawSubject) {
+			continue
+		}
+		if issuer.CheckCRLSignature(crl) != nil || crl.HasExpired(now) {
+			continue
+		}
+		return crl
+	}
+	return nil
+}
+
+func (s *Store) readCerts(dir string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	err := s.readFiles(dir, "CERTIFICATE", func(b []byte) {
+		c, err := x509.ParseCertificates(b)
+		if err == nil {
+			certs = append(certs, c...)
+		}
+	})
+	return certs, err
+}
+
+func (s *Store) readCRLs() ([]*pkix.CertificateList, error) {
+	var crls []*pkix.CertificateList
+	fn := func(b []byte) {
+		if crl, err := x509.ParseDERCRL(b); err == nil {
+			crls = append(crls, crl)
+		}
+	}
+	if err := s.readFiles(TrustedCRLDir, "X509 CRL", fn); err != nil {
+		return nil, err
+	}
+	if err := s.readFiles(IssuersCRLDir, "X509 CRL", fn); err != nil {
+		return nil, err
+	}
+	return crls, nil
+}
+
+// readFiles calls fn with the DER encoded content of the files in the
+// directory. The content of PEM encoded files must have the block type
+// typ. Files which cannot be decoded are ignored.
+func (s *Store) readFiles(dir, typ string, fn func(b []byte)) error {
+	files, err := ioutil.ReadDir(filepath.Join(s.dir, dir))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Errorf("uapki: %s", err)
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(s.dir, dir, f.Name()))
+		if err != nil {
+			return errors.Errorf("uapki: %s", err)
+		}
+		if filepath.Ext(f.Name()) != ".pem" {
+			fn(b)
+			continue
+		}
+		for {
+			var block *pem.Block
+			block, b = pem.Decode(b)
+			if block == nil {
+				break
+			}
+			if block.Type == typ {
+				fn(block.Bytes)
+			}
+		}
+	}
+	return nil
+}
+
+// writeCert writes the DER encoded certificate to the directory. The
+// file name is the thumbprint of the certificate.
+func (s *Store) writeCert(dir string, cert []byte) error {
+	if err := os.MkdirAll(filepath.Join(s.dir, dir), 0700); err != nil {
+		return errors.Errorf("uapki: %s", err)
+	}
+	sum := sha1.Sum(cert)
+	name := filepath.Join(s.dir, dir, hex.EncodeToString(sum[:])+".der")
+	if err := ioutil.WriteFile(name, cert, 0600); err != nil {
+		return errors.Errorf("uapki: %s", err)
+	}
+	return nil
+}
+
+// leafCert returns the first certificate of a certificate chain.
+func leafCert(b []byte) []byte {
+	certs, err := x509.ParseCertificates(b)
+	if err != nil || len(certs) == 0 {
+		return b
+	}
+	return certs[0].Raw
+}
+
+func hasURI(cert *x509.Certificate, uri string) bool {
+	for _, u := range cert.URIs {
+		if u.String() == uri {
+			return true
+		}
+	}
+	return false
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+	// CheckSignatureFrom rejects application certificates which are not
+	// a CA
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+func containsCert(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(certs []*x509.Certificate, chain []*x509.Certificate) bool {
+	for _, c := range chain {
+		if containsCert(certs, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package uapki
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/pascaldekloe/goe/verify"
+)
+
+const testURI = "urn:gopcua:test"
+
+var testKey *rsa.PrivateKey
+
+func init() {
+	var err error
+	if testKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+}
+
+type testCert struct {
+	der  []byte
+	cert *x509.Certificate
+}
+
+// newTestCert creates a certificate which is signed by the parent or
+// self-signed if parent is nil.
+func newTestCert(t *testing.T, serial int64, ca bool, notAfter time.Time, parent *testCert) *testCert {
+	t.Helper()
+
+	uri, _ := url.Parse(testURI)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: big.NewInt(serial).String()},
+		NotBefore:             time.Now().Add(-2 * time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  ca,
+		URIs:                  []*url.URL{uri},
+	}
+	if ca {
+		tmpl.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	parentCert := tmpl
+	if parent != nil {
+		parentCert = parent.cert
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &testKey.PublicKey, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{der, cert}
+}
+
+// writeCRL writes a revocation list of the CA to the directory.
+func writeCRL(t *testing.T, s *Store, dir string, ca *testCert, revoked ...*testCert) {
+	t.Helper()
+
+	var list []pkix.RevokedCertificate
+	for _, c := range revoked {
+		list = append(list, pkix.RevokedCertificate{SerialNumber: c.cert.SerialNumber, RevocationTime: time.Now()})
+	}
+	b, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(1),
+		ThisUpdate:          time.Now().Add(-time.Hour),
+		NextUpdate:          time.Now().Add(time.Hour),
+		RevokedCertificates: list,
+	}, ca.cert, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: b})
+	if err := ioutil.WriteFile(filepath.Join(s.Dir(), dir, ca.cert.Subject.CommonName+".pem"), b, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeCert(t *testing.T, s *Store, dir string, c *testCert) {
+	t.Helper()
+
+	if err := s.writeCert(dir, c.der); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreSelfSigned(t *testing.T) {
+	s, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := time.Now().Add(time.Hour)
+	cert := newTestCert(t, 1, false, valid, nil)
+	verify.Values(t, "untrusted", s.Validate(cert.der, testURI), ua.StatusBadCertificateUntrusted)
+
+	rejected, err := s.readCerts(RejectedCertsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "rejected", len(rejected), 1)
+
+	if err := s.Trust(cert.der); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "trusted", s.Validate(cert.der, testURI), nil)
+	verify.Values(t, "no uri", s.Validate(cert.der, ""), nil)
+	verify.Values(t, "wrong uri", s.Validate(cert.der, "urn:other"), ua.StatusBadCertificateURIInvalid)
+	verify.Values(t, "invalid", s.Validate([]byte{1, 2, 3}, ""), ua.StatusBadCertificateInvalid)
+
+	expired := newTestCert(t, 2, false, time.Now().Add(-time.Hour), nil)
+	writeCert(t, s, TrustedCertsDir, expired)
+	verify.Values(t, "expired", s.Validate(expired.der, ""), ua.StatusBadCertificateTimeInvalid)
+}
+
+func TestStoreChain(t *testing.T) {
+	valid := time.Now().Add(time.Hour)
+	root := newTestCert(t, 1, true, valid, nil)
+	ca := newTestCert(t, 2, true, valid, root)
+	leaf := newTestCert(t, 3, false, valid, ca)
+	revoked := newTestCert(t, 4, false, valid, ca)
+	chain := append(append([]byte{}, leaf.der...), ca.der...)
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, s *Store)
+		cert  []byte
+		want  error
+	}{
+		{
+			name: "missing issuer",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+			},
+			cert: leaf.der,
+			want: ua.StatusBadCertificateChainIncomplete,
+		},
+		{
+			name: "untrusted issuers",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, IssuersCertsDir, root)
+				writeCert(t, s, IssuersCertsDir, ca)
+			},
+			cert: leaf.der,
+			want: ua.StatusBadCertificateUntrusted,
+		},
+		{
+			name: "revocation unknown",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+				writeCert(t, s, IssuersCertsDir, ca)
+				writeCRL(t, s, TrustedCRLDir, root)
+			},
+			cert: leaf.der,
+			want: ua.StatusBadCertificateRevocationUnknown,
+		},
+		{
+			name: "issuer revocation unknown",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+				writeCert(t, s, IssuersCertsDir, ca)
+				writeCRL(t, s, IssuersCRLDir, ca)
+			},
+			cert: leaf.der,
+			want: ua.StatusBadCertificateIssuerRevocationUnknown,
+		},
+		{
+			name: "trusted root",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+				writeCert(t, s, IssuersCertsDir, ca)
+				writeCRL(t, s, TrustedCRLDir, root)
+				writeCRL(t, s, IssuersCRLDir, ca)
+			},
+			cert: leaf.der,
+			want: nil,
+		},
+		{
+			name: "chain in certificate",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+				writeCRL(t, s, TrustedCRLDir, root)
+				writeCRL(t, s, IssuersCRLDir, ca)
+			},
+			cert: chain,
+			want: nil,
+		},
+		{
+			name: "trusted leaf",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, leaf)
+			},
+			cert: leaf.der,
+			want: nil,
+		},
+		{
+			name: "revoked",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+				writeCert(t, s, IssuersCertsDir, ca)
+				writeCRL(t, s, TrustedCRLDir, root)
+				writeCRL(t, s, IssuersCRLDir, ca, revoked)
+			},
+			cert: revoked.der,
+			want: ua.StatusBadCertificateRevoked,
+		},
+		{
+			name: "issuer revoked",
+			setup: func(t *testing.T, s *Store) {
+				writeCert(t, s, TrustedCertsDir, root)
+				writeCert(t, s, IssuersCertsDir, ca)
+				writeCRL(t, s, TrustedCRLDir, root, ca)
+				writeCRL(t, s, IssuersCRLDir, ca)
+			},
+			cert: leaf.der,
+			want: ua.StatusBadCertificateIssuerRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStore(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setup(t, s)
+			verify.Values(t, "", s.Validate(tt.cert, testURI), tt.want)
+		})
+	}
+}
+
+func TestStoreMissingDirs(t *testing.T) {
+	s, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range storeDirs {
+		if err := os.RemoveAll(filepath.Join(s.Dir(), d)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cert := newTestCert(t, 1, false, time.Now().Add(time.Hour), nil)
+	verify.Values(t, "untrusted", s.Validate(cert.der, testURI), ua.StatusBadCertificateUntrusted)
+
+	rejected, err := s.readCerts(RejectedCertsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "rejected", len(rejected), 1)
+
+	if err := s.Trust(cert.der); err != nil {
+		t.Fatal(err)
+	}
+	verify.Values(t, "trusted", s.Validate(cert.der, testURI), nil)
+}
@@ -45,6 +45,16 @@ type Config struct {
 	// Used to encrypt the message chunks in the OpenSecureChannel phase.
 	RemoteCertificate []byte
 
+	// RemoteApplicationURI is the application URI of the server from the
+	// endpoint description. If set, the certificate of the server must
+	// contain the URI.
+	RemoteApplicationURI string
+
+	// CertificateValidator validates the certificate of the server when a
+	// client side SecureChannel is opened. If it is nil, the certificate
+	// is not validated.
+	CertificateValidator CertificateValidator
+
 	// RequestIDSeed is the initial value for RequestID counter in each new SecureChannel
 	RequestIDSeed uint32
 
@@ -76,6 +86,24 @@ type Config struct {
 	// RequestTimeout is timeout duration for all synchronous requests over SecureChannel.
 	// If the Server doesn't respond within RequestTimeout time, Client returns StatusBadTimeout
 	RequestTimeout time.Duration
+
+	// AcceptSecurity is called by a server side SecureChannel when the client opens the
+	// channel. It returns an error if the server does not accept the security policy,
+	// the security mode or the certificate of the client. The certificate is nil if the
+	// security policy is None.
+	AcceptSecurity func(policyURI string, mode ua.MessageSecurityMode, cert []byte) error
+}
+
+// CertificateValidator validates the certificate of a remote application.
+type CertificateValidator interface {
+	// Validate returns nil if the DER encoded certificate is trusted.
+	// The certificate can be followed by the certificates of its chain.
+	// If applicationURI is not empty, the certificate must contain the
+	// URI. Otherwise, Validate returns one of the
+	// ua.StatusBadCertificate* status codes.
+	//
+	// Specification: Part 4, 6.1.3
+	Validate(cert []byte, applicationURI string) error
 }
 
 // SessionConfig is a set of common configurations used in Session.
@@ -47,6 +47,9 @@ func (m *MessageHeader) Decode(b []byte) (int, error) {
 type MessageChunk struct {
 	*MessageHeader
 	Data []byte
+
+	// buf is the receive buffer of the connection which Data references.
+	buf []byte
 }
 
 func (m *MessageChunk) Decode(b []byte) (int, error) {
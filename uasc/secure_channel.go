@@ -5,6 +5,7 @@
 package uasc
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
@@ -32,6 +33,10 @@ type response struct {
 	SCID  uint32
 	V     interface{}
 	Err   error
+
+	// release returns the receive buffer which V references to the pool.
+	// It is nil when V does not reference a receive buffer.
+	release func()
 }
 
 type conditionLocker struct {
@@ -104,6 +109,10 @@ type SecureChannel struct {
 	handlers   map[uint32]chan *response
 	handlersMu sync.Mutex
 
+	// releasers contains the request IDs whose handlers release the
+	// receive buffer of the response. Guarded by handlersMu.
+	releasers map[uint32]bool
+
 	// chunks maintains a temporary list of chunks for a given request ID
 	chunks   map[uint32][]*MessageChunk
 	chunksMu sync.Mutex
@@ -117,6 +126,15 @@ type SecureChannel struct {
 	// errorCh receive dispatcher errors
 	errCh chan<- error
 
+	// isServer is set for secure channels which were accepted by a server.
+	isServer bool
+
+	// secureChannelID is the id a server assigned to the secure channel.
+	secureChannelID uint32
+
+	// tokenID is the id of the last security token issued by a server.
+	tokenID uint32
+
 	closeOnce sync.Once
 }
 
@@ -157,6 +175,7 @@ func NewSecureChannel(endpoint string, c *uacp.Conn, cfg *Config, errCh chan<- e
 		instances:    make(map[uint32][]*channelInstance),
 		chunks:       make(map[uint32][]*MessageChunk),
 		handlers:     make(map[uint32]chan *response),
+		releasers:    make(map[uint32]bool),
 	}
 
 	return s, nil
@@ -226,6 +245,9 @@ func (s *SecureChannel) dispatcher() {
 			default:
 				// this should never happen since the chan is of size one
 				debug.Printf("uasc %d/%d: unexpected state. channel write should always succeed.", s.c.ID(), resp.ReqID)
+				if resp.release != nil {
+					resp.release()
+				}
 			}
 
 			s.rcvLocker.waitIfLock()
@@ -267,11 +289,14 @@ func (s *SecureChannel) receive(ctx context.Context) *response {
 
 			switch hdr.ChunkType {
 			case 'A':
+				s.releaseChunks(s.chunks[reqID])
 				delete(s.chunks, reqID)
 				s.chunksMu.Unlock()
 
 				msga := new(MessageAbort)
-				if _, err := msga.Decode(chunk.Data); err != nil {
+				_, err := msga.Decode(chunk.Data)
+				s.releaseChunks([]*MessageChunk{chunk})
+				if err != nil {
 					debug.Printf("uasc %d/%d: invalid MSGA chunk. %s", s.c.ID(), reqID, err)
 					resp.Err = ua.StatusBadDecodingError
 					return resp
@@ -282,6 +307,7 @@ func (s *SecureChannel) receive(ctx context.Context) *response {
 			case 'C':
 				s.chunks[reqID] = append(s.chunks[reqID], chunk)
 				if n := len(s.chunks[reqID]); uint32(n) > s.c.MaxChunkCount() {
+					s.releaseChunks(s.chunks[reqID])
 					delete(s.chunks, reqID)
 					s.chunksMu.Unlock()
 					resp.Err = errors.Errorf("too many chunks: %d > %d", n, s.c.MaxChunkCount())
@@ -299,11 +325,14 @@ func (s *SecureChannel) receive(ctx context.Context) *response {
 
 			b, err := mergeChunks(all)
 			if err != nil {
+				s.releaseChunks(all)
 				resp.Err = err
 				return resp
 			}
+			b, buf := s.detachBody(reqID, all, b)
 
 			if uint32(len(b)) > s.c.MaxMessageSize() {
+				s.c.Release(buf)
 				resp.Err = errors.Errorf("message too large: %d > %d", uint32(len(b)), s.c.MaxMessageSize())
 				return resp
 			}
@@ -317,11 +346,18 @@ func (s *SecureChannel) receive(ctx context.Context) *response {
 			// handlers and check them periodically to time them out.
 			_, svc, err := ua.DecodeService(b)
 			if err != nil {
+				s.c.Release(buf)
 				resp.Err = err
 				return resp
 			}
 
 			resp.V = svc
+			if buf != nil {
+				// a second release must not put the buffer into the
+				// pool twice since two messages would then share it
+				var once sync.Once
+				resp.release = func() { once.Do(func() { s.c.Release(buf) }) }
+			}
 
 			// If the service status is not OK then bubble
 			// that error up to the caller.
@@ -351,6 +387,22 @@ func (s *SecureChannel) readChunk() (*MessageChunk, error) {
 		return nil, errors.Errorf("sechan: read header failed: %s %#v", err, err)
 	}
 
+	m, err := s.decodeChunk(b)
+	switch {
+	case string(b[:3]) == "OPN":
+		// the asymmetric security header references the buffer
+	case err != nil || s.cfg.SecurityMode == ua.MessageSecurityModeSignAndEncrypt:
+		// the chunk is invalid or its data has been decrypted into a new buffer
+		s.c.Release(b)
+	default:
+		m.buf = b
+	}
+	return m, err
+}
+
+// decodeChunk decodes the headers of a message chunk and verifies and
+// decrypts its data.
+func (s *SecureChannel) decodeChunk(b []byte) (*MessageChunk, error) {
 	const hdrlen = 12 // TODO: move to pkg level const
 	h := new(Header)
 	if _, err := h.Decode(b[:hdrlen]); err != nil {
@@ -363,7 +415,10 @@ func (s *SecureChannel) readChunk() (*MessageChunk, error) {
 		return nil, errors.Errorf("sechan: decode chunk failed: %s", err)
 	}
 
-	var decryptWith *channelInstance
+	var (
+		decryptWith *channelInstance
+		err         error
+	)
 
 	switch m.MessageType {
 	case "OPN":
@@ -372,12 +427,25 @@ func (s *SecureChannel) readChunk() (*MessageChunk, error) {
 			return nil, ua.StatusBadDecodingError // todo(dh): check if this is the correct error
 		}
 
+		if s.isServer {
+			if decryptWith, err = s.newServerInstance(m.AsymmetricSecurityHeader); err != nil {
+				return nil, err
+			}
+			break
+		}
+
 		if s.openingInstance == nil {
 			return nil, errors.Errorf("sechan: invalid state. openingInstance is nil.")
 		}
 
 		if m.SecurityPolicyURI != ua.SecurityPolicyURINone {
-			s.cfg.RemoteCertificate = m.AsymmetricSecurityHeader.SenderCertificate
+			cert := m.AsymmetricSecurityHeader.SenderCertificate
+			if v := s.cfg.CertificateValidator; v != nil && !bytes.Equal(cert, s.cfg.RemoteCertificate) {
+				if err := v.Validate(cert, s.cfg.RemoteApplicationURI); err != nil {
+					return nil, err
+				}
+			}
+			s.cfg.RemoteCertificate = cert
 			debug.Printf("uasc %d: setting securityPolicy to %s", s.c.ID(), m.SecurityPolicyURI)
 		}
 
@@ -495,6 +563,11 @@ func (s *SecureChannel) open(ctx context.Context, instance *channelInstance, req
 		if remoteKey, ok = remoteCert.PublicKey.(*rsa.PublicKey); !ok {
 			return ua.StatusBadCertificateInvalid
 		}
+		if v := s.cfg.CertificateValidator; v != nil {
+			if err := v.Validate(s.cfg.RemoteCertificate, s.cfg.RemoteApplicationURI); err != nil {
+				return err
+			}
+		}
 	}
 
 	algo, err := uapolicy.Asymmetric(s.cfg.SecurityPolicyURI, localKey, remoteKey)
@@ -663,10 +736,31 @@ func (s *SecureChannel) sendRequestWithTimeout(
 	timeout time.Duration,
 	h func(interface{}) error) error {
 
+	var rh func(interface{}, func()) error
+	if h != nil {
+		rh = func(v interface{}, _ func()) error { return h(v) }
+	}
+	return s.sendReleaseRequestWithTimeout(ctx, req, reqID, instance, authToken, timeout, false, rh)
+}
+
+// sendReleaseRequestWithTimeout sends the request and calls h with the
+// response and a function which releases its receive buffer. If release
+// is false, the response never references a receive buffer and the
+// function is a no-op.
+func (s *SecureChannel) sendReleaseRequestWithTimeout(
+	ctx context.Context,
+	req ua.Request,
+	reqID uint32,
+	instance *channelInstance,
+	authToken *ua.NodeID,
+	timeout time.Duration,
+	release bool,
+	h func(interface{}, func()) error) error {
+
 	s.pendingReq.Add(1)
 	respRequired := h != nil
 
-	ch, err := s.sendAsyncWithTimeout(ctx, req, reqID, instance, authToken, respRequired, timeout)
+	ch, err := s.sendAsyncWithTimeout(ctx, req, reqID, instance, authToken, respRequired, release, timeout)
 	s.pendingReq.Done()
 	if err != nil {
 		return err
@@ -688,13 +782,17 @@ func (s *SecureChannel) sendRequestWithTimeout(
 		s.popHandler(reqID)
 		return io.EOF
 	case resp := <-ch:
+		free := resp.release
+		if free == nil {
+			free = func() {}
+		}
 		if resp.Err != nil {
 			if resp.V != nil {
-				_ = h(resp.V) // ignore result because resp.Err takes precedence
+				_ = h(resp.V, free) // ignore result because resp.Err takes precedence
 			}
 			return resp.Err
 		}
-		return h(resp.V)
+		return h(resp.V, free)
 	case <-timer.C:
 		s.popHandler(reqID)
 		return ua.StatusBadTimeout
@@ -705,6 +803,7 @@ func (s *SecureChannel) popHandler(reqID uint32) (chan *response, bool) {
 	s.handlersMu.Lock()
 	defer s.handlersMu.Unlock()
 
+	delete(s.releasers, reqID)
 	ch, ok := s.handlers[reqID]
 	if ok {
 		delete(s.handlers, reqID)
@@ -763,6 +862,30 @@ func (s *SecureChannel) SendRequestWithTimeoutWithContext(ctx context.Context, r
 	return s.sendRequestWithTimeout(ctx, req, s.nextRequestID(), active, authToken, timeout, h)
 }
 
+// SendRequestWithRelease sends the service request and calls h with the
+// response and a release function. The response may reference the pooled
+// receive buffer of the connection instead of a copy. Calling release
+// returns the buffer to the pool after which the response and all values
+// decoded from it must no longer be used. Calling release more than once
+// has no effect and not calling it is safe.
+func (s *SecureChannel) SendRequestWithRelease(ctx context.Context, req ua.Request, authToken *ua.NodeID, timeout time.Duration, h func(v interface{}, release func()) error) error {
+	s.reqLocker.waitIfLock()
+	active, err := s.getActiveChannelInstance()
+	if err != nil {
+		return err
+	}
+
+	return s.sendReleaseRequestWithTimeout(ctx, req, s.nextRequestID(), active, authToken, timeout, true, h)
+}
+
+// releasable returns true if the handler for the request releases the
+// receive buffer of the response.
+func (s *SecureChannel) releasable(reqID uint32) bool {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+	return s.releasers[reqID]
+}
+
 func (s *SecureChannel) sendAsyncWithTimeout(
 	ctx context.Context,
 	req ua.Request,
@@ -770,6 +893,7 @@ func (s *SecureChannel) sendAsyncWithTimeout(
 	instance *channelInstance,
 	authToken *ua.NodeID,
 	respRequired bool,
+	release bool,
 	timeout time.Duration,
 ) (<-chan *response, error) {
 
@@ -795,18 +919,38 @@ func (s *SecureChannel) sendAsyncWithTimeout(
 		}
 
 		s.handlers[reqID] = resp
+		if release {
+			s.releasers[reqID] = true
+		}
 		s.handlersMu.Unlock()
 	}
 
+	if err := s.sendMessage(ctx, m, instance, reqID); err != nil {
+		if respRequired {
+			s.popHandler(reqID)
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// sendMessage encodes the message into chunks, signs and encrypts them
+// and writes them to the connection. The caller must hold the lock of
+// the instance.
+func (s *SecureChannel) sendMessage(ctx context.Context, m *Message, instance *channelInstance, reqID uint32) error {
 	chunks, err := m.EncodeChunks(instance.maxBodySize)
 	if err != nil {
-		return nil, err
+		// nothing was sent and the next message can use the sequence
+		// number of this one.
+		instance.sequenceNumber = m.SequenceHeader.SequenceNumber - 1
+		return err
 	}
 
 	for i, chunk := range chunks {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 		if i > 0 { // fix sequence number on subsequent chunks
@@ -816,22 +960,22 @@ func (s *SecureChannel) sendAsyncWithTimeout(
 
 		chunk, err = instance.signAndEncrypt(m, chunk)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// send the message
 		var n int
 		if n, err = s.c.Write(chunk); err != nil {
-			return nil, err
+			return err
 		}
 
 		atomic.AddUint64(&instance.bytesSent, uint64(n))
 		atomic.AddUint32(&instance.messagesSent, 1)
 
-		debug.Printf("uasc %d/%d: send %T with %d bytes", s.c.ID(), reqID, req, len(chunk))
+		debug.Printf("uasc %d/%d: send %T with %d bytes", s.c.ID(), reqID, m.Service, len(chunk))
 	}
 
-	return resp, nil
+	return nil
 }
 
 func (s *SecureChannel) nextRequestID() uint32 {
@@ -865,6 +1009,11 @@ func (s *SecureChannel) close() error {
 	s.reqLocker.unlock()
 	s.rcvLocker.unlock()
 
+	// the client closes the channel on the server side
+	if s.isServer {
+		return io.EOF
+	}
+
 	select {
 	case <-s.disconnected:
 		return io.EOF
@@ -894,7 +1043,12 @@ func mergeChunks(chunks []*MessageChunk) ([]byte, error) {
 		return chunks[0].Data, nil
 	}
 
-	var b []byte
+	n := 0
+	for _, c := range chunks {
+		n += len(c.Data)
+	}
+
+	b := make([]byte, 0, n)
 	var seqnr uint32
 	for _, c := range chunks {
 		if c.SequenceHeader.SequenceNumber == seqnr {
@@ -905,3 +1059,29 @@ func mergeChunks(chunks []*MessageChunk) ([]byte, error) {
 	}
 	return b, nil
 }
+
+// releaseChunks returns the receive buffers of the chunks to the pool.
+func (s *SecureChannel) releaseChunks(chunks []*MessageChunk) {
+	for _, c := range chunks {
+		s.c.Release(c.buf)
+		c.buf = nil
+	}
+}
+
+// detachBody returns the body of a message which has been merged from
+// the chunks and the receive buffer it references. The buffer is only
+// kept when the handler of the request releases it. Otherwise, the
+// body is copied and all buffers are returned to the pool.
+func (s *SecureChannel) detachBody(reqID uint32, chunks []*MessageChunk, b []byte) ([]byte, []byte) {
+	var buf []byte
+	switch {
+	case len(chunks) != 1 || chunks[0].buf == nil:
+		// b does not reference a receive buffer
+	case s.releasable(reqID):
+		buf, chunks[0].buf = chunks[0].buf, nil
+	default:
+		b = append([]byte(nil), b...)
+	}
+	s.releaseChunks(chunks)
+	return b, buf
+}
@@ -5,10 +5,12 @@
 package uasc
 
 import (
+	"bytes"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/binary"
 
+	"github.com/imatic-tech/opcua/errors"
 	"github.com/imatic-tech/opcua/ua"
 	"github.com/imatic-tech/opcua/uapolicy"
 )
@@ -124,3 +126,64 @@ func (s *SecureChannel) NewUserTokenSignature(policyURI string, cert, nonce []by
 
 	return sig, sigAlg, nil
 }
+
+// DecryptUserPassword decrypts a password which was encrypted by the client with
+// EncryptUserPassword. nonce is the server nonce the client used for the encryption.
+func (s *SecureChannel) DecryptUserPassword(policyURI string, secret, nonce []byte) (string, error) {
+	// If the User ID Token's policy was null, then default to the secure channel's policy
+	if policyURI == "" {
+		policyURI = s.cfg.SecurityPolicyURI
+	}
+
+	if policyURI == ua.SecurityPolicyURINone {
+		return string(secret), nil
+	}
+
+	enc, err := uapolicy.Asymmetric(policyURI, s.cfg.LocalKey, nil)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := enc.Decrypt(secret)
+	if err != nil {
+		return "", err
+	}
+
+	if len(b) < 4 {
+		return "", errors.Errorf("invalid user password")
+	}
+	l := int(binary.LittleEndian.Uint32(b))
+	if l < len(nonce) || 4+l > len(b) {
+		return "", errors.Errorf("invalid user password")
+	}
+	b = b[4 : 4+l]
+	if !bytes.Equal(b[len(b)-len(nonce):], nonce) {
+		return "", errors.Errorf("invalid user password nonce")
+	}
+
+	return string(b[:len(b)-len(nonce)]), nil
+}
+
+// VerifyUserTokenSignature checks the signature of a X509IdentityToken which the
+// client created with NewUserTokenSignature. cert is the certificate of the user.
+func (s *SecureChannel) VerifyUserTokenSignature(policyURI string, cert, nonce, signature []byte) error {
+	if policyURI == ua.SecurityPolicyURINone {
+		return nil
+	}
+
+	remoteX509Cert, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return err
+	}
+	remoteKey, ok := remoteX509Cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ua.StatusBadCertificateInvalid
+	}
+
+	enc, err := uapolicy.Asymmetric(policyURI, nil, remoteKey)
+	if err != nil {
+		return err
+	}
+
+	return enc.VerifySignature(append(s.cfg.Certificate, nonce...), signature)
+}
@@ -215,15 +215,15 @@ func (c *channelInstance) verifyAndDecrypt(m *MessageChunk, r []byte) ([]byte, e
 		headerLength += m.SymmetricSecurityHeader.Len()
 	}
 
-	b := make([]byte, len(r))
-	copy(b, r)
-
+	// signed messages are verified in place. r must not be modified since
+	// it is verified again with older channel instances on failure.
+	b := r
 	if c.sc.cfg.SecurityMode == ua.MessageSecurityModeSignAndEncrypt || isAsymmetric {
-		p, err := c.algo.Decrypt(b[headerLength:])
+		p, err := c.algo.Decrypt(r[headerLength:])
 		if err != nil {
 			return nil, ua.StatusBadSecurityChecksFailed
 		}
-		b = append(b[:headerLength], p...)
+		b = append(r[:headerLength:headerLength], p...)
 	}
 
 	signature := b[len(b)-c.algo.RemoteSignatureLength():]
@@ -0,0 +1,391 @@
+// Copyright 2018-2020 opcua authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package uasc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"time"
+
+	"github.com/imatic-tech/opcua/debug"
+	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uacp"
+	"github.com/imatic-tech/opcua/uapolicy"
+)
+
+// Request is a service request which was received by a server side
+// secure channel.
+type Request struct {
+	// ReqID is the id of the secure channel request. The response
+	// must be sent with the same id.
+	ReqID uint32
+
+	// Value is the decoded service request.
+	Value ua.Request
+}
+
+// NewServerSecureChannel creates a secure channel for a connection which
+// was accepted by a server.
+//
+// The security policy and the security mode are chosen by the client
+// when it opens the channel. cfg must contain the certificate and the
+// private key of the server if secure connections are supported. The
+// secure channel modifies cfg which must therefore not be shared
+// between channels.
+func NewServerSecureChannel(endpoint string, c *uacp.Conn, cfg *Config, secureChannelID uint32) (*SecureChannel, error) {
+	if c == nil {
+		return nil, errors.Errorf("no connection")
+	}
+
+	if cfg == nil {
+		return nil, errors.Errorf("no secure channel config")
+	}
+
+	s := &SecureChannel{
+		endpointURL:     endpoint,
+		c:               c,
+		cfg:             cfg,
+		isServer:        true,
+		secureChannelID: secureChannelID,
+		reqLocker:       newConditionLocker(),
+		rcvLocker:       newConditionLocker(),
+		closing:         make(chan struct{}),
+		disconnected:    make(chan struct{}),
+		instances:       make(map[uint32][]*channelInstance),
+		chunks:          make(map[uint32][]*MessageChunk),
+		handlers:        make(map[uint32]chan *response),
+	}
+
+	return s, nil
+}
+
+// ID returns the id of the secure channel. It is zero for client side
+// secure channels which have not been opened.
+func (s *SecureChannel) ID() uint32 {
+	if s.isServer {
+		return s.secureChannelID
+	}
+	instance, err := s.getActiveChannelInstance()
+	if err != nil {
+		return 0
+	}
+	return instance.secureChannelID
+}
+
+// SecurityPolicyURI returns the security policy of the secure channel.
+func (s *SecureChannel) SecurityPolicyURI() string {
+	return s.cfg.SecurityPolicyURI
+}
+
+// SecurityMode returns the security mode of the secure channel.
+func (s *SecureChannel) SecurityMode() ua.MessageSecurityMode {
+	return s.cfg.SecurityMode
+}
+
+// RemoteCertificate returns the certificate of the remote side of the
+// secure channel. It is nil if the channel is not secured.
+func (s *SecureChannel) RemoteCertificate() []byte {
+	return s.cfg.RemoteCertificate
+}
+
+// ReadRequest reads the next service request from a server side secure
+// channel.
+//
+// OpenSecureChannel requests are handled by the secure channel itself.
+// Requests which cannot be decoded are answered with a ServiceFault.
+// ReadRequest returns io.EOF when the client closes the secure channel.
+// It must not be called concurrently.
+func (s *SecureChannel) ReadRequest() (*Request, error) {
+	if !s.isServer {
+		return nil, errors.Errorf("sechan: not a server secure channel")
+	}
+
+	for {
+		chunk, err := s.readChunk()
+		if err != nil {
+			return nil, err
+		}
+
+		hdr := chunk.Header
+		reqID := chunk.SequenceHeader.RequestID
+
+		debug.Printf("uasc %d/%d: recv %s%c with %d bytes", s.c.ID(), reqID, hdr.MessageType, hdr.ChunkType, hdr.MessageSize)
+
+		switch hdr.ChunkType {
+		case 'A':
+			s.releaseChunks(append(s.chunks[reqID], chunk))
+			delete(s.chunks, reqID)
+			continue
+
+		case 'C':
+			s.chunks[reqID] = append(s.chunks[reqID], chunk)
+			if n := len(s.chunks[reqID]); uint32(n) > s.c.MaxChunkCount() {
+				s.releaseChunks(s.chunks[reqID])
+				delete(s.chunks, reqID)
+				return nil, errors.Errorf("too many chunks: %d > %d", n, s.c.MaxChunkCount())
+			}
+			continue
+		}
+
+		all := append(s.chunks[reqID], chunk)
+		delete(s.chunks, reqID)
+
+		b, err := mergeChunks(all)
+		if err != nil {
+			s.releaseChunks(all)
+			return nil, err
+		}
+		// requests are handled asynchronously and may keep values
+		// decoded from the message. Therefore, b is always detached
+		// from the receive buffer.
+		b, _ = s.detachBody(reqID, all, b)
+
+		if uint32(len(b)) > s.c.MaxMessageSize() {
+			return nil, errors.Errorf("message too large: %d > %d", uint32(len(b)), s.c.MaxMessageSize())
+		}
+
+		_, svc, err := ua.DecodeService(b)
+		if err != nil {
+			debug.Printf("uasc %d/%d: cannot decode request: %s", s.c.ID(), reqID, err)
+			if hdr.MessageType == "OPN" {
+				return nil, err
+			}
+			if err := s.SendResponse(reqID, newServiceFault(s.timeNow(), 0, ua.StatusBadDecodingError)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		req, ok := svc.(ua.Request)
+		if !ok {
+			if hdr.MessageType == "OPN" {
+				return nil, ua.StatusBadTCPMessageTypeInvalid
+			}
+			if err := s.SendResponse(reqID, newServiceFault(s.timeNow(), 0, ua.StatusBadServiceUnsupported)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if hdr.MessageType == "OPN" {
+			if err := s.handleOpenSecureChannelRequest(reqID, req); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if _, err := s.getActiveChannelInstance(); err != nil {
+			return nil, ua.StatusBadSecureChannelIDInvalid
+		}
+
+		return &Request{ReqID: reqID, Value: req}, nil
+	}
+}
+
+// SendResponse sends the response for the request with the given id on
+// a server side secure channel. It is safe for concurrent use.
+func (s *SecureChannel) SendResponse(reqID uint32, resp ua.Response) error {
+	instance, err := s.getActiveChannelInstance()
+	if err != nil {
+		return err
+	}
+	return s.sendResponse(instance, reqID, resp)
+}
+
+func (s *SecureChannel) sendResponse(instance *channelInstance, reqID uint32, resp ua.Response) error {
+	typeID := ua.ServiceTypeID(resp)
+	if typeID == 0 {
+		return errors.Errorf("unknown service %T. Did you call register?", resp)
+	}
+
+	instance.Lock()
+	defer instance.Unlock()
+
+	m := instance.newMessage(resp, typeID, reqID)
+	return s.sendMessage(context.Background(), m, instance, reqID)
+}
+
+// newServerInstance creates the channel instance which decrypts an
+// OpenSecureChannel request and encrypts the response with the
+// asymmetric algorithms of the requested security policy.
+func (s *SecureChannel) newServerInstance(h *AsymmetricSecurityHeader) (*channelInstance, error) {
+	renew := s.activeInstance != nil
+	if renew && h.SecurityPolicyURI != s.cfg.SecurityPolicyURI {
+		return nil, ua.StatusBadSecurityPolicyRejected
+	}
+	// the certificate is only validated when the channel is issued and
+	// must not change when the token is renewed
+	if renew && !bytes.Equal(h.SenderCertificate, s.cfg.RemoteCertificate) {
+		return nil, ua.StatusBadSecurityChecksFailed
+	}
+
+	var (
+		localKey  *rsa.PrivateKey
+		remoteKey *rsa.PublicKey
+	)
+
+	if h.SecurityPolicyURI != ua.SecurityPolicyURINone {
+		if s.cfg.LocalKey == nil || len(s.cfg.Certificate) == 0 {
+			return nil, ua.StatusBadSecurityPolicyRejected
+		}
+		if !bytes.Equal(h.ReceiverCertificateThumbprint, uapolicy.Thumbprint(s.cfg.Certificate)) {
+			return nil, ua.StatusBadSecurityChecksFailed
+		}
+
+		remoteCert, err := x509.ParseCertificate(h.SenderCertificate)
+		if err != nil {
+			return nil, ua.StatusBadCertificateInvalid
+		}
+		var ok bool
+		if remoteKey, ok = remoteCert.PublicKey.(*rsa.PublicKey); !ok {
+			return nil, ua.StatusBadCertificateInvalid
+		}
+		localKey = s.cfg.LocalKey
+
+		if !renew {
+			s.cfg.RemoteCertificate = h.SenderCertificate
+			s.cfg.Thumbprint = uapolicy.Thumbprint(h.SenderCertificate)
+		}
+
+		// The OpenSecureChannel messages are always signed and encrypted.
+		// The security mode for all other messages is part of the request
+		// and set once it has been decoded.
+		if s.cfg.SecurityMode == ua.MessageSecurityModeNone || s.cfg.SecurityMode == ua.MessageSecurityModeInvalid {
+			s.cfg.SecurityMode = ua.MessageSecurityModeSign
+		}
+	} else {
+		s.cfg.SecurityMode = ua.MessageSecurityModeNone
+	}
+
+	algo, err := uapolicy.Asymmetric(h.SecurityPolicyURI, localKey, remoteKey)
+	if err != nil {
+		return nil, ua.StatusBadSecurityPolicyRejected
+	}
+
+	debug.Printf("uasc %d: setting securityPolicy to %s", s.c.ID(), h.SecurityPolicyURI)
+	s.cfg.SecurityPolicyURI = h.SecurityPolicyURI
+
+	instance := newChannelInstance(s)
+	instance.algo = algo
+	instance.SetMaximumBodySize(int(s.c.SendBufSize()))
+	s.openingInstance = instance
+
+	return instance, nil
+}
+
+// handleOpenSecureChannelRequest issues or renews the security token of a
+// server side secure channel.
+func (s *SecureChannel) handleOpenSecureChannelRequest(reqID uint32, v ua.Request) error {
+	instance := s.openingInstance
+	defer func() { s.openingInstance = nil }()
+
+	req, ok := v.(*ua.OpenSecureChannelRequest)
+	if !ok || instance == nil {
+		return ua.StatusBadTCPMessageTypeInvalid
+	}
+
+	active, _ := s.getActiveChannelInstance()
+
+	switch req.RequestType {
+	case ua.SecurityTokenRequestTypeIssue:
+		if active != nil {
+			return ua.StatusBadRequestTypeInvalid
+		}
+		secure := s.cfg.SecurityPolicyURI != ua.SecurityPolicyURINone
+		if secure == (req.SecurityMode == ua.MessageSecurityModeNone) || req.SecurityMode == ua.MessageSecurityModeInvalid {
+			return ua.StatusBadSecurityModeRejected
+		}
+		s.cfg.SecurityMode = req.SecurityMode
+		if s.cfg.AcceptSecurity != nil {
+			if err := s.cfg.AcceptSecurity(s.cfg.SecurityPolicyURI, s.cfg.SecurityMode, s.cfg.RemoteCertificate); err != nil {
+				return err
+			}
+		}
+
+	case ua.SecurityTokenRequestTypeRenew:
+		if active == nil {
+			return ua.StatusBadRequestTypeInvalid
+		}
+		if req.SecurityMode != s.cfg.SecurityMode {
+			return ua.StatusBadSecurityModeRejected
+		}
+		active.Lock()
+		instance.sequenceNumber = active.sequenceNumber
+		active.Unlock()
+
+	default:
+		return ua.StatusBadRequestTypeInvalid
+	}
+
+	serverNonce, err := instance.algo.MakeNonce()
+	if err != nil {
+		return err
+	}
+
+	lifetime := req.RequestedLifetime
+	if lifetime == 0 || (s.cfg.Lifetime > 0 && lifetime > s.cfg.Lifetime) {
+		lifetime = s.cfg.Lifetime
+	}
+
+	s.tokenID++
+	instance.secureChannelID = s.secureChannelID
+	instance.securityTokenID = s.tokenID
+	instance.createdAt = s.timeNow()
+	instance.revisedLifetime = time.Millisecond * time.Duration(lifetime)
+
+	var handle uint32
+	if req.RequestHeader != nil {
+		handle = req.RequestHeader.RequestHandle
+	}
+
+	resp := &ua.OpenSecureChannelResponse{
+		ResponseHeader:        newResponseHeader(instance.createdAt, handle, ua.StatusOK),
+		ServerProtocolVersion: 0,
+		SecurityToken: &ua.ChannelSecurityToken{
+			ChannelID:       instance.secureChannelID,
+			TokenID:         instance.securityTokenID,
+			CreatedAt:       instance.createdAt,
+			RevisedLifetime: lifetime,
+		},
+		ServerNonce: serverNonce,
+	}
+
+	if err := s.sendResponse(instance, reqID, resp); err != nil {
+		return err
+	}
+
+	// all other messages are secured with the symmetric algorithms
+	if instance.algo, err = uapolicy.Symmetric(s.cfg.SecurityPolicyURI, serverNonce, req.ClientNonce); err != nil {
+		return err
+	}
+	instance.SetMaximumBodySize(int(s.c.SendBufSize()))
+	instance.state = channelActive
+
+	s.instancesMu.Lock()
+	defer s.instancesMu.Unlock()
+
+	s.instances[instance.secureChannelID] = append(s.instances[instance.secureChannelID], instance)
+	s.activeInstance = instance
+
+	debug.Printf("uasc %d: issued security token. channelID=%d tokenID=%d createdAt=%s lifetime=%s", s.c.ID(), instance.secureChannelID, instance.securityTokenID, instance.createdAt.Format(time.RFC3339), instance.revisedLifetime)
+
+	return nil
+}
+
+func newResponseHeader(now time.Time, handle uint32, status ua.StatusCode) *ua.ResponseHeader {
+	return &ua.ResponseHeader{
+		Timestamp:          now,
+		RequestHandle:      handle,
+		ServiceResult:      status,
+		ServiceDiagnostics: &ua.DiagnosticInfo{},
+	}
+}
+
+func newServiceFault(now time.Time, handle uint32, status ua.StatusCode) *ua.ServiceFault {
+	return &ua.ServiceFault{ResponseHeader: newResponseHeader(now, handle, status)}
+}
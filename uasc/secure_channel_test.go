@@ -1,6 +1,7 @@
 package uasc
 
 import (
+	"context"
 	"math"
 	"testing"
 	"time"
@@ -145,3 +146,40 @@ func TestNewRequestMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSendRequestEncodeError(t *testing.T) {
+	sc := &SecureChannel{
+		cfg:      &Config{},
+		time:     time.Now,
+		handlers: make(map[uint32]chan *response),
+	}
+	instance := &channelInstance{sc: sc, sequenceNumber: 10}
+	sc.activeInstance = instance
+
+	// node ids with a namespace uri cannot be encoded
+	req := &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: ua.MustParseNodeID("nsu=urn:a;i=1")}},
+	}
+	_, err := sc.sendAsyncWithTimeout(context.Background(), req, 1, instance, nil, true, false, time.Second)
+	verify.Values(t, "error", err, ua.ErrNamespaceURI)
+	verify.Values(t, "sequence number", instance.sequenceNumber, uint32(10))
+	verify.Values(t, "handlers", len(sc.handlers), 0)
+}
+
+func TestRenewWithOtherCertificate(t *testing.T) {
+	cert := []byte{1, 2, 3}
+	sc := &SecureChannel{
+		cfg: &Config{
+			SecurityPolicyURI: ua.SecurityPolicyURINone,
+			RemoteCertificate: cert,
+		},
+	}
+	sc.activeInstance = &channelInstance{sc: sc}
+
+	_, err := sc.newServerInstance(&AsymmetricSecurityHeader{
+		SecurityPolicyURI: ua.SecurityPolicyURINone,
+		SenderCertificate: []byte{4, 5, 6},
+	})
+	verify.Values(t, "error", err, ua.StatusBadSecurityChecksFailed)
+	verify.Values(t, "certificate", sc.cfg.RemoteCertificate, cert)
+}
@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -13,12 +10,59 @@ import (
 )
 
 type Complex struct {
-	i, j int64
+	I, J int64
 }
 
-func TestCallMethod(t *testing.T) {
+func init() {
 	ua.RegisterExtensionObject(ua.NewStringNodeID(2, "ComplexType"), new(Complex))
+}
+
+// methodNodes are the nodes for the method tests.
+var methodNodes = []Node{
+	{Name: "main"},
+	{Name: "even", Parent: "main", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		n, status := int64Arg(args)
+		if status != ua.StatusOK {
+			return nil, status
+		}
+		return []*ua.Variant{ua.MustVariant(n%2 == 0)}, ua.StatusOK
+	}},
+	{Name: "square", Parent: "main", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		n, status := int64Arg(args)
+		if status != ua.StatusOK {
+			return nil, status
+		}
+		return []*ua.Variant{ua.MustVariant(n * n)}, ua.StatusOK
+	}},
+	{Name: "sumOfSquare", Parent: "main", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		if len(args) != 1 {
+			return nil, ua.StatusBadArgumentsMissing
+		}
+		eo, ok := args[0].Value().(*ua.ExtensionObject)
+		if !ok {
+			return nil, ua.StatusBadInvalidArgument
+		}
+		c, ok := eo.Value.(*Complex)
+		if !ok {
+			return nil, ua.StatusBadInvalidArgument
+		}
+		return []*ua.Variant{ua.MustVariant(c.I*c.I + c.J*c.J)}, ua.StatusOK
+	}},
+}
 
+// int64Arg returns the single Int64 input argument.
+func int64Arg(args []*ua.Variant) (int64, ua.StatusCode) {
+	if len(args) != 1 {
+		return 0, ua.StatusBadArgumentsMissing
+	}
+	n, ok := args[0].Value().(int64)
+	if !ok {
+		return 0, ua.StatusBadInvalidArgument
+	}
+	return n, ua.StatusOK
+}
+
+func TestCallMethod(t *testing.T) {
 	tests := []struct {
 		req *ua.CallMethodRequest
 		out []*ua.Variant
@@ -57,7 +101,7 @@ func TestCallMethod(t *testing.T) {
 
 	ctx := context.Background()
 
-	srv := NewServer("method_server.py")
+	srv := NewServer(methodNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)
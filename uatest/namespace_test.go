@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -15,7 +12,7 @@ import (
 func TestNamespace(t *testing.T) {
 	ctx := context.Background()
 
-	srv := NewServer("rw_server.py")
+	srv := NewServer(rwNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)
@@ -31,13 +28,13 @@ func TestNamespace(t *testing.T) {
 		}
 		want := []string{
 			"http://opcfoundation.org/UA/",
-			"urn:freeopcua:python:server",
+			ApplicationURI,
 			"http://gopcua.com/",
 		}
 		verify.Values(t, "", got, want)
 	})
 	t.Run("FindNamespace", func(t *testing.T) {
-		ns, err := c.FindNamespaceWithContext(ctx, "http://gopcua.com/")
+		ns, err := c.FindNamespaceWithContext(ctx, Namespace)
 		if err != nil {
 			t.Fatal(err)
 		}
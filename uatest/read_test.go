@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -13,6 +10,17 @@ import (
 	"github.com/imatic-tech/opcua/ua"
 )
 
+// rwNodes are the nodes for the read and write tests.
+var rwNodes = []Node{
+	{Name: "main"},
+	{Name: "ro_bool", Parent: "main", Value: true},
+	{Name: "rw_bool", Parent: "main", Value: true, Writable: true},
+	{Name: "ro_int32", Parent: "main", Value: int32(5)},
+	{Name: "rw_int32", Parent: "main", Value: int32(5), Writable: true},
+	{Name: "array_int32", Parent: "main", Value: []int32{1, 2, 3}},
+	{Name: "2d_array_int32", Parent: "main", Value: [][]int32{{1}, {2}, {3}}},
+}
+
 // TestRead performs an integration test to read values
 // from an OPC/UA server.
 func TestRead(t *testing.T) {
@@ -30,7 +38,7 @@ func TestRead(t *testing.T) {
 
 	ctx := context.Background()
 
-	srv := NewServer("rw_server.py")
+	srv := NewServer(rwNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)
@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -12,12 +9,28 @@ import (
 	"github.com/imatic-tech/opcua/ua"
 )
 
+// intVal is the value of a structure whose data type the client does
+// not know.
+type intVal struct {
+	I int64
+}
+
+// unknownTypeNodes are the nodes for the unknown data type test.
+var unknownTypeNodes = []Node{
+	{Name: "main"},
+	{Name: "IntValZero", Parent: "main", Value: &ua.ExtensionObject{
+		TypeID:       &ua.ExpandedNodeID{NodeID: ua.NewStringNodeID(2, "IntValType")},
+		EncodingMask: ua.ExtensionObjectBinary,
+		Value:        &intVal{},
+	}},
+}
+
 // TestRead performs an integration test to read values
 // from an OPC/UA server.
 func TestReadUnknowNodeID(t *testing.T) {
 	ctx := context.Background()
 
-	srv := NewServer("read_unknow_node_id_server.py")
+	srv := NewServer(unknownTypeNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)
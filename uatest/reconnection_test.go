@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -19,12 +16,34 @@ const (
 	reconnectionTimeout = 10 * time.Second
 )
 
+// reconnectionNodes are the nodes for the reconnection tests. The
+// methods simulate failures of the server.
+var reconnectionNodes = []Node{
+	{Name: "simulations"},
+	{Name: "simulate_connection_failure", Parent: "simulations", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		s.CloseConnections()
+		return nil, ua.StatusOK
+	}},
+	{Name: "simulate_securechannel_failure", Parent: "simulations", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		s.SendError(ua.StatusBadSecureChannelIDInvalid)
+		return nil, ua.StatusOK
+	}},
+	{Name: "simulate_session_failure", Parent: "simulations", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		s.SendError(ua.StatusBadSessionIDInvalid)
+		return nil, ua.StatusOK
+	}},
+	{Name: "simulate_subscription_failure", Parent: "simulations", Method: func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+		s.SendError(ua.StatusBadSubscriptionIDInvalid)
+		return nil, ua.StatusOK
+	}},
+}
+
 // TestAutoReconnection performs an integration test the auto reconnection
 // from an OPC/UA server.
 func TestAutoReconnection(t *testing.T) {
 	ctx := context.Background()
 
-	srv := NewServer("reconnection_server.py")
+	srv := NewServer(reconnectionNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)
@@ -106,6 +125,7 @@ func TestAutoReconnection(t *testing.T) {
 			go c.CallWithContext(ctx, tt.req)
 
 			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
 			go func() {
 				// make sure the connection is down
 				for {
@@ -1,102 +1,360 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
+	"context"
+	"io"
 	"net"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"time"
+	"net/url"
+	"sync"
 
 	"github.com/imatic-tech/opcua"
 	"github.com/imatic-tech/opcua/errors"
+	"github.com/imatic-tech/opcua/id"
 	"github.com/imatic-tech/opcua/ua"
+	"github.com/imatic-tech/opcua/uacp"
 )
 
-// Server runs a python test server.
-type Server struct {
-	// Path is the path to the Python server.
-	Path string
+// ApplicationURI is the application URI of the test server and the
+// namespace URI of namespace 1.
+const ApplicationURI = "urn:gopcua:uatest"
+
+// Namespace is the namespace URI of the test nodes. It has the namespace
+// index 2.
+const Namespace = "http://gopcua.com/"
+
+// Node describes a node of the test namespace. A node with a Method is a
+// method, a node with a Value is a variable and all other nodes are
+// objects.
+type Node struct {
+	// Name is the string identifier of the node id and the browse name.
+	Name string
+
+	// Parent is the name of the object which contains the node. Nodes
+	// without parent are organized by the Objects folder. The parent
+	// must be declared before the node.
+	Parent string
+
+	// Value is the initial value of a variable. The data type and the
+	// value rank of the variable are derived from it.
+	Value interface{}
+
+	// Writable allows clients to write the value of a variable.
+	Writable bool
 
-	// Endpoint is the endpoint address which will be set
-	// after the server has started.
+	// Method is called when a client calls the method.
+	Method func(s *Server, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode)
+}
+
+// Server runs an in-process test server on a random loopback port.
+//
+// The clients are connected via a proxy which allows to simulate
+// connection failures and errors of the server.
+type Server struct {
+	// Endpoint is the endpoint address of the server.
 	Endpoint string
 
 	// Opts contains the client options required to connect to the server.
-	// They are valid after the server has been started.
 	Opts []opcua.Option
 
-	cmd    *exec.Cmd
-	waitch chan error
+	srv   *opcua.Server
+	ns    uint16
+	proxy *proxy
 }
 
-// NewServer creates a test server and starts it. The function
-// panics if the server cannot be started.
-func NewServer(path string) *Server {
-	s := &Server{Path: path, waitch: make(chan error)}
-	if err := s.Run(); err != nil {
+// NewServer creates a test server with the given nodes and starts it.
+// The function panics if the server cannot be started.
+func NewServer(nodes []Node, opts ...opcua.ServerOption) *Server {
+	s, err := startServer(nodes, opts...)
+	if err != nil {
 		panic(err)
 	}
 	return s
 }
 
-func (s *Server) Run() error {
-	wd, err := os.Getwd()
-	if err != nil {
-		return err
+func startServer(nodes []Node, opts ...opcua.ServerOption) (*Server, error) {
+	opts = append([]opcua.ServerOption{opcua.ServerApplicationURI(ApplicationURI)}, opts...)
+	s := &Server{srv: opcua.NewServer("opc.tcp://127.0.0.1:0", opts...)}
+
+	as := s.srv.AddressSpace()
+	s.ns = as.AddNamespace(Namespace, nil)
+	for _, n := range nodes {
+		if err := as.AddNode(s.node(n)); err != nil {
+			return nil, errors.Errorf("node %s: %s", n.Name, err)
+		}
 	}
-	path := filepath.Join(wd, s.Path)
 
-	py, err := exec.LookPath("python3")
+	if err := s.srv.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(s.srv.Endpoint())
 	if err != nil {
-		// fallback to python and hope it still points to a python3 version.
-		// the Windows python3 installer doesn't seem to create a `python3.exe`
-		py, err = exec.LookPath("python")
-		if err != nil {
-			return errors.Errorf("unable to find Python executable")
-		}
+		s.srv.Close()
+		return nil, err
+	}
+	s.proxy, err = newProxy(u.Host, s.srv.Endpoint())
+	if err != nil {
+		s.srv.Close()
+		return nil, err
 	}
 
-	s.cmd = exec.Command(py, path)
-	s.cmd.Stdout = os.Stdout
-	s.cmd.Stderr = os.Stderr
-	s.Endpoint = "opc.tcp://127.0.0.1:4840"
+	s.Endpoint = "opc.tcp://" + s.proxy.l.Addr().String()
 	s.Opts = []opcua.Option{opcua.SecurityMode(ua.MessageSecurityModeNone)}
-	if err := s.cmd.Start(); err != nil {
-		return err
+	return s, nil
+}
+
+// node returns the server node for the node description.
+func (s *Server) node(n Node) opcua.ServerNode {
+	base := opcua.BaseNode{
+		NodeID:     ua.NewStringNodeID(s.ns, n.Name),
+		BrowseName: &ua.QualifiedName{NamespaceIndex: s.ns, Name: n.Name},
 	}
-	go func() { s.waitch <- s.cmd.Wait() }()
-
-	// wait until endpoint is available
-	errch := make(chan error)
-	go func() {
-		deadline := time.Now().Add(10 * time.Second)
-		for time.Now().Before(deadline) {
-			c, err := net.Dial("tcp", "127.0.0.1:4840")
-			if err != nil {
-				time.Sleep(100 * time.Millisecond)
-				continue
+	if n.Parent == "" {
+		base.References = append(base.References, opcua.NewReference(ua.NewNumericNodeID(0, id.Organizes), false, ua.NewNumericNodeID(0, id.ObjectsFolder)))
+	} else {
+		base.References = append(base.References, opcua.NewReference(ua.NewNumericNodeID(0, id.HasComponent), false, ua.NewStringNodeID(s.ns, n.Parent)))
+	}
+
+	switch {
+	case n.Method != nil:
+		method := n.Method
+		return &opcua.MethodNode{
+			BaseNode:       base,
+			Executable:     true,
+			UserExecutable: true,
+			OnCall: func(ctx context.Context, sess *opcua.ServerSession, objectID *ua.NodeID, args []*ua.Variant) ([]*ua.Variant, ua.StatusCode) {
+				return method(s, args)
+			},
+		}
+
+	case n.Value != nil:
+		v := ua.MustVariant(n.Value)
+		valueRank := int32(-1)
+		if v.Has(ua.VariantArrayValues) {
+			valueRank = int32(len(v.ArrayDimensions()))
+			if valueRank == 0 {
+				valueRank = 1
 			}
+		}
+		access := ua.AccessLevelTypeCurrentRead
+		if n.Writable {
+			access |= ua.AccessLevelTypeCurrentWrite
+		}
+		base.References = append(base.References, opcua.NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.BaseDataVariableType)))
+		return &opcua.VariableNode{
+			BaseNode:        base,
+			Value:           &ua.DataValue{EncodingMask: ua.DataValueValue, Value: v},
+			DataType:        ua.NewNumericNodeID(0, uint32(v.Type())),
+			ValueRank:       valueRank,
+			AccessLevel:     access,
+			UserAccessLevel: access,
+		}
+
+	default:
+		base.References = append(base.References, opcua.NewReference(ua.NewNumericNodeID(0, id.HasTypeDefinition), true, ua.NewNumericNodeID(0, id.BaseObjectType)))
+		return &opcua.ObjectNode{BaseNode: base}
+	}
+}
+
+// Server returns the test server.
+func (s *Server) Server() *opcua.Server {
+	return s.srv
+}
+
+// SetValue sets the value of a variable of the test namespace.
+func (s *Server) SetValue(name string, v interface{}) error {
+	m := s.srv.AddressSpace().NodeManager(s.ns).(*opcua.MemoryNodeManager)
+	return m.SetValue(ua.NewStringNodeID(s.ns, name), &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(v)})
+}
+
+// CloseConnections closes the connections of all clients.
+func (s *Server) CloseConnections() {
+	s.proxy.closeConns()
+}
+
+// SendError sends an error message with the status code to all clients.
+func (s *Server) SendError(code ua.StatusCode) {
+	s.proxy.sendError(code)
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	s.proxy.close()
+	return s.srv.Close()
+}
+
+// proxy forwards the connections of the clients to the server.
+type proxy struct {
+	l        net.Listener
+	target   string
+	endpoint string
+
+	mu    sync.Mutex
+	conns map[*proxyConn]bool
+}
+
+type proxyConn struct {
+	client, server net.Conn
+
+	// mu serializes the messages to the client.
+	mu sync.Mutex
+}
+
+func newProxy(target, endpoint string) (*proxy, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &proxy{l: l, target: target, endpoint: endpoint, conns: make(map[*proxyConn]bool)}
+	go p.accept()
+	return p, nil
+}
+
+func (p *proxy) accept() {
+	for {
+		c, err := p.l.Accept()
+		if err != nil {
+			return
+		}
+		srv, err := net.Dial("tcp", p.target)
+		if err != nil {
 			c.Close()
-			errch <- nil
+			continue
 		}
-		errch <- errors.Errorf("timeout")
-	}()
 
-	select {
-	case err := <-s.waitch:
-		return err
-	case err := <-errch:
+		pc := &proxyConn{client: c, server: srv}
+		p.mu.Lock()
+		p.conns[pc] = true
+		p.mu.Unlock()
+
+		go func() {
+			if p.hello(pc) == nil {
+				io.Copy(pc.server, pc.client)
+			}
+			p.closeConn(pc)
+		}()
+		go func() {
+			pc.forward()
+			p.closeConn(pc)
+		}()
+	}
+}
+
+// hello forwards the Hello message of the client with the endpoint URL
+// of the server since the server rejects other endpoint URLs.
+func (p *proxy) hello(pc *proxyConn) error {
+	h, b, err := readMessage(pc.client)
+	if err != nil {
 		return err
 	}
+	if h.MessageType == uacp.MessageTypeHello {
+		hel := new(uacp.Hello)
+		if _, err := hel.Decode(b[8:]); err != nil {
+			return err
+		}
+		hel.EndpointURL = p.endpoint
+		if b, err = encodeMessage(h.MessageType, hel); err != nil {
+			return err
+		}
+	}
+	_, err = pc.server.Write(b)
+	return err
 }
 
-func (s *Server) Close() error {
-	if s.cmd == nil {
-		return errors.Errorf("not running")
+// forward copies the messages from the server to the client.
+func (pc *proxyConn) forward() {
+	for {
+		_, b, err := readMessage(pc.server)
+		if err != nil {
+			return
+		}
+
+		pc.mu.Lock()
+		_, err = pc.client.Write(b)
+		pc.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readMessage reads a message including the header.
+func readMessage(r io.Reader) (*uacp.Header, []byte, error) {
+	hdr := make([]byte, 8)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, nil, err
+	}
+	h := new(uacp.Header)
+	if _, err := h.Decode(hdr); err != nil {
+		return nil, nil, err
 	}
-	go func() { s.cmd.Process.Kill() }()
-	return <-s.waitch
+	if h.MessageSize < 8 {
+		return nil, nil, errors.Errorf("invalid message size %d", h.MessageSize)
+	}
+	b := make([]byte, h.MessageSize)
+	copy(b, hdr)
+	if _, err := io.ReadFull(r, b[8:]); err != nil {
+		return nil, nil, err
+	}
+	return h, b, nil
+}
+
+// encodeMessage returns the final chunk of a message including the
+// header.
+func encodeMessage(typ string, msg interface{}) ([]byte, error) {
+	body, err := ua.Encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	h := uacp.Header{
+		MessageType: typ,
+		ChunkType:   uacp.ChunkTypeFinal,
+		MessageSize: uint32(len(body) + 8),
+	}
+	hdr, err := h.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return append(hdr, body...), nil
+}
+
+func (p *proxy) closeConn(pc *proxyConn) {
+	pc.client.Close()
+	pc.server.Close()
+	p.mu.Lock()
+	delete(p.conns, pc)
+	p.mu.Unlock()
+}
+
+func (p *proxy) connections() []*proxyConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var conns []*proxyConn
+	for pc := range p.conns {
+		conns = append(conns, pc)
+	}
+	return conns
+}
+
+func (p *proxy) closeConns() {
+	for _, pc := range p.connections() {
+		p.closeConn(pc)
+	}
+}
+
+// sendError sends an error message between two messages of the server.
+func (p *proxy) sendError(code ua.StatusCode) {
+	b, err := encodeMessage(uacp.MessageTypeError, &uacp.Error{ErrorCode: uint32(code)})
+	if err != nil {
+		panic(err)
+	}
+	for _, pc := range p.connections() {
+		pc.mu.Lock()
+		pc.client.Write(b)
+		pc.mu.Unlock()
+	}
+}
+
+func (p *proxy) close() {
+	p.l.Close()
+	p.closeConns()
 }
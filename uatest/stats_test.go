@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -25,7 +22,7 @@ func TestStats(t *testing.T) {
 
 	ctx := context.Background()
 
-	srv := NewServer("rw_server.py")
+	srv := NewServer(rwNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)
@@ -36,18 +33,19 @@ func TestStats(t *testing.T) {
 	c.CloseWithContext(ctx)
 
 	want := map[string]*expvar.Int{
-		"Dial":             newExpVarInt(1),
-		"ActivateSession":  newExpVarInt(1),
-		"NamespaceArray":   newExpVarInt(1),
-		"UpdateNamespaces": newExpVarInt(1),
-		"NodesToRead":      newExpVarInt(1),
-		"Read":             newExpVarInt(1),
-		"Send":             newExpVarInt(2),
-		"Close":            newExpVarInt(1),
-		"CloseSession":     newExpVarInt(2),
-		"SecureChannel":    newExpVarInt(2),
-		"Session":          newExpVarInt(4),
-		"State":            newExpVarInt(0),
+		"Dial":                  newExpVarInt(1),
+		"ActivateSession":       newExpVarInt(1),
+		"NamespaceArray":        newExpVarInt(1),
+		"UpdateNamespaces":      newExpVarInt(1),
+		"UpdateOperationLimits": newExpVarInt(1),
+		"NodesToRead":           newExpVarInt(13),
+		"Read":                  newExpVarInt(3),
+		"Send":                  newExpVarInt(4),
+		"Close":                 newExpVarInt(1),
+		"CloseSession":          newExpVarInt(2),
+		"SecureChannel":         newExpVarInt(2),
+		"Session":               newExpVarInt(4),
+		"State":                 newExpVarInt(0),
 	}
 
 	got := map[string]expvar.Var{}
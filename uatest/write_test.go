@@ -1,6 +1,3 @@
-//go:build integration
-// +build integration
-
 package uatest
 
 import (
@@ -29,7 +26,7 @@ func TestWrite(t *testing.T) {
 
 	ctx := context.Background()
 
-	srv := NewServer("rw_server.py")
+	srv := NewServer(rwNodes)
 	defer srv.Close()
 
 	c := opcua.NewClient(srv.Endpoint, srv.Opts...)